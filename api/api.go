@@ -12,6 +12,8 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/audit"
+	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/health"
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -76,6 +78,15 @@ type Config struct {
 	Controller  Server
 	Health      health.Checker
 	Interceptor Interceptor
+
+	// Auth configures authentication and authorization of requests. The
+	// Consul client is required to authenticate requests with Consul ACL
+	// tokens.
+	Auth         *config.APIAuthConfig
+	ConsulClient client.ConsulClientInterface
+
+	// Auditor records requests that change tasks to the audit log
+	Auditor *audit.Auditor
 }
 
 // NewAPI create a new API object
@@ -94,6 +105,21 @@ func NewAPI(ctx context.Context, conf Config) (*API, error) {
 		api.tls = config.DefaultCTSTLSConfig()
 	}
 
+	// Health is excluded from authentication to support health checks that
+	// cannot be configured with a token, such as the Consul service check
+	am, err := newAuthMiddleware(conf.Auth, conf.ConsulClient, []string{healthPath})
+	if err != nil {
+		logger.Error("error configuring api authentication", "error", err)
+		return nil, err
+	}
+
+	audm := newAuditMiddleware(conf.Auditor)
+	if am != nil {
+		// requests rejected by the auth middleware do not reach the audit
+		// middleware, which needs the identity of authenticated requests
+		am.audit = audm
+	}
+
 	r := chi.NewRouter()
 
 	// add the middleware for all endpoints
@@ -104,6 +130,12 @@ func NewAPI(ctx context.Context, conf Config) (*API, error) {
 	r.Route(fmt.Sprintf("/%s", defaultAPIVersion), func(r chi.Router) {
 		lm := newLoggingMiddleware(nil, logger)
 		r.Use(lm.withLogging)
+		if am != nil {
+			r.Use(am.withAuth)
+		}
+		if audm != nil {
+			r.Use(audm.withAudit)
+		}
 		if conf.Interceptor != nil {
 			im := newInterceptMiddleware(conf.Interceptor)
 			r.Use(im.withIntercept)
@@ -129,6 +161,12 @@ func NewAPI(ctx context.Context, conf Config) (*API, error) {
 		lm := newLoggingMiddleware([]string{healthPath}, logger)
 		r.Use(lm.withLogging)
 		r.Use(withPlaintextErrorToJson)
+		if am != nil {
+			r.Use(am.withAuth)
+		}
+		if audm != nil {
+			r.Use(audm.withAudit)
+		}
 		r.Use(withSwaggerValidate)
 		if conf.Interceptor != nil {
 			im := newInterceptMiddleware(conf.Interceptor)
@@ -139,6 +177,9 @@ func NewAPI(ctx context.Context, conf Config) (*API, error) {
 		server := Handlers{
 			TaskLifeCycleHandler: NewTaskLifeCycleHandler(api.ctrl),
 			HealthHandler:        NewHealthHandler(api.health),
+			ReloadHandler:        NewReloadHandler(api.ctrl),
+			RolloutHandler:       NewRolloutHandler(api.ctrl),
+			LogLevelsHandler:     NewLogLevelsHandler(),
 		}
 
 		oapigen.HandlerFromMux(server, r)
@@ -19,6 +19,7 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mockHealth "github.com/hashicorp/consul-terraform-sync/mocks/health"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/hashicorp/consul-terraform-sync/registry"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/testutils"
 	"github.com/hashicorp/go-rootcerts"
@@ -77,7 +78,8 @@ func TestServe(t *testing.T) {
 					Name:    &taskName,
 					Enabled: config.Bool(true),
 				}, nil).
-					On("Events", mock.Anything, taskName).Return(map[string][]event.Event{}, nil)
+					On("Events", mock.Anything, taskName).Return(map[string][]event.Event{}, nil).
+					On("TaskModuleUpgrade", mock.Anything, taskName).Return(registry.ModuleUpgrade{}, false)
 			},
 			http.StatusOK,
 			`{"task_b":{"task_name":"task_b","status":"unknown","enabled":true,"events_url":"","providers":null,"services":null}}
@@ -124,7 +126,7 @@ func TestServe(t *testing.T) {
 			"",
 			func(ctrl *mocks.Server) {
 				ctrl.On("Task", mock.Anything, "task_b").Return(config.TaskConfig{}, nil)
-				ctrl.On("TaskDelete", mock.Anything, "task_b").Return(nil)
+				ctrl.On("TaskDelete", mock.Anything, "task_b", false, false).Return(nil)
 			},
 			http.StatusAccepted,
 			"{}\n",
@@ -153,7 +155,18 @@ func TestServe(t *testing.T) {
 				Port:       port,
 			})
 			require.NoError(t, err)
-			go api.Serve(ctx)
+
+			// wait for the server to stop so the port can be reused by the
+			// next case
+			stopped := make(chan struct{})
+			go func() {
+				api.Serve(ctx)
+				close(stopped)
+			}()
+			defer func() {
+				cancel()
+				<-stopped
+			}()
 			time.Sleep(500 * time.Millisecond)
 
 			u := fmt.Sprintf("http://localhost:%d/%s/%s",
@@ -0,0 +1,244 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/audit"
+)
+
+const (
+	// maxAuditErrorLen is the maximum length of an error response body that
+	// is captured for the audit log
+	maxAuditErrorLen = 4096
+
+	auditActionTaskCreate       = "task_create"
+	auditActionTaskDelete       = "task_delete"
+	auditActionTaskEnable       = "task_enable"
+	auditActionTaskDisable      = "task_disable"
+	auditActionTaskRun          = "task_run"
+	auditActionTaskInspect      = "task_inspect"
+	auditActionTaskCancel       = "task_cancel"
+	auditActionTaskExport       = "task_export"
+	auditActionTaskMigrateState = "task_migrate_state"
+	auditActionTaskBulk         = "task_bulk"
+	auditActionReload           = "config_reload"
+	auditActionLogLevels        = "log_levels_update"
+)
+
+type auditMiddleware struct {
+	auditor *audit.Auditor
+}
+
+// newAuditMiddleware returns the middleware to record requests to the audit
+// log. Returns nil if the audit log is not enabled.
+func newAuditMiddleware(auditor *audit.Auditor) *auditMiddleware {
+	if auditor == nil {
+		return nil
+	}
+	return &auditMiddleware{auditor: auditor}
+}
+
+// withAudit records requests that change tasks to the audit log once they
+// have been handled. Task exports are also recorded since they include the
+// values of provider blocks. The origin of the request is added to the
+// context passed to the next handler so that task applies triggered by the
+// request are attributed to it.
+func (am *auditMiddleware) withAudit(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			if !strings.HasSuffix(r.URL.Path, "/export") {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		var body []byte
+		if r.Body != nil {
+			var err error
+			body, err = io.ReadAll(r.Body)
+			r.Body.Close()
+			if err != nil {
+				sendError(w, r, http.StatusBadRequest,
+					fmt.Errorf("error reading request body: %s", err))
+				return
+			}
+			r.Body = io.NopCloser(bytes.NewReader(body))
+		}
+
+		ctx := r.Context()
+		origin := audit.Origin{
+			RequestID: requestIDFromContext(ctx).String(),
+			Actor:     requestActor(r),
+		}
+		r = r.WithContext(audit.WithOrigin(ctx, origin))
+
+		rw := &auditResponseWriter{
+			ResponseWriter: w,
+			captureBulk:    isBulkTasksRequest(r),
+		}
+		next.ServeHTTP(rw, r)
+
+		statusCode := rw.statusCode
+		if statusCode == 0 {
+			statusCode = http.StatusOK
+		}
+		entry := requestEntry(r, body, origin)
+		entry.Result = audit.Result{
+			Success:    statusCode < http.StatusBadRequest,
+			StatusCode: statusCode,
+			Error:      rw.errorMessage(),
+		}
+
+		// bulk operations are recorded for each task that was selected
+		results, ok := rw.bulkResults()
+		if !ok {
+			am.auditor.Record(entry)
+			return
+		}
+		for _, res := range results {
+			e := entry
+			e.TaskName = res.Name
+			e.Result = audit.Result{
+				Success:    res.Status == bulkStatusSucceeded,
+				StatusCode: statusCode,
+			}
+			switch {
+			case res.Error != nil:
+				e.Result.Error = *res.Error
+			case res.Status != bulkStatusSucceeded:
+				e.Result.Error = fmt.Sprintf("task was %s",
+					strings.ReplaceAll(res.Status, "_", " "))
+			}
+			am.auditor.Record(e)
+		}
+	})
+}
+
+// recordDenied records a request that was rejected by authentication or
+// authorization before it reached withAudit. All rejected requests are
+// recorded, including requests that only read.
+func (am *auditMiddleware) recordDenied(r *http.Request, statusCode int, err error) {
+	var body []byte
+	if r.Body != nil {
+		// best effort to identify the task of the request
+		body, _ = io.ReadAll(io.LimitReader(r.Body, maxAuditErrorLen))
+	}
+
+	origin := audit.Origin{
+		RequestID: requestIDFromContext(r.Context()).String(),
+		Actor:     requestActor(r),
+	}
+	entry := requestEntry(r, body, origin)
+	entry.Result = audit.Result{
+		StatusCode: statusCode,
+		Error:      err.Error(),
+	}
+	am.auditor.Record(entry)
+}
+
+// requestEntry returns the audit log entry of the request without its result
+func requestEntry(r *http.Request, body []byte, origin audit.Origin) audit.Entry {
+	action, taskName := auditAction(r, body)
+	return audit.Entry{
+		Type:      audit.TypeRequest,
+		Action:    action,
+		TaskName:  taskName,
+		RequestID: origin.RequestID,
+		Actor:     origin.Actor,
+		Request: &audit.Request{
+			Method: r.Method,
+			Path:   r.URL.Path,
+			Query:  r.URL.RawQuery,
+			Body:   audit.RedactBody(body),
+		},
+	}
+}
+
+// isBulkTasksRequest returns true if the request runs a bulk task operation
+func isBulkTasksRequest(r *http.Request) bool {
+	return r.Method == http.MethodPost &&
+		strings.HasPrefix(r.URL.Path, bulkTasksPath+"/")
+}
+
+// requestActor returns who made the request from the authenticated identity
+// and the verified client certificate, if any
+func requestActor(r *http.Request) *audit.Actor {
+	actor := &audit.Actor{RemoteAddr: r.RemoteAddr}
+	if id, ok := IdentityFromContext(r.Context()); ok {
+		actor.TokenName = id.Name
+		actor.Role = id.Role
+	}
+	if r.TLS != nil && len(r.TLS.PeerCertificates) > 0 {
+		actor.ClientCertCN = r.TLS.PeerCertificates[0].Subject.CommonName
+	}
+	return actor
+}
+
+// auditAction returns the action and task name of a request that changes
+// tasks. Requests to other endpoints are described by their method and path.
+func auditAction(r *http.Request, body []byte) (string, string) {
+	prefix := fmt.Sprintf("/%s/%s", defaultAPIVersion, taskPath)
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	if path == reloadPath && r.Method == http.MethodPost {
+		return auditActionReload, ""
+	}
+	if path == logLevelsPath && r.Method == http.MethodPatch {
+		return auditActionLogLevels, ""
+	}
+	if isBulkTasksRequest(r) {
+		op := strings.TrimPrefix(path, bulkTasksPath+"/")
+		return fmt.Sprintf("%s_%s", auditActionTaskBulk, op), ""
+	}
+	if path != prefix && !strings.HasPrefix(path, prefix+"/") {
+		return fmt.Sprintf("%s %s", strings.ToLower(r.Method), r.URL.Path), ""
+	}
+
+	parts := strings.Split(strings.TrimPrefix(path, prefix), "/")
+	switch {
+	case len(parts) == 1 && r.Method == http.MethodPost:
+		var req struct {
+			Task struct {
+				Name string `json:"name"`
+			} `json:"task"`
+		}
+		json.Unmarshal(body, &req) // best effort to identify the task
+		return auditActionTaskCreate, req.Task.Name
+
+	case len(parts) == 2 && r.Method == http.MethodDelete:
+		return auditActionTaskDelete, parts[1]
+
+	case len(parts) == 2 && r.Method == http.MethodPatch:
+		switch r.URL.Query().Get("run") {
+		case RunOptionNow:
+			return auditActionTaskRun, parts[1]
+		case RunOptionInspect:
+			return auditActionTaskInspect, parts[1]
+		}
+		var req struct {
+			Enabled *bool `json:"enabled"`
+		}
+		json.Unmarshal(body, &req) // best effort to identify the change
+		if req.Enabled != nil && !*req.Enabled {
+			return auditActionTaskDisable, parts[1]
+		}
+		return auditActionTaskEnable, parts[1]
+
+	case len(parts) == 3 && parts[2] == "cancel" && r.Method == http.MethodPost:
+		return auditActionTaskCancel, parts[1]
+
+	case len(parts) == 3 && parts[2] == "export" && r.Method == http.MethodGet:
+		return auditActionTaskExport, parts[1]
+
+	case len(parts) == 4 && parts[2] == "state" && parts[3] == "migrate" &&
+		r.Method == http.MethodPost:
+		return auditActionTaskMigrateState, parts[1]
+	}
+
+	return fmt.Sprintf("%s %s", strings.ToLower(r.Method), r.URL.Path), ""
+}
@@ -0,0 +1,311 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/audit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditMiddleware_withAudit(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		body       string
+		statusCode int
+		errMsg     string
+		expected   *audit.Entry
+	}{
+		{
+			"read not recorded",
+			http.MethodGet,
+			"/v1/tasks/task",
+			"",
+			http.StatusOK,
+			"",
+			nil,
+		},
+		{
+			"create",
+			http.MethodPost,
+			"/v1/tasks?run=now",
+			`{"task":{"name":"task","variables":{"password":"secret"}}}`,
+			http.StatusCreated,
+			"",
+			&audit.Entry{
+				Type:     audit.TypeRequest,
+				Action:   auditActionTaskCreate,
+				TaskName: "task",
+				Request: &audit.Request{
+					Method: http.MethodPost,
+					Path:   "/v1/tasks",
+					Query:  "run=now",
+					Body: json.RawMessage(
+						`{"task":{"name":"task","variables":{"password":"(redacted)"}}}`),
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusCreated},
+			},
+		},
+		{
+			"disable",
+			http.MethodPatch,
+			"/v1/tasks/task",
+			`{"enabled":false}`,
+			http.StatusOK,
+			"",
+			&audit.Entry{
+				Type:     audit.TypeRequest,
+				Action:   auditActionTaskDisable,
+				TaskName: "task",
+				Request: &audit.Request{
+					Method: http.MethodPatch,
+					Path:   "/v1/tasks/task",
+					Body:   json.RawMessage(`{"enabled":false}`),
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusOK},
+			},
+		},
+		{
+			"run",
+			http.MethodPatch,
+			"/v1/tasks/task?run=now",
+			`{"enabled":true}`,
+			http.StatusOK,
+			"",
+			&audit.Entry{
+				Type:     audit.TypeRequest,
+				Action:   auditActionTaskRun,
+				TaskName: "task",
+				Request: &audit.Request{
+					Method: http.MethodPatch,
+					Path:   "/v1/tasks/task",
+					Query:  "run=now",
+					Body:   json.RawMessage(`{"enabled":true}`),
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusOK},
+			},
+		},
+		{
+			"cancel",
+			http.MethodPost,
+			"/v1/tasks/task/cancel",
+			"",
+			http.StatusOK,
+			"",
+			&audit.Entry{
+				Type:     audit.TypeRequest,
+				Action:   auditActionTaskCancel,
+				TaskName: "task",
+				Request: &audit.Request{
+					Method: http.MethodPost,
+					Path:   "/v1/tasks/task/cancel",
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusOK},
+			},
+		},
+		{
+			"migrate_state",
+			http.MethodPost,
+			"/v1/tasks/task/state/migrate",
+			`{"backend":{"s3":{"secret_key":"k"}}}`,
+			http.StatusOK,
+			"",
+			&audit.Entry{
+				Type:     audit.TypeRequest,
+				Action:   auditActionTaskMigrateState,
+				TaskName: "task",
+				Request: &audit.Request{
+					Method: http.MethodPost,
+					Path:   "/v1/tasks/task/state/migrate",
+					Body:   json.RawMessage(`{"backend":{"s3":"(redacted)"}}`),
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusOK},
+			},
+		},
+		{
+			"bulk",
+			http.MethodPost,
+			"/v1/bulk/tasks/disable",
+			`{"selector":"provider=aws"}`,
+			http.StatusOK,
+			"",
+			&audit.Entry{
+				Type:   audit.TypeRequest,
+				Action: auditActionTaskBulk + "_disable",
+				Request: &audit.Request{
+					Method: http.MethodPost,
+					Path:   "/v1/bulk/tasks/disable",
+					Body:   json.RawMessage(`{"selector":"provider=aws"}`),
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusOK},
+			},
+		},
+		{
+			"export",
+			http.MethodGet,
+			"/v1/tasks/task/export",
+			"",
+			http.StatusOK,
+			"",
+			&audit.Entry{
+				Type:     audit.TypeRequest,
+				Action:   auditActionTaskExport,
+				TaskName: "task",
+				Request: &audit.Request{
+					Method: http.MethodGet,
+					Path:   "/v1/tasks/task/export",
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusOK},
+			},
+		},
+		{
+			"reload",
+			http.MethodPost,
+			"/v1/reload",
+			"",
+			http.StatusOK,
+			"",
+			&audit.Entry{
+				Type:   audit.TypeRequest,
+				Action: auditActionReload,
+				Request: &audit.Request{
+					Method: http.MethodPost,
+					Path:   "/v1/reload",
+				},
+				Result: audit.Result{Success: true, StatusCode: http.StatusOK},
+			},
+		},
+		{
+			"delete errored",
+			http.MethodDelete,
+			"/v1/tasks/task",
+			"",
+			http.StatusNotFound,
+			"task not found",
+			&audit.Entry{
+				Type:     audit.TypeRequest,
+				Action:   auditActionTaskDelete,
+				TaskName: "task",
+				Request: &audit.Request{
+					Method: http.MethodDelete,
+					Path:   "/v1/tasks/task",
+				},
+				Result: audit.Result{
+					StatusCode: http.StatusNotFound,
+					Error:      "task not found",
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			am := newAuditMiddleware(audit.NewWriter(&buf))
+
+			var origin audit.Origin
+			var body []byte
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				origin, _ = audit.OriginFromContext(r.Context())
+				body, _ = io.ReadAll(r.Body)
+				if tc.errMsg != "" {
+					sendError(w, r, tc.statusCode, errors.New(tc.errMsg))
+					return
+				}
+				w.WriteHeader(tc.statusCode)
+			})
+
+			req := httptest.NewRequest(tc.method, tc.path, strings.NewReader(tc.body))
+			ctx := identityWithContext(req.Context(), Identity{Name: "ops", Role: "operator"})
+			req = req.WithContext(requestIDWithContext(ctx,
+				"1e9bf3d2-2b8c-4d5a-9a59-6e0c8f1f1c6e"))
+			resp := httptest.NewRecorder()
+
+			am.withAudit(next).ServeHTTP(resp, req)
+			assert.Equal(t, tc.statusCode, resp.Code)
+
+			// the body is still available to the next handler
+			assert.Equal(t, tc.body, string(body))
+
+			if tc.expected == nil {
+				assert.Empty(t, buf.String())
+				return
+			}
+
+			var actual audit.Entry
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &actual))
+			assert.False(t, actual.Time.IsZero())
+			actual.Time = tc.expected.Time
+
+			expected := *tc.expected
+			expected.RequestID = "1e9bf3d2-2b8c-4d5a-9a59-6e0c8f1f1c6e"
+			expected.Actor = &audit.Actor{
+				TokenName:  "ops",
+				Role:       "operator",
+				RemoteAddr: req.RemoteAddr,
+			}
+			assert.Equal(t, expected, actual)
+
+			// the origin is available to the next handler
+			assert.Equal(t, expected.RequestID, origin.RequestID)
+			assert.Equal(t, expected.Actor, origin.Actor)
+		})
+	}
+}
+
+func TestAuditMiddleware_withAudit_Bulk(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	am := newAuditMiddleware(audit.NewWriter(&buf))
+
+	errMsg := "error disabling task"
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeResponse(w, r, http.StatusMultiStatus, oapigen.BulkTaskResponse{
+			Results: []oapigen.BulkTaskResult{
+				{Name: "a", Status: bulkStatusRolledBack},
+				{Name: "b", Status: bulkStatusFailed, Error: &errMsg},
+				{Name: "c", Status: bulkStatusSkipped},
+			},
+		})
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/bulk/tasks/disable",
+		strings.NewReader(`{"names":["a","b","c"],"atomic":true}`))
+	resp := httptest.NewRecorder()
+	am.withAudit(next).ServeHTTP(resp, req)
+	assert.Equal(t, http.StatusMultiStatus, resp.Code)
+
+	// the response is still written to the client
+	var r oapigen.BulkTaskResponse
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+	assert.Len(t, r.Results, 3)
+
+	expected := map[string]audit.Result{
+		"a": {StatusCode: http.StatusMultiStatus, Error: "task was rolled back"},
+		"b": {StatusCode: http.StatusMultiStatus, Error: errMsg},
+		"c": {StatusCode: http.StatusMultiStatus, Error: "task was skipped"},
+	}
+	actual := make(map[string]audit.Result)
+	decoder := json.NewDecoder(&buf)
+	for decoder.More() {
+		var entry audit.Entry
+		require.NoError(t, decoder.Decode(&entry))
+		assert.Equal(t, auditActionTaskBulk+"_disable", entry.Action)
+		assert.Equal(t, "/v1/bulk/tasks/disable", entry.Request.Path)
+		actual[entry.TaskName] = entry.Result
+	}
+	assert.Equal(t, expected, actual)
+}
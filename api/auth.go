@@ -0,0 +1,337 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/client"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+const (
+	// TokenHeader is the header used to send the token authenticating API
+	// requests. Requests may alternatively use the Authorization header with
+	// the Bearer scheme.
+	TokenHeader = "X-CTS-Token"
+
+	// consulACLCacheTTL is how long a role resolved from a Consul ACL token is
+	// cached before the token is validated with Consul again
+	consulACLCacheTTL = 30 * time.Second
+
+	authSubsystemName = "auth"
+)
+
+var (
+	errMissingToken = errors.New("request requires a token")
+	errInvalidToken = errors.New("token is not valid")
+)
+
+// Identity is the authenticated identity of an API request
+type Identity struct {
+	// Name identifies the token of the request. For Consul ACL tokens, this is
+	// the token's accessor ID.
+	Name string
+
+	// Role is the role granted to the token
+	Role string
+}
+
+type contextIdentityKeyType struct{}
+
+var identityContextKey = contextIdentityKeyType{}
+
+// identityWithContext inserts the identity of a request into the context
+func identityWithContext(ctx context.Context, id Identity) context.Context {
+	return context.WithValue(ctx, identityContextKey, id)
+}
+
+// IdentityFromContext retrieves the identity of a request from the context.
+// Returns false if the request was not authenticated.
+func IdentityFromContext(ctx context.Context) (Identity, bool) {
+	id, ok := ctx.Value(identityContextKey).(Identity)
+	return id, ok
+}
+
+// authenticator resolves the identity of a token
+type authenticator interface {
+	// authenticate returns the identity of the token. Returns false if the
+	// token is not known to the authenticator.
+	authenticate(ctx context.Context, token string) (Identity, bool, error)
+}
+
+type authMiddleware struct {
+	authenticators []authenticator
+
+	// audit records rejected requests to the audit log, if it is enabled
+	audit *auditMiddleware
+
+	// uriExclusions are the request URIs that do not require authentication
+	uriExclusions map[string]bool
+}
+
+// newAuthMiddleware returns the middleware to authenticate and authorize
+// requests. Returns nil if authentication is not enabled.
+func newAuthMiddleware(conf *config.APIAuthConfig, consul client.ConsulClientInterface,
+	uriExclusions []string) (*authMiddleware, error) {
+
+	if conf == nil || !config.BoolVal(conf.Enabled) {
+		return nil, nil
+	}
+
+	am := &authMiddleware{
+		uriExclusions: make(map[string]bool, len(uriExclusions)),
+	}
+	for _, v := range uriExclusions {
+		am.uriExclusions[v] = true
+	}
+
+	if len(conf.Tokens) > 0 {
+		am.authenticators = append(am.authenticators, newStaticTokens(conf.Tokens))
+	}
+
+	if conf.ConsulACL != nil && config.BoolVal(conf.ConsulACL.Enabled) {
+		if consul == nil {
+			return nil, fmt.Errorf("a Consul client is required to authenticate " +
+				"API requests with Consul ACL tokens")
+		}
+		am.authenticators = append(am.authenticators,
+			newConsulACLTokens(conf.ConsulACL.Roles, consul))
+	}
+
+	return am, nil
+}
+
+// withAuth authenticates the token of the request and checks that the role
+// granted to the token allows the request. The identity of the request is
+// added to the context passed to the next handler.
+func (am *authMiddleware) withAuth(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if am.uriExclusions[r.URL.Path] {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := r.Context()
+		logger := logging.FromContext(ctx).Named(authSubsystemName)
+
+		id, err := am.authenticate(ctx, requestToken(r))
+		if err != nil {
+			logger.Debug("request not authenticated", "error", err)
+			am.deny(w, r, http.StatusUnauthorized, err)
+			return
+		}
+
+		required := requiredRole(r)
+		if !roleAllows(id.Role, required) {
+			logger.Debug("request not authorized", "token_name", id.Name,
+				"role", id.Role, "required_role", required)
+			r = r.WithContext(identityWithContext(ctx, id))
+			am.deny(w, r, http.StatusForbidden, fmt.Errorf("token with role %q "+
+				"is not allowed to %s %s, request requires role %q", id.Role,
+				r.Method, r.URL.Path, required))
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(identityWithContext(ctx, id)))
+	})
+}
+
+// deny responds with the error of a rejected request and records the request
+// to the audit log
+func (am *authMiddleware) deny(w http.ResponseWriter, r *http.Request, statusCode int, err error) {
+	if am.audit != nil {
+		am.audit.recordDenied(r, statusCode, err)
+	}
+	sendError(w, r, statusCode, err)
+}
+
+func (am *authMiddleware) authenticate(ctx context.Context, token string) (Identity, error) {
+	if token == "" {
+		return Identity{}, errMissingToken
+	}
+
+	for _, a := range am.authenticators {
+		id, ok, err := a.authenticate(ctx, token)
+		if err != nil {
+			return Identity{}, err
+		}
+		if ok {
+			return id, nil
+		}
+	}
+
+	return Identity{}, errInvalidToken
+}
+
+// requestToken returns the token of the request from either the token header
+// or the Authorization header
+func requestToken(r *http.Request) string {
+	if token := r.Header.Get(TokenHeader); token != "" {
+		return token
+	}
+
+	const prefix = "Bearer "
+	auth := r.Header.Get("Authorization")
+	if len(auth) > len(prefix) && strings.EqualFold(auth[:len(prefix)], prefix) {
+		return strings.TrimSpace(auth[len(prefix):])
+	}
+
+	return ""
+}
+
+// requiredRole returns the role required for a request. Reading is allowed
+// for the read role, operating existing tasks (update and cancel) and
+// updating log levels for the operator role, and all other requests such as
+// creating and deleting tasks for the admin role. Exporting a task requires
+// the admin role since the export includes the values of provider blocks.
+func requiredRole(r *http.Request) string {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		if strings.HasSuffix(r.URL.Path, "/export") {
+			return config.APIRoleAdmin
+		}
+		return config.APIRoleRead
+	case http.MethodPatch:
+		return config.APIRoleOperator
+	case http.MethodPost:
+		if strings.HasSuffix(r.URL.Path, "/cancel") {
+			return config.APIRoleOperator
+		}
+		// bulk operations that only toggle or run existing tasks
+		switch strings.TrimPrefix(r.URL.Path, bulkTasksPath+"/") {
+		case bulkOperationEnable, bulkOperationDisable, bulkOperationRun:
+			return config.APIRoleOperator
+		}
+	}
+	return config.APIRoleAdmin
+}
+
+// roleAllows returns true if the role is at least as privileged as the
+// required role
+func roleAllows(role, required string) bool {
+	return roleRank(role) >= roleRank(required) && roleRank(role) >= 0
+}
+
+// roleRank returns the privilege of a role. Returns -1 for unknown roles.
+func roleRank(role string) int {
+	for i, r := range config.APIRoles {
+		if role == r {
+			return i
+		}
+	}
+	return -1
+}
+
+// staticTokens authenticates the tokens configured in CTS
+type staticTokens struct {
+	tokens []*config.APITokenConfig
+}
+
+func newStaticTokens(tokens []*config.APITokenConfig) *staticTokens {
+	return &staticTokens{tokens: tokens}
+}
+
+func (s *staticTokens) authenticate(_ context.Context, token string) (Identity, bool, error) {
+	for _, t := range s.tokens {
+		secret := config.StringVal(t.Secret)
+		if subtle.ConstantTimeCompare([]byte(secret), []byte(token)) == 1 {
+			return Identity{
+				Name: config.StringVal(t.Name),
+				Role: config.StringVal(t.Role),
+			}, true, nil
+		}
+	}
+	return Identity{}, false, nil
+}
+
+// consulACLTokens authenticates Consul ACL tokens by reading the token from
+// Consul. The role of the token is the most privileged role mapped from the
+// names of the ACL policies and roles linked to the token.
+type consulACLTokens struct {
+	client client.ConsulClientInterface
+	roles  map[string]string
+
+	mu    sync.Mutex
+	cache map[string]cachedIdentity
+}
+
+type cachedIdentity struct {
+	id      Identity
+	expires time.Time
+}
+
+func newConsulACLTokens(roles map[string]string, c client.ConsulClientInterface) *consulACLTokens {
+	return &consulACLTokens{
+		client: c,
+		roles:  roles,
+		cache:  make(map[string]cachedIdentity),
+	}
+}
+
+func (c *consulACLTokens) authenticate(ctx context.Context, token string) (Identity, bool, error) {
+	c.mu.Lock()
+	cached, ok := c.cache[token]
+	c.mu.Unlock()
+	if ok && time.Now().Before(cached.expires) {
+		return cached.id, true, nil
+	}
+
+	// Only valid tokens are cached. Errors, including an unknown token, are
+	// not cached so the token is validated with Consul on the next request.
+	t, err := c.client.ACLTokenReadSelf(ctx, &consulapi.QueryOptions{Token: token})
+	if err != nil {
+		if isACLNotFound(err) {
+			return Identity{}, false, nil
+		}
+		return Identity{}, false, fmt.Errorf("unable to validate token with "+
+			"Consul: %s", err)
+	}
+
+	role := ""
+	resolve := func(name string) {
+		if r, ok := c.roles[name]; ok && roleRank(r) > roleRank(role) {
+			role = r
+		}
+	}
+	for _, p := range t.Policies {
+		resolve(p.Name)
+	}
+	for _, r := range t.Roles {
+		resolve(r.Name)
+	}
+	if role == "" {
+		// Valid Consul ACL token that is not granted a CTS API role
+		return Identity{}, false, nil
+	}
+
+	id := Identity{Name: t.AccessorID, Role: role}
+	now := time.Now()
+	c.mu.Lock()
+	for k, v := range c.cache {
+		if now.After(v.expires) {
+			delete(c.cache, k)
+		}
+	}
+	c.cache[token] = cachedIdentity{id: id, expires: now.Add(consulACLCacheTTL)}
+	c.mu.Unlock()
+	return id, true, nil
+}
+
+// isACLNotFound returns true if Consul responded that the ACL token does not
+// exist. Other forbidden responses, such as permission denied, are errors.
+func isACLNotFound(err error) bool {
+	var statusErr consulapi.StatusError
+	if !errors.As(err, &statusErr) {
+		return false
+	}
+	return statusErr.Code == http.StatusForbidden &&
+		strings.Contains(statusErr.Body, "ACL not found")
+}
@@ -0,0 +1,393 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/audit"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAuthMiddleware(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		conf := config.DefaultAPIAuthConfig()
+		conf.Finalize()
+		am, err := newAuthMiddleware(conf, nil, nil)
+		assert.NoError(t, err)
+		assert.Nil(t, am)
+	})
+
+	t.Run("consul acl requires client", func(t *testing.T) {
+		conf := &config.APIAuthConfig{
+			ConsulACL: &config.APIConsulACLConfig{
+				Roles: map[string]string{"policy": config.APIRoleRead},
+			},
+		}
+		conf.Finalize()
+		_, err := newAuthMiddleware(conf, nil, nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuthMiddleware_withAuth(t *testing.T) {
+	t.Parallel()
+
+	conf := &config.APIAuthConfig{
+		Tokens: []*config.APITokenConfig{
+			{Name: config.String("reader"), Secret: config.String("read-secret"),
+				Role: config.String(config.APIRoleRead)},
+			{Name: config.String("ops"), Secret: config.String("ops-secret"),
+				Role: config.String(config.APIRoleOperator)},
+			{Name: config.String("admin"), Secret: config.String("admin-secret"),
+				Role: config.String(config.APIRoleAdmin)},
+		},
+	}
+	conf.Finalize()
+	am, err := newAuthMiddleware(conf, nil, []string{healthPath})
+	require.NoError(t, err)
+
+	cases := []struct {
+		name       string
+		method     string
+		path       string
+		header     string
+		token      string
+		statusCode int
+		identity   string
+	}{
+		{
+			"missing token",
+			http.MethodGet,
+			"/v1/tasks",
+			TokenHeader,
+			"",
+			http.StatusUnauthorized,
+			"",
+		},
+		{
+			"invalid token",
+			http.MethodGet,
+			"/v1/tasks",
+			TokenHeader,
+			"bad-secret",
+			http.StatusUnauthorized,
+			"",
+		},
+		{
+			"health excluded",
+			http.MethodGet,
+			healthPath,
+			TokenHeader,
+			"",
+			http.StatusOK,
+			"",
+		},
+		{
+			"read get",
+			http.MethodGet,
+			"/v1/status/tasks",
+			TokenHeader,
+			"read-secret",
+			http.StatusOK,
+			"reader",
+		},
+		{
+			"read bearer",
+			http.MethodGet,
+			"/v1/tasks/task",
+			"Authorization",
+			"Bearer read-secret",
+			http.StatusOK,
+			"reader",
+		},
+		{
+			"read patch forbidden",
+			http.MethodPatch,
+			"/v1/tasks/task",
+			TokenHeader,
+			"read-secret",
+			http.StatusForbidden,
+			"",
+		},
+		{
+			"operator patch",
+			http.MethodPatch,
+			"/v1/tasks/task",
+			TokenHeader,
+			"ops-secret",
+			http.StatusOK,
+			"ops",
+		},
+		{
+			"operator cancel",
+			http.MethodPost,
+			"/v1/tasks/task/cancel",
+			TokenHeader,
+			"ops-secret",
+			http.StatusOK,
+			"ops",
+		},
+		{
+			"operator create forbidden",
+			http.MethodPost,
+			"/v1/tasks",
+			TokenHeader,
+			"ops-secret",
+			http.StatusForbidden,
+			"",
+		},
+		{
+			"operator delete forbidden",
+			http.MethodDelete,
+			"/v1/tasks/task",
+			TokenHeader,
+			"ops-secret",
+			http.StatusForbidden,
+			"",
+		},
+		{
+			"operator export forbidden",
+			http.MethodGet,
+			"/v1/tasks/task/export",
+			TokenHeader,
+			"ops-secret",
+			http.StatusForbidden,
+			"",
+		},
+		{
+			"operator bulk disable",
+			http.MethodPost,
+			"/v1/bulk/tasks/disable",
+			TokenHeader,
+			"ops-secret",
+			http.StatusOK,
+			"ops",
+		},
+		{
+			"operator bulk delete forbidden",
+			http.MethodPost,
+			"/v1/bulk/tasks/delete",
+			TokenHeader,
+			"ops-secret",
+			http.StatusForbidden,
+			"",
+		},
+		{
+			"admin export",
+			http.MethodGet,
+			"/v1/tasks/task/export",
+			TokenHeader,
+			"admin-secret",
+			http.StatusOK,
+			"admin",
+		},
+		{
+			"admin delete",
+			http.MethodDelete,
+			"/v1/tasks/task",
+			TokenHeader,
+			"admin-secret",
+			http.StatusOK,
+			"admin",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var identity string
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if id, ok := IdentityFromContext(r.Context()); ok {
+					identity = id.Name
+				}
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set(tc.header, tc.token)
+			}
+			resp := httptest.NewRecorder()
+
+			am.withAuth(next).ServeHTTP(resp, req)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			assert.Equal(t, tc.identity, identity)
+		})
+	}
+}
+
+func TestAuthMiddleware_withAuth_Audit(t *testing.T) {
+	t.Parallel()
+
+	conf := &config.APIAuthConfig{
+		Tokens: []*config.APITokenConfig{
+			{Name: config.String("reader"), Secret: config.String("read-secret"),
+				Role: config.String(config.APIRoleRead)},
+		},
+	}
+	conf.Finalize()
+	am, err := newAuthMiddleware(conf, nil, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	am.audit = newAuditMiddleware(audit.NewWriter(&buf))
+
+	cases := []struct {
+		name       string
+		token      string
+		statusCode int
+		actor      *audit.Actor
+	}{
+		{
+			"unauthenticated",
+			"bad-secret",
+			http.StatusUnauthorized,
+			&audit.Actor{RemoteAddr: "192.0.2.1:1234"},
+		},
+		{
+			"forbidden",
+			"read-secret",
+			http.StatusForbidden,
+			&audit.Actor{TokenName: "reader", Role: config.APIRoleRead,
+				RemoteAddr: "192.0.2.1:1234"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			buf.Reset()
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				t.Fatal("rejected request reached the next handler")
+			})
+
+			req := httptest.NewRequest(http.MethodDelete, "/v1/tasks/task", nil)
+			req.Header.Set(TokenHeader, tc.token)
+			resp := httptest.NewRecorder()
+			am.withAuth(next).ServeHTTP(resp, req)
+			require.Equal(t, tc.statusCode, resp.Code)
+
+			var entry audit.Entry
+			require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+			assert.Equal(t, auditActionTaskDelete, entry.Action)
+			assert.Equal(t, "task", entry.TaskName)
+			assert.Equal(t, tc.actor, entry.Actor)
+			assert.False(t, entry.Result.Success)
+			assert.Equal(t, tc.statusCode, entry.Result.StatusCode)
+			assert.NotEmpty(t, entry.Result.Error)
+		})
+	}
+}
+
+func TestConsulACLTokens_authenticate(t *testing.T) {
+	t.Parallel()
+
+	roles := map[string]string{
+		"cts-read":  config.APIRoleRead,
+		"cts-admin": config.APIRoleAdmin,
+	}
+	ctx := context.Background()
+
+	t.Run("most privileged role", func(t *testing.T) {
+		c := new(mocks.ConsulClientInterface)
+		c.On("ACLTokenReadSelf", mock.Anything, &consulapi.QueryOptions{Token: "token"}).
+			Return(&consulapi.ACLToken{
+				AccessorID: "accessor",
+				Policies:   []*consulapi.ACLTokenPolicyLink{{Name: "cts-read"}},
+				Roles:      []*consulapi.ACLTokenRoleLink{{Name: "cts-admin"}},
+			}, nil).Once()
+		a := newConsulACLTokens(roles, c)
+
+		id, ok, err := a.authenticate(ctx, "token")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, Identity{Name: "accessor", Role: config.APIRoleAdmin}, id)
+
+		// identity is cached
+		_, ok, err = a.authenticate(ctx, "token")
+		require.NoError(t, err)
+		assert.True(t, ok)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("no mapped role", func(t *testing.T) {
+		c := new(mocks.ConsulClientInterface)
+		c.On("ACLTokenReadSelf", mock.Anything, mock.Anything).
+			Return(&consulapi.ACLToken{
+				Policies: []*consulapi.ACLTokenPolicyLink{{Name: "other"}},
+			}, nil)
+		a := newConsulACLTokens(roles, c)
+
+		_, ok, err := a.authenticate(ctx, "token")
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("token not found", func(t *testing.T) {
+		c := new(mocks.ConsulClientInterface)
+		c.On("ACLTokenReadSelf", mock.Anything, mock.Anything).
+			Return(nil, fmt.Errorf("retry attempt #1 failed '%w'", &retry.NonRetryableError{
+				Err: consulapi.StatusError{Code: 403, Body: "ACL not found"},
+			})).Twice()
+		a := newConsulACLTokens(roles, c)
+
+		// not found is not cached
+		for i := 0; i < 2; i++ {
+			_, ok, err := a.authenticate(ctx, "token")
+			require.NoError(t, err)
+			assert.False(t, ok)
+		}
+		c.AssertExpectations(t)
+	})
+
+	errCases := []struct {
+		name string
+		err  error
+	}{
+		{
+			"consul error",
+			consulapi.StatusError{Code: 500, Body: "rpc error"},
+		}, {
+			"permission denied",
+			consulapi.StatusError{Code: 403, Body: "Permission denied"},
+		}, {
+			"connection error",
+			errors.New("dial tcp 127.0.0.1:8500: connection refused: 403"),
+		},
+	}
+	for _, tc := range errCases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := new(mocks.ConsulClientInterface)
+			c.On("ACLTokenReadSelf", mock.Anything, mock.Anything).
+				Return(nil, tc.err).Once()
+			c.On("ACLTokenReadSelf", mock.Anything, mock.Anything).
+				Return(&consulapi.ACLToken{
+					AccessorID: "accessor",
+					Policies:   []*consulapi.ACLTokenPolicyLink{{Name: "cts-read"}},
+				}, nil).Once()
+			a := newConsulACLTokens(roles, c)
+
+			_, _, err := a.authenticate(ctx, "token")
+			assert.Error(t, err)
+
+			// the error is not cached and the token is validated again
+			id, ok, err := a.authenticate(ctx, "token")
+			require.NoError(t, err)
+			assert.True(t, ok)
+			assert.Equal(t, "accessor", id.Name)
+			c.AssertExpectations(t)
+		})
+	}
+}
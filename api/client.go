@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/go-rootcerts"
 )
 
@@ -25,6 +26,7 @@ const (
 
 	// Environment variable names
 	EnvAddress = "CTS_ADDRESS" // The address of the CTS daemon, supports http or https by specifying as part of the address (e.g. https://localhost:8558)
+	EnvToken   = "CTS_TOKEN"   // The token to authenticate requests when API authentication is enabled
 
 	// TLS environment variable names
 	EnvTLSCACert     = "CTS_CACERT"      // Path to a directory of CA certificates to use for TLS when communicating with Consul-Terraform-Sync
@@ -43,12 +45,14 @@ type httpClient interface {
 type Client struct {
 	version string
 	url     *url.URL
+	token   string
 	http    httpClient
 }
 
 // ClientConfig configures the client to make api requests
 type ClientConfig struct {
 	URL       string
+	Token     string
 	TLSConfig TLSConfig
 }
 
@@ -72,6 +76,10 @@ func BaseClientConfig() *ClientConfig {
 		c.URL = value
 	}
 
+	if value, found := os.LookupEnv(EnvToken); found {
+		c.Token = value
+	}
+
 	// Update TLS configs from env vars
 	if value, found := os.LookupEnv(EnvTLSCACert); found {
 		c.TLSConfig.CACert = value
@@ -117,6 +125,7 @@ func NewClient(c *ClientConfig, httpClient httpClient) (*Client, error) {
 	client := &Client{
 		version: defaultAPIVersion,
 		url:     u,
+		token:   c.Token,
 		http:    httpClient,
 	}
 
@@ -263,6 +272,9 @@ func (c *Client) request(method, path, query, body string) (*http.Response, erro
 	if err != nil {
 		return nil, err
 	}
+	if c.token != "" {
+		req.Header.Set(TokenHeader, c.token)
+	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
@@ -375,6 +387,27 @@ func (s *StatusClient) Task(name string, q *QueryParam) (map[string]TaskStatus,
 	return taskStatuses, nil
 }
 
+// TaskEventLogs is used to query for the Terraform output captured during an
+// event of a task
+func (s *StatusClient) TaskEventLogs(name, eventID string) (TaskEventLogs, error) {
+	var logs TaskEventLogs
+
+	path := fmt.Sprintf("%s/%s/events/%s/%s", taskStatusPath, name, eventID,
+		taskEventLogsSuffix)
+	resp, err := s.request(http.MethodGet, path, "", "")
+	if err != nil {
+		return logs, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&logs); err != nil {
+		return logs, err
+	}
+
+	return logs, nil
+}
+
 // TaskClient can be used to query the task endpoints
 type TaskClient struct {
 	*Client
@@ -412,6 +445,62 @@ func (t *TaskClient) Update(name string, config UpdateTaskConfig, q *QueryParam)
 	return plan, nil
 }
 
+// List is used to query for all tasks
+func (t *TaskClient) List() (TasksResponse, error) {
+	var tasks TasksResponse
+
+	resp, err := t.request(http.MethodGet, taskPath, "", "")
+	if err != nil {
+		return tasks, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&tasks); err != nil {
+		return tasks, err
+	}
+
+	return tasks, nil
+}
+
+// Get is used to query for a task by name
+func (t *TaskClient) Get(name string) (TaskResponse, error) {
+	var task TaskResponse
+
+	path := fmt.Sprintf("%s/%s", taskPath, name)
+	resp, err := t.request(http.MethodGet, path, "", "")
+	if err != nil {
+		return task, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&task); err != nil {
+		return task, err
+	}
+
+	return task, nil
+}
+
+// State is used to query for the resources managed by a task
+func (t *TaskClient) State(name string) (oapigen.TaskStateResponse, error) {
+	var state oapigen.TaskStateResponse
+
+	path := fmt.Sprintf("%s/%s/state", taskPath, name)
+	resp, err := t.request(http.MethodGet, path, "", "")
+	if err != nil {
+		return state, err
+	}
+	defer resp.Body.Close()
+
+	decoder := json.NewDecoder(resp.Body)
+	if err = decoder.Decode(&state); err != nil {
+		return state, err
+	}
+
+	return state, nil
+}
+
 func parseURL(urlString string) (*url.URL, error) {
 	u, err := url.ParseRequestURI(urlString)
 	if err != nil {
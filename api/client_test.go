@@ -11,6 +11,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/stretchr/testify/assert"
 
 	"github.com/stretchr/testify/require"
@@ -42,6 +44,7 @@ func Test_BaseClientConfig_Defaults(t *testing.T) {
 func Test_BaseClientConfig_WithEnvVars(t *testing.T) {
 	t.Cleanup(func() {
 		_ = os.Unsetenv(EnvAddress)
+		_ = os.Unsetenv(EnvToken)
 		_ = os.Unsetenv(EnvTLSCACert)
 		_ = os.Unsetenv(EnvTLSCAPath)
 		_ = os.Unsetenv(EnvTLSClientCert)
@@ -50,6 +53,7 @@ func Test_BaseClientConfig_WithEnvVars(t *testing.T) {
 	})
 
 	urlString := "https://1.2.3.4:5678"
+	token := "token"
 	caCert := "test/path/ca.pem"
 	caPath := "test/path"
 	clientCert := "test/path/client.pem"
@@ -57,6 +61,7 @@ func Test_BaseClientConfig_WithEnvVars(t *testing.T) {
 	sslVerify := "false"
 
 	require.NoError(t, os.Setenv(EnvAddress, urlString))
+	require.NoError(t, os.Setenv(EnvToken, token))
 	require.NoError(t, os.Setenv(EnvTLSCACert, caCert))
 	require.NoError(t, os.Setenv(EnvTLSCAPath, caPath))
 	require.NoError(t, os.Setenv(EnvTLSClientCert, clientCert))
@@ -66,6 +71,7 @@ func Test_BaseClientConfig_WithEnvVars(t *testing.T) {
 	clientConfig := BaseClientConfig()
 
 	assert.Equal(t, urlString, clientConfig.URL)
+	assert.Equal(t, token, clientConfig.Token)
 	assert.Equal(t, caCert, clientConfig.TLSConfig.CACert)
 	assert.Equal(t, caPath, clientConfig.TLSConfig.CAPath)
 	assert.Equal(t, clientCert, clientConfig.TLSConfig.ClientCert)
@@ -352,6 +358,94 @@ func Test_StatusClient_Overall(t *testing.T) {
 	assert.Equal(t, expectedOverallStatus, o)
 }
 
+func Test_TaskClient_List(t *testing.T) {
+	tasks := []oapigen.Task{{Name: "task_a"}, {Name: "task_b"}}
+	expected := TasksResponse{RequestId: uuid.MustParse("e9926514-79b8-a8fc-8761-9b6aaccf1e15"), Tasks: &tasks}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/tasks", r.URL.Path)
+		assert.Equal(t, http.MethodGet, r.Method)
+		err := json.NewEncoder(w).Encode(expected)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	clientConfig := BaseClientConfig()
+	clientConfig.URL = server.URL
+	c, err := NewClient(clientConfig, nil)
+	require.NoError(t, err)
+
+	actual, err := c.Task().List()
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func Test_TaskClient_Get(t *testing.T) {
+	expected := TaskResponse{
+		RequestId: uuid.MustParse("e9926514-79b8-a8fc-8761-9b6aaccf1e15"),
+		Task:      &oapigen.Task{Name: "task_a", Module: "path/to/module"},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/tasks/task_a" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		err := json.NewEncoder(w).Encode(expected)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	clientConfig := BaseClientConfig()
+	clientConfig.URL = server.URL
+	c, err := NewClient(clientConfig, nil)
+	require.NoError(t, err)
+
+	actual, err := c.Task().Get("task_a")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	_, err = c.Task().Get("task_b")
+	assert.Error(t, err)
+}
+
+func Test_TaskClient_State(t *testing.T) {
+	expected := oapigen.TaskStateResponse{
+		RequestId: uuid.MustParse("e9926514-79b8-a8fc-8761-9b6aaccf1e15"),
+		Resources: []oapigen.StateResource{{
+			Address:      "module.task_a.local_file.api",
+			Type:         "local_file",
+			Name:         "api",
+			ProviderName: "registry.terraform.io/hashicorp/local",
+			Attributes: oapigen.StateResource_Attributes{
+				AdditionalProperties: map[string]interface{}{"filename": "api.txt"},
+			},
+		}},
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/tasks/task_a/state" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		err := json.NewEncoder(w).Encode(expected)
+		assert.NoError(t, err)
+	}))
+	defer server.Close()
+
+	clientConfig := BaseClientConfig()
+	clientConfig.URL = server.URL
+	c, err := NewClient(clientConfig, nil)
+	require.NoError(t, err)
+
+	actual, err := c.Task().State("task_a")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+
+	_, err = c.Task().State("task_b")
+	assert.Error(t, err)
+}
+
 func Test_WaitForTestReadiness_success(t *testing.T) {
 	expected := map[string]TaskStatus{
 		"task_a": {Enabled: true, Status: StatusCritical},
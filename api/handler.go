@@ -20,6 +20,9 @@ const (
 type Handlers struct {
 	*TaskLifeCycleHandler
 	*HealthHandler
+	*ReloadHandler
+	*RolloutHandler
+	*LogLevelsHandler
 }
 
 //go:generate oapi-codegen -package oapigen -old-config-style -generate types -o oapigen/types.go openapi.yaml
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+const (
+	logLevelsPath          = "/v1/logging"
+	logLevelsSubsystemName = "loglevels"
+)
+
+// LogLevelsHandler handles the logging endpoints to get and update the log
+// levels at runtime
+type LogLevelsHandler struct {
+	mu sync.Mutex
+}
+
+// NewLogLevelsHandler creates a new log levels handler
+func NewLogLevelsHandler() *LogLevelsHandler {
+	return &LogLevelsHandler{}
+}
+
+// GetLogLevels returns the global log level and the log levels of subsystems
+func (h *LogLevelsHandler) GetLogLevels(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	writeResponse(w, r, http.StatusOK, logLevelsResponse(r))
+}
+
+// UpdateLogLevels updates the global log level and the log levels of
+// subsystems
+func (h *LogLevelsHandler) UpdateLogLevels(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx := r.Context()
+	logger := logging.FromContext(ctx).Named(logLevelsSubsystemName)
+
+	var req oapigen.LogLevelsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("bad request", "error", err)
+		sendError(w, r, http.StatusBadRequest,
+			fmt.Errorf("error decoding the request: %v", err))
+		return
+	}
+
+	var level string
+	if req.Level != nil {
+		level = *req.Level
+	}
+	var subsystems map[string]string
+	if req.Subsystems != nil {
+		subsystems = req.Subsystems.AdditionalProperties
+	}
+
+	if err := logging.UpdateLevels(level, subsystems); err != nil {
+		sendError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	resp := logLevelsResponse(r)
+	logger.Info("log levels updated", "level", resp.Level,
+		"subsystems", resp.Subsystems.AdditionalProperties)
+	writeResponse(w, r, http.StatusOK, resp)
+}
+
+func logLevelsResponse(r *http.Request) oapigen.LogLevelsResponse {
+	level, subsystems := logging.GetLevels()
+	return oapigen.LogLevelsResponse{
+		RequestId: requestIDFromContext(r.Context()),
+		Level:     level,
+		Subsystems: oapigen.LogLevelsResponse_Subsystems{
+			AdditionalProperties: subsystems,
+		},
+	}
+}
@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLogLevelsHandler(t *testing.T) {
+	// log levels are global, so this test is not run in parallel
+	require.NoError(t, logging.SetLevels("INFO", map[string]string{"api": "DEBUG"}))
+	defer logging.SetLevels("INFO", nil)
+
+	handler := NewLogLevelsHandler()
+
+	t.Run("get", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/v1/logging", nil)
+		resp := httptest.NewRecorder()
+		handler.GetLogLevels(resp, req)
+		require.Equal(t, http.StatusOK, resp.Code)
+
+		var actual oapigen.LogLevelsResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+		assert.Equal(t, "INFO", actual.Level)
+		assert.Equal(t, map[string]string{"api": "DEBUG"},
+			actual.Subsystems.AdditionalProperties)
+	})
+
+	cases := []struct {
+		name       string
+		body       string
+		statusCode int
+		level      string
+		subsystems map[string]string
+	}{
+		{
+			"update",
+			`{"level":"WARN","subsystems":{"templates":"TRACE","api":""}}`,
+			http.StatusOK,
+			"WARN",
+			map[string]string{"templates": "TRACE"},
+		},
+		{
+			"invalid_level",
+			`{"subsystems":{"registration":"LOUD"}}`,
+			http.StatusBadRequest,
+			"WARN",
+			map[string]string{"templates": "TRACE"},
+		},
+		{
+			"bad_request",
+			`{"level":`,
+			http.StatusBadRequest,
+			"WARN",
+			map[string]string{"templates": "TRACE"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/v1/logging",
+				strings.NewReader(tc.body))
+			resp := httptest.NewRecorder()
+			handler.UpdateLogLevels(resp, req)
+			assert.Equal(t, tc.statusCode, resp.Code)
+
+			level, subsystems := logging.GetLevels()
+			assert.Equal(t, tc.level, level)
+			assert.Equal(t, tc.subsystems, subsystems)
+		})
+	}
+}
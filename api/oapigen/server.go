@@ -20,9 +20,24 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Runs an operation on multiple tasks
+	// (POST /v1/bulk/tasks/{operation})
+	BulkTasks(w http.ResponseWriter, r *http.Request, operation BulkTasksParamsOperation)
 	// Gets health status
 	// (GET /v1/health)
 	GetHealth(w http.ResponseWriter, r *http.Request)
+	// Gets the log levels
+	// (GET /v1/logging)
+	GetLogLevels(w http.ResponseWriter, r *http.Request)
+	// Updates the log levels
+	// (PATCH /v1/logging)
+	UpdateLogLevels(w http.ResponseWriter, r *http.Request)
+	// Reloads the configuration
+	// (POST /v1/reload)
+	ReloadConfig(w http.ResponseWriter, r *http.Request)
+	// Gets the rollout progress
+	// (GET /v1/rollout)
+	GetRollout(w http.ResponseWriter, r *http.Request)
 	// Gets all tasks
 	// (GET /v1/tasks)
 	GetAllTasks(w http.ResponseWriter, r *http.Request)
@@ -31,10 +46,22 @@ type ServerInterface interface {
 	CreateTask(w http.ResponseWriter, r *http.Request, params CreateTaskParams)
 	// Marks a task for deletion
 	// (DELETE /v1/tasks/{name})
-	DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string)
+	DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string, params DeleteTaskByNameParams)
 	// Gets a task by name
 	// (GET /v1/tasks/{name})
 	GetTaskByName(w http.ResponseWriter, r *http.Request, name string)
+	// Cancels the running execution of a task
+	// (POST /v1/tasks/{name}/cancel)
+	CancelTaskByName(w http.ResponseWriter, r *http.Request, name string)
+	// Exports a task as a standalone Terraform root module
+	// (GET /v1/tasks/{name}/export)
+	ExportTaskByName(w http.ResponseWriter, r *http.Request, name string)
+	// Gets the resources managed by a task
+	// (GET /v1/tasks/{name}/state)
+	GetTaskStateByName(w http.ResponseWriter, r *http.Request, name string)
+	// Migrates the state of a task to another backend
+	// (POST /v1/tasks/{name}/state/migrate)
+	MigrateTaskStateByName(w http.ResponseWriter, r *http.Request, name string)
 }
 
 // ServerInterfaceWrapper converts contexts to parameters.
@@ -46,6 +73,32 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.HandlerFunc) http.HandlerFunc
 
+// BulkTasks operation middleware
+func (siw *ServerInterfaceWrapper) BulkTasks(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "operation" -------------
+	var operation BulkTasksParamsOperation
+
+	err = runtime.BindStyledParameter("simple", false, "operation", chi.URLParam(r, "operation"), &operation)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "operation", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.BulkTasks(w, r, operation)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetHealth operation middleware
 func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -61,6 +114,66 @@ func (siw *ServerInterfaceWrapper) GetHealth(w http.ResponseWriter, r *http.Requ
 	handler(w, r.WithContext(ctx))
 }
 
+// GetLogLevels operation middleware
+func (siw *ServerInterfaceWrapper) GetLogLevels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetLogLevels(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// UpdateLogLevels operation middleware
+func (siw *ServerInterfaceWrapper) UpdateLogLevels(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.UpdateLogLevels(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ReloadConfig operation middleware
+func (siw *ServerInterfaceWrapper) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ReloadConfig(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetRollout operation middleware
+func (siw *ServerInterfaceWrapper) GetRollout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRollout(w, r)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 // GetAllTasks operation middleware
 func (siw *ServerInterfaceWrapper) GetAllTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
@@ -122,8 +235,44 @@ func (siw *ServerInterfaceWrapper) DeleteTaskByName(w http.ResponseWriter, r *ht
 		return
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTaskByNameParams
+
+	// ------------- Optional query parameter "cancel" -------------
+	if paramValue := r.URL.Query().Get("cancel"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "cancel", r.URL.Query(), &params.Cancel)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cancel", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "destroy" -------------
+	if paramValue := r.URL.Query().Get("destroy"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "destroy", r.URL.Query(), &params.Destroy)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "destroy", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "run" -------------
+	if paramValue := r.URL.Query().Get("run"); paramValue != "" {
+
+	}
+
+	err = runtime.BindQueryParameter("form", true, false, "run", r.URL.Query(), &params.Run)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "run", Err: err})
+		return
+	}
+
 	var handler = func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.DeleteTaskByName(w, r, name)
+		siw.Handler.DeleteTaskByName(w, r, name, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -159,6 +308,110 @@ func (siw *ServerInterfaceWrapper) GetTaskByName(w http.ResponseWriter, r *http.
 	handler(w, r.WithContext(ctx))
 }
 
+// CancelTaskByName operation middleware
+func (siw *ServerInterfaceWrapper) CancelTaskByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.CancelTaskByName(w, r, name)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// ExportTaskByName operation middleware
+func (siw *ServerInterfaceWrapper) ExportTaskByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.ExportTaskByName(w, r, name)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// GetTaskStateByName operation middleware
+func (siw *ServerInterfaceWrapper) GetTaskStateByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetTaskStateByName(w, r, name)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
+// MigrateTaskStateByName operation middleware
+func (siw *ServerInterfaceWrapper) MigrateTaskStateByName(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "name" -------------
+	var name string
+
+	err = runtime.BindStyledParameter("simple", false, "name", chi.URLParam(r, "name"), &name)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "name", Err: err})
+		return
+	}
+
+	var handler = func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.MigrateTaskStateByName(w, r, name)
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler(w, r.WithContext(ctx))
+}
+
 type UnescapedCookieParamError struct {
 	ParamName string
 	Err       error
@@ -272,9 +525,24 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/bulk/tasks/{operation}", wrapper.BulkTasks)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/health", wrapper.GetHealth)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/logging", wrapper.GetLogLevels)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/v1/logging", wrapper.UpdateLogLevels)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/reload", wrapper.ReloadConfig)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/rollout", wrapper.GetRollout)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/tasks", wrapper.GetAllTasks)
 	})
@@ -287,6 +555,18 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/v1/tasks/{name}", wrapper.GetTaskByName)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tasks/{name}/cancel", wrapper.CancelTaskByName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/{name}/export", wrapper.ExportTaskByName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/v1/tasks/{name}/state", wrapper.GetTaskStateByName)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/v1/tasks/{name}/state/migrate", wrapper.MigrateTaskStateByName)
+	})
 
 	return r
 }
@@ -294,59 +574,116 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/+xaa2/bRtb+K/NOXmDTru62k1hAP6SOd2tskga2236IDGE4cyhOTc6wM0MrgqH97Yu5",
-	"kCJFyrLcJg3QpkAakXM59/Occ3iPqcxyKUAYjaf3WNMEMuL++X0Rx6A+gOKS2d+EMW64FCT9oGQOynDQ",
-	"eBqTVEMPM9BU8dy+x1N8nQCK3HaUu/0olgoZxRcLUFwskCH6FsEnoIXdMcA9nNfOvMcgSJSCu7Z58i8J",
-	"mAQUMq0buEZhF5IKMa7dvwfoDcSkSI1GRrpdi1RGJN3aTKWI+aJQ4Ck9u76yNMEnkuUp4KlRBfSwWeWA",
-	"pziSMgUi8LqHM/KpTaJlPiOfeFZk5fEyRoZnYElYEm4QiQ0oRBMiFqARUYAYGKAGGIoglgoaskrAyeuP",
-	"YQWfaFyxoo29wXHCxQ5OuPhaOZmMOlhZV09k9CtQY5k7I4akcnEF6o5T0GdSeEvea9VNo2TEEArCgHIm",
-	"WtHB6LhLpIJkoHNCYWu1Z71zh2Qwz8CQ3YTdt3dVR9/jW1jhKb4jaQG4SxAKFvApb9KzhGjwbRc1hYY5",
-	"0fNMsiKFORd5YbyJePqDU1QHBZFtO4m79beCK+vNH0sKbrq09Gi1tK2UlnuRFGiZcJo4y/KmV9mdfeaD",
-	"DgzQRbx5nhDtfjDIFVBirVcHY0Exh7Rhi0QjgrxUkJNKD3Fjw4+yu7U1EIYSUGBXVoQNygPbwY5685yX",
-	"K+yz/1cQ4yl+NtyE52GIzcOd5rzuYSqFLtL57d3eQ9zC//zc2G1fWr72bb4K65qbH0l+B93rbnPYIvAr",
-	"89acmKS5OFv1rQd2rFVAC6Wh4T+B6n0O9Jkc0VF/84Dc37nrLsrb/oKSf6zEzpWS6kAZZaA1WWyxbBKu",
-	"bSAhAoE9E5WrurJcnbRy3U7qLkHnUngxbCGtkviHXNZzGC4Fbeac7dty6VdevGkR629snHWz7uEfgKQm",
-	"OUuA3tapPUCmh7DSSksbWjpkeIgvtJPTZnkjbTzX3yCTEFOlIY1yJe84gwoWXYNSJJYqKzdKUUPNXyiF",
-	"1ePOQ1ns0MxTF+oT0kdje1cC2Vhgw8mi6MURZS9H/Vfx8Un/OD6e9KPJy6gf0Ql5ER+fHo3hBe5hK3Vi",
-	"8BQXBWddgeWyODQjBZQ8DyLeXdxIhYQ0iItYEW1UQU2hoALZS6ijbFZsCioudA60rKjaiSRPidiKm06I",
-	"AwPa9B0yTyUl6TzmKQwWCsBwscElU3QJsQKd2Au1IQYGgwH6yNl3E3YyOj6Njl+y8Qt2So/Z+ITSk9PT",
-	"k1HM2BGDyXH08vTl+MXNTDzmxt0XvTg9Op7QE3p0CicETuLR6OVLApQeTegofjV+NR7H0avx6dHNTMzE",
-	"xnsKDcx5h4bUiy14mnKutgABihhwS2KZpnJpb648bSas5AboErQsFAVEnJB9vcMF497fltwkW0foVRbJ",
-	"VE9noj/8J2KgjZIrRISjRiCqwF6rIE8JhQyEadK95GlqqyH3o3lyIGFqNyD0DB2kSZQV2qCoupl5+lTJ",
-	"3wxvds8wmuHWCTOM7u3F9s9/bWgxIAxq/PkOzYrR6Ij6v/vnP16jZ7aQs/c3ON5s6aMfIE1lD5Gc/1/9",
-	"BSpfLCF6zIvzH6831HGG2n++QzP8WLOdYdR3XAB6fivkUoSyl+R5uvpmc+sz9PwIFcI7KkPEGMWjwoBG",
-	"CWcMRFi6tjr7kBIxRWNrfoSxHhrZf/mdPf84WMtgJrrCj4npXBViXqi0HUjOLfjKFdc2Y6SrAfrp8q0t",
-	"3TeWdZbKgiFVCJ+CqFTKJV1W5R4XUVQhmjV3Ykyup8MhyfOBKU8bcGkfDLNVX6rFcCnVrQN02j5Z6qEq",
-	"hPurTyL6Bv61+IH/ejueHB2fPK58b1cbB8ZdJbfC3rfI//dOir3Yyu3uAgW/t51AjZ4XGtScQcwFsMMr",
-	"/xZJByLvmKetpbPZDNsgYv+PuECBy8E1Weid6L1xxEe8hAj3MMm5lRs3kD1IPlGKrJ5WCPw5/YydlvD0",
-	"kulvW/iSttAlrmuib/cqrdZqo3Wvr2PXIIQG5/bGZoR+jSKiOXVR1gbYst/tjdDbqKVPLYbh0mF46GVj",
-	"q0Wib888DPdQBk8/3vTwHVHcHuaIuSNqjKcl3QNXCFhu70BpT8h4MBqMHDhs2JfvxM7zqvv/ECRvTAp8",
-	"E2ojmz2lwKZ/1BBQVys6KTIikALCLH/IwCcT8iRVPIJNe7mRsWwx7X+Uwm53UOvThkY02D188IC7c+aA",
-	"YiWzEj2KxeMmCbLsu7X5tljM9zbjzqqwyW+nybT7/VtR8CEtbRVq3vy6CC0E/60AZBeUtLb1YZ+87uzq",
-	"bOy4UwpcG3tqucxdo5sV9D/KatUift249+NB4aeCNnNqgdK8gjT7ZFXpxgGsX6ptjTMr79vm801VvPcs",
-	"B156O2kZtE60FaABwgaohQCtCMtVDSRopLvKDegaxuUhYnUbIlpLypuVjp/mXIe+lb0JkTvCU+egS1vi",
-	"uNKrWr99OlP8DlR7HpQSm3eQFTAx3B5WcRi72liDaZqVj2MdZtWIhw+p7uew8B3JGyGyyxhrkjR+/FC2",
-	"VoL9NczSW+MuJp/I2RZMdV5ZxZF6DL7Zke3eQAoGvkCv7Y9pG+5p0VmOwuYDWTEh8z/o1nbNNkVu425a",
-	"vla59rAq9mbmy8KlpSfL5hHa0k8U0ROZdh1Wu6PKAvuZ2k4MBzK5Ixcc1kFuRfKzEGw8JnDDXv1VRPFW",
-	"S5gsQJh5LmUalLWHs9d2PbLr0cUby5IG8ztY8qTbX1Unz4ZnsEzOPHEzPEDn3IG6BrEWztUeOETj2uNe",
-	"+TZWP3jmRYwiaRLXINRger7d17zCkFvQyCZ8YCDoFowjdll/PDnqymlbpD1CtO8DJiMbEf+15WtT73yz",
-	"obMsKCmYWwoeIeTzJsm/W8ADdEaE98cI0AwryKSBGbbSqwmjjis2i7bMyS7u7CPuR6V/Y8ndbYM6aDyk",
-	"XdP1yVhuhVnBVQ8hrYGHiofVO7NVpVOb9JRUWUK5iGVoUxhCTdmYcIGF942UKReLPpUK2tS8/nCB3kha",
-	"ZCCMTzLu8ys3tutXUu9frQTtuVeZdHMQPzKz6zUA+ug3oPcXr9HrDxc3z8vW8XK5HPhh4YDLIZNUDwUn",
-	"Q5Lzb3APp5xCwASB4Hcf3vYngxF6G970sOt5V63oBTdJEQ2ozIYJ0QmnUuVDf0G/su6+Xgk6jFIZDTPC",
-	"xfDtxdn5+6tz5wHcOK2fXV9ZQnFnd0TmIEjO8RQfBePIiUmcbod342Hihtf21wI6Bntuqu3ns36l1fTZ",
-	"9RV2B/tMfsHwFP8bjJ+Du/m4h0fuksloVKozjA5JnqfcNwaGv+rQh3LoZR+26Zq0r9stKisPrgPBK28m",
-	"oTnypxBSiIqUdQ/rIsuIWnmZlVS6YWHhepNk4ZpwQTGuA2cVVaHATj1dglEc7kA3rNmaOElTP6PvUtnr",
-	"NL0O7z6b0pqIuUNKbgFSgQP2OfTV/JSkg4afBHzK/YAVqu8stjRVl2SpJf/7Zt3DudRd/uPmpBoRJGDp",
-	"druhWFMRftG17/HlRJEMjO+KttosPI5BWSBk87R2ClaFEFwsBuiqyHOpjHbDMSGX4XNCVQhda/xlGTCb",
-	"v9LVTBDhJ2lh/B420IpmplZ+0uY+QbRR3aY4vxiYmwYzrilRDNhMhL4BCFZ2F2pjfcc2tzz8VoBabZrB",
-	"tqTr1dQIoshcW0Au3Q53Qq1KqXLaTVVFfi/Z6g8117Ic32GsbuDphITrZZVRBaw/syPt8yNU3u7RxkYB",
-	"Pa9Eixo86c7PJqPxn0Ner2pD16j52ry+7bwdnl8Pz8N7a9RrHwZSMB3Q+x1Rt/ZEzcUiNPadF7v1NmZH",
-	"xGJF6csTV16UKMrDV1+/8DRFEcyEv8aupxC+hLIqLmNCR7DxzTOrjO9X733r7cGQU9Zf5WfIgbHgzO7T",
-	"wsqXQyuv6RIN597XTPde3XCgySPsoTbeqjdZHvfJ1Lp3gIVv9R532XlG1G34+r/U7Ndo4aU1tsywM8Ud",
-	"ijwaRr7brruAydPts8QRX9BCv3iI/+qRUlD5CgV5t4Jm+GyyW6U2zHUWbW6OD6oqpO5zJY2kMl1Ph8P7",
-	"RGqznt5bDLTGW+OTpEJnQVz+OzH32IE3tfX61cnJqzDbczc039oKzg2/PVYJP11d57i7Wf8vAAD//+6j",
-	"naGfNgAA",
+	"H4sIAAAAAAAC/+w9aW/cOJZ/haNeoLtnVZePTsdAPiROZibYdDpIPNPARoaHJb2q4lgi1SRlpzao/e0L",
+	"nhJ11JXEY+xMupHYEo/Hx8fHd+tzlLKiZBSoFNHF50ikKyiw/vEFTm+BZupHnGVEEkZx/o6zErgkIKIL",
+	"ySuIowxEykmpXkcX0dUK0BVwjheMF2huhkByhSUSknEQSK4ACYklILbQv0gsbmN0C2vI0HytH7l+OZ5D",
+	"Pka/3gHnJAMRvLTd69kyTu6Aj9FVo1HK6IIsK44VfIgIxAoiJWRowVmBOIiSUQECCUJTQESiAq9VJ4kJ",
+	"RSmHDKgkOBfjKI7gEy7KHBRCcpbiXP1QYrmKLiK1hudjudALizabOJLrEqKLiM3/AamMNnH0olosgL8D",
+	"TtgWnC5wLnqROtfdUan7owXjSHKyXAIndKlRiOATpJXqoWAtG2N+joDieQ562nDk31YgV8CR7MxABLK9",
+	"EOMoI0L/PEYvYYGrXAokme61zNkc563ODutgIL28+hDgzxCOxdCcsRwwVSgq8KcuiGrxBf5Eiqpww6ud",
+	"JwUoEO4xkQgvJHCUrjBdgkCYA8pAQqq2eQ4LxiHAlSW5r7OU6FxEfilCqhn0SggdWAmhj3UlJ9OepfRT",
+	"cn57hcXte/i9AiF3EnNIi1iygqQGOxrqAaL3pMkQo/naIsUvWiBizr8a25xuUaUpQCb08nCeWw5RjKM+",
+	"YqO4MAB1N0m/arInjVhzHGJ3FmKUQQ4SYnU+eEUDZH40HEFNjMXti+g6joiEQs/XIRb7AHOO1+p3ATmk",
+	"kvF+4DRPRK7NwVCiS1YUeCSgxBwruuLwe0U4FEClX7Nmp0l0C+tndzivIIlUd/3gD/6JZurSQaT7YsOJ",
+	"ikpIvQEFgAyJTAIunpV4rafrOzl6If0r92tMOWAJ46iB1P/gsIguou8m9XU2sXfZRNFqF8/bKdvcCweS",
+	"NnDO+C5oXulGmzji5vjckGxXF3vQXr803YQ66v0oMi/dNtZHQ50IwOnKXraEmvc8A17vo8HvPXCwhy3b",
+	"G8UNxKkT3YdsS2eZOhyNpdcLut6+IZpTHLcdXTzpV10OssAkt+zRM9c+IlUMYph1NM9ki/odU2gPKCSW",
+	"ldh+P9dgJpFldZC5k2kgT6IA9hjdY6Fa35Ky1G/nkOJKGC5qGHFj2IxkiDKJ1P56nmFG4CzPIbtRUlUS",
+	"2VsKU2ZgU0fezB+u1gPZe6006UHj02OhjxAuscQ5W34AfkdSEJeMGjI4kCQyLHEKVIKmixrULJ0N7bMo",
+	"cQqt1vbi6uvBMrgpQOJhwHpugIZkeQvr6CLSPDbqY1EclvCpDOG5h/n4j33QVAJusLgpWFblcENoWcng",
+	"4jWCmB/Ioqx9V3YOr4agd5f23pbuyUldX8Qoul8RxayYE3c8SatnRtCFMXpdnzO0wkY7yKDkkOqbTVhi",
+	"QQsCeSD/YIEwMlhBGisxIhIRgbjqLYCq7ivgoFp6wMZuwK6AnRryvHEtdvHLQXLexFHKqKjym9u7nYPo",
+	"hv/1t6C3eqnWtavzB9su7Lwn+D1wb/rJoQXgIzutRntrNi7WI3UCe9pySCsuoE9w3XWAvtFB1NBfb8H7",
+	"L3q61262f0HM74uxV05UOABHBQiBl60lyxURipFgamUM12rXFejaDULXlEkfXOxsAWtmDMa63sTRXwDn",
+	"cnW5gvQhJOhhmbIPh2+UkvILLg+6l7u31C2sR/pyRiUmXGsjjC8xJf9j5efY3l1GQQt1M6fCsqWR6u6J",
+	"XFnTS36rm6Hn7163TE0ScBFdRF5n6mO0b9jyDdxBLo5TyXPVt1+etZaEnC2RbhUAF71++6dfe8XZai7W",
+	"wikOR6L7jZtTq5b1kLWp0D/TYncHb0WZY6mmiq7eP7981YO5Hbh8ACXwq6P+SK3y2+6YVjGZteIiuWtx",
+	"e2/eoE5p0Bosq48pHHJBdjeobh7Ikj+IH9s6dcnZnbFfs5bN2nZktGG+fSC5timMbBNtDxVHm0g9QqYM",
+	"uvcd0PeQM5wdeTqN6ajHEv62a/OzbY2rQK7AY9oaC0gOIrCQ7LTsGVvcfrPbttYzIVewNqYZDgW7+6pQ",
+	"PYjZqiqzvRHPwaFe35DOHP61VryNcdiJoxrgeteue4nRrTEQA+fzn07T7Ml09PPi7Hx0tjg7Gc1PnsxH",
+	"8/QE/7Q4e3o6g5+iOFIsAMvoIqoqkvVx8/csz9nB0vscy3RlftzLemdneaG69RIIzW4kMSYvD7LCzkg/",
+	"7YF7wPT2HrBgxvbIzZxohXNpDEedQQyF9dnKuDwQniH72gf93FGfhUkBA7QqFHEQelNytuQghKIOaxlT",
+	"e2UAj647s7XIS2+snT/2WxMso5ewmntyII/DFPP1DmefGlfdDOoX08E+6+Kiq84aa9/NkK1ePXbjmEH1",
+	"fWh6qTvQnC3EOFrhzD3nFRUoq7xrSzB8q71jh3Gz/bZag9Xc6BJopkaMW1uuoDDPm5tvQN5GBQ1PxpF8",
+	"yW6jG2iredRSy6P2W9SsbA9edKh697461Lhkb5UbKxgNHxfGtVWc0AXHQvIqlZX3kFh3iXfSOvJVkhuh",
+	"ooTUOeS7h6jMMW2ZQLToM5Yg5Eg7D3SYwY264MZLDiAJrU2MF+g9LDiIlZpQkQaMx2P0kWTPTrLz6dnT",
+	"+dmTbPZT9jQ9y2bnaXr+9On5dJFlpxmcnM2fPH0y++k6ofvMODzRT09Pz07S8/T0KZxjOF9Mp0+eYEjT",
+	"05N0uvh59vNstpj/PHt6ep3QhNYybyUgMydc68iQOfnY6NNLoMCxBOuJzHN2r2b28nFCFebG6D0IVvEU",
+	"ENZINu5yQjOS1qJDOIRYF3OWi4uEjib/iTIQkrM1wjpABaiV9xCHMsepdoiGcN+TPEclcP1LOLIF4UJ1",
+	"QOg7dNBOGn/p3M+cGfi4W18S1b2TCCVRZ4QkQp/VxOrP/+rwFaASBX+eoaSaTk9T8/fo1a9X6DvlLFLz",
+	"Byuuu4zQXyDPWYxwSf7QfIHci3uY7/Pi1a9XNXQkQ90/z1AS7Uu2SYRGehWAfril7J46f1RZ5usf61m/",
+	"Qz+coopaVybCUnIyryQItCJZBtQ23ag9e5djeoFmivxwlsVoWptrYvPYUss4ob1e60V6wyt6U/EeZf4V",
+	"lcBLTgToSIYx+uv7N+oeqinrMmeVvgDNRZkybiKTMq8xao7SjjKIVlKW4mIywWU5lm60MWHqwaRYjxhf",
+	"Tu4Zv9W2WaGe3IuJumfVXyM8T1/Cn5Z/If+4nZ2cnp3vF/3RdRwcqoGxFtv7IzL//cLoTjOp7t13AXyp",
+	"ZzCV4qYSwG8yWBAK2eFOvA5IBxrRFyTvNE2SJJIgpPoXEYrsKsdXeCkGDfHBEB+VdzCKI1ySw6JQDrfp",
+	"/3Nck4OUcLz349+08JC00LuHEktwF/yBu4ezTMvuvUZVPBcsryQg28qrO3YuJbVJTNPQmuwlMx3mWWLK",
+	"xI0d4MYAPVaOn4+JQm0SXfdhpL5/Do+olawcaZNm8xYbhBz9TTkodAMBVBBJ7iChjZ6Y17KGkZOS6Aff",
+	"9MckCm65elfsYd2O4BCvpouLqHWqnYN5GMnHR9008NGAIJxJbVbfDE4OvRmeqrU+16M9ezgfhyURkq/D",
+	"S7rEOcO5ZBSkvqQnmrD64DIPBgK+zGpVk3DSPjLdeb/a5q5d7EJzQswE1Nx3G+uQu13HtmHvT5u3dtNi",
+	"bJlYwLk2HcfDczTHgqRaStI6vQ13NufJUICCjy8ndtKJfeioSivZl42Fqkmv4+gOc6IG08DcYT6LLhzc",
+	"Y21+V6u9Ay4MILPxdDy1lBQY5Hwo/dYoOttsE0cmdPem9OHiW/s1Q8s3cYjNHSb7OvgjQGkfra2qAlPE",
+	"AWcKI0jCJ2kl45STOQzEvGGK7C9uezrkHYSnB/f/sAHLqNi9QerGUGz1RbrcK/TcxLDuwpd3Im9qquqN",
+	"0WWpsU13chT6uFEvWXZDyluS0jY4Wy6YYW5WUfJ7dXTUYuOs9GKBCB2K6prZuOrAN/a980OhSkCYZfHx",
+	"IBHFeQz3C8tErnkIjb1DJcEShEkksY9XwIkUNg7TmBvccMFImIO2FgmQrVBMUaUrZJ15Zsmxx4yItRXC",
+	"n1tkj4GFwV2imKI5IChK6a0WjT72Naa2heHG2twqIAD1+xDRjuGO3PveK8iR8E2qlNUbr1bujL52HbWS",
+	"+5vvFozpOWh78156t2dslqGocxCWcWdEvaOAszHqaOEKs65VoI1bjLnta6vpfjaEhWApCa1NGkB0ZcOA",
+	"1EwI32GSa5Z5vwJqzF++fXt0l8TUTulQ+yJMbC6WZJ7XsJOFo7hwV81d1LeVpABWye2pNplzr9Vh/d4C",
+	"53JRjG85xTSFXCcHvWXIDj4I1em06IMpuGe3kdPfbEPLhQcJp7W7TbchZO5EhYdfc6AhxB+J7f5AZ8/l",
+	"65t6SIq61Ng91tvttmbYuo2VVYkGBtaAUar473qH97lKHz4cLfTg+jUPofSl9uc+Xl/JYZ4PtaJXn0rG",
+	"H8b7o73tXxhEZw3UdbqRGtP9whmT/oL0oV6qiY/y6gmKP8olFdxh2/JYfcOO8GIyWYkw2a0ZInQv2Wkb",
+	"AddQOWwPbfsvZMmxBGssOSby71DFpAW3674fgP9/zttxyJZWKd6dq9aCSHcchuUR+3yrnSqoctxaZ/lR",
+	"uNljtx45+cWRM+KI7TYegQpM8bJOk3eq2l5BPqFJ9dAMPQvfEIqPDZY9EmHd0Iqj8j8PoaMBLeawqNGO",
+	"DnIZRLLdGbvtY9A/OmGgeAlU3pSM5XazdqzsuWqPVHv0+qVaUlMjPnxJBnT1Wy2mFizTV29igEuiMXpF",
+	"jFDbBBax4IE2BeiQWLP5SqLfOubrBZozudKqvQAZm2CBcAqJb0GgkkMKGXS8B1g1G81OTnv9AiFoe6D2",
+	"rTVm4BrF/9r4lerg1h36sOwhUB7HfZD8KgT5ixE8RpeYmvM4B5REHAomfWK9R0ZT+6wbtchJNd5uoxlU",
+	"i/9tBRl2OjZNC1+m4BS4VMj0Ro3aNmgtflkzrsPbQHt0GwUooQtmnSQSp9K5RTRjISPJWE7ocpQyDl1o",
+	"nr97jV6ytCqAyroUgAnVH3msjz6saRrrV4U27NCFDqtV7QUA+mg6oLevn6sMpesfXODJ/f392CQIKIdW",
+	"xlIxoQRPcEl+jOIoJylYmcAC/Mu7N6OT8RS9sW/iSEfM+ECWJZGraj5OWTFZYbEiKePlxEww8tQ9Emua",
+	"TuY5m08KTOjkzevLV28/mBQRIvWuX159UIBGvb4ZVgLFJVHGKEscJZYrvbeTu9lEJWNNtIQx+eyT4zfq",
+	"bclEj9XsfUVFK0Gf0braQh3lZqU2K2loCy4HWXHb3dRBSGhQq2GMTDQt5tDNR3AGdlsPhTaHH6M/mZOb",
+	"2DR9D52IkfRjNoHDfkBbAIXbp0HFkYQ6S3YSVvOIgd794VkGd0nUhNo5aNRyvYdGHXxlJbS2p4TKFRSx",
+	"z3lwr23QlXUbE16LwrE3gPOKNjDPKyoS6ua0iCkKyAiWkKvwrYS+WDv7etzaN3XCCK1AGB5FTMyyLShj",
+	"9+M3xbNs7QR1XSU0HMPxOx3fba+/O+DrGtXWmEqEFHoVKdZwx0hIVgrlqbdWES4MABUHu169Hs1M7qGm",
+	"iNghOQ7cYIbeEuoJjgPiChZpCjkZXFtaUHC4AGeNRQUWZTYu0XbSYQF+qa+z6MJX6BD6GHFcgDTO0z5N",
+	"psaSZGbjaJ32oUOvtd9armq/rO8SNeV1Y3s0Ar4pcGWiuA1CvAs4iiOLDZ/GERmttCds/9rrby9YtnYM",
+	"14YGq+BCYpx6k38I66f20+9XGkWPbVh6X8C8RUmbk3TWbRRGU7dMTX4ynX4DYM0EvdD2FV2yVVNwntvN",
+	"3MTRyfTJHoA1ogCa2uC+KTS+HM7Hz01X/vM6Xv6iWb0k2sReqdcN0ffq7xffq3OLUxX/4s6kJX6bB4T0",
+	"qSQC2RSTxlwvmnPZzAA1TSuywDex5ViizfUm/mb74oq52P3p7o73tH8l0gmT1Hvg+yuFT6XhgOAzuEVV",
+	"FDpZxVyjmIa3aFHlkpR5zSEkXgpnEhMmFETd2Sudea5AXELPFa1T0s0ta1qqS+7y6kOHm/0ZpElij77h",
+	"IetLk+/Bl5JhiLAAr7/Flh0GSEU9KMHG/RmkgxL5PCe3T+Z5vVE5Wy4Vux3aqfcgOYE7EL1Zwv7Sz/fL",
+	"NCayb4d9hvdxm9wsA2myt10y9jH8K0y87sl73ptHdBPXN5ttKdrcojp7jNxAE1W40w2qclR0bSqJmAy5",
+	"1viabR9LRlgmlFdUZ5+hDy3ycjEeocDtH7vaaeg5TagJxTCzmsxdqys4EjWipZ96jC4bxR8pkwktgQsi",
+	"pBWhw9hNZ7TplDnlOkm6X2QzqAlPwSFyT+MAfBPqbchJDyf5HHp6XHrwIzw7TdLfcXwsVzbUskXF1e/F",
+	"UPJ1rc6B1nqUvqbKi6KrJgRxQn0k2Dxn6a1oKjXbkrs10VuEjxP6Fu4bGqzXgey5a7zyeeTNiWz+fEJ3",
+	"zmi1UafMBjnpqt/zd68T2jn1v6kzaZMdAw24B0Klm7lhLTdRQuqSs4rqJzjMzTUJZ0a100+02zyhtvKM",
+	"Y2sufRWRBpev4bbAIaBZyQiVfTzC7LhxT3zxNemLLriyqNeNUggf6xKpx9yf7hxefNxfmm5VkOgTeQKS",
+	"cMz0UYrNQ0ezcd7t8a6Pe52Ku4cQ5qnJh/QLqY4WUOlJiS3CyRPqaxinnAlhSL6P0P4M0qX8fimZHaU+",
+	"1qjwVRM+1in01s7gNLdmCrj1QXqS3sR1N+v88/3qJPKg1wv74DK6VtYHDff5fJY9hSkenaZPYHSGp+no",
+	"6eJU/TSbn6Sn2RmcL8LqAapy88nJaDYdzZ5ezaYXU/X/fzcVzmZG+/43cjunvIc2bZNQlDybnj3c6XjL",
+	"GpUkhDO6PWqJ1sHbKDLgD6p5VZ9U7+jecU6bBvtQ0+85b8/z3Fnsvpk4FQYFDNq8Hr8O0sRk2/gQD4hL",
+	"l/q+EwgjakWVHsZnGl2ZlIitltOXZLEADlSHxYGxRlvDuVJOypJxKdQTRNm9LT/HnVPCxK/XFvCEar9D",
+	"RV19Atsh9TBnfK3f655a4SHCNa7t+CnmqvywM1Y3PsjQqHuQUGfU/b0CXcvC2sR4pd507beU3eseeoQH",
+	"NNLuYaCtC48/qEV2p9VPgeZmNzJevQHG02PkUyuDabvs7J8DXuyzdhrQPLZT3z28w2ZH6yVURL0xbEC7",
+	"GDoM4RfMlcCPBKFLa8zUp1i31wH1WEDmvCFqOO8obmSh6PoTc0ioc5Mx+80SIvQWO57Qw2yMs0dtxov1",
+	"W5vRt43luBATV3LZ+056PDQUF90jERzu3dGwHQ6qg7iFc/ANx6fHyr+G6Tp2iR7qpfqUhvYquuCFuqs6",
+	"xFaIc1kMZhOa39MYZFsmtjzgXN2M9A77Nk5MgeSuWD5zfBsftDE9LaAJ7ULqieN70YjIMEHJilGrZ6pD",
+	"Rrh23q6d1mlqyDFbWq4BllF89bwB8Vkac6RHFgm1zdpJvcI4TYexaPsdiMbgovN3l9NpHao8Vbs6rE0P",
+	"e5ljaohiEPS+CMs2EvwBPPx+23WzPbj6YwJ0O7WQzBE2FYoiUxxl2l8c5bRZHOUA7WLXxaEmRVu3qsEF",
+	"zK128q3xddDiWlkmQ3djgfmtdc+52+Ax3oruButcXb1i8aHaSnAxDt+FfcrM8Xea0z2+2a12/c8XCx+9",
+	"dmW3fI0svvcQtCb2Fh60Vu8UHvalt3FCr0x5RJNR1hhFW1ZTpnQghE259zugYc5vnyBmYDueaL0E8phI",
+	"tmnqrTMOzezfmMu20iOHToIByysij1Lv2E21+ysjE9AZgdssR14q8tl3GAmJaYZzRpsRuY2EvC63Tugu",
+	"1aX2p3CgGfB2mPD3AqUV57r2XGBDtv4MXzFFLu4wF66UjIHdxqVmWOKmXVqZOfxswZFsgERomle2HPmd",
+	"r5jTAMuugyfUuauECdrFWUG0jycMdNfeWFzJFVBpaahRoaKPG5i8zeO5gd3lR8sNfLKoitMdS3V2/H6a",
+	"ioUOPJ+vryoBmup+z9B0PDsdT2NbuRDNxqfjaRIldKNR2aaMupCBQM/QZ9Nsc5xXqZEZarFzEFdq5eMO",
+	"cSWzfY/zejZL8Dc0Fnuwhz15k1ZP92JNtbBvvaP1tP4TssOXeUIDdhTXPmZWydJ4rf5e06NYsXs0Ulj+",
+	"+xi9xBKjhk6cUPvhWJsx0a2whToFtjKcDgTNWrlVp+R9mfAafE738TKCIyM7XVbkx0ZVtyNqsYWl10LE",
+	"Nuqe1SjDJVGpA2qTVeLAVKUJjGfTvhpo7apldgRbYaxVUOzIQmB9izwkerSbBDvEkez3ix+z96zPVHOQ",
+	"aKTXOClMavqwFmFz18XhfCeUgpBkCXWRHUCzVtiYr/yjBR8QwZfuhDRlVnydARoM1bBMuRLC9Yeaf1VJ",
+	"CKpxbRds1vUgIqEWB1kdOeKm0b+UHO4Iq0QNuUA5LGRdVFcD4MZxH9D0X+atJ27EtqhxcM4BZ2u7g6Le",
+	"0z5eaffhC/mlXeoDsssjI+oaFRnEqX5Qpbcg1Wz26+Kx/Uakm13xFL3mSowACzmamfO1P2/oqyPRc0Rt",
+	"FYh+dEr2ldxiD2eh661OsZUzunU/SoktYFgNNuWOgPteq6OwHm5pP9cznMDTmzioK9EC98l8n0vOJEtZ",
+	"vrmYTD6vmJCbi89KmtxErUJPK895LSpNpXP9WHvXeev1z+fnP+s3dobw7UrKsvFJB/ur+ses7nrzfwMA",
+	"ekF+sO+AAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
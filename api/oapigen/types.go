@@ -6,10 +6,33 @@ package oapigen
 import (
 	"encoding/json"
 	"fmt"
+	"time"
 
 	openapi_types "github.com/deepmap/oapi-codegen/pkg/types"
 )
 
+// Defines values for RolloutStatus.
+const (
+	RolloutStatusCompleted  RolloutStatus = "completed"
+	RolloutStatusHalted     RolloutStatus = "halted"
+	RolloutStatusInProgress RolloutStatus = "in_progress"
+)
+
+// Defines values for RolloutBatchStatus.
+const (
+	RolloutBatchStatusCompleted  RolloutBatchStatus = "completed"
+	RolloutBatchStatusFailed     RolloutBatchStatus = "failed"
+	RolloutBatchStatusHalted     RolloutBatchStatus = "halted"
+	RolloutBatchStatusInProgress RolloutBatchStatus = "in_progress"
+	RolloutBatchStatusPending    RolloutBatchStatus = "pending"
+	RolloutBatchStatusSoaking    RolloutBatchStatus = "soaking"
+)
+
+// The Terraform backend that stores the state of the task, keyed by the backend label. Overrides the backend of the Terraform driver. The backend configuration is omitted from responses since it may contain credentials.
+type Backend struct {
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
 // The buffer period for triggering task execution.
 type BufferPeriod struct {
 	// Whether the buffer period is enabled or disabled. Defaults to the global buffer period configured for CTS.
@@ -22,6 +45,42 @@ type BufferPeriod struct {
 	Min *string `json:"min,omitempty"`
 }
 
+// BulkTaskRequest defines model for BulkTaskRequest.
+type BulkTaskRequest struct {
+	// Whether to only change the tasks if the operation succeeds for all of them.
+	Atomic *bool `json:"atomic,omitempty"`
+
+	// The names of the tasks to enable, disable, delete, or run.
+	Names *[]string `json:"names,omitempty"`
+
+	// The label selector of the tasks to enable, disable, delete, or run. Comma-separated requirements of the form "key=value" or "key!=value" that the labels of a task must all meet.
+	Selector *string `json:"selector,omitempty"`
+
+	// The tasks to create.
+	Tasks *[]Task `json:"tasks,omitempty"`
+}
+
+// BulkTaskResponse defines model for BulkTaskResponse.
+type BulkTaskResponse struct {
+	Error     *Error    `json:"error,omitempty"`
+	RequestId RequestID `json:"request_id"`
+
+	// The result of the operation for each task, in the order that the tasks were changed.
+	Results []BulkTaskResult `json:"results"`
+}
+
+// BulkTaskResult defines model for BulkTaskResult.
+type BulkTaskResult struct {
+	// The error if the operation failed for the task.
+	Error *string `json:"error,omitempty"`
+
+	// The name of the task.
+	Name string `json:"name"`
+
+	// Whether the operation "succeeded" or "failed" for the task, was "skipped" because the atomic operation did not complete, or was "rolled_back" after another task failed.
+	Status string `json:"status"`
+}
+
 // CatalogServicesCondition defines model for CatalogServicesCondition.
 type CatalogServicesCondition struct {
 	Datacenter       *string                            `json:"datacenter,omitempty"`
@@ -77,15 +136,105 @@ type HealthCheckResponse struct {
 	Error *Error `json:"error,omitempty"`
 }
 
+// The key-value pairs to organize tasks, which select the tasks to change together with the bulk task API.
+type LabelMap struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// LogLevelsRequest defines model for LogLevelsRequest.
+type LogLevelsRequest struct {
+	// The global log level.
+	Level *string `json:"level,omitempty"`
+
+	// Log levels of subsystems keyed by subsystem name.
+	Subsystems *LogLevelsRequest_Subsystems `json:"subsystems,omitempty"`
+}
+
+// Log levels of subsystems keyed by subsystem name.
+type LogLevelsRequest_Subsystems struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// LogLevelsResponse defines model for LogLevelsResponse.
+type LogLevelsResponse struct {
+	Error *Error `json:"error,omitempty"`
+
+	// The global log level.
+	Level     string    `json:"level"`
+	RequestId RequestID `json:"request_id"`
+
+	// Log levels of subsystems that override the global log level.
+	Subsystems LogLevelsResponse_Subsystems `json:"subsystems"`
+}
+
+// Log levels of subsystems that override the global log level.
+type LogLevelsResponse_Subsystems struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
 // The additional module input(s) that the tasks provides to the Terraform module on execution. If the task has the deprecated services field configured as a module input, it is represented here as module_input.services.
 type ModuleInput struct {
 	ConsulKv *ConsulKVModuleInput `json:"consul_kv,omitempty"`
 	Services *ServicesModuleInput `json:"services,omitempty"`
 }
 
+// ReloadResponse defines model for ReloadResponse.
+type ReloadResponse struct {
+	// Names of the tasks created from the configuration files.
+	Created []string `json:"created"`
+
+	// Names of the tasks deleted since they were removed from the configuration files.
+	Deleted   []string  `json:"deleted"`
+	Error     *Error    `json:"error,omitempty"`
+	RequestId RequestID `json:"request_id"`
+
+	// Names of the tasks recreated with changes from the configuration files.
+	Updated []string `json:"updated"`
+}
+
 // RequestID defines model for RequestID.
 type RequestID = openapi_types.UUID
 
+// Rollout defines model for Rollout.
+type Rollout struct {
+	Batches []RolloutBatch `json:"batches"`
+	EndTime *time.Time     `json:"end_time,omitempty"`
+
+	// Reason the rollout halted.
+	Error     *string   `json:"error,omitempty"`
+	Id        string    `json:"id"`
+	StartTime time.Time `json:"start_time"`
+
+	// Status of the rollout.
+	Status RolloutStatus `json:"status"`
+}
+
+// Status of the rollout.
+type RolloutStatus string
+
+// RolloutBatch defines model for RolloutBatch.
+type RolloutBatch struct {
+	// Whether the batch is the canary batch of the rollout.
+	Canary bool `json:"canary"`
+
+	// Tasks of the batch that failed to update or had failed runs during the soak time.
+	FailedTasks *[]string `json:"failed_tasks,omitempty"`
+
+	// Status of the batch.
+	Status RolloutBatchStatus `json:"status"`
+	Tasks  []string           `json:"tasks"`
+}
+
+// Status of the batch.
+type RolloutBatchStatus string
+
+// RolloutResponse defines model for RolloutResponse.
+type RolloutResponse struct {
+	Error     *Error    `json:"error,omitempty"`
+	RequestId RequestID `json:"request_id"`
+	Rollout   *Rollout  `json:"rollout,omitempty"`
+}
+
 // Run defines model for Run.
 type Run struct {
 	// Whether or not infrastructure changes were detected during task inspection.
@@ -132,8 +281,39 @@ type ServicesModuleInput_CtsUserDefinedMeta struct {
 	AdditionalProperties map[string]string `json:"-"`
 }
 
+// StateResource defines model for StateResource.
+type StateResource struct {
+	// The absolute address of the resource instance.
+	Address string `json:"address"`
+
+	// The top-level attributes of the resource instance. Values of sensitive
+	// attributes are replaced with "(sensitive)".
+	Attributes StateResource_Attributes `json:"attributes"`
+
+	// The address of the module containing the resource.
+	ModuleAddress *string `json:"module_address,omitempty"`
+
+	// The name of the resource in the module.
+	Name string `json:"name"`
+
+	// The address of the provider of the resource.
+	ProviderName string `json:"provider_name"`
+
+	// The resource type.
+	Type string `json:"type"`
+}
+
+// The top-level attributes of the resource instance. Values of sensitive
+// attributes are replaced with "(sensitive)".
+type StateResource_Attributes struct {
+	AdditionalProperties map[string]interface{} `json:"-"`
+}
+
 // Task defines model for Task.
 type Task struct {
+	// The Terraform backend that stores the state of the task, keyed by the backend label. Overrides the backend of the Terraform driver. The backend configuration is omitted from responses since it may contain credentials.
+	Backend *Backend `json:"backend,omitempty"`
+
 	// The buffer period for triggering task execution.
 	BufferPeriod *BufferPeriod `json:"buffer_period,omitempty"`
 
@@ -146,6 +326,9 @@ type Task struct {
 	// Whether the task is enabled or disabled from executing.
 	Enabled *bool `json:"enabled,omitempty"`
 
+	// The key-value pairs to organize tasks, which select the tasks to change together with the bulk task API.
+	Labels *LabelMap `json:"labels,omitempty"`
+
 	// The location of the Terraform module.
 	Module string `json:"module"`
 
@@ -158,12 +341,18 @@ type Task struct {
 	// The list of provider names that the task's module uses.
 	Providers *[]string `json:"providers,omitempty"`
 
+	// The name of the task template that the task instantiates. The task inherits the options of the template that are not set for the task, such as the module, providers, and condition defaults. The module can be empty and the condition can be an empty object to use the template's.
+	Template *string `json:"template,omitempty"`
+
 	// Enterprise only. Configuration values to use for the Terraform Cloud workspace associated with the task. This is only available when used with the Terraform Cloud driver.
 	TerraformCloudWorkspace *TerraformCloudWorkspace `json:"terraform_cloud_workspace,omitempty"`
 
 	// Deprecated, use task.terraform_cloud_workspace.terraform_version instead. Enterprise only. The version of Terraform to use for the Terraform Cloud workspace associated with the task. This is only available when used with the Terraform Cloud driver. Defaults to the latest compatible version if not set.
 	TerraformVersion *string `json:"terraform_version,omitempty"`
 
+	// The maximum duration of a task execution before it is cancelled. No timeout if not set.
+	Timeout *string `json:"timeout,omitempty"`
+
 	// The map of variables that are provided to the task's module.
 	Variables *VariableMap `json:"variables,omitempty"`
 
@@ -171,12 +360,49 @@ type Task struct {
 	Version *string `json:"version,omitempty"`
 }
 
+// TaskCancelResponse defines model for TaskCancelResponse.
+type TaskCancelResponse struct {
+	// Whether a running execution of the task was cancelled.
+	Cancelled bool      `json:"cancelled"`
+	Error     *Error    `json:"error,omitempty"`
+	RequestId RequestID `json:"request_id"`
+}
+
 // TaskDeleteResponse defines model for TaskDeleteResponse.
 type TaskDeleteResponse struct {
 	Error     *Error    `json:"error,omitempty"`
 	RequestId RequestID `json:"request_id"`
 }
 
+// TaskExportResponse defines model for TaskExportResponse.
+type TaskExportResponse struct {
+	Error *Error `json:"error,omitempty"`
+
+	// The contents of the files of the root module, keyed by file name.
+	Files     TaskExportResponse_Files `json:"files"`
+	RequestId RequestID                `json:"request_id"`
+
+	// The Terraform workspace that the task's state is stored in.
+	Workspace string `json:"workspace"`
+}
+
+// The contents of the files of the root module, keyed by file name.
+type TaskExportResponse_Files struct {
+	AdditionalProperties map[string]string `json:"-"`
+}
+
+// TaskMigrateStateRequest defines model for TaskMigrateStateRequest.
+type TaskMigrateStateRequest struct {
+	// The Terraform backend that stores the state of the task, keyed by the backend label. Overrides the backend of the Terraform driver. The backend configuration is omitted from responses since it may contain credentials.
+	Backend Backend `json:"backend"`
+}
+
+// TaskMigrateStateResponse defines model for TaskMigrateStateResponse.
+type TaskMigrateStateResponse struct {
+	Error     *Error    `json:"error,omitempty"`
+	RequestId RequestID `json:"request_id"`
+}
+
 // TaskRequest defines model for TaskRequest.
 type TaskRequest struct {
 	Task Task `json:"task"`
@@ -190,6 +416,15 @@ type TaskResponse struct {
 	Task      *Task     `json:"task,omitempty"`
 }
 
+// TaskStateResponse defines model for TaskStateResponse.
+type TaskStateResponse struct {
+	Error     *Error    `json:"error,omitempty"`
+	RequestId RequestID `json:"request_id"`
+
+	// The resources managed by the task.
+	Resources []StateResource `json:"resources"`
+}
+
 // TasksResponse defines model for TasksResponse.
 type TasksResponse struct {
 	RequestId RequestID `json:"request_id"`
@@ -216,6 +451,15 @@ type VariableMap struct {
 	AdditionalProperties map[string]string `json:"-"`
 }
 
+// BulkTasksJSONBody defines parameters for BulkTasks.
+type BulkTasksJSONBody = BulkTaskRequest
+
+// BulkTasksParamsOperation defines parameters for BulkTasks.
+type BulkTasksParamsOperation string
+
+// UpdateLogLevelsJSONBody defines parameters for UpdateLogLevels.
+type UpdateLogLevelsJSONBody = LogLevelsRequest
+
 // CreateTaskJSONBody defines parameters for CreateTask.
 type CreateTaskJSONBody = TaskRequest
 
@@ -230,9 +474,95 @@ type CreateTaskParams struct {
 // CreateTaskParamsRun defines parameters for CreateTask.
 type CreateTaskParamsRun string
 
+// DeleteTaskByNameParams defines parameters for DeleteTaskByName.
+type DeleteTaskByNameParams struct {
+	// Cancels the running execution of the task, if any, instead of waiting
+	// for the execution to complete before deleting the task.
+	Cancel *bool `form:"cancel,omitempty" json:"cancel,omitempty"`
+
+	// Destroys the resources managed by the task with Terraform destroy before
+	// deleting the task. The task's workspace state and working directory are
+	// removed once the resources are destroyed. The task is not deleted if
+	// destroying the resources fails.
+	Destroy *bool `form:"destroy,omitempty" json:"destroy,omitempty"`
+
+	// Supports run inspect with the destroy parameter, which returns the plan
+	// for destroying the resources managed by the task. The task is not
+	// deleted.
+	Run *DeleteTaskByNameParamsRun `form:"run,omitempty" json:"run,omitempty"`
+}
+
+// DeleteTaskByNameParamsRun defines parameters for DeleteTaskByName.
+type DeleteTaskByNameParamsRun string
+
+// MigrateTaskStateByNameJSONBody defines parameters for MigrateTaskStateByName.
+type MigrateTaskStateByNameJSONBody = TaskMigrateStateRequest
+
+// BulkTasksJSONRequestBody defines body for BulkTasks for application/json ContentType.
+type BulkTasksJSONRequestBody = BulkTasksJSONBody
+
+// UpdateLogLevelsJSONRequestBody defines body for UpdateLogLevels for application/json ContentType.
+type UpdateLogLevelsJSONRequestBody = UpdateLogLevelsJSONBody
+
 // CreateTaskJSONRequestBody defines body for CreateTask for application/json ContentType.
 type CreateTaskJSONRequestBody = CreateTaskJSONBody
 
+// MigrateTaskStateByNameJSONRequestBody defines body for MigrateTaskStateByName for application/json ContentType.
+type MigrateTaskStateByNameJSONRequestBody = MigrateTaskStateByNameJSONBody
+
+// Getter for additional properties for Backend. Returns the specified
+// element and whether it was found
+func (a Backend) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Backend
+func (a *Backend) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Backend to handle AdditionalProperties
+func (a *Backend) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Backend to handle AdditionalProperties
+func (a Backend) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for CatalogServicesCondition_NodeMeta. Returns the specified
 // element and whether it was found
 func (a CatalogServicesCondition_NodeMeta) Get(fieldName string) (value string, found bool) {
@@ -286,6 +616,165 @@ func (a CatalogServicesCondition_NodeMeta) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
+// Getter for additional properties for LabelMap. Returns the specified
+// element and whether it was found
+func (a LabelMap) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for LabelMap
+func (a *LabelMap) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for LabelMap to handle AdditionalProperties
+func (a *LabelMap) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for LabelMap to handle AdditionalProperties
+func (a LabelMap) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for LogLevelsRequest_Subsystems. Returns the specified
+// element and whether it was found
+func (a LogLevelsRequest_Subsystems) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for LogLevelsRequest_Subsystems
+func (a *LogLevelsRequest_Subsystems) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for LogLevelsRequest_Subsystems to handle AdditionalProperties
+func (a *LogLevelsRequest_Subsystems) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for LogLevelsRequest_Subsystems to handle AdditionalProperties
+func (a LogLevelsRequest_Subsystems) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for LogLevelsResponse_Subsystems. Returns the specified
+// element and whether it was found
+func (a LogLevelsResponse_Subsystems) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for LogLevelsResponse_Subsystems
+func (a *LogLevelsResponse_Subsystems) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for LogLevelsResponse_Subsystems to handle AdditionalProperties
+func (a *LogLevelsResponse_Subsystems) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for LogLevelsResponse_Subsystems to handle AdditionalProperties
+func (a LogLevelsResponse_Subsystems) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for ServicesCondition_CtsUserDefinedMeta. Returns the specified
 // element and whether it was found
 func (a ServicesCondition_CtsUserDefinedMeta) Get(fieldName string) (value string, found bool) {
@@ -392,6 +881,112 @@ func (a ServicesModuleInput_CtsUserDefinedMeta) MarshalJSON() ([]byte, error) {
 	return json.Marshal(object)
 }
 
+// Getter for additional properties for StateResource_Attributes. Returns the specified
+// element and whether it was found
+func (a StateResource_Attributes) Get(fieldName string) (value interface{}, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for StateResource_Attributes
+func (a *StateResource_Attributes) Set(fieldName string, value interface{}) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]interface{})
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for StateResource_Attributes to handle AdditionalProperties
+func (a *StateResource_Attributes) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]interface{})
+		for fieldName, fieldBuf := range object {
+			var fieldVal interface{}
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for StateResource_Attributes to handle AdditionalProperties
+func (a StateResource_Attributes) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for TaskExportResponse_Files. Returns the specified
+// element and whether it was found
+func (a TaskExportResponse_Files) Get(fieldName string) (value string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for TaskExportResponse_Files
+func (a *TaskExportResponse_Files) Set(fieldName string, value string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for TaskExportResponse_Files to handle AdditionalProperties
+func (a *TaskExportResponse_Files) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for TaskExportResponse_Files to handle AdditionalProperties
+func (a TaskExportResponse_Files) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // Getter for additional properties for VariableMap. Returns the specified
 // element and whether it was found
 func (a VariableMap) Get(fieldName string) (value string, found bool) {
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+const (
+	reloadPath          = "/v1/reload"
+	reloadSubsystemName = "reload"
+)
+
+// ReloadHandler handles the reload endpoint
+type ReloadHandler struct {
+	mu   sync.Mutex
+	ctrl Server
+}
+
+// NewReloadHandler creates a new reload handler using the provided controller
+// to reload the configuration
+func NewReloadHandler(ctrl Server) *ReloadHandler {
+	return &ReloadHandler{
+		ctrl: ctrl,
+	}
+}
+
+// ReloadConfig reloads the configuration files and applies the changes to the
+// tasks from the configuration files
+func (h *ReloadHandler) ReloadConfig(w http.ResponseWriter, r *http.Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx := r.Context()
+	logger := logging.FromContext(ctx).Named(reloadSubsystemName)
+	logger.Trace("reload config request")
+
+	created, updated, deleted, err := h.ctrl.Reload(ctx)
+	if err != nil {
+		logger.Trace("error reloading config", "error", err)
+		sendError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := oapigen.ReloadResponse{
+		RequestId: requestIDFromContext(ctx),
+		Created:   nonNilStrings(created),
+		Updated:   nonNilStrings(updated),
+		Deleted:   nonNilStrings(deleted),
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+
+	logger.Trace("config reloaded", "reload_response", resp)
+}
+
+// nonNilStrings returns an empty slice for nil so that it is encoded as an
+// empty JSON array
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
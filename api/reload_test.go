@@ -0,0 +1,89 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadHandler_ReloadConfig(t *testing.T) {
+	t.Parallel()
+	cases := []struct {
+		name       string
+		mockServer func(*mocks.Server)
+		statusCode int
+		expected   oapigen.ReloadResponse
+	}{
+		{
+			"happy_path",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Reload", mock.Anything).Return(
+					[]string{"task_a"}, []string{"task_b"}, []string{"task_c"}, nil)
+			},
+			http.StatusOK,
+			oapigen.ReloadResponse{
+				Created: []string{"task_a"},
+				Updated: []string{"task_b"},
+				Deleted: []string{"task_c"},
+			},
+		},
+		{
+			"no_changes",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Reload", mock.Anything).Return(nil, nil, nil, nil)
+			},
+			http.StatusOK,
+			oapigen.ReloadResponse{
+				Created: []string{},
+				Updated: []string{},
+				Deleted: []string{},
+			},
+		},
+		{
+			"reload_error",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Reload", mock.Anything).Return(nil, nil, nil,
+					fmt.Errorf("error validating configuration"))
+			},
+			http.StatusInternalServerError,
+			oapigen.ReloadResponse{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewReloadHandler(ctrl)
+
+			req, err := http.NewRequest(http.MethodPost, "/v1/reload", nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.ReloadConfig(resp, req)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+
+			if tc.statusCode != http.StatusOK {
+				var errResp oapigen.ErrorResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+				assert.NotEmpty(t, errResp.Error.Message)
+				return
+			}
+
+			var actual oapigen.ReloadResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+			assert.Equal(t, tc.expected.Created, actual.Created)
+			assert.Equal(t, tc.expected.Updated, actual.Updated)
+			assert.Equal(t, tc.expected.Deleted, actual.Deleted)
+		})
+	}
+}
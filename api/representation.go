@@ -6,8 +6,12 @@ import (
 
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/config"
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
+// sensitiveValue replaces the values of sensitive resource attributes
+const sensitiveValue = "(sensitive)"
+
 // TaskRequest is a wrapper around the generated TaskRequest
 // this allows for the task request to be extended
 type TaskRequest oapigen.TaskRequest
@@ -23,6 +27,7 @@ func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 	tc := config.TaskConfig{
 		Description: tr.Task.Description,
 		Name:        &tr.Task.Name,
+		Template:    tr.Task.Template,
 		Module:      &tr.Task.Module,
 		Version:     tr.Task.Version,
 		Enabled:     tr.Task.Enabled,
@@ -142,6 +147,25 @@ func (tr TaskRequest) ToTaskConfig() (config.TaskConfig, error) {
 		}
 	}
 
+	if tr.Task.Timeout != nil {
+		timeout, err := time.ParseDuration(*tr.Task.Timeout)
+		if err != nil {
+			return config.TaskConfig{}, err
+		}
+		tc.Timeout = &timeout
+	}
+
+	if tr.Task.Backend != nil {
+		tc.Backend = tr.Task.Backend.AdditionalProperties
+	}
+
+	if tr.Task.Labels != nil {
+		tc.Labels = make(map[string]string)
+		for k, v := range tr.Task.Labels.AdditionalProperties {
+			tc.Labels[k] = v
+		}
+	}
+
 	if tr.Task.Variables != nil {
 		tc.Variables = make(map[string]string)
 		for k, v := range tr.Task.Variables.AdditionalProperties {
@@ -176,6 +200,7 @@ func tasksResponseFromTaskConfigs(tcs config.TaskConfigs, requestID oapigen.Requ
 	tasks := make([]oapigen.Task, len(tcs))
 	for i, tc := range tcs {
 		tasks[i] = oapigenTaskFromConfigTask(*tc)
+		redactBackend(&tasks[i])
 	}
 
 	return TasksResponse{
@@ -188,6 +213,7 @@ type TaskResponse oapigen.TaskResponse
 
 func taskResponseFromTaskConfig(tc config.TaskConfig, requestID oapigen.RequestID) TaskResponse {
 	task := oapigenTaskFromConfigTask(tc)
+	redactBackend(&task)
 
 	tr := TaskResponse{
 		RequestId: requestID,
@@ -196,6 +222,102 @@ func taskResponseFromTaskConfig(tc config.TaskConfig, requestID oapigen.RequestI
 	return tr
 }
 
+// redactBackend removes the configuration of the task's backend, which may
+// contain credentials, so that only the backend label is returned
+func redactBackend(task *oapigen.Task) {
+	if task.Backend == nil {
+		return
+	}
+	redacted := make(map[string]interface{}, len(task.Backend.AdditionalProperties))
+	for label := range task.Backend.AdditionalProperties {
+		redacted[label] = map[string]interface{}{}
+	}
+	task.Backend = &oapigen.Backend{AdditionalProperties: redacted}
+}
+
+// stateResourcesFromState converts the managed resources of a Terraform state
+// to their API representation. Attributes that are sensitive, or that contain
+// a sensitive value, are redacted.
+func stateResourcesFromState(state *tfjson.State) []oapigen.StateResource {
+	resources := make([]oapigen.StateResource, 0)
+	if state == nil || state.Values == nil {
+		return resources
+	}
+
+	var walk func(m *tfjson.StateModule)
+	walk = func(m *tfjson.StateModule) {
+		if m == nil {
+			return
+		}
+		for _, r := range m.Resources {
+			if r.Mode != tfjson.ManagedResourceMode {
+				continue
+			}
+			resources = append(resources, stateResourceFromResource(m.Address, r))
+		}
+		for _, child := range m.ChildModules {
+			walk(child)
+		}
+	}
+	walk(state.Values.RootModule)
+
+	return resources
+}
+
+func stateResourceFromResource(moduleAddress string, r *tfjson.StateResource) oapigen.StateResource {
+	// All attributes are redacted if the sensitive values cannot be read
+	var sensitive map[string]interface{}
+	redactAll := false
+	if len(r.SensitiveValues) > 0 {
+		if err := json.Unmarshal(r.SensitiveValues, &sensitive); err != nil {
+			redactAll = true
+		}
+	}
+
+	attrs := make(map[string]interface{}, len(r.AttributeValues))
+	for k, v := range r.AttributeValues {
+		if redactAll || isSensitive(sensitive[k]) {
+			attrs[k] = sensitiveValue
+			continue
+		}
+		attrs[k] = v
+	}
+
+	resource := oapigen.StateResource{
+		Address:      r.Address,
+		Type:         r.Type,
+		Name:         r.Name,
+		ProviderName: r.ProviderName,
+		Attributes:   oapigen.StateResource_Attributes{AdditionalProperties: attrs},
+	}
+	if moduleAddress != "" {
+		resource.ModuleAddress = &moduleAddress
+	}
+	return resource
+}
+
+// isSensitive returns whether the sensitive values of an attribute mark the
+// attribute or any of its nested values as sensitive
+func isSensitive(v interface{}) bool {
+	switch v := v.(type) {
+	case bool:
+		return v
+	case map[string]interface{}:
+		for _, val := range v {
+			if isSensitive(val) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, val := range v {
+			if isSensitive(val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 func (tresp TaskResponse) String() string {
 	data, _ := json.Marshal(tresp)
 	return string(data)
@@ -208,10 +330,20 @@ func oapigenTaskFromConfigTask(tc config.TaskConfig) oapigen.Task {
 		Enabled:     tc.Enabled,
 	}
 
+	if config.StringVal(tc.Template) != "" {
+		task.Template = tc.Template
+	}
+
 	if tc.Name != nil {
 		task.Name = *tc.Name
 	}
 
+	if len(tc.Labels) > 0 {
+		task.Labels = &oapigen.LabelMap{
+			AdditionalProperties: tc.Labels,
+		}
+	}
+
 	if tc.Module != nil {
 		task.Module = *tc.Module
 	}
@@ -314,6 +446,15 @@ func oapigenTaskFromConfigTask(tc config.TaskConfig) oapigen.Task {
 		}
 	}
 
+	if timeout := config.TimeDurationVal(tc.Timeout); timeout > 0 {
+		t := timeout.String()
+		task.Timeout = &t
+	}
+
+	if len(tc.Backend) > 0 {
+		task.Backend = &oapigen.Backend{AdditionalProperties: tc.Backend}
+	}
+
 	// Tasks created via API cannot configure the `services` field, but tasks
 	// created via CTS config file can currently configure `services` (deprecated).
 	// Handle `services` by converting to condition or module_input. There is
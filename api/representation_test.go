@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/config"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -466,6 +467,57 @@ func TestTaskRequest_ToTaskConfig(t *testing.T) {
 				Module: config.String("path"),
 			},
 		},
+		{
+			name: "template",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:     "test-name",
+					Template: config.String("test-template"),
+					Condition: oapigen.Condition{
+						Services: &oapigen.ServicesCondition{
+							Names: &[]string{"api"},
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name:     config.String("test-name"),
+				Template: config.String("test-template"),
+				Condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module: config.String(""),
+			},
+		},
+		{
+			name: "labels",
+			request: &TaskRequest{
+				Task: oapigen.Task{
+					Name:   "test-name",
+					Module: "path",
+					Labels: &oapigen.LabelMap{
+						AdditionalProperties: map[string]string{"team": "payments"},
+					},
+					Condition: oapigen.Condition{
+						Services: &oapigen.ServicesCondition{
+							Names: &[]string{"api"},
+						},
+					},
+				},
+			},
+			taskConfigExpected: config.TaskConfig{
+				Name:   config.String("test-name"),
+				Labels: map[string]string{"team": "payments"},
+				Condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module: config.String("path"),
+			},
+		},
 		{
 			name: "basic_fields_filled",
 			request: &TaskRequest{
@@ -911,3 +963,105 @@ func TestTaskResponse_taskResponseFromTaskConfig(t *testing.T) {
 	actual := taskResponseFromTaskConfig(tc.taskConfig, uuid.MustParse("e9926514-79b8-a8fc-8761-9b6aaccf1e15"))
 	assert.Equal(t, tc.expectedResponse, actual)
 }
+
+func TestTaskResponse_taskResponseFromTaskConfig_RedactBackend(t *testing.T) {
+	tc := config.TaskConfig{
+		Backend: map[string]interface{}{
+			"s3": map[string]interface{}{
+				"bucket":     "cts",
+				"secret_key": "secret",
+			},
+		},
+	}
+
+	actual := taskResponseFromTaskConfig(tc, uuid.MustParse("e9926514-79b8-a8fc-8761-9b6aaccf1e15"))
+	require.NotNil(t, actual.Task.Backend)
+	assert.Equal(t, map[string]interface{}{"s3": map[string]interface{}{}},
+		actual.Task.Backend.AdditionalProperties)
+
+	// the task configuration is not modified
+	assert.Contains(t, tc.Backend["s3"], "secret_key")
+}
+
+func TestStateResourcesFromState(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil", func(t *testing.T) {
+		assert.Empty(t, stateResourcesFromState(nil))
+		assert.Empty(t, stateResourcesFromState(&tfjson.State{}))
+	})
+
+	t.Run("resources", func(t *testing.T) {
+		state := &tfjson.State{
+			Values: &tfjson.StateValues{
+				RootModule: &tfjson.StateModule{
+					Resources: []*tfjson.StateResource{
+						{
+							Address:      "data.local_file.config",
+							Mode:         tfjson.DataResourceMode,
+							Type:         "local_file",
+							Name:         "config",
+							ProviderName: "registry.terraform.io/hashicorp/local",
+						},
+					},
+					ChildModules: []*tfjson.StateModule{{
+						Address: "module.task",
+						Resources: []*tfjson.StateResource{{
+							Address:      `module.task.panos_address_object.this["api"]`,
+							Mode:         tfjson.ManagedResourceMode,
+							Type:         "panos_address_object",
+							Name:         "this",
+							ProviderName: "registry.terraform.io/paloaltonetworks/panos",
+							AttributeValues: map[string]interface{}{
+								"name":  "api",
+								"value": "10.0.0.10",
+								"auth":  map[string]interface{}{"user": "u", "password": "p"},
+								"tags":  []interface{}{"a"},
+							},
+							SensitiveValues: json.RawMessage(
+								`{"auth":{"password":true},"tags":[false]}`),
+						}},
+					}},
+				},
+			},
+		}
+
+		actual := stateResourcesFromState(state)
+		require.Len(t, actual, 1, "data sources are omitted")
+		assert.Equal(t, oapigen.StateResource{
+			Address:       `module.task.panos_address_object.this["api"]`,
+			ModuleAddress: config.String("module.task"),
+			Type:          "panos_address_object",
+			Name:          "this",
+			ProviderName:  "registry.terraform.io/paloaltonetworks/panos",
+			Attributes: oapigen.StateResource_Attributes{
+				AdditionalProperties: map[string]interface{}{
+					"name":  "api",
+					"value": "10.0.0.10",
+					"auth":  sensitiveValue,
+					"tags":  []interface{}{"a"},
+				},
+			},
+		}, actual[0])
+	})
+
+	t.Run("unreadable sensitive values", func(t *testing.T) {
+		state := &tfjson.State{
+			Values: &tfjson.StateValues{
+				RootModule: &tfjson.StateModule{
+					Resources: []*tfjson.StateResource{{
+						Address:         "local_file.api",
+						Mode:            tfjson.ManagedResourceMode,
+						AttributeValues: map[string]interface{}{"content": "secret"},
+						SensitiveValues: json.RawMessage(`true`),
+					}},
+				},
+			},
+		}
+
+		actual := stateResourcesFromState(state)
+		require.Len(t, actual, 1)
+		assert.Nil(t, actual[0].ModuleAddress)
+		assert.Equal(t, sensitiveValue, actual[0].Attributes.AdditionalProperties["content"])
+	})
+}
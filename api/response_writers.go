@@ -93,3 +93,71 @@ func (r *loggerResponseWriter) WriteHeader(code int) {
 	r.statusCode = code
 	r.ResponseWriter.WriteHeader(code)
 }
+
+// auditResponseWriter is a wrapper around the standard http response writer
+// that captures the status code and the body of error responses for use in
+// the audit log. The body of successful bulk task responses is also captured
+// to record the result for each task.
+type auditResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+	errBody    bytes.Buffer
+
+	captureBulk bool
+	bulkBody    bytes.Buffer
+}
+
+// WriteHeader handles writing the header and captures the
+// status code
+func (r *auditResponseWriter) WriteHeader(code int) {
+	r.statusCode = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+// Write captures the body of error responses, up to a limit, and writes the
+// body
+func (r *auditResponseWriter) Write(p []byte) (int, error) {
+	if r.statusCode == 0 {
+		r.statusCode = http.StatusOK
+	}
+	if r.statusCode >= http.StatusBadRequest {
+		if n := maxAuditErrorLen - r.errBody.Len(); n > 0 {
+			if len(p) < n {
+				n = len(p)
+			}
+			r.errBody.Write(p[:n])
+		}
+	} else if r.captureBulk {
+		r.bulkBody.Write(p)
+	}
+	return r.ResponseWriter.Write(p)
+}
+
+// bulkResults returns the results of a bulk task response. Returns false if
+// the response is not a bulk task response with results.
+func (r *auditResponseWriter) bulkResults() ([]oapigen.BulkTaskResult, bool) {
+	if r.bulkBody.Len() == 0 {
+		return nil, false
+	}
+
+	var resp oapigen.BulkTaskResponse
+	if err := json.Unmarshal(r.bulkBody.Bytes(), &resp); err != nil ||
+		len(resp.Results) == 0 {
+		return nil, false
+	}
+	return resp.Results, true
+}
+
+// errorMessage returns the message of the error response, if any
+func (r *auditResponseWriter) errorMessage() string {
+	if r.errBody.Len() == 0 {
+		return ""
+	}
+
+	var errResp oapigen.ErrorResponse
+	if err := json.Unmarshal(r.errBody.Bytes(), &errResp); err == nil &&
+		errResp.Error.Message != "" {
+		return errResp.Error.Message
+	}
+	return strings.TrimSpace(r.errBody.String())
+}
@@ -0,0 +1,77 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/rollout"
+)
+
+const (
+	rolloutPath          = "/v1/rollout"
+	rolloutSubsystemName = "rollout"
+)
+
+// RolloutHandler handles the rollout endpoint
+type RolloutHandler struct {
+	ctrl Server
+}
+
+// NewRolloutHandler creates a new rollout handler using the provided
+// controller to retrieve the rollout progress
+func NewRolloutHandler(ctrl Server) *RolloutHandler {
+	return &RolloutHandler{
+		ctrl: ctrl,
+	}
+}
+
+// GetRollout returns the progress of the most recent rollout of configuration
+// changes across tasks
+func (h *RolloutHandler) GetRollout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx).Named(rolloutSubsystemName)
+	logger.Trace("get rollout request")
+
+	ro, ok := h.ctrl.Rollout(ctx)
+	if !ok {
+		sendError(w, r, http.StatusNotFound,
+			errors.New("no rollout of configuration changes has started"))
+		return
+	}
+
+	resp := oapigen.RolloutResponse{
+		RequestId: requestIDFromContext(ctx),
+		Rollout:   rolloutToResponse(ro),
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+
+	logger.Trace("rollout retrieved", "rollout_id", ro.ID, "status", ro.Status)
+}
+
+// rolloutToResponse converts the rollout into its API representation
+func rolloutToResponse(ro rollout.Rollout) *oapigen.Rollout {
+	resp := &oapigen.Rollout{
+		Id:        ro.ID,
+		Status:    oapigen.RolloutStatus(ro.Status),
+		StartTime: ro.StartTime,
+		EndTime:   ro.EndTime,
+		Batches:   make([]oapigen.RolloutBatch, len(ro.Batches)),
+	}
+	if ro.Error != "" {
+		resp.Error = &ro.Error
+	}
+	for i, b := range ro.Batches {
+		resp.Batches[i] = oapigen.RolloutBatch{
+			Canary: b.Canary,
+			Tasks:  nonNilStrings(b.Tasks),
+			Status: oapigen.RolloutBatchStatus(b.Status),
+		}
+		if len(b.FailedTasks) > 0 {
+			failed := b.FailedTasks
+			resp.Batches[i].FailedTasks = &failed
+		}
+	}
+	return resp
+}
@@ -0,0 +1,112 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/hashicorp/consul-terraform-sync/rollout"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutHandler_GetRollout(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2022, 10, 19, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Minute)
+	halted := rollout.Rollout{
+		ID:     "5b1d9e0a-3c7e-4a0c-9f3e-4a1b2c3d4e5f",
+		Status: rollout.StatusHalted,
+		Batches: []rollout.Batch{
+			{
+				Canary:      true,
+				Tasks:       []string{"task_a"},
+				Status:      rollout.StatusFailed,
+				FailedTasks: []string{"task_a"},
+			},
+			{
+				Tasks:  []string{"task_b", "task_c"},
+				Status: rollout.StatusPending,
+			},
+		},
+		StartTime: start,
+		EndTime:   &end,
+		Error:     "batch 0 failed",
+	}
+	errMsg := "batch 0 failed"
+	failed := []string{"task_a"}
+
+	cases := []struct {
+		name       string
+		rollout    rollout.Rollout
+		ok         bool
+		statusCode int
+		expected   *oapigen.Rollout
+	}{
+		{
+			"halted",
+			halted,
+			true,
+			http.StatusOK,
+			&oapigen.Rollout{
+				Id:     halted.ID,
+				Status: oapigen.RolloutStatusHalted,
+				Batches: []oapigen.RolloutBatch{
+					{
+						Canary:      true,
+						Tasks:       []string{"task_a"},
+						Status:      oapigen.RolloutBatchStatusFailed,
+						FailedTasks: &failed,
+					},
+					{
+						Tasks:  []string{"task_b", "task_c"},
+						Status: oapigen.RolloutBatchStatusPending,
+					},
+				},
+				StartTime: start,
+				EndTime:   &end,
+				Error:     &errMsg,
+			},
+		},
+		{
+			"no_rollout",
+			rollout.Rollout{},
+			false,
+			http.StatusNotFound,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			ctrl.On("Rollout", mock.Anything).Return(tc.rollout, tc.ok)
+			handler := NewRolloutHandler(ctrl)
+
+			req, err := http.NewRequest(http.MethodGet, rolloutPath, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.GetRollout(resp, req)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+
+			if tc.statusCode != http.StatusOK {
+				var errResp oapigen.ErrorResponse
+				require.NoError(t, json.NewDecoder(resp.Body).Decode(&errResp))
+				assert.NotEmpty(t, errResp.Error.Message)
+				return
+			}
+
+			var actual oapigen.RolloutResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&actual))
+			assert.Equal(t, tc.expected, actual.Rollout)
+		})
+	}
+}
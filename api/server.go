@@ -4,7 +4,10 @@ import (
 	"context"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/consul-terraform-sync/rollout"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
 //go:generate mockery --name=Server --filename=server.go --output=../mocks/server
@@ -17,12 +20,26 @@ type Server interface {
 	Task(ctx context.Context, taskName string) (config.TaskConfig, error)
 	TaskCreate(context.Context, config.TaskConfig) (config.TaskConfig, error)
 	TaskCreateAndRun(context.Context, config.TaskConfig) (config.TaskConfig, error)
-	TaskDelete(ctx context.Context, taskName string) error
+	TaskDelete(ctx context.Context, taskName string, destroy, cancel bool) error
+	TaskCancel(ctx context.Context, taskName string) (bool, error)
+	TaskExport(ctx context.Context, taskName string) (map[string][]byte, error)
+	TaskMigrateState(ctx context.Context, taskName string, backend map[string]interface{}) error
+	TaskState(ctx context.Context, taskName string) (*tfjson.State, error)
+	TaskModuleUpgrade(ctx context.Context, taskName string) (registry.ModuleUpgrade, bool)
 	// TODO: update signatures to return a new run object
 	TaskInspect(context.Context, config.TaskConfig) (bool, string, string, error)
+	TaskInspectDestroy(ctx context.Context, taskName string) (bool, string, string, error)
 	// TODO: update signature with an update config object since only a subset of
 	// options can be changed and determine the location of sharable objects
 	// across packages
 	TaskUpdate(ctx context.Context, updateConf config.TaskConfig, runOp string) (bool, string, string, error)
 	Tasks(context.Context) config.TaskConfigs
+
+	// Reload reloads the configuration files and returns the names of the
+	// tasks that were created, updated, and deleted
+	Reload(ctx context.Context) ([]string, []string, []string, error)
+
+	// Rollout returns the progress of the most recent rollout of reloaded
+	// configuration changes across tasks
+	Rollout(ctx context.Context) (rollout.Rollout, bool)
 }
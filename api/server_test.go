@@ -14,6 +14,7 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/config"
 	apiMocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/hashicorp/consul-terraform-sync/registry"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/testutils"
 	"github.com/stretchr/testify/assert"
@@ -121,6 +122,7 @@ func TestStatus(t *testing.T) {
 	}
 	ctrl.On("Tasks", mock.Anything).Return(confs)
 	ctrl.On("Events", mock.Anything, "").Return(events, nil)
+	ctrl.On("TaskModuleUpgrade", mock.Anything, mock.Anything).Return(registry.ModuleUpgrade{}, false)
 
 	// start up server
 	port := testutils.FreePort(t)
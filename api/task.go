@@ -18,6 +18,11 @@ const (
 	createTaskSubsystemName = "createtask"
 	deleteTaskSubsystemName = "deletetask"
 	getTaskSubsystemName    = "gettask"
+	cancelTaskSubsystemName = "canceltask"
+	exportTaskSubsystemName = "exporttask"
+
+	getTaskStateSubsystemName     = "gettaskstate"
+	migrateTaskStateSubsystemName = "migratetaskstate"
 
 	taskPath = "tasks"
 
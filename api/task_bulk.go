@@ -0,0 +1,342 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+const (
+	bulkTasksPath          = "/v1/bulk/tasks"
+	bulkTasksSubsystemName = "bulktasks"
+
+	bulkOperationCreate  = "create"
+	bulkOperationEnable  = "enable"
+	bulkOperationDisable = "disable"
+	bulkOperationDelete  = "delete"
+	bulkOperationRun     = "run"
+
+	bulkStatusSucceeded  = "succeeded"
+	bulkStatusFailed     = "failed"
+	bulkStatusSkipped    = "skipped"
+	bulkStatusRolledBack = "rolled_back"
+)
+
+// BulkTasks runs an operation on multiple tasks and responds with the result
+// of the operation for each task. Tasks are created from the tasks of the
+// request, and the other operations change the tasks selected by name or by
+// label selector. Atomic operations are only started if the operation can
+// run for all selected tasks, stop at the first failure, and revert the tasks
+// that were created, enabled, or disabled.
+func (h *TaskLifeCycleHandler) BulkTasks(w http.ResponseWriter, r *http.Request, operation oapigen.BulkTasksParamsOperation) {
+	ctx := r.Context()
+	requestID := requestIDFromContext(ctx)
+	logger := logging.FromContext(ctx).Named(bulkTasksSubsystemName).With(
+		"operation", operation)
+	logger.Trace("bulk tasks request received, reading request")
+
+	var req oapigen.BulkTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("bad request", "error", err)
+		sendError(w, r, http.StatusBadRequest,
+			fmt.Errorf("error decoding the request: %v", err))
+		return
+	}
+	atomic := req.Atomic != nil && *req.Atomic
+
+	var results []oapigen.BulkTaskResult
+	switch op := string(operation); op {
+	case bulkOperationCreate:
+		if req.Names != nil || req.Selector != nil {
+			sendError(w, r, http.StatusBadRequest, fmt.Errorf("tasks to "+
+				"create are set with 'tasks', not 'names' or 'selector'"))
+			return
+		}
+		if req.Tasks == nil || len(*req.Tasks) == 0 {
+			sendError(w, r, http.StatusBadRequest,
+				fmt.Errorf("at least one task to create is required"))
+			return
+		}
+		results = h.bulkCreate(ctx, *req.Tasks, atomic)
+
+	case bulkOperationEnable, bulkOperationDisable, bulkOperationDelete,
+		bulkOperationRun:
+		names, err := h.selectTasks(ctx, req)
+		if err != nil {
+			logger.Trace("bad request", "error", err)
+			sendError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		if op == bulkOperationRun {
+			results = h.bulkRun(ctx, names, atomic)
+		} else {
+			results = h.bulkUpdate(ctx, op, names, atomic)
+		}
+
+	default:
+		sendError(w, r, http.StatusBadRequest,
+			fmt.Errorf("unsupported bulk task operation '%s'", op))
+		return
+	}
+
+	statusCode := http.StatusOK
+	for _, res := range results {
+		if res.Status != bulkStatusSucceeded {
+			statusCode = http.StatusMultiStatus
+			break
+		}
+	}
+
+	resp := oapigen.BulkTaskResponse{
+		RequestId: requestID,
+		Results:   results,
+	}
+	writeResponse(w, r, statusCode, resp)
+	logger.Trace("bulk tasks request complete", "bulk_tasks_response", resp)
+}
+
+// selectTasks returns the names of the tasks selected by the request, either
+// by name or by label selector
+func (h *TaskLifeCycleHandler) selectTasks(ctx context.Context, req oapigen.BulkTaskRequest) ([]string, error) {
+	if req.Tasks != nil {
+		return nil, fmt.Errorf("'tasks' can only be set to create tasks, " +
+			"select existing tasks with 'names' or 'selector'")
+	}
+	if (req.Names == nil) == (req.Selector == nil) {
+		return nil, fmt.Errorf("exactly one of 'names' or 'selector' is " +
+			"required to select the tasks")
+	}
+
+	if req.Names != nil {
+		if len(*req.Names) == 0 {
+			return nil, fmt.Errorf("at least one task name is required")
+		}
+		seen := make(map[string]bool)
+		var names []string
+		for _, name := range *req.Names {
+			if !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+		return names, nil
+	}
+
+	selector, err := config.ParseLabelSelector(*req.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var names []string
+	for _, tc := range h.ctrl.Tasks(ctx) {
+		if selector.Matches(tc.Labels) {
+			names = append(names, config.StringVal(tc.Name))
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// bulkCreate creates the tasks. Atomic creates only start if all tasks can be
+// created, and the created tasks are deleted if a task fails to create.
+func (h *TaskLifeCycleHandler) bulkCreate(ctx context.Context, tasks []oapigen.Task, atomic bool) []oapigen.BulkTaskResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	results := make([]oapigen.BulkTaskResult, len(tasks))
+	confs := make([]config.TaskConfig, len(tasks))
+	seen := make(map[string]bool)
+	valid := true
+	for i, task := range tasks {
+		results[i].Name = task.Name
+
+		var err error
+		switch {
+		case seen[task.Name]:
+			err = fmt.Errorf("task with name %s is included more than once",
+				task.Name)
+		default:
+			if _, terr := h.ctrl.Task(ctx, task.Name); terr == nil {
+				err = fmt.Errorf("task with name %s already exists", task.Name)
+			} else {
+				confs[i], err = TaskRequest{Task: task}.ToTaskConfig()
+			}
+		}
+		seen[task.Name] = true
+
+		if err != nil {
+			setBulkResult(&results[i], fmt.Errorf("error with task "+
+				"configuration: %s", err))
+			valid = false
+		}
+	}
+	if atomic && !valid {
+		return skipPending(results)
+	}
+
+	for i := range tasks {
+		if results[i].Status != "" {
+			continue
+		}
+		_, err := h.ctrl.TaskCreate(ctx, confs[i])
+		setBulkResult(&results[i], err)
+		if err == nil || !atomic {
+			continue
+		}
+
+		for j := 0; j < i; j++ {
+			name := results[j].Name
+			if err := h.ctrl.TaskDelete(ctx, name, false, false); err != nil {
+				setBulkResult(&results[j], fmt.Errorf("error deleting "+
+					"task to roll back: %s", err))
+				continue
+			}
+			results[j].Status = bulkStatusRolledBack
+		}
+		return skipPending(results)
+	}
+	return results
+}
+
+// bulkUpdate enables, disables, or deletes the tasks. Atomic updates only
+// start if all tasks exist, and the tasks that were enabled or disabled are
+// reverted if the operation fails for a task.
+func (h *TaskLifeCycleHandler) bulkUpdate(ctx context.Context, op string, names []string, atomic bool) []oapigen.BulkTaskResult {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	results, confs, valid := h.bulkTaskConfigs(ctx, op, names)
+	if atomic && !valid {
+		return skipPending(results)
+	}
+
+	for i, name := range names {
+		if results[i].Status != "" {
+			continue
+		}
+
+		var err error
+		switch op {
+		case bulkOperationEnable, bulkOperationDisable:
+			tc := *confs[i].Copy()
+			tc.Enabled = config.Bool(op == bulkOperationEnable)
+			_, _, _, err = h.ctrl.TaskUpdate(ctx, tc, "")
+		case bulkOperationDelete:
+			err = h.ctrl.TaskDelete(ctx, name, false, false)
+		}
+		setBulkResult(&results[i], err)
+		if err == nil || !atomic {
+			continue
+		}
+
+		if op == bulkOperationEnable || op == bulkOperationDisable {
+			for j := 0; j < i; j++ {
+				if _, _, _, err := h.ctrl.TaskUpdate(ctx, confs[j], ""); err != nil {
+					setBulkResult(&results[j], fmt.Errorf("error reverting "+
+						"task to roll back: %s", err))
+					continue
+				}
+				results[j].Status = bulkStatusRolledBack
+			}
+		}
+		return skipPending(results)
+	}
+	return results
+}
+
+// bulkRun runs the tasks. Like the request to run a single task, the handler
+// is not locked while the tasks run so that requests to other tasks, or to
+// cancel a run, are not blocked until the tasks are applied. Atomic runs only
+// start if all tasks exist and are enabled, and run the tasks one at a time
+// until a task fails. Otherwise the tasks run concurrently.
+func (h *TaskLifeCycleHandler) bulkRun(ctx context.Context, names []string, atomic bool) []oapigen.BulkTaskResult {
+	results, confs, valid := h.bulkTaskConfigs(ctx, bulkOperationRun, names)
+	if atomic && !valid {
+		return skipPending(results)
+	}
+
+	run := func(i int) error {
+		_, _, _, err := h.ctrl.TaskUpdate(ctx, confs[i], RunOptionNow)
+		setBulkResult(&results[i], err)
+		return err
+	}
+
+	if atomic {
+		for i := range names {
+			if err := run(i); err != nil {
+				return skipPending(results)
+			}
+		}
+		return results
+	}
+
+	var wg sync.WaitGroup
+	for i := range names {
+		if results[i].Status != "" {
+			continue
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			run(i)
+		}(i)
+	}
+	wg.Wait()
+	return results
+}
+
+// bulkTaskConfigs returns the configurations of the tasks to run the operation
+// for. Tasks that do not exist, or are disabled and cannot run, have a failed
+// result and the returned bool is false.
+func (h *TaskLifeCycleHandler) bulkTaskConfigs(ctx context.Context, op string, names []string) ([]oapigen.BulkTaskResult, []config.TaskConfig, bool) {
+	results := make([]oapigen.BulkTaskResult, len(names))
+	confs := make([]config.TaskConfig, len(names))
+	valid := true
+	for i, name := range names {
+		results[i].Name = name
+
+		tc, err := h.ctrl.Task(ctx, name)
+		if err == nil && op == bulkOperationRun && !config.BoolVal(tc.Enabled) {
+			err = fmt.Errorf("task '%s' is disabled and cannot run", name)
+		}
+		if err != nil {
+			setBulkResult(&results[i], err)
+			valid = false
+			continue
+		}
+		confs[i] = tc
+	}
+	return results, confs, valid
+}
+
+// setBulkResult sets the status of the result from the error of the operation
+func setBulkResult(res *oapigen.BulkTaskResult, err error) {
+	if err != nil {
+		msg := err.Error()
+		res.Status = bulkStatusFailed
+		res.Error = &msg
+		return
+	}
+	res.Status = bulkStatusSucceeded
+	res.Error = nil
+}
+
+// skipPending marks the results of the tasks that the operation did not run
+// for as skipped
+func skipPending(results []oapigen.BulkTaskResult) []oapigen.BulkTaskResult {
+	for i := range results {
+		if results[i].Status == "" {
+			results[i].Status = bulkStatusSkipped
+		}
+	}
+	return results
+}
@@ -0,0 +1,319 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLifeCycleHandler_BulkTasks(t *testing.T) {
+	t.Parallel()
+
+	taskA := config.TaskConfig{
+		Name:    config.String("a"),
+		Enabled: config.Bool(true),
+		Labels:  map[string]string{"provider": "aws"},
+	}
+	taskB := config.TaskConfig{
+		Name:    config.String("b"),
+		Enabled: config.Bool(true),
+		Labels:  map[string]string{"provider": "aws"},
+	}
+	taskC := config.TaskConfig{
+		Name:    config.String("c"),
+		Enabled: config.Bool(false),
+		Labels:  map[string]string{"provider": "gcp"},
+	}
+	named := func(name string) interface{} {
+		return mock.MatchedBy(func(tc config.TaskConfig) bool {
+			return config.StringVal(tc.Name) == name
+		})
+	}
+	enabled := func(name string, e bool) interface{} {
+		return mock.MatchedBy(func(tc config.TaskConfig) bool {
+			return config.StringVal(tc.Name) == name &&
+				config.BoolVal(tc.Enabled) == e
+		})
+	}
+
+	cases := []struct {
+		name       string
+		operation  string
+		request    string
+		mockServer func(*mocks.Server)
+		statusCode int
+		results    map[string]string
+	}{
+		{
+			"disable_names",
+			bulkOperationDisable,
+			`{"names": ["a", "b", "a"]}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("Task", mock.Anything, "b").Return(taskB, nil)
+				ctrl.On("TaskUpdate", mock.Anything, enabled("a", false), "").
+					Return(true, "", "", nil).Once()
+				ctrl.On("TaskUpdate", mock.Anything, enabled("b", false), "").
+					Return(true, "", "", nil).Once()
+			},
+			http.StatusOK,
+			map[string]string{"a": bulkStatusSucceeded, "b": bulkStatusSucceeded},
+		},
+		{
+			"disable_selector",
+			bulkOperationDisable,
+			`{"selector": "provider=aws"}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Tasks", mock.Anything).Return(
+					config.TaskConfigs{&taskC, &taskB, &taskA})
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("Task", mock.Anything, "b").Return(taskB, nil)
+				ctrl.On("TaskUpdate", mock.Anything, enabled("a", false), "").
+					Return(true, "", "", nil).Once()
+				ctrl.On("TaskUpdate", mock.Anything, enabled("b", false), "").
+					Return(true, "", "", nil).Once()
+			},
+			http.StatusOK,
+			map[string]string{"a": bulkStatusSucceeded, "b": bulkStatusSucceeded},
+		},
+		{
+			"enable_partial_failure",
+			bulkOperationEnable,
+			`{"names": ["a", "dne"]}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("Task", mock.Anything, "dne").
+					Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+				ctrl.On("TaskUpdate", mock.Anything, enabled("a", true), "").
+					Return(false, "", "", nil).Once()
+			},
+			http.StatusMultiStatus,
+			map[string]string{"a": bulkStatusSucceeded, "dne": bulkStatusFailed},
+		},
+		{
+			"atomic_not_started",
+			bulkOperationDelete,
+			`{"names": ["a", "dne"], "atomic": true}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("Task", mock.Anything, "dne").
+					Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+			},
+			http.StatusMultiStatus,
+			map[string]string{"a": bulkStatusSkipped, "dne": bulkStatusFailed},
+		},
+		{
+			"atomic_rolled_back",
+			bulkOperationDisable,
+			`{"selector": "provider!=gcp", "atomic": true}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Tasks", mock.Anything).Return(
+					config.TaskConfigs{&taskA, &taskB, &taskC})
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("Task", mock.Anything, "b").Return(taskB, nil)
+				ctrl.On("TaskUpdate", mock.Anything, enabled("a", false), "").
+					Return(true, "", "", nil).Once()
+				ctrl.On("TaskUpdate", mock.Anything, enabled("b", false), "").
+					Return(false, "", "", fmt.Errorf("error")).Once()
+				ctrl.On("TaskUpdate", mock.Anything, enabled("a", true), "").
+					Return(true, "", "", nil).Once()
+			},
+			http.StatusMultiStatus,
+			map[string]string{"a": bulkStatusRolledBack, "b": bulkStatusFailed},
+		},
+		{
+			"run_disabled",
+			bulkOperationRun,
+			`{"names": ["a", "c"]}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("Task", mock.Anything, "c").Return(taskC, nil)
+				ctrl.On("TaskUpdate", mock.Anything, named("a"), RunOptionNow).
+					Return(false, "", "", nil).Once()
+			},
+			http.StatusMultiStatus,
+			map[string]string{"a": bulkStatusSucceeded, "c": bulkStatusFailed},
+		},
+		{
+			"run_atomic_failed",
+			bulkOperationRun,
+			`{"names": ["a", "b"], "atomic": true}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("Task", mock.Anything, "b").Return(taskB, nil)
+				ctrl.On("TaskUpdate", mock.Anything, named("a"), RunOptionNow).
+					Return(false, "", "", fmt.Errorf("error")).Once()
+			},
+			http.StatusMultiStatus,
+			map[string]string{"a": bulkStatusFailed, "b": bulkStatusSkipped},
+		},
+		{
+			"delete",
+			bulkOperationDelete,
+			`{"names": ["a"]}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+				ctrl.On("TaskDelete", mock.Anything, "a", false, false).Return(nil)
+			},
+			http.StatusOK,
+			map[string]string{"a": bulkStatusSucceeded},
+		},
+		{
+			"create",
+			bulkOperationCreate,
+			`{"tasks": [{"name": "d", "module": "path"}]}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "d").
+					Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+				ctrl.On("TaskCreate", mock.Anything, named("d")).
+					Return(config.TaskConfig{}, nil)
+			},
+			http.StatusOK,
+			map[string]string{"d": bulkStatusSucceeded},
+		},
+		{
+			"create_atomic_rolled_back",
+			bulkOperationCreate,
+			`{"tasks": [{"name": "d", "module": "path"},
+				{"name": "e", "module": "path"}], "atomic": true}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, mock.Anything).
+					Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+				ctrl.On("TaskCreate", mock.Anything, named("d")).
+					Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskCreate", mock.Anything, named("e")).
+					Return(config.TaskConfig{}, fmt.Errorf("error"))
+				ctrl.On("TaskDelete", mock.Anything, "d", false, false).Return(nil)
+			},
+			http.StatusMultiStatus,
+			map[string]string{"d": bulkStatusRolledBack, "e": bulkStatusFailed},
+		},
+		{
+			"create_exists",
+			bulkOperationCreate,
+			`{"tasks": [{"name": "a", "module": "path"}]}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, "a").Return(taskA, nil)
+			},
+			http.StatusMultiStatus,
+			map[string]string{"a": bulkStatusFailed},
+		},
+		{
+			"names_and_selector",
+			bulkOperationEnable,
+			`{"names": ["a"], "selector": "provider=aws"}`,
+			func(ctrl *mocks.Server) {},
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"no_selection",
+			bulkOperationEnable,
+			`{}`,
+			func(ctrl *mocks.Server) {},
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"invalid_selector",
+			bulkOperationEnable,
+			`{"selector": "provider"}`,
+			func(ctrl *mocks.Server) {},
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"tasks_for_update",
+			bulkOperationEnable,
+			`{"tasks": [{"name": "a", "module": "path"}]}`,
+			func(ctrl *mocks.Server) {},
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"create_without_tasks",
+			bulkOperationCreate,
+			`{"names": ["a"]}`,
+			func(ctrl *mocks.Server) {},
+			http.StatusBadRequest,
+			nil,
+		},
+		{
+			"unsupported_operation",
+			"cancel",
+			`{"names": ["a"]}`,
+			func(ctrl *mocks.Server) {},
+			http.StatusBadRequest,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewTaskLifeCycleHandler(ctrl)
+
+			path := fmt.Sprintf("%s/%s", bulkTasksPath, tc.operation)
+			req, err := http.NewRequest(http.MethodPost, path,
+				strings.NewReader(tc.request))
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.BulkTasks(resp, req,
+				oapigen.BulkTasksParamsOperation(tc.operation))
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+
+			if tc.results == nil {
+				return
+			}
+			var r oapigen.BulkTaskResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&r))
+			actual := make(map[string]string)
+			for _, res := range r.Results {
+				actual[res.Name] = res.Status
+			}
+			assert.Equal(t, tc.results, actual)
+		})
+	}
+}
+
+func TestTaskLifeCycleHandler_BulkTasks_RunUnlocked(t *testing.T) {
+	t.Parallel()
+
+	task := config.TaskConfig{
+		Name:    config.String("a"),
+		Enabled: config.Bool(true),
+	}
+	ctrl := new(mocks.Server)
+	handler := NewTaskLifeCycleHandler(ctrl)
+	ctrl.On("Task", mock.Anything, "a").Return(task, nil)
+	ctrl.On("TaskUpdate", mock.Anything, mock.Anything, RunOptionNow).
+		Run(func(mock.Arguments) {
+			// other requests, like cancelling the run, can lock the handler
+			// while the task runs
+			require.True(t, handler.mu.TryLock())
+			handler.mu.Unlock()
+		}).Return(false, "", "", nil).Once()
+
+	path := fmt.Sprintf("%s/%s", bulkTasksPath, bulkOperationRun)
+	req, err := http.NewRequest(http.MethodPost, path,
+		strings.NewReader(`{"names": ["a"]}`))
+	require.NoError(t, err)
+	resp := httptest.NewRecorder()
+
+	handler.BulkTasks(resp, req, oapigen.BulkTasksParamsOperation(bulkOperationRun))
+	assert.Equal(t, http.StatusOK, resp.Code)
+	ctrl.AssertExpectations(t)
+}
@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+// CancelTaskByName cancels the running execution of an existing task
+func (h *TaskLifeCycleHandler) CancelTaskByName(w http.ResponseWriter, r *http.Request, name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx := r.Context()
+	requestID := requestIDFromContext(ctx)
+	logger := logging.FromContext(ctx).Named(cancelTaskSubsystemName).With("task_name", name)
+	logger.Trace("cancel task request")
+
+	// Check if task exists
+	_, err := h.ctrl.Task(ctx, name)
+	if err != nil {
+		logger.Trace("task not found", "error", err)
+		sendError(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	cancelled, err := h.ctrl.TaskCancel(ctx, name)
+	if err != nil {
+		sendError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := oapigen.TaskCancelResponse{
+		RequestId: requestID,
+		Cancelled: cancelled,
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+
+	logger.Trace("task cancelled", "cancel_task_response", resp)
+}
@@ -0,0 +1,88 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLifeCycleHandler_CancelTaskByName(t *testing.T) {
+	t.Parallel()
+	taskName := "task"
+	cases := []struct {
+		name       string
+		mockServer func(*mocks.Server)
+		statusCode int
+		cancelled  bool
+	}{
+		{
+			"happy_path",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskCancel", mock.Anything, taskName).Return(true, nil)
+			},
+			http.StatusOK,
+			true,
+		},
+		{
+			"task_not_running",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskCancel", mock.Anything, taskName).Return(false, nil)
+			},
+			http.StatusOK,
+			false,
+		},
+		{
+			"task_not_found",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+			},
+			http.StatusNotFound,
+			false,
+		},
+		{
+			"task_errored",
+			func(ctrl *mocks.Server) {
+				err := fmt.Errorf("task cancel error")
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskCancel", mock.Anything, taskName).Return(false, err)
+			},
+			http.StatusInternalServerError,
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewTaskLifeCycleHandler(ctrl)
+
+			path := fmt.Sprintf("/v1/tasks/%s/cancel", taskName)
+			req, err := http.NewRequest(http.MethodPost, path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.CancelTaskByName(resp, req, taskName)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+
+			if tc.statusCode != http.StatusOK {
+				return
+			}
+			var cancelResp oapigen.TaskCancelResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&cancelResp))
+			assert.Equal(t, tc.cancelled, cancelResp.Cancelled)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"fmt"
 	"net/http"
 
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
@@ -8,8 +9,12 @@ import (
 )
 
 // DeleteTaskByName deletes an existing task and its events asynchronously. Does not delete
-// until the task is inactive and not running.
-func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string) {
+// until the task is inactive and not running. The running execution of the task is
+// cancelled if the cancel parameter is set. The resources managed by the task are
+// destroyed before deleting the task if the destroy parameter is set, and the plan for
+// destroying the resources is returned without deleting the task with the inspect run
+// option.
+func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.Request, name string, params oapigen.DeleteTaskByNameParams) {
 	h.mu.Lock()
 	defer h.mu.Unlock()
 
@@ -26,7 +31,21 @@ func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	err = h.ctrl.TaskDelete(ctx, name)
+	destroy := params.Destroy != nil && *params.Destroy
+	if params.Run != nil && *params.Run != "" {
+		if string(*params.Run) != RunOptionInspect || !destroy {
+			sendError(w, r, http.StatusBadRequest, fmt.Errorf("unsupported run "+
+				"option '%s', only run inspect with destroy is supported when "+
+				"deleting a task", *params.Run))
+			return
+		}
+		logger.Trace("run inspect option")
+		h.inspectDestroyTask(w, r, name)
+		return
+	}
+
+	cancel := params.Cancel != nil && *params.Cancel
+	err = h.ctrl.TaskDelete(ctx, name, destroy, cancel)
 	if err != nil {
 		sendError(w, r, http.StatusInternalServerError, err)
 		return
@@ -37,3 +56,29 @@ func (h *TaskLifeCycleHandler) DeleteTaskByName(w http.ResponseWriter, r *http.R
 
 	logger.Trace("task deleted", "delete_task_response", resp)
 }
+
+func (h *TaskLifeCycleHandler) inspectDestroyTask(w http.ResponseWriter, r *http.Request, name string) {
+	ctx := r.Context()
+	logger := logging.FromContext(ctx).Named(deleteTaskSubsystemName).With("task_name", name)
+
+	changes, plan, runUrl, err := h.ctrl.TaskInspectDestroy(ctx, name)
+	if err != nil {
+		logger.Error("error inspecting destroy for task", "error", err)
+		sendError(w, r, http.StatusBadRequest, err)
+		return
+	}
+
+	resp := oapigen.TaskResponse{
+		RequestId: requestIDFromContext(ctx),
+		Run: &oapigen.Run{
+			Plan:           &plan,
+			ChangesPresent: &changes,
+		},
+	}
+	if runUrl != "" {
+		resp.Run.TfcRunUrl = &runUrl
+	}
+
+	writeResponse(w, r, http.StatusOK, resp)
+	logger.Trace("task destroy inspection complete", "delete_task_response", resp)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
 	"github.com/stretchr/testify/assert"
@@ -16,21 +17,77 @@ import (
 func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 	t.Parallel()
 	taskName := "task"
+	inspect := oapigen.DeleteTaskByNameParamsRun(RunOptionInspect)
 	cases := []struct {
 		name       string
+		params     oapigen.DeleteTaskByNameParams
 		mockServer func(*mocks.Server)
 		statusCode int
 	}{
 		{
 			"happy_path",
+			oapigen.DeleteTaskByNameParams{},
 			func(ctrl *mocks.Server) {
 				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
-				ctrl.On("TaskDelete", mock.Anything, taskName).Return(nil)
+				ctrl.On("TaskDelete", mock.Anything, taskName, false, false).Return(nil)
 			},
 			http.StatusAccepted,
 		},
+		{
+			"happy_path_cancel",
+			oapigen.DeleteTaskByNameParams{Cancel: config.Bool(true)},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskDelete", mock.Anything, taskName, false, true).Return(nil)
+			},
+			http.StatusAccepted,
+		},
+		{
+			"happy_path_destroy",
+			oapigen.DeleteTaskByNameParams{Destroy: config.Bool(true)},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskDelete", mock.Anything, taskName, true, false).Return(nil)
+			},
+			http.StatusAccepted,
+		},
+		{
+			"inspect_destroy",
+			oapigen.DeleteTaskByNameParams{
+				Destroy: config.Bool(true),
+				Run:     &inspect,
+			},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskInspectDestroy", mock.Anything, taskName).
+					Return(true, "plan", "", nil)
+			},
+			http.StatusOK,
+		},
+		{
+			"inspect_destroy_errored",
+			oapigen.DeleteTaskByNameParams{
+				Destroy: config.Bool(true),
+				Run:     &inspect,
+			},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskInspectDestroy", mock.Anything, taskName).
+					Return(false, "", "", fmt.Errorf("unsupported"))
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"inspect_without_destroy",
+			oapigen.DeleteTaskByNameParams{Run: &inspect},
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+			},
+			http.StatusBadRequest,
+		},
 		{
 			"task_not_found",
+			oapigen.DeleteTaskByNameParams{},
 			func(ctrl *mocks.Server) {
 				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, fmt.Errorf("DNE"))
 			},
@@ -38,10 +95,11 @@ func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 		},
 		{
 			"task_errored",
+			oapigen.DeleteTaskByNameParams{},
 			func(ctrl *mocks.Server) {
 				err := fmt.Errorf("task deletion error")
 				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
-				ctrl.On("TaskDelete", mock.Anything, taskName).Return(err)
+				ctrl.On("TaskDelete", mock.Anything, taskName, false, false).Return(err)
 			},
 			http.StatusInternalServerError,
 		},
@@ -58,8 +116,9 @@ func TestTaskLifeCycleHandler_DeleteTaskByName(t *testing.T) {
 			require.NoError(t, err)
 			resp := httptest.NewRecorder()
 
-			handler.DeleteTaskByName(resp, req, taskName)
+			handler.DeleteTaskByName(resp, req, taskName, tc.params)
 			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
 		})
 	}
 }
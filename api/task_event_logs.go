@@ -0,0 +1,81 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+// taskEventLogsSuffix is the suffix of the path of the logs of a task event,
+// which has the format status/tasks/{name}/events/{id}/logs
+const taskEventLogsSuffix = "logs"
+
+// TaskEventLogs is the Terraform output captured during a task's event
+type TaskEventLogs struct {
+	TaskName string `json:"task_name"`
+	EventID  string `json:"event_id"`
+	Logs     string `json:"logs"`
+}
+
+// parseTaskEventLogsPath returns the task name and event ID of a request for
+// the logs of a task event. The last return value is false if the path is
+// not for the logs of an event.
+func parseTaskEventLogsPath(reqPath, version string) (string, string, bool) {
+	prefix := fmt.Sprintf("/%s/%s/", version, taskStatusPath)
+	if !strings.HasPrefix(reqPath, prefix) {
+		return "", "", false
+	}
+
+	parts := strings.Split(strings.TrimPrefix(reqPath, prefix), "/")
+	if len(parts) != 4 || parts[1] != "events" || parts[3] != taskEventLogsSuffix {
+		return "", "", false
+	}
+	if parts[0] == "" || parts[2] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[2], true
+}
+
+// getTaskEventLogs returns the Terraform output captured during an event of
+// a task
+func (h *taskStatusHandler) getTaskEventLogs(w http.ResponseWriter,
+	r *http.Request, taskName, eventID string) {
+
+	ctx := r.Context()
+	logger := logging.FromContext(ctx).Named(taskStatusSubsystemName)
+
+	if _, err := h.ctrl.Task(ctx, taskName); err != nil {
+		logger.Trace("error getting task", "error", err)
+		jsonErrorResponse(ctx, w, http.StatusNotFound, err)
+		return
+	}
+
+	data, err := h.ctrl.Events(ctx, taskName)
+	if err != nil {
+		logger.Trace("error getting task events", "error", err)
+		jsonErrorResponse(ctx, w, http.StatusInternalServerError, err)
+		return
+	}
+
+	for _, e := range data[taskName] {
+		if e.ID != eventID {
+			continue
+		}
+		resp := TaskEventLogs{
+			TaskName: taskName,
+			EventID:  eventID,
+			Logs:     e.Logs,
+		}
+		if err = jsonResponse(w, http.StatusOK, resp); err != nil {
+			logger.Error("error, could not generate json response", "error", err)
+		}
+		return
+	}
+
+	err = fmt.Errorf("event '%s' not found for task '%s'. Only the most "+
+		"recent events of a task are retained", eventID, taskName)
+	logger.Trace("event not found", "error", err)
+	jsonErrorResponse(ctx, w, http.StatusNotFound, err)
+}
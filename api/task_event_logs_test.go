@@ -0,0 +1,117 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	serverMocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskEventLogs_ServeHTTP(t *testing.T) {
+	t.Parallel()
+
+	events := map[string][]event.Event{
+		"task_a": {
+			{ID: "2", TaskName: "task_a", Logs: "Apply complete!"},
+			{ID: "1", TaskName: "task_a", Logs: "Error: failed"},
+		},
+	}
+
+	ctrl := new(serverMocks.Server)
+	ctrl.On("Task", mock.Anything, "task_a").
+		Return(createTaskConf("task_a", true), nil).
+		On("Events", mock.Anything, "task_a").Return(events, nil).
+		On("Task", mock.Anything, "task_nonexistent").
+		Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+
+	handler := newTaskStatusHandler(ctrl, "v1")
+
+	cases := []struct {
+		name       string
+		path       string
+		statusCode int
+		expected   *TaskEventLogs
+	}{
+		{
+			"latest event",
+			"/v1/status/tasks/task_a/events/2/logs",
+			http.StatusOK,
+			&TaskEventLogs{TaskName: "task_a", EventID: "2", Logs: "Apply complete!"},
+		},
+		{
+			"older event",
+			"/v1/status/tasks/task_a/events/1/logs",
+			http.StatusOK,
+			&TaskEventLogs{TaskName: "task_a", EventID: "1", Logs: "Error: failed"},
+		},
+		{
+			"event not found",
+			"/v1/status/tasks/task_a/events/3/logs",
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"task not found",
+			"/v1/status/tasks/task_nonexistent/events/1/logs",
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"unsupported resource",
+			"/v1/status/tasks/task_a/events/1",
+			http.StatusBadRequest,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req, err := http.NewRequest(http.MethodGet, tc.path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+			assert.Equal(t, tc.statusCode, resp.Code)
+
+			if tc.expected == nil {
+				return
+			}
+			var actual TaskEventLogs
+			require.NoError(t, json.Unmarshal(resp.Body.Bytes(), &actual))
+			assert.Equal(t, *tc.expected, actual)
+		})
+	}
+}
+
+func TestParseTaskEventLogsPath(t *testing.T) {
+	cases := []struct {
+		name    string
+		path    string
+		task    string
+		eventID string
+		ok      bool
+	}{
+		{"logs path", "/v1/status/tasks/task_a/events/abc/logs", "task_a", "abc", true},
+		{"task status path", "/v1/status/tasks/task_a", "", "", false},
+		{"all task status path", "/v1/status/tasks", "", "", false},
+		{"missing event ID", "/v1/status/tasks/task_a/events//logs", "", "", false},
+		{"other resource", "/v1/status/tasks/task_a/events/abc/plan", "", "", false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			task, eventID, ok := parseTaskEventLogsPath(tc.path, "v1")
+			assert.Equal(t, tc.ok, ok)
+			assert.Equal(t, tc.task, task)
+			assert.Equal(t, tc.eventID, eventID)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+// ExportTaskByName returns the files of a standalone Terraform root module
+// for an existing task
+func (h *TaskLifeCycleHandler) ExportTaskByName(w http.ResponseWriter, r *http.Request, name string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ctx := r.Context()
+	requestID := requestIDFromContext(ctx)
+	logger := logging.FromContext(ctx).Named(exportTaskSubsystemName).With("task_name", name)
+	logger.Trace("export task request")
+
+	// Check if task exists
+	_, err := h.ctrl.Task(ctx, name)
+	if err != nil {
+		logger.Trace("task not found", "error", err)
+		sendError(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	files, err := h.ctrl.TaskExport(ctx, name)
+	if err != nil {
+		sendError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := oapigen.TaskExportResponse{
+		RequestId: requestID,
+		// tasks are run in a Terraform workspace named after the task
+		Workspace: name,
+		Files: oapigen.TaskExportResponse_Files{
+			AdditionalProperties: make(map[string]string, len(files)),
+		},
+	}
+	for filename, content := range files {
+		resp.Files.Set(filename, string(content))
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+
+	logger.Trace("task exported", "files", len(files))
+}
@@ -0,0 +1,87 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLifeCycleHandler_ExportTaskByName(t *testing.T) {
+	t.Parallel()
+	taskName := "task"
+	files := map[string][]byte{
+		"main.tf":          []byte("module \"task\" {}\n"),
+		"terraform.tfvars": []byte("services = {}\n"),
+	}
+	cases := []struct {
+		name       string
+		mockServer func(*mocks.Server)
+		statusCode int
+		files      map[string]string
+	}{
+		{
+			"happy_path",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskExport", mock.Anything, taskName).Return(files, nil)
+			},
+			http.StatusOK,
+			map[string]string{
+				"main.tf":          "module \"task\" {}\n",
+				"terraform.tfvars": "services = {}\n",
+			},
+		},
+		{
+			"task_not_found",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+			},
+			http.StatusNotFound,
+			nil,
+		},
+		{
+			"task_errored",
+			func(ctrl *mocks.Server) {
+				err := fmt.Errorf("task export error")
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskExport", mock.Anything, taskName).Return(nil, err)
+			},
+			http.StatusInternalServerError,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewTaskLifeCycleHandler(ctrl)
+
+			path := fmt.Sprintf("/v1/tasks/%s/export", taskName)
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.ExportTaskByName(resp, req, taskName)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+
+			if tc.statusCode != http.StatusOK {
+				return
+			}
+			var exportResp oapigen.TaskExportResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&exportResp))
+			assert.Equal(t, taskName, exportResp.Workspace)
+			assert.Equal(t, tc.files, exportResp.Files.AdditionalProperties)
+		})
+	}
+}
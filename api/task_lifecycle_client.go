@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -43,7 +44,15 @@ func NewTaskLifecycleClient(c *ClientConfig, httpClient httpClient) (*TaskLifecy
 	gc := &TaskLifecycleClient{url: u}
 
 	// Create the new underlying client based on generated code
-	oc, err := oapigen.NewClientWithResponses(gc.url.String(), oapigen.WithHTTPClient(httpClient))
+	opts := []oapigen.ClientOption{oapigen.WithHTTPClient(httpClient)}
+	if c.Token != "" {
+		opts = append(opts, oapigen.WithRequestEditorFn(
+			func(_ context.Context, req *http.Request) error {
+				req.Header.Set(TokenHeader, c.Token)
+				return nil
+			}))
+	}
+	oc, err := oapigen.NewClientWithResponses(gc.url.String(), opts...)
 	if err != nil {
 		return nil, err
 	}
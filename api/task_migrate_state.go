@@ -0,0 +1,71 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+// MigrateTaskStateByName moves the Terraform state of an existing, disabled
+// task to a new backend
+func (h *TaskLifeCycleHandler) MigrateTaskStateByName(w http.ResponseWriter, r *http.Request, name string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	ctx := r.Context()
+	requestID := requestIDFromContext(ctx)
+	logger := logging.FromContext(ctx).Named(migrateTaskStateSubsystemName).With("task_name", name)
+	logger.Trace("migrate task state request")
+
+	// Check if task exists
+	tc, err := h.ctrl.Task(ctx, name)
+	if err != nil {
+		logger.Trace("task not found", "error", err)
+		sendError(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	var req oapigen.TaskMigrateStateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		logger.Error("bad request", "error", err)
+		sendError(w, r, http.StatusBadRequest,
+			fmt.Errorf("error decoding the request: %v", err))
+		return
+	}
+
+	if config.BoolVal(tc.Enabled) {
+		sendError(w, r, http.StatusBadRequest,
+			fmt.Errorf("task '%s' must be disabled to migrate its state", name))
+		return
+	}
+
+	backend := req.Backend.AdditionalProperties
+	if len(backend) == 0 {
+		sendError(w, r, http.StatusBadRequest,
+			fmt.Errorf("backend is required to migrate the state of task '%s'", name))
+		return
+	}
+	tc.Backend = backend
+	if err := tc.Validate(); err != nil {
+		sendError(w, r, http.StatusBadRequest,
+			fmt.Errorf("error with backend configuration: %s", err))
+		return
+	}
+
+	if err := h.ctrl.TaskMigrateState(ctx, name, backend); err != nil {
+		logger.Error("error migrating task state", "error", err)
+		sendError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := oapigen.TaskMigrateStateResponse{
+		RequestId: requestID,
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+
+	logger.Trace("task state migrated")
+}
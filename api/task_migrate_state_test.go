@@ -0,0 +1,120 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLifeCycleHandler_MigrateTaskStateByName(t *testing.T) {
+	t.Parallel()
+	taskName := "task"
+	disabled := config.TaskConfig{
+		Name:    config.String(taskName),
+		Enabled: config.Bool(false),
+		Module:  config.String("path"),
+		Condition: &config.ServicesConditionConfig{
+			ServicesMonitorConfig: config.ServicesMonitorConfig{
+				Names: []string{"api"},
+			},
+		},
+	}
+	enabled := *disabled.Copy()
+	enabled.Enabled = config.Bool(true)
+	backend := map[string]interface{}{
+		"local": map[string]interface{}{"path": "terraform.tfstate"},
+	}
+	body := `{"backend": {"local": {"path": "terraform.tfstate"}}}`
+
+	cases := []struct {
+		name       string
+		body       string
+		mockServer func(*mocks.Server)
+		statusCode int
+	}{
+		{
+			"happy_path",
+			body,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(disabled, nil)
+				ctrl.On("TaskMigrateState", mock.Anything, taskName, backend).Return(nil)
+			},
+			http.StatusOK,
+		},
+		{
+			"task_not_found",
+			body,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+			},
+			http.StatusNotFound,
+		},
+		{
+			"bad_request_body",
+			`{"backend": "local"}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(disabled, nil)
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"task_enabled",
+			body,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(enabled, nil)
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"missing_backend",
+			`{"backend": {}}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(disabled, nil)
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"unsupported_backend",
+			`{"backend": {"unsupported": {}}}`,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(disabled, nil)
+			},
+			http.StatusBadRequest,
+		},
+		{
+			"migrate_errored",
+			body,
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(disabled, nil)
+				ctrl.On("TaskMigrateState", mock.Anything, taskName, backend).
+					Return(fmt.Errorf("migrate error"))
+			},
+			http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewTaskLifeCycleHandler(ctrl)
+
+			path := fmt.Sprintf("/v1/tasks/%s/state/migrate", taskName)
+			req, err := http.NewRequest(http.MethodPost, path, bytes.NewBufferString(tc.body))
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.MigrateTaskStateByName(resp, req, taskName)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+		})
+	}
+}
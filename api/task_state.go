@@ -0,0 +1,41 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+// GetTaskStateByName returns the resources managed by an existing task
+func (h *TaskLifeCycleHandler) GetTaskStateByName(w http.ResponseWriter, r *http.Request, name string) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	ctx := r.Context()
+	requestID := requestIDFromContext(ctx)
+	logger := logging.FromContext(ctx).Named(getTaskStateSubsystemName).With("task_name", name)
+	logger.Trace("get task state request")
+
+	// Check if task exists
+	_, err := h.ctrl.Task(ctx, name)
+	if err != nil {
+		logger.Trace("task not found", "error", err)
+		sendError(w, r, http.StatusNotFound, err)
+		return
+	}
+
+	state, err := h.ctrl.TaskState(ctx, name)
+	if err != nil {
+		sendError(w, r, http.StatusInternalServerError, err)
+		return
+	}
+
+	resp := oapigen.TaskStateResponse{
+		RequestId: requestID,
+		Resources: stateResourcesFromState(state),
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+
+	logger.Trace("task state retrieved", "resources", len(resp.Resources))
+}
@@ -0,0 +1,107 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLifeCycleHandler_GetTaskStateByName(t *testing.T) {
+	t.Parallel()
+	taskName := "task"
+	state := &tfjson.State{
+		Values: &tfjson.StateValues{
+			RootModule: &tfjson.StateModule{
+				ChildModules: []*tfjson.StateModule{{
+					Address: "module.task",
+					Resources: []*tfjson.StateResource{{
+						Address:      "module.task.local_file.api",
+						Mode:         tfjson.ManagedResourceMode,
+						Type:         "local_file",
+						Name:         "api",
+						ProviderName: "registry.terraform.io/hashicorp/local",
+						AttributeValues: map[string]interface{}{
+							"filename": "api.txt",
+						},
+					}},
+				}},
+			},
+		},
+	}
+	cases := []struct {
+		name       string
+		mockServer func(*mocks.Server)
+		statusCode int
+		resources  int
+	}{
+		{
+			"happy_path",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskState", mock.Anything, taskName).Return(state, nil)
+			},
+			http.StatusOK,
+			1,
+		},
+		{
+			"empty_state",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskState", mock.Anything, taskName).Return(&tfjson.State{}, nil)
+			},
+			http.StatusOK,
+			0,
+		},
+		{
+			"task_not_found",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, fmt.Errorf("DNE"))
+			},
+			http.StatusNotFound,
+			0,
+		},
+		{
+			"task_errored",
+			func(ctrl *mocks.Server) {
+				ctrl.On("Task", mock.Anything, taskName).Return(config.TaskConfig{}, nil)
+				ctrl.On("TaskState", mock.Anything, taskName).Return(nil, fmt.Errorf("show error"))
+			},
+			http.StatusInternalServerError,
+			0,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := new(mocks.Server)
+			tc.mockServer(ctrl)
+			handler := NewTaskLifeCycleHandler(ctrl)
+
+			path := fmt.Sprintf("/v1/tasks/%s/state", taskName)
+			req, err := http.NewRequest(http.MethodGet, path, nil)
+			require.NoError(t, err)
+			resp := httptest.NewRecorder()
+
+			handler.GetTaskStateByName(resp, req, taskName)
+			assert.Equal(t, tc.statusCode, resp.Code)
+			ctrl.AssertExpectations(t)
+
+			if tc.statusCode != http.StatusOK {
+				return
+			}
+			var stateResp oapigen.TaskStateResponse
+			require.NoError(t, json.NewDecoder(resp.Body).Decode(&stateResp))
+			assert.Len(t, stateResp.Resources, tc.resources)
+		})
+	}
+}
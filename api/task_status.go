@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/registry"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 )
 
@@ -23,6 +24,10 @@ type TaskStatus struct {
 	EventsURL string        `json:"events_url"`
 	Events    []event.Event `json:"events,omitempty"`
 
+	// ModuleUpgrade is the result of the most recent check for a newer
+	// version of the task's module, if module upgrades are tracked
+	ModuleUpgrade *registry.ModuleUpgrade `json:"module_upgrade,omitempty"`
+
 	// Providers and Services are deprecated in v0.5. These are configuration
 	// details about the task rather than status information. Users should
 	// switch to using the Get Task API to request the task's provider and
@@ -70,6 +75,11 @@ func (h *taskStatusHandler) getTaskStatus(w http.ResponseWriter, r *http.Request
 	ctx := r.Context()
 	logger := logging.FromContext(ctx).Named(taskStatusSubsystemName)
 
+	if name, id, ok := parseTaskEventLogsPath(r.URL.Path, h.version); ok {
+		h.getTaskEventLogs(w, r, name, id)
+		return
+	}
+
 	taskName, err := getTaskName(r.URL.Path, taskStatusPath, h.version)
 	if err != nil {
 		logger.Trace("bad request", "error", err)
@@ -136,6 +146,13 @@ func (h *taskStatusHandler) getTaskStatus(w http.ResponseWriter, r *http.Request
 		}
 	}
 
+	for name, status := range statuses {
+		if u, ok := h.ctrl.TaskModuleUpgrade(ctx, name); ok {
+			status.ModuleUpgrade = &u
+			statuses[name] = status
+		}
+	}
+
 	if err = jsonResponse(w, http.StatusOK, statuses); err != nil {
 		logger.Error("error, could not generate json response", "error", err)
 	}
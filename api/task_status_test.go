@@ -10,6 +10,7 @@ import (
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	serverMocks "github.com/hashicorp/consul-terraform-sync/mocks/server"
+	"github.com/hashicorp/consul-terraform-sync/registry"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -73,6 +74,13 @@ func TestTaskStatus_ServeHTTP(t *testing.T) {
 		On("Task", mock.Anything, "task_nonexistent").Return(config.TaskConfig{}, fmt.Errorf("DNE"))
 	ctrl.On("Events", mock.Anything, "").Return(events, nil)
 	ctrl.On("Tasks", mock.Anything).Return(confs)
+	upgrade := registry.ModuleUpgrade{
+		Source:           "example/basic/local",
+		InstalledVersion: "1.0.0",
+		LatestVersion:    "1.1.0",
+	}
+	ctrl.On("TaskModuleUpgrade", mock.Anything, "task_c").Return(upgrade, true)
+	ctrl.On("TaskModuleUpgrade", mock.Anything, mock.Anything).Return(registry.ModuleUpgrade{}, false)
 
 	handler := newTaskStatusHandler(ctrl, "v1")
 
@@ -106,12 +114,13 @@ func TestTaskStatus_ServeHTTP(t *testing.T) {
 					EventsURL: "/v1/status/tasks/task_b?include=events",
 				},
 				"task_c": {
-					TaskName:  "task_c",
-					Status:    StatusErrored,
-					Enabled:   true,
-					Providers: []string{},
-					Services:  []string{},
-					EventsURL: "/v1/status/tasks/task_c?include=events",
+					TaskName:      "task_c",
+					Status:        StatusErrored,
+					Enabled:       true,
+					Providers:     []string{},
+					Services:      []string{},
+					EventsURL:     "/v1/status/tasks/task_c?include=events",
+					ModuleUpgrade: &upgrade,
 				},
 				"task_d": {
 					TaskName:  "task_d",
@@ -147,13 +156,14 @@ func TestTaskStatus_ServeHTTP(t *testing.T) {
 					Events:    events["task_b"],
 				},
 				"task_c": {
-					TaskName:  "task_c",
-					Status:    StatusErrored,
-					Enabled:   true,
-					Providers: []string{},
-					Services:  []string{},
-					EventsURL: "/v1/status/tasks/task_c?include=events",
-					Events:    events["task_c"],
+					TaskName:      "task_c",
+					Status:        StatusErrored,
+					Enabled:       true,
+					Providers:     []string{},
+					Services:      []string{},
+					EventsURL:     "/v1/status/tasks/task_c?include=events",
+					ModuleUpgrade: &upgrade,
+					Events:        events["task_c"],
 				},
 				"task_d": {
 					TaskName:  "task_d",
@@ -0,0 +1,257 @@
+package audit
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	gsyslog "github.com/hashicorp/go-syslog"
+)
+
+const (
+	logSystemName = "audit"
+
+	// TypeRequest is the type of entries that record API requests
+	TypeRequest = "request"
+
+	// TypeApply is the type of entries that record Terraform applies
+	TypeApply = "apply"
+
+	// ActionApply is the action of entries that record Terraform applies
+	ActionApply = "task_apply"
+
+	// ActionDestroy is the action of entries that record Terraform destroys
+	// of the resources of a deleted task
+	ActionDestroy = "task_destroy"
+
+	filePerms = 0600
+)
+
+// Entry is a record in the audit log
+type Entry struct {
+	Time time.Time `json:"time"`
+	Type string    `json:"type"`
+
+	// Action is what was done, such as task_create or task_apply
+	Action   string `json:"action"`
+	TaskName string `json:"task_name,omitempty"`
+
+	// RequestID and Actor identify the API request that the entry is for or,
+	// for applies, that triggered the apply. They are empty for applies
+	// triggered by CTS.
+	RequestID string `json:"request_id,omitempty"`
+	Actor     *Actor `json:"actor,omitempty"`
+
+	Request *Request `json:"request,omitempty"`
+	Apply   *Apply   `json:"apply,omitempty"`
+	Result  Result   `json:"result"`
+}
+
+// Actor identifies who made an API request
+type Actor struct {
+	// TokenName is the name of the static token or the accessor ID of the
+	// Consul ACL token that authenticated the request
+	TokenName string `json:"token_name,omitempty"`
+	Role      string `json:"role,omitempty"`
+
+	// ClientCertCN is the common name of the verified client certificate
+	ClientCertCN string `json:"client_cert_cn,omitempty"`
+
+	RemoteAddr string `json:"remote_addr,omitempty"`
+}
+
+// Request describes an API request. Sensitive values of the body are
+// redacted.
+type Request struct {
+	Method string          `json:"method"`
+	Path   string          `json:"path"`
+	Query  string          `json:"query,omitempty"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+// Apply describes a Terraform apply of a task
+type Apply struct {
+	EventID string               `json:"event_id"`
+	Changes *event.ChangeSummary `json:"changes,omitempty"`
+}
+
+// Result is the outcome of the request or apply
+type Result struct {
+	Success    bool   `json:"success"`
+	StatusCode int    `json:"status_code,omitempty"`
+	Cancelled  bool   `json:"cancelled,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Auditor records entries to an append-only audit sink. A nil Auditor is
+// valid and does not record entries.
+type Auditor struct {
+	logger logging.Logger
+
+	mu     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+}
+
+// New returns an auditor for the sink configured by conf. Returns nil if the
+// audit log is not enabled.
+func New(conf *config.AuditConfig) (*Auditor, error) {
+	if conf == nil || !config.BoolVal(conf.Enabled) {
+		return nil, nil
+	}
+
+	switch sink := config.StringVal(conf.Sink); sink {
+	case config.AuditSinkFile:
+		path := config.StringVal(conf.Path)
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, filePerms)
+		if err != nil {
+			return nil, fmt.Errorf("error opening audit file %q: %s", path, err)
+		}
+		a := NewWriter(syncWriter{f})
+		a.closer = f
+		return a, nil
+
+	case config.AuditSinkSyslog:
+		l, err := gsyslog.NewLogger(gsyslog.LOG_NOTICE,
+			config.StringVal(conf.SyslogFacility), config.StringVal(conf.SyslogName))
+		if err != nil {
+			return nil, fmt.Errorf("error setting up audit syslog: %s", err)
+		}
+		a := NewWriter(l)
+		a.closer = l
+		return a, nil
+
+	case config.AuditSinkStdout:
+		return NewWriter(os.Stdout), nil
+
+	default:
+		return nil, fmt.Errorf("unsupported audit sink %q", sink)
+	}
+}
+
+// NewWriter returns an auditor that writes each entry to w as a line of JSON
+func NewWriter(w io.Writer) *Auditor {
+	return &Auditor{
+		logger: logging.Global().Named(logSystemName),
+		w:      w,
+	}
+}
+
+// Record writes the entry to the audit sink. The time of the entry is set if
+// it is not already. Errors are logged since an audited operation has already
+// completed once it is recorded.
+func (a *Auditor) Record(e Entry) {
+	if a == nil {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+
+	b, err := json.Marshal(e)
+	if err != nil {
+		a.logger.Error("error encoding audit entry", "action", e.Action, "error", err)
+		return
+	}
+	b = append(b, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(b); err != nil {
+		a.logger.Error("error writing audit entry", "action", e.Action,
+			"request_id", e.RequestID, "error", err)
+	}
+}
+
+// Close closes the audit sink
+func (a *Auditor) Close() error {
+	if a == nil || a.closer == nil {
+		return nil
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.closer.Close()
+}
+
+// syncWriter flushes each write to a file to stable storage so that entries
+// are durable once recorded
+type syncWriter struct {
+	f *os.File
+}
+
+func (w syncWriter) Write(p []byte) (int, error) {
+	n, err := w.f.Write(p)
+	if err != nil {
+		return n, err
+	}
+	return n, w.f.Sync()
+}
+
+type ctxKey struct{}
+
+// Origin identifies the API request that an operation was made for
+type Origin struct {
+	RequestID string
+	Actor     *Actor
+}
+
+// WithOrigin stores the origin of an operation in the context so that
+// entries recorded while completing the operation, such as applies, identify
+// the request that triggered them.
+func WithOrigin(ctx context.Context, o Origin) context.Context {
+	return context.WithValue(ctx, ctxKey{}, o)
+}
+
+// OriginFromContext returns the origin stored in the context. Returns false
+// if the context does not have an origin.
+func OriginFromContext(ctx context.Context) (Origin, bool) {
+	if ctx == nil {
+		return Origin{}, false
+	}
+	o, ok := ctx.Value(ctxKey{}).(Origin)
+	return o, ok
+}
+
+// ApplyEntry returns the entry for a Terraform apply of a task recorded on the
+// event. The origin of the apply is read from the context.
+func ApplyEntry(ctx context.Context, ev *event.Event) Entry {
+	e := Entry{
+		Type:     TypeApply,
+		Action:   ActionApply,
+		TaskName: ev.TaskName,
+		Apply: &Apply{
+			EventID: ev.ID,
+			Changes: ev.Changes,
+		},
+		Result: Result{
+			Success:   ev.Success,
+			Cancelled: ev.Cancelled,
+		},
+	}
+	if ev.EventError != nil {
+		e.Result.Error = ev.EventError.Message
+	}
+	if o, ok := OriginFromContext(ctx); ok {
+		e.RequestID = o.RequestID
+		e.Actor = o.Actor
+	}
+	return e
+}
+
+// DestroyEntry returns the entry for a Terraform destroy of the resources of
+// a task recorded on the event. The origin of the destroy is read from the
+// context.
+func DestroyEntry(ctx context.Context, ev *event.Event) Entry {
+	e := ApplyEntry(ctx, ev)
+	e.Action = ActionDestroy
+	return e
+}
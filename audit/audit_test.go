@@ -0,0 +1,191 @@
+package audit
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	t.Run("disabled", func(t *testing.T) {
+		conf := config.DefaultAuditConfig()
+		conf.Finalize()
+		a, err := New(conf)
+		assert.NoError(t, err)
+		assert.Nil(t, a)
+	})
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "audit.log")
+		require.NoError(t, os.WriteFile(path, []byte("{}\n"), 0600))
+
+		conf := &config.AuditConfig{Path: config.String(path)}
+		conf.Finalize()
+		a, err := New(conf)
+		require.NoError(t, err)
+
+		a.Record(Entry{Type: TypeRequest, Action: "task_create"})
+		require.NoError(t, a.Close())
+
+		// entries are appended to the existing file
+		b, err := os.ReadFile(path)
+		require.NoError(t, err)
+		lines := bytes.Split(bytes.TrimSpace(b), []byte("\n"))
+		require.Len(t, lines, 2)
+
+		var e Entry
+		require.NoError(t, json.Unmarshal(lines[1], &e))
+		assert.Equal(t, "task_create", e.Action)
+		assert.False(t, e.Time.IsZero())
+	})
+
+	t.Run("file_error", func(t *testing.T) {
+		conf := &config.AuditConfig{
+			Path: config.String(filepath.Join(t.TempDir(), "missing", "audit.log")),
+		}
+		conf.Finalize()
+		_, err := New(conf)
+		assert.Error(t, err)
+	})
+}
+
+func TestAuditor_Record(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil_auditor", func(t *testing.T) {
+		var a *Auditor
+		a.Record(Entry{})
+		assert.NoError(t, a.Close())
+	})
+
+	t.Run("json_lines", func(t *testing.T) {
+		var buf bytes.Buffer
+		a := NewWriter(&buf)
+		a.Record(Entry{Type: TypeRequest, Action: "task_delete", TaskName: "a"})
+		a.Record(Entry{Type: TypeApply, Action: ActionApply, TaskName: "b"})
+
+		var actions []string
+		s := bufio.NewScanner(&buf)
+		for s.Scan() {
+			var e Entry
+			require.NoError(t, json.Unmarshal(s.Bytes(), &e))
+			actions = append(actions, e.Action)
+		}
+		assert.Equal(t, []string{"task_delete", ActionApply}, actions)
+	})
+}
+
+func TestApplyEntry(t *testing.T) {
+	t.Parallel()
+
+	ev := &event.Event{
+		ID:       "id",
+		TaskName: "task",
+		Changes:  &event.ChangeSummary{Add: 1},
+	}
+	ev.End(errors.New("apply error"))
+
+	t.Run("triggered_by_cts", func(t *testing.T) {
+		e := ApplyEntry(context.Background(), ev)
+		assert.Equal(t, Entry{
+			Type:     TypeApply,
+			Action:   ActionApply,
+			TaskName: "task",
+			Apply: &Apply{
+				EventID: "id",
+				Changes: &event.ChangeSummary{Add: 1},
+			},
+			Result: Result{Error: "apply error"},
+		}, e)
+	})
+
+	t.Run("triggered_by_request", func(t *testing.T) {
+		actor := &Actor{TokenName: "ops", Role: config.APIRoleOperator}
+		ctx := WithOrigin(context.Background(), Origin{RequestID: "req", Actor: actor})
+		e := ApplyEntry(ctx, ev)
+		assert.Equal(t, "req", e.RequestID)
+		assert.Equal(t, actor, e.Actor)
+	})
+}
+
+func TestDestroyEntry(t *testing.T) {
+	t.Parallel()
+
+	ev := &event.Event{ID: "id", TaskName: "task"}
+	ev.End(nil)
+
+	ctx := WithOrigin(context.Background(), Origin{RequestID: "req"})
+	e := DestroyEntry(ctx, ev)
+	assert.Equal(t, Entry{
+		Type:      TypeApply,
+		Action:    ActionDestroy,
+		TaskName:  "task",
+		RequestID: "req",
+		Apply:     &Apply{EventID: "id"},
+		Result:    Result{Success: true},
+	}, e)
+}
+
+func TestRedactBody(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		body     string
+		expected string
+	}{
+		{
+			"empty",
+			"",
+			"",
+		},
+		{
+			"not_json",
+			"enabled=true",
+			"",
+		},
+		{
+			"no_sensitive_values",
+			`{"enabled":true}`,
+			`{"enabled":true}`,
+		},
+		{
+			"variables",
+			`{"task":{"name":"task","variables":{"a":"1","b":{"c":2}}}}`,
+			`{"task":{"name":"task","variables":{"a":"(redacted)","b":"(redacted)"}}}`,
+		},
+		{
+			"backend",
+			`{"backend":{"s3":{"bucket":"cts","access_key":"k"}}}`,
+			`{"backend":{"s3":"(redacted)"}}`,
+		},
+		{
+			"sensitive_keys",
+			`{"task":{"env":{"API_TOKEN":"t","DB_Password":"p","REGION":"r"}}}`,
+			`{"task":{"env":{"API_TOKEN":"(redacted)","DB_Password":"(redacted)","REGION":"r"}}}`,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := RedactBody([]byte(tc.body))
+			if tc.expected == "" {
+				assert.Nil(t, actual)
+				return
+			}
+			assert.JSONEq(t, tc.expected, string(actual))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package audit
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const redactMessage = "(redacted)"
+
+// sensitiveKeys are substrings of the keys of values that are always redacted
+var sensitiveKeys = []string{
+	"password",
+	"secret",
+	"token",
+}
+
+// RedactBody returns the JSON request body with sensitive values redacted.
+// CTS cannot know which module variables are sensitive, so the values of all
+// variables are redacted along with any value with a key that names a secret.
+// Backend configurations are redacted to their labels since they commonly
+// contain credentials.
+// Returns nil if the body is empty or is not JSON.
+func RedactBody(body []byte) json.RawMessage {
+	if len(body) == 0 {
+		return nil
+	}
+
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+
+	b, err := json.Marshal(redact(v))
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+func redact(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			switch {
+			case k == "variables", k == "backend":
+				v[k] = redactValues(val)
+			case isSensitiveKey(k):
+				v[k] = redactMessage
+			default:
+				v[k] = redact(val)
+			}
+		}
+		return v
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redact(val)
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+// redactValues redacts the values of an object but keeps its keys
+func redactValues(v interface{}) interface{} {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return redactMessage
+	}
+	for k := range m {
+		m[k] = redactMessage
+	}
+	return m
+}
+
+func isSensitiveKey(k string) bool {
+	k = strings.ToLower(k)
+	for _, s := range sensitiveKeys {
+		if strings.Contains(k, s) {
+			return true
+		}
+	}
+	return false
+}
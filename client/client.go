@@ -3,6 +3,8 @@ package client
 import (
 	"context"
 	"io"
+
+	"github.com/hashicorp/terraform-json"
 )
 
 //go:generate mockery --name=Client --filename=client.go  --output=../mocks/client
@@ -16,6 +18,9 @@ type Client interface {
 	// SetStdout Set the standard out for the client
 	SetStdout(w io.Writer)
 
+	// SetStderr Set the standard error for the client
+	SetStderr(w io.Writer)
+
 	// Init initializes the client and environment
 	Init(ctx context.Context) error
 
@@ -25,6 +30,38 @@ type Client interface {
 	// Plan makes a request to generate a plan of proposed changes
 	Plan(ctx context.Context) (bool, error)
 
+	// SavePlan generates a plan of proposed changes and saves it to a file
+	SavePlan(ctx context.Context, planFile string) (bool, error)
+
+	// ShowPlan returns the JSON representation of a saved plan file
+	ShowPlan(ctx context.Context, planFile string) (*tfjson.Plan, error)
+
+	// ShowState returns the JSON representation of the state of the workspace
+	ShowState(ctx context.Context) (*tfjson.State, error)
+
+	// ApplyPlan applies the changes of a saved plan file
+	ApplyPlan(ctx context.Context, planFile string) error
+
+	// Destroy makes a request to destroy all managed resources
+	Destroy(ctx context.Context) error
+
+	// PlanDestroy makes a request to generate a plan of destroying all
+	// managed resources
+	PlanDestroy(ctx context.Context) (bool, error)
+
+	// DeleteWorkspace deletes the workspace and its state
+	DeleteWorkspace(ctx context.Context) error
+
+	// Reconfigure initializes the client and environment, disregarding the
+	// backend that was previously initialized
+	Reconfigure(ctx context.Context) error
+
+	// PullState returns the state of the workspace
+	PullState(ctx context.Context) (string, error)
+
+	// PushState overwrites the state of the workspace with a state file
+	PushState(ctx context.Context, stateFile string) error
+
 	// Validate verifies that the generated configurations are valid
 	Validate(ctx context.Context) error
 
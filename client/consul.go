@@ -78,6 +78,10 @@ type ConsulClientInterface interface {
 	Lock(l *consulapi.Lock, stopCh <-chan struct{}) (<-chan struct{}, error)
 	Unlock(l *consulapi.Lock) error
 	KVGet(ctx context.Context, key string, q *consulapi.QueryOptions) (*consulapi.KVPair, *consulapi.QueryMeta, error)
+	KVPut(ctx context.Context, p *consulapi.KVPair, q *consulapi.WriteOptions) (*consulapi.WriteMeta, error)
+	ACLTokenReadSelf(ctx context.Context, q *consulapi.QueryOptions) (*consulapi.ACLToken, error)
+	CatalogServices(ctx context.Context, q *consulapi.QueryOptions) (map[string][]string, *consulapi.QueryMeta, error)
+	CatalogService(ctx context.Context, service string, q *consulapi.QueryOptions) ([]*consulapi.CatalogService, *consulapi.QueryMeta, error)
 }
 
 // ConsulClient is a client to the Consul API
@@ -348,6 +352,118 @@ func (c *ConsulClient) KVGet(ctx context.Context, key string, q *consulapi.Query
 	return kv, meta, err
 }
 
+// KVPut writes a Consul KV pair, retrying the request on server errors and rate limit errors.
+func (c *ConsulClient) KVPut(ctx context.Context, p *consulapi.KVPair, q *consulapi.WriteOptions) (*consulapi.WriteMeta, error) {
+	c.logger.Debug("putting KV pair", "key", p.Key)
+	desc := "KVPut"
+	var meta *consulapi.WriteMeta
+	f := func(context.Context) error {
+		var err error
+		meta, err = c.KV().Put(p, q)
+		if err != nil {
+			statusCode := getResponseCodeFromError(ctx, err)
+
+			// If we get a StatusForbidden assume that this is because CTS
+			// does not have the correct ACLs to access this resource in Consul
+			// and wrap in the appropriate error
+			if statusCode == http.StatusForbidden {
+				err = &MissingConsulACLError{Err: err}
+			}
+
+			// non-retryable errors allows for termination of retries
+			if !isResponseCodeRetryable(statusCode) {
+				err = &retry.NonRetryableError{Err: err}
+			}
+
+			return err
+		}
+		return nil
+	}
+
+	err := c.retry.Do(ctx, f, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return meta, nil
+}
+
+// CatalogServices lists the services registered in the Consul catalog with
+// their tags, retrying the request on server errors and rate limit errors.
+func (c *ConsulClient) CatalogServices(ctx context.Context, q *consulapi.QueryOptions) (map[string][]string, *consulapi.QueryMeta, error) {
+	c.logger.Debug("listing catalog services")
+	desc := "CatalogServices"
+	var services map[string][]string
+	var meta *consulapi.QueryMeta
+	f := func(context.Context) error {
+		var err error
+		services, meta, err = c.Catalog().Services(q)
+		if err != nil {
+			statusCode := getResponseCodeFromError(ctx, err)
+
+			// If we get a StatusForbidden assume that this is because CTS
+			// does not have the correct ACLs to access this resource in Consul
+			// and wrap in the appropriate error
+			if statusCode == http.StatusForbidden {
+				err = &MissingConsulACLError{Err: err}
+			}
+
+			// non-retryable errors allows for termination of retries
+			if !isResponseCodeRetryable(statusCode) {
+				err = &retry.NonRetryableError{Err: err}
+			}
+
+			return err
+		}
+		return nil
+	}
+
+	err := c.retry.Do(ctx, f, desc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return services, meta, nil
+}
+
+// CatalogService lists the instances of a service registered in the Consul
+// catalog, retrying the request on server errors and rate limit errors.
+func (c *ConsulClient) CatalogService(ctx context.Context, service string, q *consulapi.QueryOptions) ([]*consulapi.CatalogService, *consulapi.QueryMeta, error) {
+	c.logger.Debug("listing catalog service instances", "service", service)
+	desc := "CatalogService"
+	var instances []*consulapi.CatalogService
+	var meta *consulapi.QueryMeta
+	f := func(context.Context) error {
+		var err error
+		instances, meta, err = c.Catalog().Service(service, "", q)
+		if err != nil {
+			statusCode := getResponseCodeFromError(ctx, err)
+
+			// If we get a StatusForbidden assume that this is because CTS
+			// does not have the correct ACLs to access this resource in Consul
+			// and wrap in the appropriate error
+			if statusCode == http.StatusForbidden {
+				err = &MissingConsulACLError{Err: err}
+			}
+
+			// non-retryable errors allows for termination of retries
+			if !isResponseCodeRetryable(statusCode) {
+				err = &retry.NonRetryableError{Err: err}
+			}
+
+			return err
+		}
+		return nil
+	}
+
+	err := c.retry.Do(ctx, f, desc)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return instances, meta, nil
+}
+
 func getResponseCodeFromError(ctx context.Context, err error) int {
 	// Extract the unexpected response substring
 	s := regexUnexpectedResponseCode.FindString(err.Error())
@@ -386,3 +502,33 @@ func checkStatusCodeCategory(category int, statusCode int) bool {
 
 	return category == i
 }
+
+// ACLTokenReadSelf reads the ACL token set in the query options, retrying the
+// request on server errors and rate limit errors. Client errors, such as a
+// forbidden response for a token that does not exist, are not retried.
+func (c *ConsulClient) ACLTokenReadSelf(ctx context.Context, q *consulapi.QueryOptions) (*consulapi.ACLToken, error) {
+	desc := "ACLTokenReadSelf"
+	var token *consulapi.ACLToken
+	f := func(context.Context) error {
+		var err error
+		token, _, err = c.ACL().TokenReadSelf(q)
+		if err != nil {
+			statusCode := getResponseCodeFromError(ctx, err)
+
+			// non-retryable errors allows for termination of retries
+			if !isResponseCodeRetryable(statusCode) {
+				err = &retry.NonRetryableError{Err: err}
+			}
+
+			return err
+		}
+		return nil
+	}
+
+	err := c.retry.Do(ctx, f, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
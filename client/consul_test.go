@@ -411,3 +411,76 @@ func TestKVGet(t *testing.T) {
 		})
 	}
 }
+
+func TestCatalogService(t *testing.T) {
+	t.Parallel()
+
+	var nonRetryableError *retry.NonRetryableError
+	var missingConsulACLError *MissingConsulACLError
+	cases := []struct {
+		name                string
+		responseCode        int
+		responseBody        string
+		expectErr           bool
+		isNonRetryableError bool
+		isMissingAClError   bool
+	}{
+		{
+			name:         "success",
+			responseCode: http.StatusOK,
+			responseBody: `[
+  {
+    "ServiceID": "api-1",
+    "ServiceName": "api",
+    "ServiceMeta": {
+      "cts-module": "org/module"
+    }
+  }
+]`,
+		},
+		{
+			name:                "non_retryable_error",
+			responseCode:        http.StatusBadRequest,
+			expectErr:           true,
+			isNonRetryableError: true,
+		},
+		{
+			name:         "retryable_error",
+			responseCode: http.StatusInternalServerError,
+			expectErr:    true,
+		},
+		{
+			name:                "acl_error",
+			responseCode:        http.StatusForbidden,
+			expectErr:           true,
+			isNonRetryableError: true,
+			isMissingAClError:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			intercepts := []*testutils.HttpIntercept{
+				{
+					Path:               "/v1/catalog/service/api",
+					ResponseStatusCode: tc.responseCode,
+					ResponseData:       []byte(tc.responseBody),
+				},
+			}
+			c := newTestConsulClient(t, testutils.NewHttpClient(t, intercepts), 1)
+
+			instances, meta, err := c.CatalogService(context.Background(), "api", nil)
+			if !tc.expectErr {
+				require.NoError(t, err)
+				assert.NotNil(t, meta)
+				require.Len(t, instances, 1)
+				assert.Equal(t, "org/module", instances[0].ServiceMeta["cts-module"])
+			} else {
+				assert.Error(t, err)
+				// Verify the error types
+				assert.Equal(t, tc.isNonRetryableError, errors.As(err, &nonRetryableError))
+				assert.Equal(t, tc.isMissingAClError, errors.As(err, &missingConsulACLError))
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"io"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/terraform-json"
 )
 
 var _ Client = (*Printer)(nil)
@@ -64,6 +65,11 @@ func (p *Printer) SetStdout(io.Writer) {
 	p.logger.Info("setting standard out for workspace")
 }
 
+// SetStderr logs out 'set standard error'
+func (p *Printer) SetStderr(io.Writer) {
+	p.logger.Info("setting standard error for workspace")
+}
+
 // Init logs out 'init'
 func (p *Printer) Init(context.Context) error {
 	p.logger.Info("initing workspace")
@@ -82,6 +88,66 @@ func (p *Printer) Plan(context.Context) (bool, error) {
 	return true, nil
 }
 
+// SavePlan logs out 'plan' with the plan file
+func (p *Printer) SavePlan(_ context.Context, planFile string) (bool, error) {
+	p.logger.Info("planning workspace", "plan_file", planFile)
+	return true, nil
+}
+
+// ShowPlan logs out 'show' with the plan file and returns an empty plan
+func (p *Printer) ShowPlan(_ context.Context, planFile string) (*tfjson.Plan, error) {
+	p.logger.Info("showing plan for workspace", "plan_file", planFile)
+	return &tfjson.Plan{}, nil
+}
+
+// ShowState logs out 'show' and returns an empty state
+func (p *Printer) ShowState(context.Context) (*tfjson.State, error) {
+	p.logger.Info("showing state for workspace")
+	return &tfjson.State{}, nil
+}
+
+// ApplyPlan logs out 'apply' with the plan file
+func (p *Printer) ApplyPlan(_ context.Context, planFile string) error {
+	p.logger.Info("applying workspace", "plan_file", planFile)
+	return nil
+}
+
+// Destroy logs out 'destroy'
+func (p *Printer) Destroy(context.Context) error {
+	p.logger.Info("destroying workspace")
+	return nil
+}
+
+// PlanDestroy logs out 'plan' for destroying
+func (p *Printer) PlanDestroy(context.Context) (bool, error) {
+	p.logger.Info("planning destroy for workspace")
+	return true, nil
+}
+
+// DeleteWorkspace logs out 'delete workspace'
+func (p *Printer) DeleteWorkspace(context.Context) error {
+	p.logger.Info("deleting workspace")
+	return nil
+}
+
+// Reconfigure logs out 'init' with reconfiguring the backend
+func (p *Printer) Reconfigure(context.Context) error {
+	p.logger.Info("initializing workspace with a reconfigured backend")
+	return nil
+}
+
+// PullState logs out 'state pull'
+func (p *Printer) PullState(context.Context) (string, error) {
+	p.logger.Info("pulling state for workspace")
+	return "", nil
+}
+
+// PushState logs out 'state push'
+func (p *Printer) PushState(_ context.Context, stateFile string) error {
+	p.logger.Info("pushing state for workspace", "state_file", stateFile)
+	return nil
+}
+
 // Validate logs out 'validate'
 func (p *Printer) Validate(context.Context) error {
 	p.logger.Info("validating workspace")
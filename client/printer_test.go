@@ -207,6 +207,48 @@ func TestPrinterPlan(t *testing.T) {
 	assert.Contains(t, buf.String(), "plan")
 }
 
+func TestPrinterPlanFile(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p, err := DefaultTestPrinter(&buf)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	diff, err := p.SavePlan(ctx, "tfplan")
+	assert.True(t, diff)
+	assert.NoError(t, err)
+
+	plan, err := p.ShowPlan(ctx, "tfplan")
+	assert.NotNil(t, plan)
+	assert.NoError(t, err)
+
+	err = p.ApplyPlan(ctx, "tfplan")
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "plan_file=tfplan")
+}
+
+func TestPrinterDestroy(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	p, err := DefaultTestPrinter(&buf)
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+	diff, err := p.PlanDestroy(ctx)
+	assert.True(t, diff)
+	assert.NoError(t, err)
+
+	err = p.Destroy(ctx)
+	assert.NoError(t, err)
+
+	err = p.DeleteWorkspace(ctx)
+	assert.NoError(t, err)
+	assert.Contains(t, buf.String(), "destroying workspace")
+	assert.Contains(t, buf.String(), "deleting workspace")
+}
+
 func TestPrinterValidate(t *testing.T) {
 	t.Parallel()
 
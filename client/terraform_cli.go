@@ -5,13 +5,18 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"log"
+	"os"
 	"path/filepath"
 	"regexp"
 	"strings"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/hashicorp/terraform-exec/tfexec"
+	"github.com/hashicorp/terraform-json"
+	"github.com/zclconf/go-cty/cty"
 )
 
 var (
@@ -23,6 +28,16 @@ var (
 
 const (
 	tcliSubsystemName = "terraformcli"
+
+	// CLIConfigFilename is the name of the Terraform CLI configuration file
+	// written to the working directory when provider installation is
+	// configured
+	CLIConfigFilename = "cts.tfrc"
+
+	cliConfigFileEnvVar  = "TF_CLI_CONFIG_FILE"
+	pluginCacheDirEnvVar = "TF_PLUGIN_CACHE_DIR"
+	cliConfigFilePerms   = os.FileMode(0640)
+	pluginCacheDirPerms  = os.FileMode(0750)
 )
 
 // TerraformCLI is the client that wraps around terraform-exec
@@ -32,6 +47,12 @@ type TerraformCLI struct {
 	workingDir string
 	workspace  string
 	logger     logging.Logger
+
+	// cliEnv is the environment that configures the Terraform CLI for all
+	// commands, and cliConfig is the content of the CLI configuration file
+	cliEnv         map[string]string
+	cliConfig      []byte
+	pluginCacheDir string
 }
 
 // TerraformCLIConfig configures the Terraform client
@@ -39,8 +60,48 @@ type TerraformCLIConfig struct {
 	Log        bool
 	PersistLog bool
 	ExecPath   string
+	// Binary is the name of the Terraform CLI binary in ExecPath, such as
+	// tofu for OpenTofu. Defaults to terraform.
+	Binary     string
 	WorkingDir string
 	Workspace  string
+
+	// PluginCacheDir is the directory that providers are cached in and
+	// shared by all workspaces
+	PluginCacheDir string
+	// ProviderInstallation configures the mirrors providers are installed
+	// from. Terraform's default installation is used when nil.
+	ProviderInstallation *ProviderInstallation
+}
+
+// ProviderInstallation configures the installation methods of providers
+type ProviderInstallation struct {
+	// FilesystemMirror is a local directory of providers
+	FilesystemMirror string
+	// NetworkMirror is the URL of a provider network mirror
+	NetworkMirror string
+	// Direct installs providers from their origin registries in addition to
+	// the mirrors
+	Direct bool
+}
+
+// cliConfig returns the content of a Terraform CLI configuration file with a
+// provider_installation block for the installation methods
+func (p *ProviderInstallation) cliConfig() []byte {
+	f := hclwrite.NewEmptyFile()
+	body := f.Body().AppendNewBlock("provider_installation", nil).Body()
+	if p.FilesystemMirror != "" {
+		body.AppendNewBlock("filesystem_mirror", nil).Body().
+			SetAttributeValue("path", cty.StringVal(p.FilesystemMirror))
+	}
+	if p.NetworkMirror != "" {
+		body.AppendNewBlock("network_mirror", nil).Body().
+			SetAttributeValue("url", cty.StringVal(p.NetworkMirror))
+	}
+	if p.Direct {
+		body.AppendNewBlock("direct", nil)
+	}
+	return f.Bytes()
 }
 
 // NewTerraformCLI creates a terraform-exec client and configures and
@@ -50,7 +111,11 @@ func NewTerraformCLI(config *TerraformCLIConfig) (*TerraformCLI, error) {
 		return nil, errors.New("TerraformCLIConfig cannot be nil - no meaningful default values")
 	}
 
-	tfPath := filepath.Join(config.ExecPath, "terraform")
+	binary := config.Binary
+	if binary == "" {
+		binary = "terraform"
+	}
+	tfPath := filepath.Join(config.ExecPath, binary)
 	tf, err := tfexec.NewTerraform(config.WorkingDir, tfPath)
 	if err != nil {
 		return nil, err
@@ -83,18 +148,53 @@ func NewTerraformCLI(config *TerraformCLIConfig) (*TerraformCLI, error) {
 	}
 
 	client := &TerraformCLI{
-		tf:         tf,
-		workingDir: config.WorkingDir,
-		workspace:  config.Workspace,
-		logger:     logger,
+		tf:             tf,
+		workingDir:     config.WorkingDir,
+		workspace:      config.Workspace,
+		logger:         logger,
+		cliEnv:         make(map[string]string),
+		pluginCacheDir: config.PluginCacheDir,
+	}
+
+	// Settings for air-gapped hosts are passed to Terraform with environment
+	// variables so that they apply to every command.
+	if config.PluginCacheDir != "" {
+		client.cliEnv[pluginCacheDirEnvVar] = config.PluginCacheDir
+	}
+	if config.ProviderInstallation != nil {
+		client.cliConfig = config.ProviderInstallation.cliConfig()
+		client.cliEnv[cliConfigFileEnvVar] = filepath.Join(config.WorkingDir, CLIConfigFilename)
+	}
+	if len(client.cliEnv) > 0 {
+		// Setting the environment disables inheriting the os environment
+		env := make(map[string]string)
+		for _, kv := range os.Environ() {
+			if i := strings.Index(kv, "="); i > 0 {
+				env[kv[:i]] = kv[i+1:]
+			}
+		}
+		if err := client.SetEnv(env); err != nil {
+			return nil, err
+		}
 	}
 	logger.Trace("created Terraform CLI client", "client", client.GoString())
 
 	return client, nil
 }
 
-// SetEnv sets the environment for the Terraform workspace
+// SetEnv sets the environment for the Terraform workspace. The environment
+// that configures the CLI for the client takes precedence.
 func (t *TerraformCLI) SetEnv(env map[string]string) error {
+	if len(t.cliEnv) > 0 {
+		merged := make(map[string]string, len(env)+len(t.cliEnv))
+		for k, v := range env {
+			merged[k] = v
+		}
+		for k, v := range t.cliEnv {
+			merged[k] = v
+		}
+		env = merged
+	}
 	return t.tf.SetEnv(env)
 }
 
@@ -103,17 +203,39 @@ func (t *TerraformCLI) SetStdout(w io.Writer) {
 	t.tf.SetStdout(w)
 }
 
+// SetStderr sets the standard error for Terraform
+func (t *TerraformCLI) SetStderr(w io.Writer) {
+	t.tf.SetStderr(w)
+}
+
 // Init initializes by executing the cli command `terraform init` and
-// `terraform workspace new <name>`
+// `terraform workspace new <name>`. The plugin cache directory and the CLI
+// configuration file for provider installation are prepared beforehand.
 func (t *TerraformCLI) Init(ctx context.Context) error {
+	return t.init(ctx)
+}
+
+// Reconfigure initializes by executing the cli command `terraform init
+// -reconfigure`, which disregards the backend that was previously initialized
+// without migrating its state, and selects the workspace like Init
+func (t *TerraformCLI) Reconfigure(ctx context.Context) error {
+	return t.init(ctx, tfexec.Reconfigure(true))
+}
+
+func (t *TerraformCLI) init(ctx context.Context, opts ...tfexec.InitOption) error {
 	var wsCreated bool
 
+	if err := t.prepareCLIConfig(); err != nil {
+		t.logger.Error("unable to prepare the Terraform CLI configuration", "error", err)
+		return err
+	}
+
 	// This is special handling for when the workspace has been detected in
 	// .terraform/environment with a non-existing state. This case is common
 	// when the state for the workspace has been deleted.
 	// https://github.com/hashicorp/terraform/issues/21393
 TF_INIT_AGAIN:
-	if err := t.tf.Init(ctx); err != nil {
+	if err := t.tf.Init(ctx, opts...); err != nil {
 		var wsErr *tfexec.ErrNoWorkspace
 		matchedFailedToSelect := wsFailedToSelectRegexp.MatchString(err.Error())
 		matchedDoesNotExist := wsDoesNotExistRegexp.MatchString(err.Error())
@@ -155,6 +277,25 @@ TF_INIT_AGAIN:
 	return nil
 }
 
+// prepareCLIConfig creates the plugin cache directory, which Terraform requires
+// to exist, and writes the CLI configuration file to the working directory
+func (t *TerraformCLI) prepareCLIConfig() error {
+	if t.pluginCacheDir != "" {
+		if err := os.MkdirAll(t.pluginCacheDir, pluginCacheDirPerms); err != nil {
+			return err
+		}
+	}
+
+	if t.cliConfig != nil {
+		path := filepath.Join(t.workingDir, CLIConfigFilename)
+		if err := ioutil.WriteFile(path, t.cliConfig, cliConfigFilePerms); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // Apply executes the cli command `terraform apply` for a given workspace
 func (t *TerraformCLI) Apply(ctx context.Context) error {
 	return t.tf.Apply(ctx)
@@ -165,6 +306,63 @@ func (t *TerraformCLI) Plan(ctx context.Context) (bool, error) {
 	return t.tf.Plan(ctx)
 }
 
+// SavePlan executes the cli command `terraform plan` for a given workspace and
+// saves the plan to the file
+func (t *TerraformCLI) SavePlan(ctx context.Context, planFile string) (bool, error) {
+	return t.tf.Plan(ctx, tfexec.Out(planFile))
+}
+
+// ShowPlan executes the cli command `terraform show` for a saved plan file and
+// returns the JSON representation of the plan
+func (t *TerraformCLI) ShowPlan(ctx context.Context, planFile string) (*tfjson.Plan, error) {
+	return t.tf.ShowPlanFile(ctx, planFile)
+}
+
+// ShowState executes the cli command `terraform show` for a given workspace
+// and returns the JSON representation of its state
+func (t *TerraformCLI) ShowState(ctx context.Context) (*tfjson.State, error) {
+	return t.tf.Show(ctx)
+}
+
+// ApplyPlan executes the cli command `terraform apply` for a saved plan file
+func (t *TerraformCLI) ApplyPlan(ctx context.Context, planFile string) error {
+	return t.tf.Apply(ctx, tfexec.DirOrPlan(planFile))
+}
+
+// Destroy executes the cli command `terraform destroy` for a given workspace
+func (t *TerraformCLI) Destroy(ctx context.Context) error {
+	return t.tf.Destroy(ctx)
+}
+
+// PlanDestroy executes the cli command `terraform plan -destroy` for a given
+// workspace
+func (t *TerraformCLI) PlanDestroy(ctx context.Context) (bool, error) {
+	return t.tf.Plan(ctx, tfexec.Destroy(true))
+}
+
+// DeleteWorkspace executes the cli commands `terraform workspace select
+// default` and `terraform workspace delete <name>` to delete the workspace
+// and its state from the backend. The current workspace cannot be deleted.
+func (t *TerraformCLI) DeleteWorkspace(ctx context.Context) error {
+	if err := t.tf.WorkspaceSelect(ctx, "default"); err != nil {
+		return err
+	}
+	return t.tf.WorkspaceDelete(ctx, t.workspace)
+}
+
+// PullState executes the cli command `terraform state pull` for a given
+// workspace and returns the state
+func (t *TerraformCLI) PullState(ctx context.Context) (string, error) {
+	return t.tf.StatePull(ctx)
+}
+
+// PushState executes the cli command `terraform state push -force` for a given
+// workspace. The state of the workspace is overwritten with the state file
+// regardless of its lineage and serial.
+func (t *TerraformCLI) PushState(ctx context.Context, stateFile string) error {
+	return t.tf.StatePush(ctx, stateFile, tfexec.Force(true))
+}
+
 // Validate verifies the generated configuration files
 func (t *TerraformCLI) Validate(ctx context.Context) error {
 	output, err := t.tf.Validate(ctx)
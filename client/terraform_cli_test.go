@@ -4,6 +4,9 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -157,6 +160,67 @@ func TestTerraformCLIInit(t *testing.T) {
 	}
 }
 
+func TestTerraformCLIInit_CLIConfig(t *testing.T) {
+	t.Parallel()
+
+	wd := t.TempDir()
+	cacheDir := filepath.Join(t.TempDir(), "plugin-cache")
+	client, err := NewTerraformCLI(&TerraformCLIConfig{
+		WorkingDir:     wd,
+		Workspace:      "test-workspace",
+		PluginCacheDir: cacheDir,
+		ProviderInstallation: &ProviderInstallation{
+			FilesystemMirror: "/opt/providers",
+			NetworkMirror:    "https://mirror.example.com/providers/",
+		},
+	})
+	require.NoError(t, err)
+
+	m := new(mocks.TerraformExec)
+	m.On("Init", mock.Anything).Return(nil).Once()
+	m.On("WorkspaceNew", mock.Anything, mock.Anything).Return(nil)
+	m.On("WorkspaceSelect", mock.Anything, mock.Anything).Return(nil)
+	client.tf = m
+
+	require.NoError(t, client.Init(context.Background()))
+	m.AssertExpectations(t)
+
+	info, err := os.Stat(cacheDir)
+	require.NoError(t, err)
+	assert.True(t, info.IsDir())
+
+	content, err := ioutil.ReadFile(filepath.Join(wd, CLIConfigFilename))
+	require.NoError(t, err)
+	assert.Equal(t, `provider_installation {
+  filesystem_mirror {
+    path = "/opt/providers"
+  }
+  network_mirror {
+    url = "https://mirror.example.com/providers/"
+  }
+}
+`, string(content))
+}
+
+func TestTerraformCLISetEnv(t *testing.T) {
+	t.Parallel()
+
+	m := new(mocks.TerraformExec)
+	m.On("SetEnv", map[string]string{
+		"FOO":                 "bar",
+		"TF_PLUGIN_CACHE_DIR": "/opt/plugin-cache",
+	}).Return(nil).Once()
+
+	client := NewTestTerraformCLI(nil, m)
+	client.cliEnv = map[string]string{"TF_PLUGIN_CACHE_DIR": "/opt/plugin-cache"}
+	err := client.SetEnv(map[string]string{
+		"FOO":                 "bar",
+		"TF_PLUGIN_CACHE_DIR": "/tmp/other",
+	})
+	require.NoError(t, err)
+	m.AssertExpectations(t)
+}
+
 func TestTerraformCLIInit_HandleWorkspaceError(t *testing.T) {
 
 	t.Parallel()
@@ -275,6 +339,98 @@ func TestTerraformCLIPlan(t *testing.T) {
 	}
 }
 
+func TestTerraformCLIPlanFile(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	planFile := "test/working/dir/tfplan"
+
+	m := new(mocks.TerraformExec)
+	m.On("Plan", ctx, mock.Anything).Return(true, nil).Once()
+	m.On("ShowPlanFile", ctx, planFile).Return(&tfjson.Plan{}, nil).Once()
+	m.On("Apply", ctx, mock.Anything).Return(nil).Once()
+	client := NewTestTerraformCLI(nil, m)
+
+	changes, err := client.SavePlan(ctx, planFile)
+	assert.NoError(t, err)
+	assert.True(t, changes)
+
+	plan, err := client.ShowPlan(ctx, planFile)
+	assert.NoError(t, err)
+	assert.NotNil(t, plan)
+
+	err = client.ApplyPlan(ctx, planFile)
+	assert.NoError(t, err)
+
+	m.AssertExpectations(t)
+}
+
+func TestTerraformCLIDestroy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	m := new(mocks.TerraformExec)
+	m.On("Plan", ctx, mock.Anything).Return(true, nil).Once()
+	m.On("Destroy", ctx).Return(nil).Once()
+	m.On("WorkspaceSelect", ctx, "default").Return(nil).Once()
+	m.On("WorkspaceDelete", ctx, "test-workspace").Return(nil).Once()
+	client := NewTestTerraformCLI(nil, m)
+
+	changes, err := client.PlanDestroy(ctx)
+	assert.NoError(t, err)
+	assert.True(t, changes)
+
+	err = client.Destroy(ctx)
+	assert.NoError(t, err)
+
+	err = client.DeleteWorkspace(ctx)
+	assert.NoError(t, err)
+
+	m.AssertExpectations(t)
+
+	t.Run("select error", func(t *testing.T) {
+		m := new(mocks.TerraformExec)
+		m.On("WorkspaceSelect", ctx, "default").Return(errors.New("error")).Once()
+		client := NewTestTerraformCLI(nil, m)
+
+		err := client.DeleteWorkspace(ctx)
+		assert.Error(t, err)
+		m.AssertNotCalled(t, "WorkspaceDelete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTerraformCLIState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	m := new(mocks.TerraformExec)
+	m.On("Init", ctx, tfexec.Reconfigure(true)).Return(nil).Once()
+	m.On("WorkspaceNew", ctx, "test-workspace").Return(nil).Once()
+	m.On("WorkspaceSelect", ctx, "test-workspace").Return(nil).Once()
+	m.On("StatePull", ctx).Return(`{"version": 4}`, nil).Once()
+	m.On("StatePush", ctx, "state.tfstate", tfexec.Force(true)).Return(nil).Once()
+	m.On("Show", ctx).Return(&tfjson.State{FormatVersion: "1.0"}, nil).Once()
+	client := NewTestTerraformCLI(nil, m)
+
+	err := client.Reconfigure(ctx)
+	assert.NoError(t, err)
+
+	state, err := client.PullState(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version": 4}`, state)
+
+	err = client.PushState(ctx, "state.tfstate")
+	assert.NoError(t, err)
+
+	shown, err := client.ShowState(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.0", shown.FormatVersion)
+
+	m.AssertExpectations(t)
+}
+
 func TestTerraformCLIValidate(t *testing.T) {
 	t.Parallel()
 
@@ -17,10 +17,17 @@ var _ terraformExec = (*tfexec.Terraform)(nil)
 type terraformExec interface {
 	SetEnv(env map[string]string) error
 	SetStdout(w io.Writer)
+	SetStderr(w io.Writer)
 	Init(ctx context.Context, opts ...tfexec.InitOption) error
 	Apply(ctx context.Context, opts ...tfexec.ApplyOption) error
 	Plan(ctx context.Context, opts ...tfexec.PlanOption) (bool, error)
+	Destroy(ctx context.Context, opts ...tfexec.DestroyOption) error
+	Show(ctx context.Context, opts ...tfexec.ShowOption) (*tfjson.State, error)
+	ShowPlanFile(ctx context.Context, planPath string, opts ...tfexec.ShowOption) (*tfjson.Plan, error)
 	WorkspaceNew(ctx context.Context, workspace string, opts ...tfexec.WorkspaceNewCmdOption) error
 	WorkspaceSelect(ctx context.Context, workspace string) error
+	WorkspaceDelete(ctx context.Context, workspace string, opts ...tfexec.WorkspaceDeleteCmdOption) error
+	StatePull(ctx context.Context, opts ...tfexec.StatePullOption) (string, error)
+	StatePush(ctx context.Context, path string, opts ...tfexec.StatePushCmdOption) error
 	Validate(ctx context.Context) (*tfjson.ValidateOutput, error)
 }
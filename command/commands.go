@@ -45,9 +45,39 @@ func Commands(writer io.Writer, errorWriter io.Writer) map[string]cli.CommandFac
 		cmdTaskDeleteName: func() (cli.Command, error) {
 			return newTaskDeleteCommand(m), nil
 		},
+		cmdTaskCancelName: func() (cli.Command, error) {
+			return newTaskCancelCommand(m), nil
+		},
+		cmdTaskLogsName: func() (cli.Command, error) {
+			return newTaskLogsCommand(m), nil
+		},
+		cmdTaskListName: func() (cli.Command, error) {
+			return newTaskListCommand(m), nil
+		},
+		cmdTaskGetName: func() (cli.Command, error) {
+			return newTaskGetCommand(m), nil
+		},
+		cmdTaskExportName: func() (cli.Command, error) {
+			return newTaskExportCommand(m), nil
+		},
+		cmdTaskStateName: func() (cli.Command, error) {
+			return newTaskStateCommand(m), nil
+		},
+		cmdTaskMigrateStateName: func() (cli.Command, error) {
+			return newTaskMigrateStateCommand(m), nil
+		},
+		cmdTaskStatusName: func() (cli.Command, error) {
+			return newTaskStatusCommand(m), nil
+		},
+		cmdStatusName: func() (cli.Command, error) {
+			return newStatusCommand(m), nil
+		},
 		cmdTaskCreateName: func() (cli.Command, error) {
 			return newTaskCreateCommand(m), nil
 		},
+		cmdValidateName: func() (cli.Command, error) {
+			return newValidateCommand(m), nil
+		},
 		cmdStartName: func() (cli.Command, error) {
 			return newStartCommand(m), nil
 		},
@@ -1,11 +1,17 @@
 package command
 
 import (
+	"bytes"
+	"encoding/json"
 	"io/ioutil"
+	"net/http"
 	"testing"
 
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
 	"github.com/mitchellh/cli"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_Commands(t *testing.T) {
@@ -13,11 +19,21 @@ func Test_Commands(t *testing.T) {
 
 	// map of commands to synopsis
 	expectedCommands := map[string]cli.Command{
-		cmdTaskCreateName:  &taskCreateCommand{},
-		cmdTaskEnableName:  &taskEnableCommand{},
-		cmdTaskDisableName: &taskDisableCommand{},
-		cmdTaskDeleteName:  &taskDeleteCommand{},
-		cmdStartName:       &startCommand{},
+		cmdTaskCreateName:       &taskCreateCommand{},
+		cmdTaskEnableName:       &taskEnableCommand{},
+		cmdTaskDisableName:      &taskDisableCommand{},
+		cmdTaskDeleteName:       &taskDeleteCommand{},
+		cmdTaskCancelName:       &taskCancelCommand{},
+		cmdTaskLogsName:         &taskLogsCommand{},
+		cmdTaskListName:         &taskListCommand{},
+		cmdTaskGetName:          &taskGetCommand{},
+		cmdTaskExportName:       &taskExportCommand{},
+		cmdTaskStateName:        &taskStateCommand{},
+		cmdTaskMigrateStateName: &taskMigrateStateCommand{},
+		cmdTaskStatusName:       &taskStatusCommand{},
+		cmdStatusName:           &statusCommand{},
+		cmdValidateName:         &validateCommand{},
+		cmdStartName:            &startCommand{},
 	}
 
 	assert.Equal(t, len(expectedCommands), len(cf))
@@ -33,3 +49,28 @@ func Test_Commands(t *testing.T) {
 		assert.IsType(t, v, c)
 	}
 }
+
+// newTestMeta returns the meta for a command that writes to a mock UI and
+// makes API requests with the mock HTTP client
+func newTestMeta(h *mocks.HttpClient) (meta, *cli.MockUi) {
+	ui := cli.NewMockUi()
+	return meta{UI: ui, writer: ioutil.Discard, httpClient: h}, ui
+}
+
+// requestPath matches an API request by its URL path
+func requestPath(path string) interface{} {
+	return mock.MatchedBy(func(req *http.Request) bool {
+		return req.URL.Path == path
+	})
+}
+
+// jsonResponse returns an API response with the body encoded as JSON
+func jsonResponse(t *testing.T, code int, body interface{}) *http.Response {
+	b, err := json.Marshal(body)
+	require.NoError(t, err)
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       ioutil.NopCloser(bytes.NewReader(b)),
+	}
+}
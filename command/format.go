@@ -0,0 +1,173 @@
+package command
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/zclconf/go-cty/cty"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+// Output formats supported by the commands that read information from CTS
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatHCL   = "hcl"
+)
+
+var outputFormats = []string{formatTable, formatJSON, formatHCL}
+
+// hclBlocks are the nested objects of a value that are written as blocks
+// when formatted as HCL. All other nested objects are written as attributes.
+type hclBlocks struct {
+	// labeled are objects whose entries are each written as a block with
+	// the entry's key as the label, e.g. condition "services" { ... }
+	labeled map[string]bool
+
+	// unlabeled are objects written as a block without a label
+	unlabeled map[string]bool
+}
+
+// taskHCLBlocks are the blocks of a task as it is configured
+var taskHCLBlocks = hclBlocks{
+	labeled: map[string]bool{
+		"condition":    true,
+		"module_input": true,
+	},
+	unlabeled: map[string]bool{
+		"buffer_period":             true,
+		"terraform_cloud_workspace": true,
+	},
+}
+
+// formatFlag adds the flag for the output format to the flag set
+func formatFlag(flags *flag.FlagSet, defaultFormat string) *string {
+	return flags.String(FlagFormat, defaultFormat, fmt.Sprintf("The format "+
+		"of the output. Supported formats are: %s",
+		strings.Join(outputFormats, ", ")))
+}
+
+// validateFormat returns an error if the output format is not supported
+func validateFormat(format string) error {
+	for _, f := range outputFormats {
+		if format == f {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported format '%s'. Supported formats are: %s",
+		format, strings.Join(outputFormats, ", "))
+}
+
+// toJSON returns the value as indented JSON
+func toJSON(v interface{}) (string, error) {
+	b, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// toHCL returns the value as an HCL block of the block type and labels. The
+// value is converted using its JSON representation, and null values are
+// omitted.
+func toHCL(v interface{}, blocks hclBlocks, blockType string, labels ...string) (string, error) {
+	val, err := toCty(v)
+	if err != nil {
+		return "", err
+	}
+
+	f := hclwrite.NewEmptyFile()
+	block := f.Body().AppendNewBlock(blockType, labels)
+	if err = writeHCLBody(block.Body(), val, blocks); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(f.Bytes())), nil
+}
+
+// toCty converts the value to a cty value using its JSON representation
+func toCty(v interface{}) (cty.Value, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	t, err := ctyjson.ImpliedType(b)
+	if err != nil {
+		return cty.NilVal, err
+	}
+	return ctyjson.Unmarshal(b, t)
+}
+
+// writeHCLBody writes the attributes of the object value to the body
+// followed by its blocks
+func writeHCLBody(body *hclwrite.Body, val cty.Value, blocks hclBlocks) error {
+	if !val.Type().IsObjectType() {
+		return fmt.Errorf("unable to format %s as an HCL block",
+			val.Type().FriendlyName())
+	}
+
+	attrs := val.AsValueMap()
+	var blockKeys []string
+	for _, k := range sortedKeys(attrs) {
+		attr := attrs[k]
+		if attr.IsNull() {
+			continue
+		}
+		if attr.Type().IsObjectType() && (blocks.labeled[k] || blocks.unlabeled[k]) {
+			blockKeys = append(blockKeys, k)
+			continue
+		}
+		body.SetAttributeValue(k, attr)
+	}
+
+	for _, k := range blockKeys {
+		if blocks.unlabeled[k] {
+			block := body.AppendNewBlock(k, nil)
+			if err := writeHCLBody(block.Body(), attrs[k], hclBlocks{}); err != nil {
+				return err
+			}
+			continue
+		}
+
+		nested := attrs[k].AsValueMap()
+		for _, label := range sortedKeys(nested) {
+			if nested[label].IsNull() {
+				continue
+			}
+			// labels are configured with hyphens, e.g. "consul-kv"
+			hclLabel := strings.ReplaceAll(label, "_", "-")
+			block := body.AppendNewBlock(k, []string{hclLabel})
+			if err := writeHCLBody(block.Body(), nested[label], hclBlocks{}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]cty.Value) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toTable returns the rows as a table with the header as the first row.
+// Columns are aligned and separated by spaces.
+func toTable(header []string, rows [][]string) string {
+	var b bytes.Buffer
+	tw := tabwriter.NewWriter(&b, 0, 2, 3, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+	for _, row := range rows {
+		fmt.Fprintln(tw, strings.Join(row, "\t"))
+	}
+	tw.Flush()
+	return strings.TrimRight(b.String(), "\n")
+}
@@ -0,0 +1,130 @@
+package command
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTask() oapigen.Task {
+	enabled := true
+	providers := []string{"local"}
+	names := []string{"api", "web"}
+	return oapigen.Task{
+		Name:      "my_task",
+		Enabled:   &enabled,
+		Module:    "path/to/module",
+		Providers: &providers,
+		Condition: oapigen.Condition{
+			CatalogServices: &oapigen.CatalogServicesCondition{Regexp: "^web.*"},
+		},
+		ModuleInput: &oapigen.ModuleInput{
+			Services: &oapigen.ServicesModuleInput{Names: &names},
+		},
+		BufferPeriod: &oapigen.BufferPeriod{Enabled: &enabled},
+	}
+}
+
+func TestValidateFormat(t *testing.T) {
+	for _, f := range outputFormats {
+		assert.NoError(t, validateFormat(f))
+	}
+	assert.Error(t, validateFormat("yaml"))
+}
+
+func TestFormatTasks(t *testing.T) {
+	tasks := []oapigen.Task{testTask()}
+
+	t.Run("table", func(t *testing.T) {
+		out, err := formatTasks(formatTable, tasks)
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"NAME      ENABLED   CONDITION          MODULE           PROVIDERS\n"+
+			"my_task   true      catalog-services   path/to/module   local", out)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := formatTasks(formatJSON, tasks)
+		require.NoError(t, err)
+		assert.Contains(t, out, `"name": "my_task"`)
+		assert.Contains(t, out, `"regexp": "^web.*"`)
+	})
+
+	t.Run("hcl", func(t *testing.T) {
+		out, err := formatTasks(formatHCL, tasks)
+		require.NoError(t, err)
+		assert.Equal(t, `task {
+  enabled   = true
+  module    = "path/to/module"
+  name      = "my_task"
+  providers = ["local"]
+  buffer_period {
+    enabled = true
+  }
+  condition "catalog-services" {
+    regexp = "^web.*"
+  }
+  module_input "services" {
+    names = ["api", "web"]
+  }
+}`, out)
+	})
+}
+
+func TestFormatTaskStatuses(t *testing.T) {
+	start := time.Date(2022, 6, 1, 16, 2, 7, 0, time.UTC)
+	statuses := map[string]api.TaskStatus{
+		"my_task": {
+			TaskName: "my_task",
+			Status:   api.StatusErrored,
+			Enabled:  true,
+			Events: []event.Event{{
+				ID:         "1",
+				TaskName:   "my_task",
+				StartTime:  start,
+				EndTime:    start.Add(5 * time.Second),
+				EventError: &event.Error{Message: "error applying\nmore details"},
+			}},
+		},
+	}
+
+	t.Run("table", func(t *testing.T) {
+		out, err := formatTaskStatuses(formatTable, statuses)
+		require.NoError(t, err)
+		assert.Equal(t, ""+
+			"NAME      STATUS    ENABLED\n"+
+			"my_task   errored   true\n"+
+			"\n"+
+			"ID   SUCCESS   START TIME             END TIME               ERROR\n"+
+			"1    false     2022-06-01T16:02:07Z   2022-06-01T16:02:12Z   error applying", out)
+	})
+
+	t.Run("hcl", func(t *testing.T) {
+		out, err := formatTaskStatuses(formatHCL, statuses)
+		require.NoError(t, err)
+		assert.Contains(t, out, `task_status "my_task" {`)
+		assert.Contains(t, out, `status     = "errored"`)
+	})
+}
+
+func TestFormatOverallStatus(t *testing.T) {
+	status := api.OverallStatus{TaskSummary: api.TaskSummary{
+		Status:  api.StatusSummary{Successful: 2, Critical: 1},
+		Enabled: api.EnabledSummary{True: 3},
+	}}
+
+	out, err := formatOverallStatus(formatTable, status)
+	require.NoError(t, err)
+	assert.Equal(t, ""+
+		"SUCCESSFUL   ERRORED   CRITICAL   UNKNOWN   ENABLED   DISABLED\n"+
+		"2            0         1          0         3         0", out)
+
+	out, err = formatOverallStatus(formatHCL, status)
+	require.NoError(t, err)
+	assert.Contains(t, out, "status {\n  task_summary {")
+}
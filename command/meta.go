@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net/http"
 	"net/url"
 	"strings"
 
@@ -26,9 +27,19 @@ type meta struct {
 	helpOptions []string
 	port        *int
 	addr        *string
+	token       *string
 
 	tls    tls
 	writer io.Writer
+
+	// httpClient makes the requests of the API clients. The default HTTP
+	// client configured by the flags is used when nil.
+	httpClient httpClient
+}
+
+// httpClient describes the interface for the API clients to make http calls
+type httpClient interface {
+	Do(req *http.Request) (*http.Response, error)
 }
 
 type tls struct {
@@ -50,7 +61,15 @@ const (
 	FlagClientKey  = "client-key"
 	FlagSSLVerify  = "ssl-verify"
 
+	FlagToken = "token"
+
 	FlagAutoApprove = "auto-approve"
+	FlagCancel      = "cancel"
+	FlagDestroy     = "destroy"
+	FlagEventID     = "event-id"
+	FlagOutputDir   = "output-dir"
+
+	FlagFormat = "format"
 )
 
 func (m *meta) defaultFlagSet(name string) *flag.FlagSet {
@@ -66,6 +85,10 @@ func (m *meta) defaultFlagSet(name string) *flag.FlagSet {
 		"\n\t\tvia the %s environment variable. The scheme can also be set to HTTPS "+
 		"\n\t\tby including https in the provided address (eg. https://127.0.0.1:8558)", api.EnvAddress))
 
+	m.token = m.flags.String(FlagToken, "", fmt.Sprintf("The token to authenticate "+
+		"requests to the CTS daemon when API authentication is enabled. This can also "+
+		"\n\t\tbe specified using the %s environment variable.", api.EnvToken))
+
 	// Initialize TLS flags
 	m.tls.caPath = m.flags.String(FlagCAPath, "", fmt.Sprintf("Path to a directory of CA certificates to use for TLS when communicating "+
 		"\n\t\twith Consul-Terraform-Sync. This can also be specified using the "+
@@ -151,6 +174,10 @@ func (m *meta) clientConfig() (*api.ClientConfig, error) {
 		c.URL = *m.addr
 	}
 
+	if m.token != nil && *m.token != "" {
+		c.Token = *m.token
+	}
+
 	// If we need custom TLS configuration, then set it
 	if m.tls.caCert != nil && *m.tls.caCert != "" {
 		c.TLSConfig.CACert = *m.tls.caCert
@@ -177,7 +204,7 @@ func (m *meta) client() (*api.Client, error) {
 		return nil, err
 	}
 
-	c, err := api.NewClient(clientConfig, nil)
+	c, err := api.NewClient(clientConfig, m.httpClient)
 	if err != nil {
 		return nil, err
 	}
@@ -191,7 +218,7 @@ func (m *meta) taskLifecycleClient() (*api.TaskLifecycleClient, error) {
 		return nil, err
 	}
 
-	c, err := api.NewTaskLifecycleClient(clientConfig, nil)
+	c, err := api.NewTaskLifecycleClient(clientConfig, m.httpClient)
 
 	if err != nil {
 		return nil, err
@@ -230,15 +257,42 @@ func (m *meta) requestUserApprovalEnable(taskName string) (int, bool) {
 // requestUserApprovalDelete prints a prompt for user approval of deleting a task
 // and waits for the user input. It returns an exit code and boolean describing
 // if the user approved.
-func (m *meta) requestUserApprovalDelete(taskName string) (int, bool) {
+func (m *meta) requestUserApprovalDelete(taskName string, destroy bool) (int, bool) {
 	m.UI.Info(fmt.Sprintf("Do you want to delete '%s'?", taskName))
 	m.UI.Output(" - This action cannot be undone.")
-	m.UI.Output(" - Deleting a task will not destroy the infrastructure managed by the task.")
+	if destroy {
+		m.UI.Output(" - The infrastructure managed by the task will be destroyed before the")
+		m.UI.Output("   task is deleted. The task is not deleted if destroying fails.")
+	} else {
+		m.UI.Output(" - Deleting a task will not destroy the infrastructure managed by the task.")
+	}
 	m.UI.Output(" - If the task is not running, it will be deleted immediately.")
 	m.UI.Output(" - If the task is running, it will be deleted once it has completed.")
 	return m.requestUserApproval(taskName, "deleting")
 }
 
+// requestUserApprovalCancel prints a prompt for user approval of cancelling a
+// task's running execution and waits for the user input. It returns an exit
+// code and boolean describing if the user approved.
+func (m *meta) requestUserApprovalCancel(taskName string) (int, bool) {
+	m.UI.Info(fmt.Sprintf("Do you want to cancel the running execution of '%s'?", taskName))
+	m.UI.Output(" - This action cannot be undone.")
+	m.UI.Output(" - Terraform will be interrupted and changes may be partially applied.")
+	m.UI.Output(" - The task will run again on its next trigger.")
+	return m.requestUserApproval(taskName, "cancelling")
+}
+
+// requestUserApprovalMigrateState prints a prompt for user approval of
+// migrating the state of a task to a new backend and waits for the user input.
+// It returns an exit code and boolean describing if the user approved.
+func (m *meta) requestUserApprovalMigrateState(taskName, backend string) (int, bool) {
+	m.UI.Info(fmt.Sprintf("Do you want to migrate the state of '%s' to the '%s' backend?",
+		taskName, backend))
+	m.UI.Output(" - The task will use the new backend once the state is migrated.")
+	m.UI.Output(" - The state in the previous backend will not be deleted.")
+	return m.requestUserApproval(taskName, "migrating the state of")
+}
+
 // requestUserApprovalCreate prints a prompt for user approval of deleting a task
 // and waits for the user input. It returns an exit code and boolean describing
 // if the user approved.
@@ -273,6 +327,7 @@ func (m *meta) autoCompleteFlags() complete.Flags {
 	return complete.Flags{
 		fmt.Sprintf("-%s", FlagPort):       complete.PredictAnything,
 		fmt.Sprintf("-%s", FlagHTTPAddr):   complete.PredictAnything,
+		fmt.Sprintf("-%s", FlagToken):      complete.PredictAnything,
 		fmt.Sprintf("-%s", FlagCAPath):     complete.PredictDirs("*"),
 		fmt.Sprintf("-%s", FlagCACert):     complete.PredictFiles("*"),
 		fmt.Sprintf("-%s", FlagClientCert): complete.PredictFiles("*"),
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
 	"time"
 
@@ -233,11 +234,13 @@ func (c *startCommand) Run(args []string) int {
 	}
 
 	if err := logging.Setup(&logging.Config{
-		Level:          config.StringVal(conf.LogLevel),
-		Syslog:         config.BoolVal(conf.Syslog.Enabled),
-		SyslogFacility: config.StringVal(conf.Syslog.Facility),
-		SyslogName:     config.StringVal(conf.Syslog.Name),
-		Writer:         os.Stderr,
+		Level:           config.StringVal(conf.LogLevel),
+		SubsystemLevels: conf.LogLevels,
+		JSON:            config.StringVal(conf.LogFormat) == config.LogFormatJSON,
+		Syslog:          config.BoolVal(conf.Syslog.Enabled),
+		SyslogFacility:  config.StringVal(conf.Syslog.Facility),
+		SyslogName:      config.StringVal(conf.Syslog.Name),
+		Writer:          os.Stderr,
 	}); err != nil {
 		logger.Error("error setting up logging", "error", err)
 		return ExitCodeConfigError
@@ -273,7 +276,12 @@ func (c *startCommand) Run(args []string) int {
 		logger.Debug("once mode enabled, processing then exiting")
 		ctrl, err = controller.NewOnce(conf)
 	default:
-		ctrl, err = controller.NewDaemon(conf)
+		var d *controller.Daemon
+		d, err = controller.NewDaemon(conf)
+		if err == nil {
+			d.SetConfigLoader(c.configLoader())
+			ctrl = d
+		}
 	}
 	if err != nil {
 		logger.Error("error setting up controller", "error", err)
@@ -319,15 +327,43 @@ func (c *startCommand) Run(args []string) int {
 
 	interruptCh := make(chan os.Signal, 1)
 	signal.Notify(interruptCh, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	var reloading int32
 	for {
 		select {
+		case sig := <-reloadCh:
+			r, ok := ctrl.(reloader)
+			if !ok {
+				logger.Info("signal received, configuration reload is only "+
+					"supported in daemon mode", "signal", sig)
+				continue
+			}
+			if !atomic.CompareAndSwapInt32(&reloading, 0, 1) {
+				logger.Info("signal received, configuration is already "+
+					"reloading", "signal", sig)
+				continue
+			}
+			logger.Info("signal received to reload configuration", "signal", sig)
+
+			// Reloading may apply tasks, so it runs in the background for the
+			// shutdown signals to be handled. Shutting down cancels the reload.
+			go func() {
+				defer atomic.StoreInt32(&reloading, 0)
+				if err := r.Reload(ctx); err != nil {
+					logger.Error("error reloading configuration", "error", err)
+				}
+			}()
+
 		case sig := <-interruptCh:
 			// Cancel the context and wait for controller go routine to gracefully
-			// shutdown
+			// shutdown. Allow time for active tasks to drain on top of the time
+			// to stop the controller.
 			logger.Info("signal received to initiate graceful shutdown", "signal", sig)
 			cancel()
 			counter := 0
 			start := time.Now()
+			shutdownTimeout := config.TimeDurationVal(conf.DrainTimeout) + 10*time.Second
 			for {
 				since := time.Since(start)
 				select {
@@ -337,7 +373,7 @@ func (c *startCommand) Run(args []string) int {
 						logger.Info("graceful shutdown")
 						return ExitCodeOK
 					}
-				case <-time.After(10*time.Second - since):
+				case <-time.After(shutdownTimeout - since):
 					logger.Info("graceful shutdown timed out, exiting")
 					return ExitCodeInterrupt
 				}
@@ -356,3 +392,28 @@ func (c *startCommand) Run(args []string) int {
 		}
 	}
 }
+
+// reloader is implemented by controllers that support reloading the
+// configuration
+type reloader interface {
+	Reload(ctx context.Context) error
+}
+
+// configLoader returns the function to load the configuration from the
+// configuration files of the command for reloading
+func (c *startCommand) configLoader() func() (*config.Config, error) {
+	return func() (*config.Config, error) {
+		conf, err := config.BuildConfig(*c.configFiles)
+		if err != nil {
+			return nil, fmt.Errorf("error building configuration: %s", err)
+		}
+		if err := conf.Finalize(); err != nil {
+			return nil, fmt.Errorf("error finalizing configuration: %s", err)
+		}
+		if err := conf.Validate(); err != nil {
+			return nil, fmt.Errorf("error validating configuration: %s", err)
+		}
+		conf.ClientType = config.String(*c.clientType)
+		return conf, nil
+	}
+}
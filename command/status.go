@@ -0,0 +1,157 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdStatusName = "status"
+
+// statusCommand handles the `status` command
+type statusCommand struct {
+	meta
+	format *string
+	flags  *flag.FlagSet
+}
+
+func newStatusCommand(m meta) *statusCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdStatusName)
+	flags.SetOutput(m.writer)
+	f := formatFlag(flags, formatTable)
+	return &statusCommand{
+		meta:   m,
+		format: f,
+		flags:  flags,
+	}
+}
+
+// Name returns the subcommand
+func (c statusCommand) Name() string {
+	return cmdStatusName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *statusCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync status [-help] [options]
+
+  Status is used to get the overall status of Consul-Terraform-Sync, which
+  summarizes the status of all tasks.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync status
+  SUCCESSFUL   ERRORED   CRITICAL   UNKNOWN   ENABLED   DISABLED
+  2            0         1          0         3         0
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *statusCommand) Synopsis() string {
+	return "Gets the overall status of all tasks."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *statusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagFormat): complete.PredictSet(outputFormats...),
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// Since argument completion is not supported, this will return
+// complete.PredictNothing.
+func (c *statusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run runs the command
+func (c *statusCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	if args = c.flags.Args(); len(args) != 0 {
+		c.UI.Error("Error: this command does not accept arguments")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		return ExitCodeRequiredFlagsError
+	}
+
+	if err := validateFormat(*c.format); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: %s", err))
+		return ExitCodeRequiredFlagsError
+	}
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error(errCreatingClient)
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	status, err := client.Status().Overall()
+	if err != nil {
+		c.UI.Error("Error: unable to get overall status")
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	out, err := formatOverallStatus(*c.format, status)
+	if err != nil {
+		c.UI.Error("Error: unable to format overall status")
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+	c.UI.Output(out)
+
+	return ExitCodeOK
+}
+
+// formatOverallStatus returns the overall status in the output format
+func formatOverallStatus(format string, status api.OverallStatus) (string, error) {
+	switch format {
+	case formatJSON:
+		return toJSON(status)
+	case formatHCL:
+		return toHCL(status, hclBlocks{
+			unlabeled: map[string]bool{"task_summary": true},
+		}, "status")
+	default:
+		s := status.TaskSummary
+		row := []string{
+			strconv.Itoa(s.Status.Successful),
+			strconv.Itoa(s.Status.Errored),
+			strconv.Itoa(s.Status.Critical),
+			strconv.Itoa(s.Status.Unknown),
+			strconv.Itoa(s.Enabled.True),
+			strconv.Itoa(s.Enabled.False),
+		}
+		return toTable([]string{"SUCCESSFUL", "ERRORED", "CRITICAL",
+			"UNKNOWN", "ENABLED", "DISABLED"}, [][]string{row}), nil
+	}
+}
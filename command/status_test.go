@@ -0,0 +1,137 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatusCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newStatusCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestStatusCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	overallPath := requestPath("/v1/status")
+	statusResponse := func(t *testing.T) *http.Response {
+		return jsonResponse(t, http.StatusOK, api.OverallStatus{
+			TaskSummary: api.TaskSummary{
+				Status:  api.StatusSummary{Successful: 2, Errored: 1},
+				Enabled: api.EnabledSummary{True: 2, False: 1},
+			},
+		})
+	}
+
+	cases := []struct {
+		name           string
+		args           []string
+		mockHTTP       func(*testing.T, *mocks.HttpClient)
+		exitCode       int
+		output         string
+		outputContains []string
+		errorContains  []string
+	}{
+		{
+			name:     "table",
+			args:     []string{},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", overallPath).Return(statusResponse(t), nil).Once()
+			},
+			output: "" +
+				"SUCCESSFUL   ERRORED   CRITICAL   UNKNOWN   ENABLED   DISABLED\n" +
+				"2            1         0          0         2         1",
+		},
+		{
+			name:     "json",
+			args:     []string{"-format", "json"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", overallPath).Return(statusResponse(t), nil).Once()
+			},
+			outputContains: []string{`"successful": 2`, `"errored": 1`},
+		},
+		{
+			name:     "request error",
+			args:     []string{},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", overallPath).Return(nil, errors.New("connection refused")).Once()
+			},
+			outputContains: []string{"connection refused"},
+			errorContains:  []string{"Error: unable to get overall status"},
+		},
+		{
+			name:          "invalid format",
+			args:          []string{"-format", "yaml"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"yaml"},
+		},
+		{
+			name:          "arguments",
+			args:          []string{"task_a"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command does not accept arguments"},
+		},
+		{
+			name:          "unsupported flag",
+			args:          []string{"-events"},
+			exitCode:      ExitCodeParseFlagsError,
+			errorContains: []string{"unsupported arguments in flags"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := new(mocks.HttpClient)
+			if tc.mockHTTP != nil {
+				tc.mockHTTP(t, h)
+			}
+			m, ui := newTestMeta(h)
+			cmd := newStatusCommand(m)
+
+			exitCode := cmd.Run(tc.args)
+			require.Equal(t, tc.exitCode, exitCode, ui.ErrorWriter.String())
+
+			if tc.output != "" {
+				assert.Equal(t, tc.output, strings.TrimSpace(ui.OutputWriter.String()))
+			}
+			for _, expect := range tc.outputContains {
+				assert.Contains(t, ui.OutputWriter.String(), expect)
+			}
+			for _, expect := range tc.errorContains {
+				assert.Contains(t, ui.ErrorWriter.String(), expect)
+			}
+			h.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,186 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdTaskCancelName = "task cancel"
+
+// taskCancelCommand handles the `task cancel` command
+type taskCancelCommand struct {
+	meta
+	autoApprove *bool
+	flags       *flag.FlagSet
+
+	predictorClient oapigen.ClientWithResponsesInterface
+}
+
+func newTaskCancelCommand(m meta) *taskCancelCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskCancelName)
+	flags.SetOutput(m.writer)
+	a := flags.Bool(FlagAutoApprove, false, "Skip interactive approval of cancelling a task")
+	return &taskCancelCommand{
+		meta:        m,
+		autoApprove: a,
+		flags:       flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskCancelCommand) Name() string {
+	return cmdTaskCancelName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskCancelCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task cancel [-help] [options] <task name>
+
+  Task Cancel is used to cancel the running execution of an existing task.
+  The task is not modified and will run again on its next trigger. If the
+  task is not running, no action is taken.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task cancel my_task
+  ==> Do you want to cancel the running execution of 'my_task'?
+       - This action cannot be undone.
+       - Terraform will be interrupted and changes may be partially applied.
+       - The task will run again on its next trigger.
+      Only 'yes' will be accepted to approve, enter 'no' or leave blank to reject.
+
+  Enter a value: yes
+
+  ==> Cancelling task 'my_task'...
+
+  ==> The running execution of task 'my_task' has been cancelled.
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskCancelCommand) Synopsis() string {
+	return "Cancels the running execution of a task."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskCancelCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagAutoApprove): complete.PredictNothing,
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// This commands uses a client to fetch a list of existing tasks
+// to predict the correct cancel argument
+func (c *taskCancelCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		var client oapigen.ClientWithResponsesInterface
+		var err error
+		if c.predictorClient == nil {
+			client, err = c.meta.taskLifecycleClient()
+			if err != nil {
+				return nil
+			}
+		} else {
+			client = c.predictorClient
+		}
+
+		tasksResp, err := getTasks(context.Background(), client)
+		if err != nil {
+			return nil
+		}
+
+		taskNames := make([]string, 0)
+
+		if tasksResp.Tasks != nil {
+			for _, tasks := range *tasksResp.Tasks {
+				taskNames = append(taskNames, tasks.Name)
+			}
+		}
+		return taskNames
+	})
+}
+
+// Run runs the command
+func (c *taskCancelCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if ok := c.meta.oneArgCheck(c.Name(), args); !ok {
+		return ExitCodeRequiredFlagsError
+	}
+
+	taskName := args[0]
+
+	client, err := c.meta.taskLifecycleClient()
+	if err != nil {
+		c.UI.Error(errCreatingClient)
+		c.UI.Output(fmt.Sprintf("client could not be created for '%s'", taskName))
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if !*c.autoApprove {
+		if exitCode, approved := c.meta.requestUserApprovalCancel(taskName); !approved {
+			return exitCode
+		}
+	}
+
+	c.UI.Info(fmt.Sprintf("Cancelling task '%s'...\n", taskName))
+	resp, err := client.CancelTaskByNameWithResponse(context.Background(), taskName)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to cancel '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if resp.JSON200 == nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to cancel '%s'", taskName))
+		if resp.JSONDefault != nil {
+			msg := wordwrap.WrapString(resp.JSONDefault.Error.Message, uint(78))
+			c.UI.Output(msg)
+		} else {
+			c.UI.Output(fmt.Sprintf("received nil response with status %s", resp.Status()))
+		}
+
+		return ExitCodeError
+	}
+
+	if !resp.JSON200.Cancelled {
+		c.UI.Info(fmt.Sprintf("Task '%s' is not running, no execution "+
+			"was cancelled.", taskName))
+		return ExitCodeOK
+	}
+
+	c.UI.Info(fmt.Sprintf("The running execution of task '%s' has been "+
+		"cancelled.", taskName))
+
+	return ExitCodeOK
+}
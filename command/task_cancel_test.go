@@ -0,0 +1,256 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskCancelCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newTaskCancelCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestTaskCancelCommand_AutocompleteArgs(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		taskNames []string
+	}{
+		{
+			name:      "nominal",
+			taskNames: []string{"first", "second", "third"},
+		},
+		{
+			name:      "no tasks",
+			taskNames: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskCancelCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			tasks := make([]oapigen.Task, len(tc.taskNames))
+			for i, n := range tc.taskNames {
+				tasks[i].Name = n
+			}
+
+			tasksResponse := oapigen.TasksResponse{
+				RequestId: uuid.New(),
+				Tasks:     &tasks,
+			}
+
+			resp := oapigen.GetAllTasksResponse{
+				JSON200: &tasksResponse,
+			}
+
+			// Return the response, and expect each task name to be present in the prediction
+			p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+
+			predictor := cmd.AutocompleteArgs()
+
+			res := predictor.Predict(complete.Args{})
+
+			assert.ElementsMatch(t, tc.taskNames, res, "flags and predictions didn't match, make sure to add "+
+				"new flags to the command AutoCompleteFlags function")
+		})
+	}
+}
+
+func TestTaskCancelCommand_AutocompleteArgs_Errors(t *testing.T) {
+
+	scenarioClientError := "client error"
+	scenarioEmptyTasks := "empty tasks"
+
+	cases := []struct {
+		name     string
+		scenario string
+	}{
+		{
+			name:     "predictor client returns error",
+			scenario: scenarioClientError,
+		},
+		{
+			name:     "empty task response",
+			scenario: scenarioEmptyTasks,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskCancelCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			switch tc.scenario {
+			case scenarioClientError:
+				err := errors.New("some error")
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(nil, err)
+			case scenarioEmptyTasks:
+				resp := oapigen.GetAllTasksResponse{}
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+			}
+
+			predictor := cmd.AutocompleteArgs()
+
+			// Not panicking is a success
+			predictor.Predict(complete.Args{})
+		})
+	}
+}
+
+func TestTaskCancelCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	cancelPath := requestPath("/v1/tasks/task_a/cancel")
+	cancelResponse := func(t *testing.T, cancelled bool) *http.Response {
+		return jsonResponse(t, http.StatusOK, oapigen.TaskCancelResponse{
+			Cancelled: cancelled,
+			RequestId: uuid.New(),
+		})
+	}
+
+	cases := []struct {
+		name           string
+		args           []string
+		input          string
+		mockHTTP       func(*testing.T, *mocks.HttpClient)
+		exitCode       int
+		outputContains []string
+		errorContains  []string
+	}{
+		{
+			name:     "cancelled",
+			args:     []string{"task_a"},
+			input:    "yes\n",
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", cancelPath).Return(cancelResponse(t, true), nil).Once()
+			},
+			outputContains: []string{
+				"Do you want to cancel the running execution of 'task_a'?",
+				"Terraform will be interrupted and changes may be partially applied.",
+				"Cancelling task 'task_a'...",
+				"The running execution of task 'task_a' has been cancelled.",
+			},
+		},
+		{
+			name:     "auto-approve not running",
+			args:     []string{"-auto-approve", "task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", cancelPath).Return(cancelResponse(t, false), nil).Once()
+			},
+			outputContains: []string{
+				"Task 'task_a' is not running, no execution was cancelled.",
+			},
+		},
+		{
+			name:     "rejected",
+			args:     []string{"task_a"},
+			input:    "no\n",
+			exitCode: ExitCodeOK,
+			outputContains: []string{
+				"Cancelled cancelling task 'task_a'",
+			},
+		},
+		{
+			name:     "error response",
+			args:     []string{"-auto-approve", "task_a"},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", cancelPath).Return(jsonResponse(t, http.StatusNotFound,
+					oapigen.ErrorResponse{
+						Error: oapigen.Error{Message: "task with name task_a does not exist"},
+					}), nil).Once()
+			},
+			outputContains: []string{"task with name task_a does not exist"},
+			errorContains:  []string{"Error: unable to cancel 'task_a'"},
+		},
+		{
+			name:     "request error",
+			args:     []string{"-auto-approve", "task_a"},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", cancelPath).Return(nil, errors.New("connection refused")).Once()
+			},
+			outputContains: []string{"connection refused"},
+			errorContains:  []string{"Error: unable to cancel 'task_a'"},
+		},
+		{
+			name:          "no arguments",
+			args:          []string{},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command requires one argument"},
+		},
+		{
+			name:          "flag after argument",
+			args:          []string{"task_a", "-auto-approve"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command requires one argument"},
+		},
+		{
+			name:          "unsupported flag",
+			args:          []string{"-unsupported", "task_a"},
+			exitCode:      ExitCodeParseFlagsError,
+			errorContains: []string{"unsupported arguments in flags"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := new(mocks.HttpClient)
+			if tc.mockHTTP != nil {
+				tc.mockHTTP(t, h)
+			}
+			m, ui := newTestMeta(h)
+			ui.InputReader = strings.NewReader(tc.input)
+			cmd := newTaskCancelCommand(m)
+
+			exitCode := cmd.Run(tc.args)
+			require.Equal(t, tc.exitCode, exitCode, ui.ErrorWriter.String())
+
+			for _, expect := range tc.outputContains {
+				assert.Contains(t, ui.OutputWriter.String(), expect)
+			}
+			for _, expect := range tc.errorContains {
+				assert.Contains(t, ui.ErrorWriter.String(), expect)
+			}
+			h.AssertExpectations(t)
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/hashicorp/consul-terraform-sync/api"
 	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	"github.com/mitchellh/go-wordwrap"
@@ -18,6 +19,8 @@ const cmdTaskDeleteName = "task delete"
 type taskDeleteCommand struct {
 	meta
 	autoApprove *bool
+	cancel      *bool
+	destroy     *bool
 	flags       *flag.FlagSet
 
 	predictorClient oapigen.ClientWithResponsesInterface
@@ -28,9 +31,15 @@ func newTaskDeleteCommand(m meta) *taskDeleteCommand {
 	flags := m.defaultFlagSet(cmdTaskDeleteName)
 	flags.SetOutput(m.writer)
 	a := flags.Bool(FlagAutoApprove, false, "Skip interactive approval of deleting a task")
+	cancel := flags.Bool(FlagCancel, false, "Cancel the running execution of the "+
+		"task, if any, instead of waiting for it to complete")
+	destroy := flags.Bool(FlagDestroy, false, "Destroy the infrastructure "+
+		"managed by the task with Terraform before deleting the task")
 	return &taskDeleteCommand{
 		meta:        m,
 		autoApprove: a,
+		cancel:      cancel,
+		destroy:     destroy,
 		flags:       flags,
 	}
 }
@@ -48,7 +57,8 @@ Usage: consul-terraform-sync task delete [-help] [options] <task name>
 
   Task Delete is used to delete an existing task. If the task is not running,
   then it is deleted immediately. Otherwise, it will be deleted once the task
-  is complete.
+  is complete, or once the running execution is cancelled with the -cancel
+  option.
 
 Options:
 %s
@@ -84,6 +94,8 @@ func (c *taskDeleteCommand) AutocompleteFlags() complete.Flags {
 	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
 		complete.Flags{
 			fmt.Sprintf("-%s", FlagAutoApprove): complete.PredictNothing,
+			fmt.Sprintf("-%s", FlagCancel):      complete.PredictNothing,
+			fmt.Sprintf("-%s", FlagDestroy):     complete.PredictNothing,
 		})
 }
 
@@ -144,14 +156,21 @@ func (c *taskDeleteCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
+	if *c.destroy {
+		if exitCode, ok := c.inspectDestroy(client, taskName); !ok {
+			return exitCode
+		}
+	}
+
 	if !*c.autoApprove {
-		if exitCode, approved := c.meta.requestUserApprovalDelete(taskName); !approved {
+		if exitCode, approved := c.meta.requestUserApprovalDelete(taskName, *c.destroy); !approved {
 			return exitCode
 		}
 	}
 
 	c.UI.Info(fmt.Sprintf("Marking task '%s' for deletion...\n", taskName))
-	resp, err := client.DeleteTaskByName(context.Background(), taskName)
+	resp, err := client.DeleteTaskByName(context.Background(), taskName,
+		&oapigen.DeleteTaskByNameParams{Cancel: c.cancel, Destroy: c.destroy})
 	if resp != nil {
 		defer resp.Body.Close()
 	}
@@ -165,8 +184,55 @@ func (c *taskDeleteCommand) Run(args []string) int {
 		return ExitCodeError
 	}
 
+	if *c.destroy {
+		c.UI.Info(fmt.Sprintf("Task '%s' has been marked for deletion. Its "+
+			"infrastructure will be destroyed before it is deleted.", taskName))
+		c.UI.Output("Check the status of the task for errors destroying the infrastructure.")
+		return ExitCodeOK
+	}
+
+	if *c.cancel {
+		c.UI.Info(fmt.Sprintf("Task '%s' has been marked for deletion "+
+			"and any running execution has been cancelled.", taskName))
+		return ExitCodeOK
+	}
+
 	c.UI.Info(fmt.Sprintf("Task '%s' has been marked for deletion "+
 		"and will be deleted when not running.", taskName))
 
 	return ExitCodeOK
 }
+
+// inspectDestroy outputs the plan for destroying the infrastructure managed
+// by the task. It returns an exit code and boolean describing if the plan was
+// retrieved.
+func (c *taskDeleteCommand) inspectDestroy(client *api.TaskLifecycleClient, taskName string) (int, bool) {
+	c.UI.Info(fmt.Sprintf("Inspecting infrastructure to destroy if deleting '%s'...\n",
+		taskName))
+	c.UI.Output("Generating plan that Consul-Terraform-Sync will use Terraform to execute\n")
+
+	runInspect := oapigen.DeleteTaskByNameParamsRun(api.RunOptionInspect)
+	resp, err := client.DeleteTaskByNameWithResponse(context.Background(), taskName,
+		&oapigen.DeleteTaskByNameParams{Destroy: c.destroy, Run: &runInspect})
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to generate plan for '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError, false
+	}
+
+	if resp.JSON200 == nil || resp.JSON200.Run == nil || resp.JSON200.Run.Plan == nil {
+		c.UI.Error(fmt.Sprintf("Error: received nil response with status %s", resp.Status()))
+		return ExitCodeError, false
+	}
+
+	run := resp.JSON200.Run
+	c.UI.Output(fmt.Sprintf("Plan: \n%s", *run.Plan))
+	if run.TfcRunUrl != nil {
+		c.UI.Output(fmt.Sprintf("Terraform Cloud Run URL: %s\n", *run.TfcRunUrl))
+	}
+	return ExitCodeOK, true
+}
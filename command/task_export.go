@@ -0,0 +1,259 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const (
+	cmdTaskExportName = "task export"
+
+	// Permissions for the exported directory and files, which are only
+	// readable by the user since they contain provider credentials
+	exportDirPerms  = os.FileMode(0700) // drwx------
+	exportFilePerms = os.FileMode(0600) // -rw-------
+)
+
+// taskExportCommand handles the `task export` command
+type taskExportCommand struct {
+	meta
+	outputDir *string
+	flags     *flag.FlagSet
+
+	predictorClient oapigen.ClientWithResponsesInterface
+}
+
+func newTaskExportCommand(m meta) *taskExportCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskExportName)
+	flags.SetOutput(m.writer)
+	o := flags.String(FlagOutputDir, "", "The directory to write the root "+
+		"module to. The directory must not exist or be empty. Defaults to a "+
+		"directory named after the task in the current directory.")
+	return &taskExportCommand{
+		meta:      m,
+		outputDir: o,
+		flags:     flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskExportCommand) Name() string {
+	return cmdTaskExportName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskExportCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task export [-help] [options] <task name>
+
+  Task Export is used to export an existing task as a standalone Terraform
+  root module. The root module is generated with the task's current
+  configuration, and terraform.tfvars contains the Consul data most recently
+  rendered for the task. Terraform can be run in the exported directory
+  without CTS, for example to debug the task's module or to make changes
+  during an incident.
+
+  The exported files include the values of the task's provider blocks, which
+  may contain credentials. Environment variables configured with task_env are
+  not exported. Exporting a task requires the admin role when API
+  authentication is enabled.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task export -output-dir=my_task_export my_task
+  ==> Exported task 'my_task' to 'my_task_export'
+
+  To run Terraform for the task:
+
+    $ cd my_task_export
+    $ terraform init
+    $ terraform workspace select my_task
+    $ terraform plan
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskExportCommand) Synopsis() string {
+	return "Exports a task as a standalone Terraform root module."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskExportCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagOutputDir): complete.PredictDirs("*"),
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// This commands uses a client to fetch a list of existing tasks
+// to predict the correct export argument
+func (c *taskExportCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		var client oapigen.ClientWithResponsesInterface
+		var err error
+		if c.predictorClient == nil {
+			client, err = c.meta.taskLifecycleClient()
+			if err != nil {
+				return nil
+			}
+		} else {
+			client = c.predictorClient
+		}
+
+		tasksResp, err := getTasks(context.Background(), client)
+		if err != nil {
+			return nil
+		}
+
+		taskNames := make([]string, 0)
+
+		if tasksResp.Tasks != nil {
+			for _, tasks := range *tasksResp.Tasks {
+				taskNames = append(taskNames, tasks.Name)
+			}
+		}
+		return taskNames
+	})
+}
+
+// Run runs the command
+func (c *taskExportCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if ok := c.meta.oneArgCheck(c.Name(), args); !ok {
+		return ExitCodeRequiredFlagsError
+	}
+
+	taskName := args[0]
+	outputDir := *c.outputDir
+	if outputDir == "" {
+		outputDir = taskName
+	}
+
+	if err := checkExportDir(outputDir); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to export '%s'", taskName))
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+
+	client, err := c.meta.taskLifecycleClient()
+	if err != nil {
+		c.UI.Error(errCreatingClient)
+		c.UI.Output(fmt.Sprintf("client could not be created for '%s'", taskName))
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	resp, err := client.ExportTaskByNameWithResponse(context.Background(), taskName)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to export '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if resp.JSON200 == nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to export '%s'", taskName))
+		if resp.JSONDefault != nil {
+			msg := wordwrap.WrapString(resp.JSONDefault.Error.Message, uint(78))
+			c.UI.Output(msg)
+		} else {
+			c.UI.Output(fmt.Sprintf("received nil response with status %s", resp.Status()))
+		}
+
+		return ExitCodeError
+	}
+
+	if err = writeExportFiles(outputDir, resp.JSON200.Files.AdditionalProperties); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to write the files of '%s'", taskName))
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+
+	c.UI.Info(fmt.Sprintf("Exported task '%s' to '%s'\n", taskName, outputDir))
+	c.UI.Output(fmt.Sprintf(`To run Terraform for the task:
+
+  $ cd %s
+  $ terraform init
+  $ terraform workspace select %s
+  $ terraform plan
+`, outputDir, resp.JSON200.Workspace))
+	c.UI.Warn(wordwrap.WrapString("The exported files may contain provider "+
+		"credentials. Terraform uses the same state as CTS, so applying "+
+		"changes will affect the task's infrastructure.", width))
+
+	return ExitCodeOK
+}
+
+// checkExportDir returns an error if the directory exists and is not empty,
+// so that exporting a task does not overwrite existing files
+func checkExportDir(dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(infos) > 0 {
+		return fmt.Errorf("output directory '%s' is not empty", dir)
+	}
+	return nil
+}
+
+// writeExportFiles writes the files of the exported root module to the
+// directory, creating it if it does not exist
+func writeExportFiles(dir string, files map[string]string) error {
+	if err := os.MkdirAll(dir, exportDirPerms); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		// file names are generated by CTS, but check that they do not
+		// escape the output directory
+		if name != filepath.Base(name) {
+			return fmt.Errorf("invalid file name '%s'", name)
+		}
+		path := filepath.Join(dir, name)
+		if err := ioutil.WriteFile(path, []byte(files[name]), exportFilePerms); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdTaskGetName = "task get"
+
+// taskGetCommand handles the `task get` command
+type taskGetCommand struct {
+	meta
+	format *string
+	flags  *flag.FlagSet
+
+	predictorClient oapigen.ClientWithResponsesInterface
+}
+
+func newTaskGetCommand(m meta) *taskGetCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskGetName)
+	flags.SetOutput(m.writer)
+	f := formatFlag(flags, formatHCL)
+	return &taskGetCommand{
+		meta:   m,
+		format: f,
+		flags:  flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskGetCommand) Name() string {
+	return cmdTaskGetName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskGetCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task get [-help] [options] <task name>
+
+  Task Get is used to get the configuration of an existing task.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task get my_task
+  task {
+    enabled   = true
+    module    = "path/to/local/module"
+    name      = "my_task"
+    providers = ["local"]
+    condition "services" {
+      names = ["api", "web"]
+    }
+  }
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskGetCommand) Synopsis() string {
+	return "Gets the configuration of a task."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskGetCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagFormat): complete.PredictSet(outputFormats...),
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// This commands uses a client to fetch a list of existing tasks
+// to predict the correct get argument
+func (c *taskGetCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		var client oapigen.ClientWithResponsesInterface
+		var err error
+		if c.predictorClient == nil {
+			client, err = c.meta.taskLifecycleClient()
+			if err != nil {
+				return nil
+			}
+		} else {
+			client = c.predictorClient
+		}
+
+		tasksResp, err := getTasks(context.Background(), client)
+		if err != nil {
+			return nil
+		}
+
+		taskNames := make([]string, 0)
+
+		if tasksResp.Tasks != nil {
+			for _, tasks := range *tasksResp.Tasks {
+				taskNames = append(taskNames, tasks.Name)
+			}
+		}
+		return taskNames
+	})
+}
+
+// Run runs the command
+func (c *taskGetCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if ok := c.meta.oneArgCheck(c.Name(), args); !ok {
+		return ExitCodeRequiredFlagsError
+	}
+
+	if err := validateFormat(*c.format); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: %s", err))
+		return ExitCodeRequiredFlagsError
+	}
+
+	taskName := args[0]
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to create client for '%s'", taskName))
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	resp, err := client.Task().Get(taskName)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to get '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if resp.Task == nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to get '%s'", taskName))
+		c.UI.Output("received response without a task")
+		return ExitCodeError
+	}
+
+	var out string
+	switch *c.format {
+	case formatJSON:
+		out, err = toJSON(resp.Task)
+	case formatHCL:
+		out, err = toHCL(resp.Task, taskHCLBlocks, "task")
+	default:
+		out = toTable(taskTableHeader, [][]string{taskTableRow(*resp.Task)})
+	}
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to format '%s'", taskName))
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+	c.UI.Output(out)
+
+	return ExitCodeOK
+}
@@ -0,0 +1,270 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskGetCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newTaskGetCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestTaskGetCommand_AutocompleteArgs(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		taskNames []string
+	}{
+		{
+			name:      "nominal",
+			taskNames: []string{"first", "second", "third"},
+		},
+		{
+			name:      "no tasks",
+			taskNames: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskGetCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			tasks := make([]oapigen.Task, len(tc.taskNames))
+			for i, n := range tc.taskNames {
+				tasks[i].Name = n
+			}
+
+			tasksResponse := oapigen.TasksResponse{
+				RequestId: uuid.New(),
+				Tasks:     &tasks,
+			}
+
+			resp := oapigen.GetAllTasksResponse{
+				JSON200: &tasksResponse,
+			}
+
+			// Return the response, and expect each task name to be present in the prediction
+			p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+
+			predictor := cmd.AutocompleteArgs()
+
+			res := predictor.Predict(complete.Args{})
+
+			assert.ElementsMatch(t, tc.taskNames, res, "flags and predictions didn't match, make sure to add "+
+				"new flags to the command AutoCompleteFlags function")
+		})
+	}
+}
+
+func TestTaskGetCommand_AutocompleteArgs_Errors(t *testing.T) {
+
+	scenarioClientError := "client error"
+	scenarioEmptyTasks := "empty tasks"
+
+	cases := []struct {
+		name     string
+		scenario string
+	}{
+		{
+			name:     "predictor client returns error",
+			scenario: scenarioClientError,
+		},
+		{
+			name:     "empty task response",
+			scenario: scenarioEmptyTasks,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskGetCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			switch tc.scenario {
+			case scenarioClientError:
+				err := errors.New("some error")
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(nil, err)
+			case scenarioEmptyTasks:
+				resp := oapigen.GetAllTasksResponse{}
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+			}
+
+			predictor := cmd.AutocompleteArgs()
+
+			// Not panicking is a success
+			predictor.Predict(complete.Args{})
+		})
+	}
+}
+
+func TestTaskGetCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	taskPath := requestPath("/v1/tasks/task_a")
+	task := oapigen.Task{
+		Name:      "task_a",
+		Enabled:   config.Bool(true),
+		Module:    "org/module/aws",
+		Providers: &[]string{"aws", "local"},
+		Condition: oapigen.Condition{
+			Services: &oapigen.ServicesCondition{Names: &[]string{"web"}},
+		},
+	}
+	taskResponse := func(t *testing.T) *http.Response {
+		return jsonResponse(t, http.StatusOK, api.TaskResponse{
+			RequestId: uuid.New(),
+			Task:      &task,
+		})
+	}
+
+	cases := []struct {
+		name           string
+		args           []string
+		mockHTTP       func(*testing.T, *mocks.HttpClient)
+		exitCode       int
+		output         string
+		outputContains []string
+		errorContains  []string
+	}{
+		{
+			name:     "hcl",
+			args:     []string{"task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", taskPath).Return(taskResponse(t), nil).Once()
+			},
+			output: `task {
+  enabled   = true
+  module    = "org/module/aws"
+  name      = "task_a"
+  providers = ["aws", "local"]
+  condition "services" {
+    names = ["web"]
+  }
+}`,
+		},
+		{
+			name:     "table",
+			args:     []string{"-format", "table", "task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", taskPath).Return(taskResponse(t), nil).Once()
+			},
+			output: "" +
+				"NAME     ENABLED   CONDITION   MODULE           PROVIDERS\n" +
+				"task_a   true      services    org/module/aws   aws,local",
+		},
+		{
+			name:     "json",
+			args:     []string{"-format", "json", "task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", taskPath).Return(taskResponse(t), nil).Once()
+			},
+			outputContains: []string{`"name": "task_a"`, `"module": "org/module/aws"`},
+		},
+		{
+			name:     "error response",
+			args:     []string{"task_a"},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", taskPath).Return(jsonResponse(t, http.StatusNotFound,
+					api.NewErrorResponse(errors.New("task does not exist"))), nil).Once()
+			},
+			outputContains: []string{"task does not exist"},
+			errorContains:  []string{"Error: unable to get 'task_a'"},
+		},
+		{
+			name:     "response without task",
+			args:     []string{"task_a"},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", taskPath).Return(jsonResponse(t, http.StatusOK,
+					api.TaskResponse{RequestId: uuid.New()}), nil).Once()
+			},
+			outputContains: []string{"received response without a task"},
+			errorContains:  []string{"Error: unable to get 'task_a'"},
+		},
+		{
+			name:          "invalid format",
+			args:          []string{"-format", "yaml", "task_a"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"yaml"},
+		},
+		{
+			name:          "no arguments",
+			args:          []string{},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command requires one argument"},
+		},
+		{
+			name:          "too many arguments",
+			args:          []string{"task_a", "task_b"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command requires one argument"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := new(mocks.HttpClient)
+			if tc.mockHTTP != nil {
+				tc.mockHTTP(t, h)
+			}
+			m, ui := newTestMeta(h)
+			cmd := newTaskGetCommand(m)
+
+			exitCode := cmd.Run(tc.args)
+			require.Equal(t, tc.exitCode, exitCode, ui.ErrorWriter.String())
+
+			if tc.output != "" {
+				assert.Equal(t, tc.output, strings.TrimSpace(ui.OutputWriter.String()))
+			}
+			for _, expect := range tc.outputContains {
+				assert.Contains(t, ui.OutputWriter.String(), expect)
+			}
+			for _, expect := range tc.errorContains {
+				assert.Contains(t, ui.ErrorWriter.String(), expect)
+			}
+			h.AssertExpectations(t)
+		})
+	}
+}
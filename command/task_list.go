@@ -0,0 +1,201 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdTaskListName = "task list"
+
+// taskTableHeader is the header of the table format of tasks
+var taskTableHeader = []string{"NAME", "ENABLED", "CONDITION", "MODULE", "PROVIDERS"}
+
+// taskListCommand handles the `task list` command
+type taskListCommand struct {
+	meta
+	format *string
+	flags  *flag.FlagSet
+}
+
+func newTaskListCommand(m meta) *taskListCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskListName)
+	flags.SetOutput(m.writer)
+	f := formatFlag(flags, formatTable)
+	return &taskListCommand{
+		meta:   m,
+		format: f,
+		flags:  flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskListCommand) Name() string {
+	return cmdTaskListName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskListCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task list [-help] [options]
+
+  Task List is used to list all existing tasks.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task list
+  NAME      ENABLED   CONDITION   MODULE                 PROVIDERS
+  my_task   true      services    path/to/local/module   local
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskListCommand) Synopsis() string {
+	return "Lists all existing tasks."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskListCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagFormat): complete.PredictSet(outputFormats...),
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// Since argument completion is not supported, this will return
+// complete.PredictNothing.
+func (c *taskListCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run runs the command
+func (c *taskListCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	if args = c.flags.Args(); len(args) != 0 {
+		c.UI.Error("Error: this command does not accept arguments")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		return ExitCodeRequiredFlagsError
+	}
+
+	if err := validateFormat(*c.format); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: %s", err))
+		return ExitCodeRequiredFlagsError
+	}
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error(errCreatingClient)
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	resp, err := client.Task().List()
+	if err != nil {
+		c.UI.Error("Error: unable to list tasks")
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	tasks := make([]oapigen.Task, 0)
+	if resp.Tasks != nil {
+		tasks = *resp.Tasks
+	}
+
+	out, err := formatTasks(*c.format, tasks)
+	if err != nil {
+		c.UI.Error("Error: unable to format tasks")
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+	c.UI.Output(out)
+
+	return ExitCodeOK
+}
+
+// formatTasks returns the tasks in the output format
+func formatTasks(format string, tasks []oapigen.Task) (string, error) {
+	switch format {
+	case formatJSON:
+		return toJSON(tasks)
+	case formatHCL:
+		blocks := make([]string, len(tasks))
+		for i, task := range tasks {
+			block, err := toHCL(task, taskHCLBlocks, "task")
+			if err != nil {
+				return "", err
+			}
+			blocks[i] = block
+		}
+		return strings.Join(blocks, "\n\n"), nil
+	default:
+		rows := make([][]string, len(tasks))
+		for i, task := range tasks {
+			rows[i] = taskTableRow(task)
+		}
+		return toTable(taskTableHeader, rows), nil
+	}
+}
+
+// taskTableRow returns the row of the table format of a task
+func taskTableRow(task oapigen.Task) []string {
+	enabled := true
+	if task.Enabled != nil {
+		enabled = *task.Enabled
+	}
+
+	var providers []string
+	if task.Providers != nil {
+		providers = *task.Providers
+	}
+
+	return []string{
+		task.Name,
+		strconv.FormatBool(enabled),
+		conditionType(task.Condition),
+		task.Module,
+		strings.Join(providers, ","),
+	}
+}
+
+// conditionType returns the type of the task's condition as it is configured
+func conditionType(c oapigen.Condition) string {
+	switch {
+	case c.Services != nil:
+		return "services"
+	case c.CatalogServices != nil:
+		return "catalog-services"
+	case c.ConsulKv != nil:
+		return "consul-kv"
+	case c.Schedule != nil:
+		return "schedule"
+	default:
+		return "none"
+	}
+}
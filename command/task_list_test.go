@@ -0,0 +1,159 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskListCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newTaskListCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestTaskListCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	tasksPath := requestPath("/v1/tasks")
+	tasks := []oapigen.Task{
+		{
+			Name:      "task_a",
+			Enabled:   config.Bool(true),
+			Module:    "org/module/aws",
+			Providers: &[]string{"aws"},
+			Condition: oapigen.Condition{
+				Schedule: &oapigen.ScheduleCondition{Cron: "*/10 * * * * * *"},
+			},
+		},
+		{
+			Name:    "task_b",
+			Enabled: config.Bool(false),
+			Module:  "./module",
+		},
+	}
+	tasksResponse := func(t *testing.T) *http.Response {
+		return jsonResponse(t, http.StatusOK, api.TasksResponse{
+			RequestId: uuid.New(),
+			Tasks:     &tasks,
+		})
+	}
+
+	cases := []struct {
+		name           string
+		args           []string
+		mockHTTP       func(*testing.T, *mocks.HttpClient)
+		exitCode       int
+		output         string
+		outputContains []string
+		errorContains  []string
+	}{
+		{
+			name:     "table",
+			args:     []string{},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", tasksPath).Return(tasksResponse(t), nil).Once()
+			},
+			output: "" +
+				"NAME     ENABLED   CONDITION   MODULE           PROVIDERS\n" +
+				"task_a   true      schedule    org/module/aws   aws\n" +
+				"task_b   false     none        ./module",
+		},
+		{
+			name:     "json",
+			args:     []string{"-format=json"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", tasksPath).Return(tasksResponse(t), nil).Once()
+			},
+			outputContains: []string{`"name": "task_a"`, `"name": "task_b"`},
+		},
+		{
+			name:     "no tasks",
+			args:     []string{},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", tasksPath).Return(jsonResponse(t, http.StatusOK,
+					api.TasksResponse{RequestId: uuid.New()}), nil).Once()
+			},
+			output: "NAME   ENABLED   CONDITION   MODULE   PROVIDERS",
+		},
+		{
+			name:     "request error",
+			args:     []string{},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", tasksPath).Return(nil, errors.New("connection refused")).Once()
+			},
+			outputContains: []string{"connection refused"},
+			errorContains:  []string{"Error: unable to list tasks"},
+		},
+		{
+			name:          "invalid format",
+			args:          []string{"-format", "yaml"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"yaml"},
+		},
+		{
+			name:          "arguments",
+			args:          []string{"task_a"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command does not accept arguments"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := new(mocks.HttpClient)
+			if tc.mockHTTP != nil {
+				tc.mockHTTP(t, h)
+			}
+			m, ui := newTestMeta(h)
+			cmd := newTaskListCommand(m)
+
+			exitCode := cmd.Run(tc.args)
+			require.Equal(t, tc.exitCode, exitCode, ui.ErrorWriter.String())
+
+			if tc.output != "" {
+				assert.Equal(t, tc.output, strings.TrimSpace(ui.OutputWriter.String()))
+			}
+			for _, expect := range tc.outputContains {
+				assert.Contains(t, ui.OutputWriter.String(), expect)
+			}
+			for _, expect := range tc.errorContains {
+				assert.Contains(t, ui.ErrorWriter.String(), expect)
+			}
+			h.AssertExpectations(t)
+		})
+	}
+}
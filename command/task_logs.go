@@ -0,0 +1,182 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdTaskLogsName = "task logs"
+
+// taskLogsCommand handles the `task logs` command
+type taskLogsCommand struct {
+	meta
+	eventID *string
+	flags   *flag.FlagSet
+
+	predictorClient oapigen.ClientWithResponsesInterface
+}
+
+func newTaskLogsCommand(m meta) *taskLogsCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskLogsName)
+	flags.SetOutput(m.writer)
+	id := flags.String(FlagEventID, "", "The ID of the event to show the "+
+		"Terraform output of. Defaults to the most recent event of the task.")
+	return &taskLogsCommand{
+		meta:    m,
+		eventID: id,
+		flags:   flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskLogsCommand) Name() string {
+	return cmdTaskLogsName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskLogsCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task logs [-help] [options] <task name>
+
+  Task Logs is used to show the Terraform output of a task run. Sensitive
+  values configured for the task are redacted from the output. Output is only
+  retained for the events in the task's event history.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task logs -event-id=ea9f2cc1-2c6b-4a3a-9b4c-4d1e8b2f0c1a my_task
+  ==> Terraform output of event 'ea9f2cc1-2c6b-4a3a-9b4c-4d1e8b2f0c1a' for task 'my_task'
+
+  Terraform will perform the following actions:
+  ...
+  Apply complete! Resources: 1 added, 0 changed, 0 destroyed.
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskLogsCommand) Synopsis() string {
+	return "Shows the Terraform output of a task run."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskLogsCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagEventID): complete.PredictAnything,
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// This commands uses a client to fetch a list of existing tasks
+// to predict the correct logs argument
+func (c *taskLogsCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		var client oapigen.ClientWithResponsesInterface
+		var err error
+		if c.predictorClient == nil {
+			client, err = c.meta.taskLifecycleClient()
+			if err != nil {
+				return nil
+			}
+		} else {
+			client = c.predictorClient
+		}
+
+		tasksResp, err := getTasks(context.Background(), client)
+		if err != nil {
+			return nil
+		}
+
+		taskNames := make([]string, 0)
+
+		if tasksResp.Tasks != nil {
+			for _, tasks := range *tasksResp.Tasks {
+				taskNames = append(taskNames, tasks.Name)
+			}
+		}
+		return taskNames
+	})
+}
+
+// Run runs the command
+func (c *taskLogsCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if ok := c.meta.oneArgCheck(c.Name(), args); !ok {
+		return ExitCodeRequiredFlagsError
+	}
+
+	taskName := args[0]
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to create client for '%s'", taskName))
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	eventID := *c.eventID
+	if eventID == "" {
+		statuses, err := client.Status().Task(taskName,
+			&api.QueryParam{IncludeEvents: true})
+		if err != nil {
+			c.UI.Error(fmt.Sprintf("Error: unable to get the events of '%s'", taskName))
+			err = processEOFError(client.Scheme(), err)
+
+			msg := wordwrap.WrapString(err.Error(), uint(78))
+			c.UI.Output(msg)
+
+			return ExitCodeError
+		}
+
+		// events are ordered from the most recent
+		status := statuses[taskName]
+		if len(status.Events) == 0 {
+			c.UI.Info(fmt.Sprintf("Task '%s' has no events with Terraform "+
+				"output.", taskName))
+			return ExitCodeOK
+		}
+		eventID = status.Events[0].ID
+	}
+
+	logs, err := client.Status().TaskEventLogs(taskName, eventID)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to get the Terraform output of "+
+			"event '%s' for '%s'", eventID, taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	c.UI.Info(fmt.Sprintf("Terraform output of event '%s' for task '%s'\n",
+		eventID, taskName))
+	c.UI.Output(logs.Logs)
+
+	return ExitCodeOK
+}
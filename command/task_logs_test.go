@@ -0,0 +1,264 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskLogsCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newTaskLogsCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestTaskLogsCommand_AutocompleteArgs(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		taskNames []string
+	}{
+		{
+			name:      "nominal",
+			taskNames: []string{"first", "second", "third"},
+		},
+		{
+			name:      "no tasks",
+			taskNames: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskLogsCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			tasks := make([]oapigen.Task, len(tc.taskNames))
+			for i, n := range tc.taskNames {
+				tasks[i].Name = n
+			}
+
+			tasksResponse := oapigen.TasksResponse{
+				RequestId: uuid.New(),
+				Tasks:     &tasks,
+			}
+
+			resp := oapigen.GetAllTasksResponse{
+				JSON200: &tasksResponse,
+			}
+
+			// Return the response, and expect each task name to be present in the prediction
+			p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+
+			predictor := cmd.AutocompleteArgs()
+
+			res := predictor.Predict(complete.Args{})
+
+			assert.ElementsMatch(t, tc.taskNames, res, "flags and predictions didn't match, make sure to add "+
+				"new flags to the command AutoCompleteFlags function")
+		})
+	}
+}
+
+func TestTaskLogsCommand_AutocompleteArgs_Errors(t *testing.T) {
+
+	scenarioClientError := "client error"
+	scenarioEmptyTasks := "empty tasks"
+
+	cases := []struct {
+		name     string
+		scenario string
+	}{
+		{
+			name:     "predictor client returns error",
+			scenario: scenarioClientError,
+		},
+		{
+			name:     "empty task response",
+			scenario: scenarioEmptyTasks,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskLogsCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			switch tc.scenario {
+			case scenarioClientError:
+				err := errors.New("some error")
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(nil, err)
+			case scenarioEmptyTasks:
+				resp := oapigen.GetAllTasksResponse{}
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+			}
+
+			predictor := cmd.AutocompleteArgs()
+
+			// Not panicking is a success
+			predictor.Predict(complete.Args{})
+		})
+	}
+}
+
+func TestTaskLogsCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	statusPath := requestPath("/v1/status/tasks/task_a")
+	logsPath := func(eventID string) interface{} {
+		return requestPath(fmt.Sprintf("/v1/status/tasks/task_a/events/%s/logs", eventID))
+	}
+	logs := "module.task.local_file.a: Creating...\n" +
+		"password = (redacted)\n" +
+		"Apply complete! Resources: 1 added, 0 changed, 0 destroyed."
+	logsResponse := func(t *testing.T, eventID string) *http.Response {
+		return jsonResponse(t, http.StatusOK, api.TaskEventLogs{
+			TaskName: "task_a",
+			EventID:  eventID,
+			Logs:     logs,
+		})
+	}
+
+	cases := []struct {
+		name           string
+		args           []string
+		mockHTTP       func(*testing.T, *mocks.HttpClient)
+		exitCode       int
+		outputContains []string
+		errorContains  []string
+	}{
+		{
+			name:     "most recent event",
+			args:     []string{"task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				// events are ordered from the most recent
+				h.On("Do", statusPath).Return(jsonResponse(t, http.StatusOK,
+					map[string]api.TaskStatus{"task_a": {
+						TaskName: "task_a",
+						Events: []event.Event{
+							{ID: "event_2", TaskName: "task_a"},
+							{ID: "event_1", TaskName: "task_a"},
+						},
+					}}), nil).Once()
+				h.On("Do", logsPath("event_2")).Return(logsResponse(t, "event_2"), nil).Once()
+			},
+			outputContains: []string{
+				"Terraform output of event 'event_2' for task 'task_a'",
+				logs,
+			},
+		},
+		{
+			name:     "event id",
+			args:     []string{"-event-id", "event_1", "task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", logsPath("event_1")).Return(logsResponse(t, "event_1"), nil).Once()
+			},
+			outputContains: []string{
+				"Terraform output of event 'event_1' for task 'task_a'",
+				logs,
+			},
+		},
+		{
+			name:     "no events",
+			args:     []string{"task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", statusPath).Return(jsonResponse(t, http.StatusOK,
+					map[string]api.TaskStatus{"task_a": {TaskName: "task_a"}}), nil).Once()
+			},
+			outputContains: []string{"Task 'task_a' has no events with Terraform output."},
+		},
+		{
+			name:     "logs error response",
+			args:     []string{"-event-id", "event_0", "task_a"},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", logsPath("event_0")).Return(jsonResponse(t, http.StatusNotFound,
+					api.NewErrorResponse(errors.New("event event_0 does not exist"))), nil).Once()
+			},
+			outputContains: []string{"event event_0 does not exist"},
+			errorContains: []string{
+				"Error: unable to get the Terraform output of event 'event_0' for 'task_a'",
+			},
+		},
+		{
+			name:     "status request error",
+			args:     []string{"task_a"},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", statusPath).Return(nil, errors.New("connection refused")).Once()
+			},
+			outputContains: []string{"connection refused"},
+			errorContains:  []string{"Error: unable to get the events of 'task_a'"},
+		},
+		{
+			name:          "no arguments",
+			args:          []string{},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command requires one argument"},
+		},
+		{
+			name:          "unsupported flag",
+			args:          []string{"-tail", "task_a"},
+			exitCode:      ExitCodeParseFlagsError,
+			errorContains: []string{"unsupported arguments in flags"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := new(mocks.HttpClient)
+			if tc.mockHTTP != nil {
+				tc.mockHTTP(t, h)
+			}
+			m, ui := newTestMeta(h)
+			cmd := newTaskLogsCommand(m)
+
+			exitCode := cmd.Run(tc.args)
+			require.Equal(t, tc.exitCode, exitCode, ui.ErrorWriter.String())
+
+			for _, expect := range tc.outputContains {
+				assert.Contains(t, ui.OutputWriter.String(), expect)
+			}
+			for _, expect := range tc.errorContains {
+				assert.Contains(t, ui.ErrorWriter.String(), expect)
+			}
+			h.AssertExpectations(t)
+		})
+	}
+}
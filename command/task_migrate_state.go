@@ -0,0 +1,217 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdTaskMigrateStateName = "task migrate-state"
+
+// taskMigrateStateCommand handles the `task migrate-state` command
+type taskMigrateStateCommand struct {
+	meta
+	autoApprove *bool
+	taskFile    *string
+	flags       *flag.FlagSet
+}
+
+func newTaskMigrateStateCommand(m meta) *taskMigrateStateCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskMigrateStateName)
+	flags.SetOutput(m.writer)
+	a := flags.Bool(FlagAutoApprove, false, "Skip interactive approval of migrating the task state")
+	f := flags.String(flagTaskFile, "", "[Required] A file containing the hcl or json "+
+		"definition of the task with the backend to migrate the state to")
+	return &taskMigrateStateCommand{
+		meta:        m,
+		autoApprove: a,
+		taskFile:    f,
+		flags:       flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskMigrateStateCommand) Name() string {
+	return cmdTaskMigrateStateName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskMigrateStateCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task migrate-state [-help] [options] -task-file=<task config>
+
+  Task Migrate State is used to move the Terraform state of an existing task
+  to the backend configured in the task file. The task is identified by the
+  name in the task file and must be disabled. After the state is copied, the
+  task uses the new backend. The state in the previous backend is not deleted.
+
+  The state is not migrated if the new backend already contains resources for
+  the task. Migrating state requires the admin role when API authentication
+  is enabled.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task disable my_task
+  $ consul-terraform-sync task migrate-state -task-file="task.hcl"
+  ==> Do you want to migrate the state of 'my_task' to the 's3' backend?
+       - The task will use the new backend once the state is migrated.
+       - The state in the previous backend will not be deleted.
+
+      Only 'yes' will be accepted to approve, enter 'no' or leave blank to reject.
+
+  Enter a value: yes
+
+  ==> Migrated the state of 'my_task' to the 's3' backend
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskMigrateStateCommand) Synopsis() string {
+	return "Migrates the Terraform state of a task to a new backend."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskMigrateStateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", flagTaskFile): complete.PredictOr(
+				complete.PredictFiles("*.hcl"),
+				complete.PredictFiles("*.json"),
+			),
+			fmt.Sprintf("-%s", FlagAutoApprove): complete.PredictNothing,
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// Since argument completion is not supported, this will return
+// complete.PredictNothing.
+func (c *taskMigrateStateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run runs the command
+func (c *taskMigrateStateCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	// Check that a task file was provided
+	taskFile := *c.taskFile
+	if len(taskFile) == 0 {
+		c.UI.Error(errCreatingRequest)
+		c.UI.Output("no task file provided")
+		help := fmt.Sprintf("For additional help try 'consul-terraform-sync %s --help'",
+			cmdTaskMigrateStateName)
+		help = wordwrap.WrapString(help, width)
+
+		c.UI.Output(help)
+
+		return ExitCodeRequiredFlagsError
+	}
+
+	cfg, err := config.BuildConfig([]string{taskFile})
+	if err != nil {
+		c.UI.Error(errCreatingRequest)
+		c.UI.Output("unable to read task file")
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	taskConfigs := *cfg.Tasks
+	if l := len(taskConfigs); l != 1 {
+		c.UI.Error(errCreatingRequest)
+		c.UI.Output(fmt.Sprintf("task file '%s' must contain exactly 1 task, "+
+			"contains %d tasks", taskFile, l))
+		return ExitCodeError
+	}
+
+	taskConfig := taskConfigs[0]
+	taskName := config.StringVal(taskConfig.Name)
+	if len(taskConfig.Backend) == 0 {
+		c.UI.Error(errCreatingRequest)
+		c.UI.Output(fmt.Sprintf("task '%s' in task file '%s' does not "+
+			"configure a backend", taskName, taskFile))
+		return ExitCodeError
+	}
+	label := backendLabel(taskConfig.Backend)
+
+	client, err := c.meta.taskLifecycleClient()
+	if err != nil {
+		c.UI.Error(errCreatingClient)
+		c.UI.Output(fmt.Sprintf("client could not be created for '%s'", taskName))
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if !*c.autoApprove {
+		if exitCode, approved := c.meta.requestUserApprovalMigrateState(taskName, label); !approved {
+			return exitCode
+		}
+	}
+
+	c.UI.Info(fmt.Sprintf("Migrating the state of '%s'...\n", taskName))
+	req := oapigen.TaskMigrateStateRequest{
+		Backend: oapigen.Backend{AdditionalProperties: taskConfig.Backend},
+	}
+	resp, err := client.MigrateTaskStateByNameWithResponse(context.Background(),
+		taskName, oapigen.MigrateTaskStateByNameJSONRequestBody(req))
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to migrate the state of '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	if resp.JSON200 == nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to migrate the state of '%s'", taskName))
+		if resp.JSONDefault != nil {
+			msg := wordwrap.WrapString(resp.JSONDefault.Error.Message, uint(78))
+			c.UI.Output(msg)
+		} else {
+			c.UI.Output(fmt.Sprintf("received nil response with status %s", resp.Status()))
+		}
+
+		return ExitCodeError
+	}
+
+	c.UI.Info(fmt.Sprintf("Migrated the state of '%s' to the '%s' backend", taskName, label))
+	c.UI.Output(fmt.Sprintf("Request ID: '%s'", resp.JSON200.RequestId))
+
+	return ExitCodeOK
+}
+
+// backendLabel returns the label of the backend. Validating the task
+// configuration ensures there is only one label.
+func backendLabel(backend map[string]interface{}) string {
+	labels := make([]string, 0, len(backend))
+	for label := range backend {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return strings.Join(labels, ", ")
+}
@@ -0,0 +1,82 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskMigrateStateCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newTaskMigrateStateCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestTaskMigrateStateCommand_Run_Errors(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	noBackend := filepath.Join(dir, "no_backend.hcl")
+	require.NoError(t, ioutil.WriteFile(noBackend, []byte(`
+task {
+  name   = "my_task"
+  module = "path"
+  condition "services" {
+    names = ["api"]
+  }
+}`), 0600))
+
+	cases := []struct {
+		name     string
+		args     []string
+		exitCode int
+		output   string
+	}{
+		{
+			"missing_task_file",
+			[]string{},
+			ExitCodeRequiredFlagsError,
+			"no task file provided",
+		},
+		{
+			"missing_backend",
+			[]string{fmt.Sprintf("-%s=%s", flagTaskFile, noBackend)},
+			ExitCodeError,
+			"does not configure a backend",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ui := cli.NewMockUi()
+			cmd := newTaskMigrateStateCommand(meta{UI: ui})
+
+			exitCode := cmd.Run(tc.args)
+			assert.Equal(t, tc.exitCode, exitCode)
+			assert.Contains(t, ui.OutputWriter.String(), tc.output)
+		})
+	}
+}
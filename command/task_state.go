@@ -0,0 +1,222 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdTaskStateName = "task state"
+
+// stateTableHeader is the header of the table format of the resources
+// managed by a task
+var stateTableHeader = []string{"ADDRESS", "TYPE", "ID"}
+
+// stateResourceHCLBlocks are the blocks of a resource managed by a task
+var stateResourceHCLBlocks = hclBlocks{
+	unlabeled: map[string]bool{
+		"attributes": true,
+	},
+}
+
+// taskStateCommand handles the `task state` command
+type taskStateCommand struct {
+	meta
+	format *string
+	flags  *flag.FlagSet
+
+	predictorClient oapigen.ClientWithResponsesInterface
+}
+
+func newTaskStateCommand(m meta) *taskStateCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskStateName)
+	flags.SetOutput(m.writer)
+	f := formatFlag(flags, formatTable)
+	return &taskStateCommand{
+		meta:   m,
+		format: f,
+		flags:  flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskStateCommand) Name() string {
+	return cmdTaskStateName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskStateCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task state [-help] [options] <task name>
+
+  Task State is used to list the resources managed by an existing task from
+  its Terraform state. The JSON and HCL formats include the attributes of each
+  resource. The values of sensitive attributes are redacted.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task state my_task
+  ADDRESS                                            TYPE                   ID
+  module.my_task.panos_address_object.this["api"]   panos_address_object   api
+  module.my_task.panos_address_object.this["web"]   panos_address_object   web
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskStateCommand) Synopsis() string {
+	return "Lists the resources managed by a task."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskStateCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagFormat): complete.PredictSet(outputFormats...),
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// This commands uses a client to fetch a list of existing tasks
+// to predict the correct state argument
+func (c *taskStateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		var client oapigen.ClientWithResponsesInterface
+		var err error
+		if c.predictorClient == nil {
+			client, err = c.meta.taskLifecycleClient()
+			if err != nil {
+				return nil
+			}
+		} else {
+			client = c.predictorClient
+		}
+
+		tasksResp, err := getTasks(context.Background(), client)
+		if err != nil {
+			return nil
+		}
+
+		taskNames := make([]string, 0)
+
+		if tasksResp.Tasks != nil {
+			for _, tasks := range *tasksResp.Tasks {
+				taskNames = append(taskNames, tasks.Name)
+			}
+		}
+		return taskNames
+	})
+}
+
+// Run runs the command
+func (c *taskStateCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if ok := c.meta.oneArgCheck(c.Name(), args); !ok {
+		return ExitCodeRequiredFlagsError
+	}
+
+	if err := validateFormat(*c.format); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: %s", err))
+		return ExitCodeRequiredFlagsError
+	}
+
+	taskName := args[0]
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to create client for '%s'", taskName))
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	resp, err := client.Task().State(taskName)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to get the state of '%s'", taskName))
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	out, err := formatStateResources(*c.format, resp.Resources)
+	if err != nil {
+		c.UI.Error(fmt.Sprintf("Error: unable to format the state of '%s'", taskName))
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+	c.UI.Output(out)
+
+	return ExitCodeOK
+}
+
+// formatStateResources returns the resources managed by a task in the output
+// format
+func formatStateResources(format string, resources []oapigen.StateResource) (string, error) {
+	if resources == nil {
+		resources = make([]oapigen.StateResource, 0)
+	}
+
+	switch format {
+	case formatJSON:
+		return toJSON(resources)
+	case formatHCL:
+		blocks := make([]string, len(resources))
+		for i, r := range resources {
+			block, err := toHCL(stateResourceHCLValue(r), stateResourceHCLBlocks,
+				"resource", r.Address)
+			if err != nil {
+				return "", err
+			}
+			blocks[i] = block
+		}
+		return strings.Join(blocks, "\n\n"), nil
+	default:
+		rows := make([][]string, len(resources))
+		for i, r := range resources {
+			var id string
+			if v, ok := r.Attributes.Get("id"); ok && v != nil {
+				id = fmt.Sprint(v)
+			}
+			rows[i] = []string{r.Address, r.Type, id}
+		}
+		return toTable(stateTableHeader, rows), nil
+	}
+}
+
+// stateResourceHCLValue returns the value of a resource to format as an HCL
+// block, omitting the address that is used as the block label
+func stateResourceHCLValue(r oapigen.StateResource) map[string]interface{} {
+	v := map[string]interface{}{
+		"type":          r.Type,
+		"name":          r.Name,
+		"provider_name": r.ProviderName,
+		"attributes":    r.Attributes.AdditionalProperties,
+	}
+	if r.ModuleAddress != nil {
+		v["module_address"] = *r.ModuleAddress
+	}
+	return v
+}
@@ -0,0 +1,84 @@
+package command
+
+import (
+	"flag"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStateCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newTaskStateCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestFormatStateResources(t *testing.T) {
+	t.Parallel()
+
+	moduleAddress := "module.my_task"
+	resources := []oapigen.StateResource{{
+		Address:       "module.my_task.local_file.api",
+		ModuleAddress: &moduleAddress,
+		Type:          "local_file",
+		Name:          "api",
+		ProviderName:  "registry.terraform.io/hashicorp/local",
+		Attributes: oapigen.StateResource_Attributes{
+			AdditionalProperties: map[string]interface{}{
+				"id":       "abc123",
+				"filename": "api.txt",
+				"content":  "(sensitive)",
+			},
+		},
+	}}
+
+	t.Run("table", func(t *testing.T) {
+		out, err := formatStateResources(formatTable, resources)
+		require.NoError(t, err)
+		assert.Equal(t, `ADDRESS                         TYPE         ID
+module.my_task.local_file.api   local_file   abc123`, out)
+	})
+
+	t.Run("json", func(t *testing.T) {
+		out, err := formatStateResources(formatJSON, nil)
+		require.NoError(t, err)
+		assert.Equal(t, "[]", out)
+	})
+
+	t.Run("hcl", func(t *testing.T) {
+		out, err := formatStateResources(formatHCL, resources)
+		require.NoError(t, err)
+		assert.Equal(t, `resource "module.my_task.local_file.api" {
+  module_address = "module.my_task"
+  name           = "api"
+  provider_name  = "registry.terraform.io/hashicorp/local"
+  type           = "local_file"
+  attributes {
+    content  = "(sensitive)"
+    filename = "api.txt"
+    id       = "abc123"
+  }
+}`, out)
+	})
+}
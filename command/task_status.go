@@ -0,0 +1,235 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const cmdTaskStatusName = "task status"
+
+// taskStatusCommand handles the `task status` command
+type taskStatusCommand struct {
+	meta
+	format *string
+	flags  *flag.FlagSet
+
+	predictorClient oapigen.ClientWithResponsesInterface
+}
+
+func newTaskStatusCommand(m meta) *taskStatusCommand {
+	logging.DisableLogging()
+	flags := m.defaultFlagSet(cmdTaskStatusName)
+	flags.SetOutput(m.writer)
+	f := formatFlag(flags, formatTable)
+	return &taskStatusCommand{
+		meta:   m,
+		format: f,
+		flags:  flags,
+	}
+}
+
+// Name returns the subcommand
+func (c taskStatusCommand) Name() string {
+	return cmdTaskStatusName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *taskStatusCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync task status [-help] [options] [<task name>]
+
+  Task Status is used to get the status of all tasks or of a single task.
+  The events of the task are included when the status of a single task is
+  requested.
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync task status my_task
+  NAME      STATUS       ENABLED
+  my_task   successful   true
+
+  ID                                     SUCCESS   START TIME             END TIME               ERROR
+  ea9f2cc1-2c6b-4a3a-9b4c-4d1e8b2f0c1a   true      2022-06-01T16:02:07Z   2022-06-01T16:02:12Z
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *taskStatusCommand) Synopsis() string {
+	return "Gets the status of tasks."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *taskStatusCommand) AutocompleteFlags() complete.Flags {
+	return mergeAutocompleteFlags(c.meta.autoCompleteFlags(),
+		complete.Flags{
+			fmt.Sprintf("-%s", FlagFormat): complete.PredictSet(outputFormats...),
+		})
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// This commands uses a client to fetch a list of existing tasks
+// to predict the correct status argument
+func (c *taskStatusCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictFunc(func(a complete.Args) []string {
+		var client oapigen.ClientWithResponsesInterface
+		var err error
+		if c.predictorClient == nil {
+			client, err = c.meta.taskLifecycleClient()
+			if err != nil {
+				return nil
+			}
+		} else {
+			client = c.predictorClient
+		}
+
+		tasksResp, err := getTasks(context.Background(), client)
+		if err != nil {
+			return nil
+		}
+
+		taskNames := make([]string, 0)
+
+		if tasksResp.Tasks != nil {
+			for _, tasks := range *tasksResp.Tasks {
+				taskNames = append(taskNames, tasks.Name)
+			}
+		}
+		return taskNames
+	})
+}
+
+// Run runs the command
+func (c *taskStatusCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	args = c.flags.Args()
+	if len(args) > 1 {
+		c.UI.Error("Error: this command accepts at most one argument: " +
+			"[options] [<task name>]")
+		c.UI.Output(fmt.Sprintf("%d arguments were passed to the command: '%s'",
+			len(args), strings.Join(args, ", ")))
+		c.UI.Output("All flags are required to appear before positional arguments if set")
+		return ExitCodeRequiredFlagsError
+	}
+
+	if err := validateFormat(*c.format); err != nil {
+		c.UI.Error(fmt.Sprintf("Error: %s", err))
+		return ExitCodeRequiredFlagsError
+	}
+
+	var taskName string
+	if len(args) == 1 {
+		taskName = args[0]
+	}
+
+	client, err := c.meta.client()
+	if err != nil {
+		c.UI.Error(errCreatingClient)
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	statuses, err := client.Status().Task(taskName,
+		&api.QueryParam{IncludeEvents: taskName != ""})
+	if err != nil {
+		c.UI.Error("Error: unable to get task status")
+		err = processEOFError(client.Scheme(), err)
+
+		msg := wordwrap.WrapString(err.Error(), uint(78))
+		c.UI.Output(msg)
+
+		return ExitCodeError
+	}
+
+	out, err := formatTaskStatuses(*c.format, statuses)
+	if err != nil {
+		c.UI.Error("Error: unable to format task status")
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+	c.UI.Output(out)
+
+	return ExitCodeOK
+}
+
+// formatTaskStatuses returns the task statuses in the output format. Tables
+// include the events of a task when the status of a single task is formatted.
+func formatTaskStatuses(format string, statuses map[string]api.TaskStatus) (string, error) {
+	names := make([]string, 0, len(statuses))
+	for name := range statuses {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	switch format {
+	case formatJSON:
+		return toJSON(statuses)
+	case formatHCL:
+		blocks := make([]string, len(names))
+		for i, name := range names {
+			block, err := toHCL(statuses[name], hclBlocks{}, "task_status", name)
+			if err != nil {
+				return "", err
+			}
+			blocks[i] = block
+		}
+		return strings.Join(blocks, "\n\n"), nil
+	}
+
+	rows := make([][]string, len(names))
+	for i, name := range names {
+		s := statuses[name]
+		rows[i] = []string{s.TaskName, s.Status, strconv.FormatBool(s.Enabled)}
+	}
+	out := toTable([]string{"NAME", "STATUS", "ENABLED"}, rows)
+
+	if len(names) != 1 || len(statuses[names[0]].Events) == 0 {
+		return out, nil
+	}
+	return out + "\n\n" + eventsTable(statuses[names[0]].Events), nil
+}
+
+// eventsTable returns the events of a task as a table
+func eventsTable(events []event.Event) string {
+	rows := make([][]string, len(events))
+	for i, e := range events {
+		var errMsg string
+		if e.EventError != nil {
+			// only the first line of the error to keep the table readable
+			errMsg = strings.SplitN(e.EventError.Message, "\n", 2)[0]
+		}
+		rows[i] = []string{
+			e.ID,
+			strconv.FormatBool(e.Success),
+			e.StartTime.Format(time.RFC3339),
+			e.EndTime.Format(time.RFC3339),
+			errMsg,
+		}
+	}
+	return toTable([]string{"ID", "SUCCESS", "START TIME", "END TIME", "ERROR"}, rows)
+}
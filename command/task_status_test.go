@@ -0,0 +1,281 @@
+package command
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hashicorp/consul-terraform-sync/api"
+	"github.com/hashicorp/consul-terraform-sync/api/oapigen"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/api"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/mitchellh/cli"
+	"github.com/posener/complete"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskStatusCommand_AutocompleteFlags(t *testing.T) {
+	t.Parallel()
+	cmd := newTaskStatusCommand(meta{UI: cli.NewMockUi()})
+
+	predictor := cmd.AutocompleteFlags()
+
+	// Test that we get the expected number of predictions
+	args := complete.Args{Last: "-"}
+	res := predictor.Predict(args)
+
+	// Grab the list of flags from the Flag object
+	flags := make([]string, 0)
+	cmd.flags.VisitAll(func(flag *flag.Flag) {
+		flags = append(flags, fmt.Sprintf("-%s", flag.Name))
+	})
+
+	// Verify that there is a prediction for each flag associated with the command
+	assert.Equal(t, len(flags), len(res))
+	assert.ElementsMatch(t, flags, res, "flags and predictions didn't match, make sure to add "+
+		"new flags to the command AutoCompleteFlags function")
+}
+
+func TestTaskStatusCommand_AutocompleteArgs(t *testing.T) {
+
+	cases := []struct {
+		name      string
+		taskNames []string
+	}{
+		{
+			name:      "nominal",
+			taskNames: []string{"first", "second", "third"},
+		},
+		{
+			name:      "no tasks",
+			taskNames: []string{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskStatusCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			tasks := make([]oapigen.Task, len(tc.taskNames))
+			for i, n := range tc.taskNames {
+				tasks[i].Name = n
+			}
+
+			tasksResponse := oapigen.TasksResponse{
+				RequestId: uuid.New(),
+				Tasks:     &tasks,
+			}
+
+			resp := oapigen.GetAllTasksResponse{
+				JSON200: &tasksResponse,
+			}
+
+			// Return the response, and expect each task name to be present in the prediction
+			p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+
+			predictor := cmd.AutocompleteArgs()
+
+			res := predictor.Predict(complete.Args{})
+
+			assert.ElementsMatch(t, tc.taskNames, res, "flags and predictions didn't match, make sure to add "+
+				"new flags to the command AutoCompleteFlags function")
+		})
+	}
+}
+
+func TestTaskStatusCommand_AutocompleteArgs_Errors(t *testing.T) {
+
+	scenarioClientError := "client error"
+	scenarioEmptyTasks := "empty tasks"
+
+	cases := []struct {
+		name     string
+		scenario string
+	}{
+		{
+			name:     "predictor client returns error",
+			scenario: scenarioClientError,
+		},
+		{
+			name:     "empty task response",
+			scenario: scenarioEmptyTasks,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			cmd := newTaskStatusCommand(meta{UI: cli.NewMockUi()})
+
+			p := new(mocks.ClientWithResponsesInterface)
+			cmd.predictorClient = p
+
+			switch tc.scenario {
+			case scenarioClientError:
+				err := errors.New("some error")
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(nil, err)
+			case scenarioEmptyTasks:
+				resp := oapigen.GetAllTasksResponse{}
+				p.On("GetAllTasksWithResponse", mock.Anything).Return(&resp, nil)
+			}
+
+			predictor := cmd.AutocompleteArgs()
+
+			// Not panicking is a success
+			predictor.Predict(complete.Args{})
+		})
+	}
+}
+
+func TestTaskStatusCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2022, 6, 1, 16, 2, 7, 0, time.UTC)
+	statusTaskA := api.TaskStatus{
+		TaskName: "task_a",
+		Status:   api.StatusSuccessful,
+		Enabled:  true,
+		Events: []event.Event{
+			{
+				ID:        "event_2",
+				TaskName:  "task_a",
+				Success:   true,
+				StartTime: start.Add(time.Minute),
+				EndTime:   start.Add(time.Minute + 5*time.Second),
+			},
+			{
+				ID:         "event_1",
+				TaskName:   "task_a",
+				StartTime:  start,
+				EndTime:    start.Add(5 * time.Second),
+				EventError: &event.Error{Message: "error applying\nmore details"},
+			},
+		},
+	}
+	statusTaskB := api.TaskStatus{
+		TaskName: "task_b",
+		Status:   api.StatusUnknown,
+		Enabled:  false,
+	}
+	// events are only requested for the status of a single task
+	statusPath := func(path, query string) interface{} {
+		return mock.MatchedBy(func(req *http.Request) bool {
+			return req.URL.Path == path && req.URL.RawQuery == query
+		})
+	}
+
+	cases := []struct {
+		name           string
+		args           []string
+		mockHTTP       func(*testing.T, *mocks.HttpClient)
+		exitCode       int
+		output         string
+		outputContains []string
+		errorContains  []string
+	}{
+		{
+			name:     "all tasks",
+			args:     []string{},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", statusPath("/v1/status/tasks", "")).Return(
+					jsonResponse(t, http.StatusOK, map[string]api.TaskStatus{
+						"task_b": statusTaskB,
+						"task_a": {TaskName: "task_a", Status: api.StatusSuccessful, Enabled: true},
+					}), nil).Once()
+			},
+			output: "" +
+				"NAME     STATUS       ENABLED\n" +
+				"task_a   successful   true\n" +
+				"task_b   unknown      false",
+		},
+		{
+			name:     "single task with events",
+			args:     []string{"task_a"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", statusPath("/v1/status/tasks/task_a", "include=events")).Return(
+					jsonResponse(t, http.StatusOK, map[string]api.TaskStatus{
+						"task_a": statusTaskA,
+					}), nil).Once()
+			},
+			output: "" +
+				"NAME     STATUS       ENABLED\n" +
+				"task_a   successful   true\n" +
+				"\n" +
+				"ID        SUCCESS   START TIME             END TIME               ERROR\n" +
+				"event_2   true      2022-06-01T16:03:07Z   2022-06-01T16:03:12Z   \n" +
+				"event_1   false     2022-06-01T16:02:07Z   2022-06-01T16:02:12Z   error applying",
+		},
+		{
+			name:     "json",
+			args:     []string{"-format", "json", "task_b"},
+			exitCode: ExitCodeOK,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", statusPath("/v1/status/tasks/task_b", "include=events")).Return(
+					jsonResponse(t, http.StatusOK, map[string]api.TaskStatus{
+						"task_b": statusTaskB,
+					}), nil).Once()
+			},
+			outputContains: []string{`"task_name": "task_b"`, `"status": "unknown"`},
+		},
+		{
+			name:     "error response",
+			args:     []string{"task_c"},
+			exitCode: ExitCodeError,
+			mockHTTP: func(t *testing.T, h *mocks.HttpClient) {
+				h.On("Do", statusPath("/v1/status/tasks/task_c", "include=events")).Return(
+					jsonResponse(t, http.StatusNotFound, api.NewErrorResponse(
+						errors.New("task does not exist"))), nil).Once()
+			},
+			outputContains: []string{"task does not exist"},
+			errorContains:  []string{"Error: unable to get task status"},
+		},
+		{
+			name:          "invalid format",
+			args:          []string{"-format", "yaml"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"yaml"},
+		},
+		{
+			name:          "too many arguments",
+			args:          []string{"task_a", "task_b"},
+			exitCode:      ExitCodeRequiredFlagsError,
+			errorContains: []string{"this command accepts at most one argument"},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := new(mocks.HttpClient)
+			if tc.mockHTTP != nil {
+				tc.mockHTTP(t, h)
+			}
+			m, ui := newTestMeta(h)
+			cmd := newTaskStatusCommand(m)
+
+			exitCode := cmd.Run(tc.args)
+			require.Equal(t, tc.exitCode, exitCode, ui.ErrorWriter.String())
+
+			if tc.output != "" {
+				assert.Equal(t, tc.output, strings.TrimSpace(ui.OutputWriter.String()))
+			}
+			for _, expect := range tc.outputContains {
+				assert.Contains(t, ui.OutputWriter.String(), expect)
+			}
+			for _, expect := range tc.errorContains {
+				assert.Contains(t, ui.ErrorWriter.String(), expect)
+			}
+			h.AssertExpectations(t)
+		})
+	}
+}
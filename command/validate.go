@@ -0,0 +1,159 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/validate"
+	"github.com/mitchellh/go-wordwrap"
+	"github.com/posener/complete"
+)
+
+const (
+	cmdValidateName = "validate"
+
+	flagOffline = "offline"
+)
+
+// validateCommand handles the `validate` command
+type validateCommand struct {
+	meta
+	configFiles *config.FlagAppendSliceValue
+	offline     *bool
+	flags       *flag.FlagSet
+}
+
+func newValidateCommand(m meta) *validateCommand {
+	logging.DisableLogging()
+	flags := flag.NewFlagSet(cmdValidateName, flag.ContinueOnError)
+	flags.SetOutput(m.writer)
+
+	var configFiles config.FlagAppendSliceValue
+	flags.Var(&configFiles, flagConfigDir, "A directory to load configuration "+
+		"files from. This option can be specified multiple times.")
+	flags.Var(&configFiles, flagConfigFiles, "A configuration file to load. "+
+		"This option can be specified multiple times.")
+	offline := flags.Bool(flagOffline, false, "Do not fetch remote modules "+
+		"that are not installed in the task's working directory. These modules "+
+		"are reported as warnings and their inputs are not checked.")
+
+	m.flags = flags
+	return &validateCommand{
+		meta:        m,
+		configFiles: &configFiles,
+		offline:     offline,
+		flags:       flags,
+	}
+}
+
+// Name returns the subcommand
+func (c validateCommand) Name() string {
+	return cmdValidateName
+}
+
+// Help returns the command's usage, list of flags, and examples
+func (c *validateCommand) Help() string {
+	c.meta.setHelpOptions()
+	helpText := fmt.Sprintf(`
+Usage: consul-terraform-sync validate [-help] [options]
+
+  Validate is used to check a configuration for errors without running tasks
+  or connecting to Consul. In addition to the checks made when CTS starts,
+  each task is checked that:
+    - its providers match the configured terraform_provider blocks
+    - its module is a local directory, is installed in its working directory,
+      or can be fetched
+    - its module declares the input variables that the task provides to it,
+      and the task provides the variables that the module requires
+
+Options:
+%s
+
+Example:
+
+  $ consul-terraform-sync validate -config-file=config.hcl
+  config.hcl:12:3: task "my_task": module "./my-module" does not declare the
+  input variable "catalog_services" provided by condition "catalog-services"
+
+  Error: configuration is invalid, found 1 error(s)
+`, strings.Join(c.meta.helpOptions, "\n"))
+	return strings.TrimSpace(helpText)
+}
+
+// Synopsis is a short one-line synopsis of the command
+func (c *validateCommand) Synopsis() string {
+	return "Validates a configuration without running tasks."
+}
+
+// AutocompleteFlags returns a mapping of supported flags and autocomplete
+// options for this command. The map key for the Flags map should be the
+// complete flag such as "-foo" or "--foo".
+func (c *validateCommand) AutocompleteFlags() complete.Flags {
+	return complete.Flags{
+		fmt.Sprintf("-%s", flagConfigDir): complete.PredictDirs("*"),
+		fmt.Sprintf("-%s", flagConfigFiles): complete.PredictOr(
+			complete.PredictFiles("*.hcl"),
+			complete.PredictFiles("*.json"),
+		),
+		fmt.Sprintf("-%s", flagOffline): complete.PredictNothing,
+	}
+}
+
+// AutocompleteArgs returns the argument predictor for this command.
+// Since argument completion is not supported, this will return
+// complete.PredictNothing.
+func (c *validateCommand) AutocompleteArgs() complete.Predictor {
+	return complete.PredictNothing
+}
+
+// Run runs the command
+func (c *validateCommand) Run(args []string) int {
+	c.meta.setFlagsUsage(c.flags, args, c.Help())
+
+	if err := c.flags.Parse(args); err != nil {
+		return ExitCodeParseFlagsError
+	}
+
+	if len(*c.configFiles) == 0 {
+		c.UI.Error("Error: no configuration files provided")
+		help := fmt.Sprintf("For additional help try 'consul-terraform-sync %s --help'",
+			cmdValidateName)
+		c.UI.Output(wordwrap.WrapString(help, width))
+		return ExitCodeRequiredFlagsError
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	result, err := validate.Config(ctx, *c.configFiles, validate.Options{
+		Offline: *c.offline,
+	})
+	if err != nil {
+		c.UI.Error("Error: unable to validate the configuration")
+		c.UI.Output(wordwrap.WrapString(err.Error(), uint(78)))
+		return ExitCodeError
+	}
+
+	for _, w := range result.Warnings {
+		c.UI.Warn(fmt.Sprintf("Warning: %s", w))
+	}
+	for _, e := range result.Errors {
+		c.UI.Error(e.String())
+	}
+
+	if !result.Valid() {
+		c.UI.Output("")
+		c.UI.Error(fmt.Sprintf("Error: configuration is invalid, found %d "+
+			"error(s)", len(result.Errors)))
+		return ExitCodeConfigError
+	}
+
+	c.UI.Info("The configuration is valid!")
+	return ExitCodeOK
+}
@@ -0,0 +1,135 @@
+package config
+
+import "fmt"
+
+// DefaultAnsiblePlaybookPath is the default executable to run playbooks
+const DefaultAnsiblePlaybookPath = "ansible-playbook"
+
+// AnsibleConfig is the configuration for the Ansible driver. The Ansible
+// driver runs each task's module as a playbook with ansible-playbook,
+// instead of running a Terraform module.
+type AnsibleConfig struct {
+	// Path is the path of the ansible-playbook executable. The executable is
+	// looked up in the PATH if the path is only a file name.
+	Path *string `mapstructure:"path"`
+
+	// Args are additional arguments for ansible-playbook, e.g. to configure
+	// the connection to hosts
+	Args []string `mapstructure:"args"`
+
+	// Log enables logging the output of ansible-playbook
+	Log *bool `mapstructure:"log"`
+}
+
+// DefaultAnsibleConfig returns the default configuration struct.
+func DefaultAnsibleConfig() *AnsibleConfig {
+	return &AnsibleConfig{
+		Path: String(DefaultAnsiblePlaybookPath),
+		Args: []string{},
+		Log:  Bool(false),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *AnsibleConfig) Copy() *AnsibleConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o AnsibleConfig
+
+	o.Path = StringCopy(c.Path)
+
+	if c.Args != nil {
+		o.Args = append([]string{}, c.Args...)
+	}
+
+	o.Log = BoolCopy(c.Log)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// The arguments are overwritten rather than merged since their order matters.
+func (c *AnsibleConfig) Merge(o *AnsibleConfig) *AnsibleConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Path != nil {
+		r.Path = StringCopy(o.Path)
+	}
+
+	if o.Args != nil {
+		r.Args = append([]string{}, o.Args...)
+	}
+
+	if o.Log != nil {
+		r.Log = BoolCopy(o.Log)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *AnsibleConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Path == nil || *c.Path == "" {
+		c.Path = String(DefaultAnsiblePlaybookPath)
+	}
+
+	if c.Args == nil {
+		c.Args = []string{}
+	}
+
+	if c.Log == nil {
+		c.Log = Bool(false)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *AnsibleConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	for _, arg := range c.Args {
+		switch arg {
+		case "--check", "-C", "--diff", "-D":
+			return fmt.Errorf("ansible driver args cannot include %q, tasks "+
+				"are inspected with --check and --diff", arg)
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *AnsibleConfig) GoString() string {
+	if c == nil {
+		return "(*AnsibleConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&AnsibleConfig{"+
+		"Path:%s, "+
+		"Args:%s, "+
+		"Log:%t"+
+		"}",
+		StringVal(c.Path),
+		c.Args,
+		BoolVal(c.Log),
+	)
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnsibleConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *AnsibleConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&AnsibleConfig{},
+		},
+		{
+			"same_enabled",
+			&AnsibleConfig{
+				Path: String("/usr/bin/ansible-playbook"),
+				Args: []string{"--forks", "10"},
+				Log:  Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestAnsibleConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *AnsibleConfig
+		b    *AnsibleConfig
+		r    *AnsibleConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&AnsibleConfig{},
+			&AnsibleConfig{},
+		},
+		{
+			"nil_b",
+			&AnsibleConfig{},
+			nil,
+			&AnsibleConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"path_overrides",
+			&AnsibleConfig{Path: String("a")},
+			&AnsibleConfig{Path: String("b")},
+			&AnsibleConfig{Path: String("b")},
+		},
+		{
+			"args_overrides",
+			&AnsibleConfig{Args: []string{"a", "b"}},
+			&AnsibleConfig{Args: []string{"c"}},
+			&AnsibleConfig{Args: []string{"c"}},
+		},
+		{
+			"args_empty_two",
+			&AnsibleConfig{},
+			&AnsibleConfig{Args: []string{"a"}},
+			&AnsibleConfig{Args: []string{"a"}},
+		},
+		{
+			"log_overrides",
+			&AnsibleConfig{Log: Bool(true)},
+			&AnsibleConfig{Log: Bool(false)},
+			&AnsibleConfig{Log: Bool(false)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestAnsibleConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *AnsibleConfig
+		r    *AnsibleConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&AnsibleConfig{},
+			DefaultAnsibleConfig(),
+		},
+		{
+			"empty_path",
+			&AnsibleConfig{Path: String(""), Args: []string{"--forks", "10"}},
+			&AnsibleConfig{
+				Path: String(DefaultAnsiblePlaybookPath),
+				Args: []string{"--forks", "10"},
+				Log:  Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestAnsibleConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *AnsibleConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"default",
+			DefaultAnsibleConfig(),
+			true,
+		},
+		{
+			"args",
+			&AnsibleConfig{Args: []string{"--user", "deploy"}},
+			true,
+		},
+		{
+			"check_arg",
+			&AnsibleConfig{Args: []string{"--check"}},
+			false,
+		},
+		{
+			"diff_arg",
+			&AnsibleConfig{Args: []string{"-D"}},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,369 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// APIRoleRead allows read-only access to the CTS API, such as retrieving
+	// tasks and their status
+	APIRoleRead = "read"
+
+	// APIRoleOperator allows access of the read role and operating existing
+	// tasks, such as enabling, disabling, running, and cancelling tasks
+	APIRoleOperator = "operator"
+
+	// APIRoleAdmin allows access of the operator role and managing tasks,
+	// such as creating and deleting tasks
+	APIRoleAdmin = "admin"
+)
+
+// APIRoles are the roles that can be granted to API tokens, ordered from the
+// least to the most privileged
+var APIRoles = []string{
+	APIRoleRead,
+	APIRoleOperator,
+	APIRoleAdmin,
+}
+
+// APIAuthConfig is the configuration for authenticating and authorizing
+// requests to the CTS API. Requests are authenticated with a token that is
+// either a static token configured with a token block or a Consul ACL token.
+type APIAuthConfig struct {
+	Enabled *bool `mapstructure:"enabled"`
+
+	// Tokens are static tokens and the role granted to each. This block may be
+	// specified multiple times to configure multiple tokens.
+	Tokens []*APITokenConfig `mapstructure:"token"`
+
+	// ConsulACL configures authenticating requests with Consul ACL tokens
+	ConsulACL *APIConsulACLConfig `mapstructure:"consul_acl"`
+}
+
+// APITokenConfig is the configuration for a static API token
+type APITokenConfig struct {
+	// Name identifies the token in logs
+	Name *string `mapstructure:"name"`
+
+	// Secret is the value of the token sent with requests
+	Secret *string `mapstructure:"secret"`
+
+	// Role is the role granted to the token: read, operator, or admin
+	Role *string `mapstructure:"role"`
+}
+
+// APIConsulACLConfig is the configuration for authenticating API requests
+// with Consul ACL tokens. Tokens are validated with Consul, and the role of a
+// token is the most privileged role mapped from the names of the Consul ACL
+// policies and roles linked to the token.
+type APIConsulACLConfig struct {
+	Enabled *bool `mapstructure:"enabled"`
+
+	// Roles maps the names of Consul ACL policies or roles to the CTS API role
+	// granted to tokens linked to them
+	Roles map[string]string `mapstructure:"roles"`
+}
+
+// DefaultAPIAuthConfig returns a configuration that is populated with the
+// default values.
+func DefaultAPIAuthConfig() *APIAuthConfig {
+	return &APIAuthConfig{}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *APIAuthConfig) Copy() *APIAuthConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o APIAuthConfig
+	o.Enabled = BoolCopy(c.Enabled)
+	if c.Tokens != nil {
+		o.Tokens = make([]*APITokenConfig, 0, len(c.Tokens))
+		for _, t := range c.Tokens {
+			o.Tokens = append(o.Tokens, t.Copy())
+		}
+	}
+	o.ConsulACL = c.ConsulACL.Copy()
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *APIAuthConfig) Merge(o *APIAuthConfig) *APIAuthConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Enabled != nil {
+		r.Enabled = BoolCopy(o.Enabled)
+	}
+
+	for _, t := range o.Tokens {
+		r.Tokens = append(r.Tokens, t.Copy())
+	}
+
+	if o.ConsulACL != nil {
+		r.ConsulACL = r.ConsulACL.Merge(o.ConsulACL)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *APIAuthConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Tokens == nil {
+		c.Tokens = []*APITokenConfig{}
+	}
+	for _, t := range c.Tokens {
+		t.Finalize()
+	}
+
+	if c.ConsulACL == nil {
+		c.ConsulACL = &APIConsulACLConfig{}
+	}
+	c.ConsulACL.Finalize()
+
+	if c.Enabled == nil {
+		c.Enabled = Bool(len(c.Tokens) > 0 || *c.ConsulACL.Enabled)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *APIAuthConfig) Validate() error {
+	if c == nil || !BoolVal(c.Enabled) {
+		return nil
+	}
+
+	if len(c.Tokens) == 0 && !BoolVal(c.ConsulACL.Enabled) {
+		return fmt.Errorf("api_auth requires at least one token or consul_acl " +
+			"to be configured when enabled")
+	}
+
+	secrets := make(map[string]bool, len(c.Tokens))
+	for _, t := range c.Tokens {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+		if secrets[*t.Secret] {
+			return fmt.Errorf("api_auth token secrets must be unique")
+		}
+		secrets[*t.Secret] = true
+	}
+
+	return c.ConsulACL.Validate()
+}
+
+// GoString defines the printable version of this struct.
+func (c *APIAuthConfig) GoString() string {
+	if c == nil {
+		return "(*APIAuthConfig)(nil)"
+	}
+
+	tokens := make([]string, len(c.Tokens))
+	for i, t := range c.Tokens {
+		tokens[i] = t.GoString()
+	}
+
+	return fmt.Sprintf("&APIAuthConfig{"+
+		"Enabled:%v, "+
+		"Tokens:[%s], "+
+		"ConsulACL:%s"+
+		"}",
+		BoolVal(c.Enabled),
+		strings.Join(tokens, ", "),
+		c.ConsulACL.GoString(),
+	)
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *APITokenConfig) Copy() *APITokenConfig {
+	if c == nil {
+		return nil
+	}
+
+	return &APITokenConfig{
+		Name:   StringCopy(c.Name),
+		Secret: StringCopy(c.Secret),
+		Role:   StringCopy(c.Role),
+	}
+}
+
+// Finalize ensures there no nil pointers.
+func (c *APITokenConfig) Finalize() {
+	if c.Name == nil {
+		c.Name = String("")
+	}
+	if c.Secret == nil {
+		c.Secret = String("")
+	}
+	if c.Role == nil {
+		c.Role = String("")
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *APITokenConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("api_auth token cannot be nil")
+	}
+
+	if StringVal(c.Secret) == "" {
+		return fmt.Errorf("api_auth token %q requires a secret", StringVal(c.Name))
+	}
+
+	if err := validateAPIRole(StringVal(c.Role)); err != nil {
+		return fmt.Errorf("api_auth token %q: %s", StringVal(c.Name), err)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct. The secret is
+// redacted.
+func (c *APITokenConfig) GoString() string {
+	if c == nil {
+		return "(*APITokenConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&APITokenConfig{"+
+		"Name:%s, "+
+		"Secret:%s, "+
+		"Role:%s"+
+		"}",
+		StringVal(c.Name),
+		redactMessage,
+		StringVal(c.Role),
+	)
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *APIConsulACLConfig) Copy() *APIConsulACLConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o APIConsulACLConfig
+	o.Enabled = BoolCopy(c.Enabled)
+	if c.Roles != nil {
+		o.Roles = make(map[string]string, len(c.Roles))
+		for k, v := range c.Roles {
+			o.Roles[k] = v
+		}
+	}
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *APIConsulACLConfig) Merge(o *APIConsulACLConfig) *APIConsulACLConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Enabled != nil {
+		r.Enabled = BoolCopy(o.Enabled)
+	}
+
+	if o.Roles != nil && r.Roles == nil {
+		r.Roles = make(map[string]string, len(o.Roles))
+	}
+	for k, v := range o.Roles {
+		r.Roles[k] = v
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *APIConsulACLConfig) Finalize() {
+	if c.Roles == nil {
+		c.Roles = make(map[string]string)
+	}
+
+	if c.Enabled == nil {
+		c.Enabled = Bool(len(c.Roles) > 0)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *APIConsulACLConfig) Validate() error {
+	if c == nil || !BoolVal(c.Enabled) {
+		return nil
+	}
+
+	if len(c.Roles) == 0 {
+		return fmt.Errorf("api_auth consul_acl requires roles to map Consul " +
+			"ACL policies or roles to CTS API roles")
+	}
+
+	for name, role := range c.Roles {
+		if err := validateAPIRole(role); err != nil {
+			return fmt.Errorf("api_auth consul_acl role for %q: %s", name, err)
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *APIConsulACLConfig) GoString() string {
+	if c == nil {
+		return "(*APIConsulACLConfig)(nil)"
+	}
+
+	names := make([]string, 0, len(c.Roles))
+	for name := range c.Roles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	roles := make([]string, len(names))
+	for i, name := range names {
+		roles[i] = fmt.Sprintf("%s:%s", name, c.Roles[name])
+	}
+
+	return fmt.Sprintf("&APIConsulACLConfig{"+
+		"Enabled:%v, "+
+		"Roles:map[%s]"+
+		"}",
+		BoolVal(c.Enabled),
+		strings.Join(roles, " "),
+	)
+}
+
+func validateAPIRole(role string) error {
+	for _, r := range APIRoles {
+		if role == r {
+			return nil
+		}
+	}
+	return fmt.Errorf("unsupported role %q, role must be one of: %s",
+		role, strings.Join(APIRoles, ", "))
+}
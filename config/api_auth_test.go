@@ -0,0 +1,292 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIAuthConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &APIAuthConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *APIAuthConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&APIAuthConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&APIAuthConfig{
+				Enabled: Bool(true),
+				Tokens: []*APITokenConfig{{
+					Name:   String("name"),
+					Secret: String("secret"),
+					Role:   String(APIRoleAdmin),
+				}},
+				ConsulACL: &APIConsulACLConfig{
+					Enabled: Bool(true),
+					Roles:   map[string]string{"policy": APIRoleRead},
+				},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestAPIAuthConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *APIAuthConfig
+		b    *APIAuthConfig
+		r    *APIAuthConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&APIAuthConfig{},
+			&APIAuthConfig{},
+		},
+		{
+			"nil_b",
+			&APIAuthConfig{},
+			nil,
+			&APIAuthConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"enabled_overrides",
+			&APIAuthConfig{Enabled: Bool(true)},
+			&APIAuthConfig{Enabled: Bool(false)},
+			&APIAuthConfig{Enabled: Bool(false)},
+		},
+		{
+			"tokens_merge",
+			&APIAuthConfig{Tokens: []*APITokenConfig{{Secret: String("a")}}},
+			&APIAuthConfig{Tokens: []*APITokenConfig{{Secret: String("b")}}},
+			&APIAuthConfig{Tokens: []*APITokenConfig{
+				{Secret: String("a")}, {Secret: String("b")}}},
+		},
+		{
+			"consul_acl_roles_merge",
+			&APIAuthConfig{ConsulACL: &APIConsulACLConfig{
+				Roles: map[string]string{"a": APIRoleRead, "b": APIRoleRead}}},
+			&APIAuthConfig{ConsulACL: &APIConsulACLConfig{
+				Enabled: Bool(true),
+				Roles:   map[string]string{"b": APIRoleAdmin}}},
+			&APIAuthConfig{ConsulACL: &APIConsulACLConfig{
+				Enabled: Bool(true),
+				Roles:   map[string]string{"a": APIRoleRead, "b": APIRoleAdmin}}},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestAPIAuthConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *APIAuthConfig
+		r    *APIAuthConfig
+	}{
+		{
+			"empty",
+			&APIAuthConfig{},
+			&APIAuthConfig{
+				Enabled: Bool(false),
+				Tokens:  []*APITokenConfig{},
+				ConsulACL: &APIConsulACLConfig{
+					Enabled: Bool(false),
+					Roles:   map[string]string{},
+				},
+			},
+		},
+		{
+			"enabled_with_token",
+			&APIAuthConfig{
+				Tokens: []*APITokenConfig{{Secret: String("secret")}},
+			},
+			&APIAuthConfig{
+				Enabled: Bool(true),
+				Tokens: []*APITokenConfig{{
+					Name:   String(""),
+					Secret: String("secret"),
+					Role:   String(""),
+				}},
+				ConsulACL: &APIConsulACLConfig{
+					Enabled: Bool(false),
+					Roles:   map[string]string{},
+				},
+			},
+		},
+		{
+			"enabled_with_consul_acl_roles",
+			&APIAuthConfig{
+				ConsulACL: &APIConsulACLConfig{
+					Roles: map[string]string{"policy": APIRoleRead},
+				},
+			},
+			&APIAuthConfig{
+				Enabled: Bool(true),
+				Tokens:  []*APITokenConfig{},
+				ConsulACL: &APIConsulACLConfig{
+					Enabled: Bool(true),
+					Roles:   map[string]string{"policy": APIRoleRead},
+				},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestAPIAuthConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *APIAuthConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"disabled",
+			&APIAuthConfig{Enabled: Bool(false)},
+			true,
+		},
+		{
+			"valid",
+			&APIAuthConfig{
+				Tokens: []*APITokenConfig{
+					{Secret: String("a"), Role: String(APIRoleRead)},
+					{Secret: String("b"), Role: String(APIRoleAdmin)},
+				},
+				ConsulACL: &APIConsulACLConfig{
+					Roles: map[string]string{"policy": APIRoleOperator},
+				},
+			},
+			true,
+		},
+		{
+			"enabled_without_tokens",
+			&APIAuthConfig{Enabled: Bool(true)},
+			false,
+		},
+		{
+			"token_missing_secret",
+			&APIAuthConfig{
+				Tokens: []*APITokenConfig{{Role: String(APIRoleRead)}},
+			},
+			false,
+		},
+		{
+			"token_invalid_role",
+			&APIAuthConfig{
+				Tokens: []*APITokenConfig{{Secret: String("a"), Role: String("root")}},
+			},
+			false,
+		},
+		{
+			"duplicate_secrets",
+			&APIAuthConfig{
+				Tokens: []*APITokenConfig{
+					{Secret: String("a"), Role: String(APIRoleRead)},
+					{Secret: String("a"), Role: String(APIRoleAdmin)},
+				},
+			},
+			false,
+		},
+		{
+			"consul_acl_enabled_without_roles",
+			&APIAuthConfig{
+				ConsulACL: &APIConsulACLConfig{Enabled: Bool(true)},
+			},
+			false,
+		},
+		{
+			"consul_acl_invalid_role",
+			&APIAuthConfig{
+				ConsulACL: &APIConsulACLConfig{
+					Roles: map[string]string{"policy": "root"},
+				},
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestAPIAuthConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	conf := &APIAuthConfig{
+		Tokens: []*APITokenConfig{{
+			Name:   String("ops"),
+			Secret: String("secret"),
+			Role:   String(APIRoleOperator),
+		}},
+		ConsulACL: &APIConsulACLConfig{
+			Roles: map[string]string{"policy": APIRoleRead},
+		},
+	}
+	conf.Finalize()
+
+	expected := "&APIAuthConfig{Enabled:true, " +
+		"Tokens:[&APITokenConfig{Name:ops, Secret:(redacted), Role:operator}], " +
+		"ConsulACL:&APIConsulACLConfig{Enabled:true, Roles:map[policy:read]}}"
+	assert.Equal(t, expected, conf.GoString())
+}
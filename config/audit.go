@@ -0,0 +1,177 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// AuditSinkFile appends audit entries as JSON lines to a file
+	AuditSinkFile = "file"
+
+	// AuditSinkSyslog writes audit entries as JSON to syslog
+	AuditSinkSyslog = "syslog"
+
+	// AuditSinkStdout writes audit entries as JSON lines to stdout
+	AuditSinkStdout = "stdout"
+
+	// DefaultAuditSink is the default sink for audit entries
+	DefaultAuditSink = AuditSinkFile
+)
+
+// AuditSinks are the supported sinks for audit entries
+var AuditSinks = []string{
+	AuditSinkFile,
+	AuditSinkSyslog,
+	AuditSinkStdout,
+}
+
+// AuditConfig is the configuration for the audit log. The audit log records
+// API requests that change tasks and every Terraform apply of a task.
+type AuditConfig struct {
+	Enabled *bool `mapstructure:"enabled"`
+
+	// Sink is where audit entries are written: file, syslog, or stdout
+	Sink *string `mapstructure:"sink"`
+
+	// Path is the path of the file that audit entries are appended to. It is
+	// required for the file sink.
+	Path *string `mapstructure:"path"`
+
+	// SyslogFacility and SyslogName configure the syslog sink
+	SyslogFacility *string `mapstructure:"syslog_facility"`
+	SyslogName     *string `mapstructure:"syslog_name"`
+}
+
+// DefaultAuditConfig returns the default configuration struct.
+func DefaultAuditConfig() *AuditConfig {
+	return &AuditConfig{
+		// No default values. `Enabled` value depends on other fields as
+		// handled in Finalize()
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *AuditConfig) Copy() *AuditConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o AuditConfig
+	o.Enabled = BoolCopy(c.Enabled)
+	o.Sink = StringCopy(c.Sink)
+	o.Path = StringCopy(c.Path)
+	o.SyslogFacility = StringCopy(c.SyslogFacility)
+	o.SyslogName = StringCopy(c.SyslogName)
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *AuditConfig) Merge(o *AuditConfig) *AuditConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Enabled != nil {
+		r.Enabled = BoolCopy(o.Enabled)
+	}
+
+	if o.Sink != nil {
+		r.Sink = StringCopy(o.Sink)
+	}
+
+	if o.Path != nil {
+		r.Path = StringCopy(o.Path)
+	}
+
+	if o.SyslogFacility != nil {
+		r.SyslogFacility = StringCopy(o.SyslogFacility)
+	}
+
+	if o.SyslogName != nil {
+		r.SyslogName = StringCopy(o.SyslogName)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *AuditConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Enabled == nil {
+		c.Enabled = Bool(StringPresent(c.Sink) || StringPresent(c.Path))
+	}
+
+	if c.Sink == nil {
+		c.Sink = String(DefaultAuditSink)
+	}
+
+	if c.Path == nil {
+		c.Path = String("")
+	}
+
+	if c.SyslogFacility == nil {
+		c.SyslogFacility = String(DefaultSyslogFacility)
+	}
+
+	if c.SyslogName == nil {
+		c.SyslogName = String(DefaultSyslogName)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *AuditConfig) Validate() error {
+	if c == nil || !BoolVal(c.Enabled) {
+		return nil
+	}
+
+	sink := StringVal(c.Sink)
+	switch sink {
+	case AuditSinkFile:
+		if StringVal(c.Path) == "" {
+			return fmt.Errorf("audit path is required for the %q sink", sink)
+		}
+	case AuditSinkSyslog, AuditSinkStdout:
+	default:
+		return fmt.Errorf("unsupported audit sink %q, sink must be one of: %s",
+			sink, strings.Join(AuditSinks, ", "))
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *AuditConfig) GoString() string {
+	if c == nil {
+		return "(*AuditConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&AuditConfig{"+
+		"Enabled:%t, "+
+		"Sink:%s, "+
+		"Path:%s, "+
+		"SyslogFacility:%s, "+
+		"SyslogName:%s"+
+		"}",
+		BoolVal(c.Enabled),
+		StringVal(c.Sink),
+		StringVal(c.Path),
+		StringVal(c.SyslogFacility),
+		StringVal(c.SyslogName),
+	)
+}
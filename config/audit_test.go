@@ -0,0 +1,214 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &AuditConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *AuditConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&AuditConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&AuditConfig{
+				Enabled:        Bool(true),
+				Sink:           String(AuditSinkSyslog),
+				Path:           String("path"),
+				SyslogFacility: String("AUTH"),
+				SyslogName:     String("name"),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestAuditConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *AuditConfig
+		b    *AuditConfig
+		r    *AuditConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&AuditConfig{},
+			&AuditConfig{},
+		},
+		{
+			"nil_b",
+			&AuditConfig{},
+			nil,
+			&AuditConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"enabled_overrides",
+			&AuditConfig{Enabled: Bool(true)},
+			&AuditConfig{Enabled: Bool(false)},
+			&AuditConfig{Enabled: Bool(false)},
+		},
+		{
+			"sink_overrides",
+			&AuditConfig{Sink: String(AuditSinkFile), Path: String("path")},
+			&AuditConfig{Sink: String(AuditSinkSyslog)},
+			&AuditConfig{Sink: String(AuditSinkSyslog), Path: String("path")},
+		},
+		{
+			"syslog_merges",
+			&AuditConfig{SyslogFacility: String("AUTH")},
+			&AuditConfig{SyslogName: String("name")},
+			&AuditConfig{SyslogFacility: String("AUTH"), SyslogName: String("name")},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestAuditConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *AuditConfig
+		r    *AuditConfig
+	}{
+		{
+			"empty",
+			&AuditConfig{},
+			&AuditConfig{
+				Enabled:        Bool(false),
+				Sink:           String(DefaultAuditSink),
+				Path:           String(""),
+				SyslogFacility: String(DefaultSyslogFacility),
+				SyslogName:     String(DefaultSyslogName),
+			},
+		},
+		{
+			"path_enables",
+			&AuditConfig{Path: String("path")},
+			&AuditConfig{
+				Enabled:        Bool(true),
+				Sink:           String(AuditSinkFile),
+				Path:           String("path"),
+				SyslogFacility: String(DefaultSyslogFacility),
+				SyslogName:     String(DefaultSyslogName),
+			},
+		},
+		{
+			"sink_enables",
+			&AuditConfig{Sink: String(AuditSinkStdout)},
+			&AuditConfig{
+				Enabled:        Bool(true),
+				Sink:           String(AuditSinkStdout),
+				Path:           String(""),
+				SyslogFacility: String(DefaultSyslogFacility),
+				SyslogName:     String(DefaultSyslogName),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestAuditConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *AuditConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"disabled",
+			&AuditConfig{},
+			true,
+		},
+		{
+			"file",
+			&AuditConfig{Path: String("path")},
+			true,
+		},
+		{
+			"file_missing_path",
+			&AuditConfig{Sink: String(AuditSinkFile)},
+			false,
+		},
+		{
+			"syslog",
+			&AuditConfig{Sink: String(AuditSinkSyslog)},
+			true,
+		},
+		{
+			"stdout",
+			&AuditConfig{Sink: String(AuditSinkStdout)},
+			true,
+		},
+		{
+			"unsupported_sink",
+			&AuditConfig{Sink: String("kafka")},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/internal/decode"
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -20,6 +21,13 @@ const (
 	// DefaultLogLevel is the default logging level.
 	DefaultLogLevel = "INFO"
 
+	// LogFormatText and LogFormatJSON are the supported log formats
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+
+	// DefaultLogFormat is the default log format.
+	DefaultLogFormat = LogFormatText
+
 	// DefaultPort is the default port to use for api server.
 	DefaultPort = 8558
 
@@ -28,6 +36,10 @@ const (
 	// created for each task with its task name.
 	DefaultWorkingDir = "sync-tasks"
 
+	// DefaultDrainTimeout is the default duration to wait for active task
+	// executions to complete when shutting down.
+	DefaultDrainTimeout = 1 * time.Minute
+
 	filePathLogKey = "file_path"
 )
 
@@ -49,20 +61,36 @@ var (
 // Config is used to configure CTS
 type Config struct {
 	LogLevel   *string `mapstructure:"log_level"`
+	LogFormat  *string `mapstructure:"log_format"`
 	ClientType *string `mapstructure:"client_type"`
 	Port       *int    `mapstructure:"port"`
 	WorkingDir *string `mapstructure:"working_dir"`
 	ID         *string `mapstructure:"id"`
 
+	// LogLevels overrides the log level for subsystems, keyed by the name of
+	// the subsystem's logger, such as "tasksmanager" or "templates".
+	LogLevels map[string]string `mapstructure:"log_levels"`
+
+	// DrainTimeout is the maximum duration to wait for active task executions
+	// to complete on shutdown before they are cancelled.
+	DrainTimeout *time.Duration `mapstructure:"drain_timeout"`
+
 	Syslog             *SyslogConfig             `mapstructure:"syslog"`
 	Consul             *ConsulConfig             `mapstructure:"consul"`
 	Vault              *VaultConfig              `mapstructure:"vault"`
 	Driver             *DriverConfig             `mapstructure:"driver"`
 	Tasks              *TaskConfigs              `mapstructure:"task"`
+	TaskTemplates      *TaskTemplateConfigs      `mapstructure:"task_template"`
+	TaskGenerators     *TaskGeneratorConfigs     `mapstructure:"task_generator"`
 	DeprecatedServices *ServiceConfigs           `mapstructure:"service"`
 	TerraformProviders *TerraformProviderConfigs `mapstructure:"terraform_provider"`
+	Policies           *PolicyConfigs            `mapstructure:"policy"`
 	BufferPeriod       *BufferPeriodConfig       `mapstructure:"buffer_period"`
 	TLS                *CTSTLSConfig             `mapstructure:"tls"`
+	APIAuth            *APIAuthConfig            `mapstructure:"api_auth"`
+	Audit              *AuditConfig              `mapstructure:"audit"`
+	ModuleUpgrades     *ModuleUpgradesConfig     `mapstructure:"module_upgrades"`
+	Rollout            *RolloutConfig            `mapstructure:"rollout"`
 }
 
 // BuildConfig builds a new Config object from the default configuration and
@@ -94,15 +122,24 @@ func DefaultConfig() *Config {
 	consul := DefaultConsulConfig()
 	return &Config{
 		LogLevel:           String(DefaultLogLevel),
+		LogFormat:          String(DefaultLogFormat),
 		Syslog:             DefaultSyslogConfig(),
 		Port:               Int(DefaultPort),
+		DrainTimeout:       TimeDuration(DefaultDrainTimeout),
 		Consul:             consul,
 		Driver:             DefaultDriverConfig(),
 		Tasks:              DefaultTaskConfigs(),
+		TaskTemplates:      DefaultTaskTemplateConfigs(),
+		TaskGenerators:     DefaultTaskGeneratorConfigs(),
 		DeprecatedServices: DefaultServiceConfigs(),
 		TerraformProviders: DefaultTerraformProviderConfigs(),
+		Policies:           DefaultPolicyConfigs(),
 		BufferPeriod:       DefaultBufferPeriodConfig(),
 		TLS:                DefaultCTSTLSConfig(),
+		APIAuth:            DefaultAPIAuthConfig(),
+		Audit:              DefaultAuditConfig(),
+		ModuleUpgrades:     DefaultModuleUpgradesConfig(),
+		Rollout:            DefaultRolloutConfig(),
 	}
 }
 
@@ -115,18 +152,28 @@ func (c *Config) Copy() *Config {
 
 	return &Config{
 		LogLevel:           StringCopy(c.LogLevel),
+		LogFormat:          StringCopy(c.LogFormat),
+		LogLevels:          copyLogLevels(c.LogLevels),
 		Syslog:             c.Syslog.Copy(),
 		Port:               IntCopy(c.Port),
 		WorkingDir:         StringCopy(c.WorkingDir),
 		ID:                 StringCopy(c.ID),
+		DrainTimeout:       TimeDurationCopy(c.DrainTimeout),
 		Consul:             c.Consul.Copy(),
 		Vault:              c.Vault.Copy(),
 		Driver:             c.Driver.Copy(),
 		Tasks:              c.Tasks.Copy(),
+		TaskTemplates:      c.TaskTemplates.Copy(),
+		TaskGenerators:     c.TaskGenerators.Copy(),
 		DeprecatedServices: c.DeprecatedServices.Copy(),
 		TerraformProviders: c.TerraformProviders.Copy(),
+		Policies:           c.Policies.Copy(),
 		BufferPeriod:       c.BufferPeriod.Copy(),
 		TLS:                c.TLS.Copy(),
+		APIAuth:            c.APIAuth.Copy(),
+		Audit:              c.Audit.Copy(),
+		ModuleUpgrades:     c.ModuleUpgrades.Copy(),
+		Rollout:            c.Rollout.Copy(),
 		ClientType:         StringCopy(c.ClientType),
 	}
 }
@@ -153,6 +200,19 @@ func (c *Config) Merge(o *Config) *Config {
 		r.LogLevel = StringCopy(o.LogLevel)
 	}
 
+	if o.LogFormat != nil {
+		r.LogFormat = StringCopy(o.LogFormat)
+	}
+
+	if o.LogLevels != nil {
+		if r.LogLevels == nil {
+			r.LogLevels = make(map[string]string, len(o.LogLevels))
+		}
+		for k, v := range o.LogLevels {
+			r.LogLevels[k] = v
+		}
+	}
+
 	if o.Port != nil {
 		r.Port = IntCopy(o.Port)
 	}
@@ -165,6 +225,10 @@ func (c *Config) Merge(o *Config) *Config {
 		r.ID = StringCopy(o.ID)
 	}
 
+	if o.DrainTimeout != nil {
+		r.DrainTimeout = TimeDurationCopy(o.DrainTimeout)
+	}
+
 	if o.Syslog != nil {
 		r.Syslog = r.Syslog.Merge(o.Syslog)
 	}
@@ -185,6 +249,14 @@ func (c *Config) Merge(o *Config) *Config {
 		r.Tasks = r.Tasks.Merge(o.Tasks)
 	}
 
+	if o.TaskTemplates != nil {
+		r.TaskTemplates = r.TaskTemplates.Merge(o.TaskTemplates)
+	}
+
+	if o.TaskGenerators != nil {
+		r.TaskGenerators = r.TaskGenerators.Merge(o.TaskGenerators)
+	}
+
 	if o.DeprecatedServices != nil {
 		r.DeprecatedServices = r.DeprecatedServices.Merge(o.DeprecatedServices)
 	}
@@ -193,6 +265,10 @@ func (c *Config) Merge(o *Config) *Config {
 		r.TerraformProviders = r.TerraformProviders.Merge(o.TerraformProviders)
 	}
 
+	if o.Policies != nil {
+		r.Policies = r.Policies.Merge(o.Policies)
+	}
+
 	if o.BufferPeriod != nil {
 		r.BufferPeriod = r.BufferPeriod.Merge(o.BufferPeriod)
 	}
@@ -201,6 +277,22 @@ func (c *Config) Merge(o *Config) *Config {
 		r.TLS = r.TLS.Merge(o.TLS)
 	}
 
+	if o.APIAuth != nil {
+		r.APIAuth = r.APIAuth.Merge(o.APIAuth)
+	}
+
+	if o.Audit != nil {
+		r.Audit = r.Audit.Merge(o.Audit)
+	}
+
+	if o.ModuleUpgrades != nil {
+		r.ModuleUpgrades = r.ModuleUpgrades.Merge(o.ModuleUpgrades)
+	}
+
+	if o.Rollout != nil {
+		r.Rollout = r.Rollout.Merge(o.Rollout)
+	}
+
 	return r
 }
 
@@ -214,6 +306,14 @@ func (c *Config) Finalize() error {
 		c.Port = Int(DefaultPort)
 	}
 
+	if c.LogFormat == nil {
+		c.LogFormat = String(DefaultLogFormat)
+	}
+
+	if c.LogLevels == nil {
+		c.LogLevels = make(map[string]string)
+	}
+
 	if c.ClientType == nil {
 		c.ClientType = String("")
 	}
@@ -226,6 +326,10 @@ func (c *Config) Finalize() error {
 		c.ID = &id
 	}
 
+	if c.DrainTimeout == nil {
+		c.DrainTimeout = TimeDuration(DefaultDrainTimeout)
+	}
+
 	if c.Syslog == nil {
 		c.Syslog = DefaultSyslogConfig()
 	}
@@ -261,14 +365,29 @@ func (c *Config) Finalize() error {
 	}
 	c.BufferPeriod.Finalize()
 
+	// task templates must be finalized and inherited by the tasks that
+	// instantiate them before the task configs are finalized
+	if c.TaskTemplates == nil {
+		c.TaskTemplates = DefaultTaskTemplateConfigs()
+	}
+	c.TaskTemplates.Finalize()
+
 	if c.Tasks == nil {
 		c.Tasks = DefaultTaskConfigs()
 	}
+	if err := c.Tasks.InheritTemplates(c.TaskTemplates); err != nil {
+		return err
+	}
 	err := c.Tasks.Finalize()
 	if err != nil {
 		return err
 	}
 
+	if c.TaskGenerators == nil {
+		c.TaskGenerators = DefaultTaskGeneratorConfigs()
+	}
+	c.TaskGenerators.Finalize()
+
 	if c.DeprecatedServices == nil {
 		c.DeprecatedServices = DefaultServiceConfigs()
 	}
@@ -283,11 +402,36 @@ func (c *Config) Finalize() error {
 	}
 	c.TerraformProviders.Finalize()
 
+	if c.Policies == nil {
+		c.Policies = DefaultPolicyConfigs()
+	}
+	c.Policies.Finalize()
+
 	if c.TLS == nil {
 		c.TLS = DefaultCTSTLSConfig()
 	}
 	c.TLS.Finalize()
 
+	if c.APIAuth == nil {
+		c.APIAuth = DefaultAPIAuthConfig()
+	}
+	c.APIAuth.Finalize()
+
+	if c.Audit == nil {
+		c.Audit = DefaultAuditConfig()
+	}
+	c.Audit.Finalize()
+
+	if c.ModuleUpgrades == nil {
+		c.ModuleUpgrades = DefaultModuleUpgradesConfig()
+	}
+	c.ModuleUpgrades.Finalize()
+
+	if c.Rollout == nil {
+		c.Rollout = DefaultRolloutConfig()
+	}
+	c.Rollout.Finalize()
+
 	return nil
 }
 
@@ -297,10 +441,29 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("missing required configuration")
 	}
 
+	switch format := StringVal(c.LogFormat); format {
+	case "", LogFormatText, LogFormatJSON:
+	default:
+		return fmt.Errorf("log_format %q is not supported, must be %q or %q",
+			format, LogFormatText, LogFormatJSON)
+	}
+
+	if c.DrainTimeout != nil && *c.DrainTimeout < 0 {
+		return fmt.Errorf("drain_timeout cannot be negative")
+	}
+
 	if err := c.Driver.Validate(); err != nil {
 		return err
 	}
 
+	if err := c.TaskTemplates.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.validateTaskGenerators(); err != nil {
+		return err
+	}
+
 	if err := c.Tasks.Validate(); err != nil {
 		return err
 	}
@@ -313,6 +476,14 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.Policies.Validate(); err != nil {
+		return err
+	}
+
+	if c.Driver.Terraform == nil && c.Policies.Len() > 0 {
+		return fmt.Errorf("policies are only supported by the terraform driver")
+	}
+
 	if err := c.BufferPeriod.Validate(); err != nil {
 		return err
 	}
@@ -329,6 +500,22 @@ func (c *Config) Validate() error {
 		return err
 	}
 
+	if err := c.APIAuth.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Audit.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.ModuleUpgrades.Validate(); err != nil {
+		return err
+	}
+
+	if err := c.Rollout.Validate(); err != nil {
+		return err
+	}
+
 	if err := c.Consul.Validate(); err != nil {
 		return err
 	}
@@ -368,6 +555,29 @@ func (c *Config) validateTaskProvider() error {
 	return nil
 }
 
+// validateTaskGenerators validates the task generators and checks that the
+// task templates they instantiate are configured
+func (c *Config) validateTaskGenerators() error {
+	if err := c.TaskGenerators.Validate(); err != nil {
+		return err
+	}
+	if c.TaskGenerators == nil {
+		return nil
+	}
+
+	for _, g := range *c.TaskGenerators {
+		name := StringVal(g.Template)
+		if name == "" {
+			continue
+		}
+		if _, ok := c.TaskTemplates.Get(name); !ok {
+			return fmt.Errorf("task template %q of task generator %q is not "+
+				"configured", name, StringVal(g.Name))
+		}
+	}
+	return nil
+}
+
 // GoString defines the printable version of this struct.
 func (c *Config) GoString() string {
 	if c == nil {
@@ -376,35 +586,67 @@ func (c *Config) GoString() string {
 
 	return fmt.Sprintf("&Config{"+
 		"LogLevel:%s, "+
+		"LogFormat:%s, "+
+		"LogLevels:%v, "+
 		"Port:%d, "+
 		"WorkingDir:%s, "+
 		"ID:%s, "+
+		"DrainTimeout:%s, "+
 		"Syslog:%s, "+
 		"Consul:%s, "+
 		"Vault:%s, "+
 		"Driver:%s, "+
 		"Tasks:%s, "+
+		"TaskTemplates:%s, "+
+		"TaskGenerators:%s, "+
 		"Services (deprecated):%s, "+
 		"TerraformProviders:%s, "+
+		"Policies:%s, "+
 		"BufferPeriod:%s,"+
-		"TLS:%s"+
+		"TLS:%s, "+
+		"APIAuth:%s, "+
+		"Audit:%s, "+
+		"ModuleUpgrades:%s, "+
+		"Rollout:%s"+
 		"}",
 		StringVal(c.LogLevel),
+		StringVal(c.LogFormat),
+		c.LogLevels,
 		IntVal(c.Port),
 		StringVal(c.WorkingDir),
 		StringVal(c.ID),
+		TimeDurationVal(c.DrainTimeout),
 		c.Syslog.GoString(),
 		c.Consul.GoString(),
 		c.Vault.GoString(),
 		c.Driver.GoString(),
 		c.Tasks.GoString(),
+		c.TaskTemplates.GoString(),
+		c.TaskGenerators.GoString(),
 		c.DeprecatedServices.GoString(),
 		c.TerraformProviders.GoString(),
+		c.Policies.GoString(),
 		c.BufferPeriod.GoString(),
 		c.TLS.GoString(),
+		c.APIAuth.GoString(),
+		c.Audit.GoString(),
+		c.ModuleUpgrades.GoString(),
+		c.Rollout.GoString(),
 	)
 }
 
+func copyLogLevels(levels map[string]string) map[string]string {
+	if levels == nil {
+		return nil
+	}
+
+	r := make(map[string]string, len(levels))
+	for k, v := range levels {
+		r[k] = v
+	}
+	return r
+}
+
 func (c *Config) validateDynamicConfigs() error {
 	// If dynamic provider configs contain Vault dependency, verify that Vault is
 	// configured.
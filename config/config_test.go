@@ -23,10 +23,13 @@ var (
 	}
 
 	longConfig = Config{
-		LogLevel:   String("ERR"),
-		Port:       Int(8502),
-		WorkingDir: String("working"),
-		ID:         String("cts-123"),
+		LogLevel:     String("ERR"),
+		LogFormat:    String("json"),
+		LogLevels:    map[string]string{"templates": "TRACE"},
+		Port:         Int(8502),
+		WorkingDir:   String("working"),
+		ID:           String("cts-123"),
+		DrainTimeout: TimeDuration(2 * time.Minute),
 		Syslog: &SyslogConfig{
 			Enabled: Bool(true),
 			Name:    String("syslog"),
@@ -74,6 +77,29 @@ var (
 			VerifyIncoming: Bool(true),
 			CACert:         String("../testutils/certs/consul_cert.pem"),
 		},
+		APIAuth: &APIAuthConfig{
+			Tokens: []*APITokenConfig{{
+				Name:   String("ops"),
+				Secret: String("ops-secret"),
+				Role:   String("operator"),
+			}},
+			ConsulACL: &APIConsulACLConfig{
+				Roles: map[string]string{"cts-admin": "admin"},
+			},
+		},
+		Audit: &AuditConfig{
+			Sink: String("file"),
+			Path: String("/var/log/cts/audit.log"),
+		},
+		ModuleUpgrades: &ModuleUpgradesConfig{
+			Enabled:  Bool(true),
+			Interval: TimeDuration(12 * time.Hour),
+		},
+		Rollout: &RolloutConfig{
+			Enabled:    Bool(true),
+			CanarySize: Int(2),
+			SoakTime:   TimeDuration(10 * time.Minute),
+		},
 		Driver: &DriverConfig{
 			Terraform: &TerraformConfig{
 				Log:  Bool(true),
@@ -108,6 +134,7 @@ var (
 			{
 				Description:        String("automate services for X to do Y"),
 				Name:               String("task"),
+				Labels:             map[string]string{"team": "payments"},
 				DeprecatedServices: []string{"serviceA", "serviceB", "serviceC"},
 				Providers:          []string{"X"},
 				Module:             String("Y"),
@@ -135,9 +162,39 @@ var (
 				},
 			},
 		},
+		TaskTemplates: &TaskTemplateConfigs{
+			{
+				Name:      String("template"),
+				Providers: []string{"X"},
+				Module:    String("Z"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Datacenter: String("dc1"),
+					},
+				},
+			},
+		},
+		TaskGenerators: &TaskGeneratorConfigs{
+			{
+				Name:             String("generator"),
+				Template:         String("template"),
+				AllowedModules:   []string{"Z"},
+				AllowedProviders: []string{"X"},
+				Datacenter:       String("dc1"),
+				Interval:         TimeDuration(time.Minute),
+			},
+		},
 		TerraformProviders: &TerraformProviderConfigs{{
 			"X": map[string]interface{}{},
 		}},
+		Policies: &PolicyConfigs{
+			{
+				Name:             String("no_open_ingress"),
+				Path:             String("policies/security"),
+				Query:            String("data.terraform.security.deny"),
+				EnforcementLevel: String("advisory"),
+			},
+		},
 		BufferPeriod: &BufferPeriodConfig{
 			Min: TimeDuration(20 * time.Second),
 			Max: TimeDuration(60 * time.Second),
@@ -367,9 +424,22 @@ func TestConfig_Finalize(t *testing.T) {
 	expected.TLS.VerifyIncoming = Bool(true)
 	expected.TLS.CACert = String("../testutils/certs/consul_cert.pem")
 	expected.TLS.Finalize()
+	expected.APIAuth.Finalize()
+	expected.Audit.Finalize()
+	expected.ModuleUpgrades.Finalize()
+	expected.Rollout.Finalize()
+	expected.TaskGenerators.Finalize()
 	expected.Driver.consul = expected.Consul
 	expected.Driver.Terraform.Version = String("")
 	expected.Driver.Terraform.PersistLog = Bool(false)
+	expected.Driver.Terraform.Binary = String(TerraformBinary)
+	expected.Driver.Terraform.ArchivePath = String("")
+	expected.Driver.Terraform.Mirror = String("")
+	expected.Driver.Terraform.Checksum = String("")
+	expected.Driver.Terraform.PluginCacheDir = String("")
+	expected.Driver.Terraform.ProviderInstallation = &ProviderInstallationConfig{}
+	expected.Driver.Terraform.ProviderInstallation.Finalize()
+	expected.Driver.Terraform.ModuleSourceRewrites = map[string]string{}
 	backend := expected.Driver.Terraform.Backend["consul"].(map[string]interface{})
 	backend["scheme"] = "https"
 	backend["ca_file"] = "ca_cert"
@@ -382,6 +452,8 @@ func TestConfig_Finalize(t *testing.T) {
 	(*expected.Tasks)[0].BufferPeriod = nil
 	(*expected.Tasks)[0].Variables = map[string]string{}
 	(*expected.Tasks)[0].WorkingDir = nil
+	(*expected.Tasks)[0].Hooks = DefaultHookConfigs()
+	(*expected.Tasks)[0].Timeout = TimeDuration(0)
 	(*expected.DeprecatedServices)[0].ID = String("serviceA")
 	(*expected.DeprecatedServices)[0].Namespace = String("")
 	(*expected.DeprecatedServices)[0].Datacenter = String("")
@@ -444,6 +516,19 @@ func TestConfig_Validate(t *testing.T) {
 	validEmptyTasks := longConfig.Copy()
 	*validEmptyTasks.Tasks = TaskConfigs{}
 
+	negativeDrainTimeout := longConfig.Copy()
+	negativeDrainTimeout.DrainTimeout = TimeDuration(-1 * time.Second)
+
+	unsupportedLogFormat := longConfig.Copy()
+	unsupportedLogFormat.LogFormat = String("xml")
+
+	validExecDriver := longConfig.Copy()
+	validExecDriver.Driver = &DriverConfig{Exec: &ExecConfig{}}
+	validExecDriver.Policies = &PolicyConfigs{}
+
+	execDriverPolicies := longConfig.Copy()
+	execDriverPolicies.Driver = &DriverConfig{Exec: &ExecConfig{}}
+
 	cases := []struct {
 		name    string
 		i       *Config
@@ -481,6 +566,22 @@ func TestConfig_Validate(t *testing.T) {
 			"autocommitting provider reuse error",
 			autoCommit.Copy(),
 			false,
+		}, {
+			"negative drain timeout",
+			negativeDrainTimeout.Copy(),
+			false,
+		}, {
+			"unsupported log format",
+			unsupportedLogFormat.Copy(),
+			false,
+		}, {
+			"exec driver valid",
+			validExecDriver.Copy(),
+			true,
+		}, {
+			"exec driver with policies",
+			execDriverPolicies.Copy(),
+			false,
 		},
 	}
 
@@ -1,6 +1,9 @@
 package config
 
-import "fmt"
+import (
+	"fmt"
+	"strings"
+)
 
 // DriverConfig is the configuration for the CTS driver used to execute
 // infrastructure updates.
@@ -8,6 +11,8 @@ type DriverConfig struct {
 	consul *ConsulConfig
 
 	Terraform *TerraformConfig `mapstructure:"terraform"`
+	Exec      *ExecConfig      `mapstructure:"exec"`
+	Ansible   *AnsibleConfig   `mapstructure:"ansible"`
 }
 
 // DefaultDriverConfig returns the default configuration struct.
@@ -31,6 +36,14 @@ func (c *DriverConfig) Copy() *DriverConfig {
 		o.Terraform = c.Terraform.Copy()
 	}
 
+	if c.Exec != nil {
+		o.Exec = c.Exec.Copy()
+	}
+
+	if c.Ansible != nil {
+		o.Ansible = c.Ansible.Copy()
+	}
+
 	return &o
 }
 
@@ -60,15 +73,30 @@ func (c *DriverConfig) Merge(o *DriverConfig) *DriverConfig {
 		r.Terraform = r.Terraform.Merge(o.Terraform)
 	}
 
+	if o.Exec != nil {
+		r.Exec = r.Exec.Merge(o.Exec)
+	}
+
+	if o.Ansible != nil {
+		r.Ansible = r.Ansible.Merge(o.Ansible)
+	}
+
 	return r
 }
 
-// Finalize ensures there no nil pointers.
+// Finalize ensures there no nil pointers. The Terraform driver is the default
+// driver when no other driver is configured.
 func (c *DriverConfig) Finalize() {
 	if c == nil {
 		return
 	}
 
+	c.Exec.Finalize()
+	c.Ansible.Finalize()
+	if c.Terraform == nil && (c.Exec != nil || c.Ansible != nil) {
+		return
+	}
+
 	if c.Terraform == nil {
 		c.Terraform = DefaultTerraformConfig()
 	}
@@ -81,7 +109,29 @@ func (c *DriverConfig) Validate() error {
 		return fmt.Errorf("missing driver configuration")
 	}
 
-	return c.Terraform.Validate()
+	var drivers []string
+	if c.Terraform != nil {
+		drivers = append(drivers, "terraform")
+	}
+	if c.Exec != nil {
+		drivers = append(drivers, "exec")
+	}
+	if c.Ansible != nil {
+		drivers = append(drivers, "ansible")
+	}
+	if len(drivers) > 1 {
+		return fmt.Errorf("only one driver can be configured, found the %s "+
+			"drivers", strings.Join(drivers, ", "))
+	}
+
+	switch {
+	case c.Exec != nil:
+		return c.Exec.Validate()
+	case c.Ansible != nil:
+		return c.Ansible.Validate()
+	default:
+		return c.Terraform.Validate()
+	}
 }
 
 // GoString defines the printable version of this struct.
@@ -91,8 +141,12 @@ func (c *DriverConfig) GoString() string {
 	}
 
 	return fmt.Sprintf("&DriverConfig{"+
-		"Terraform:%s"+
+		"Terraform:%s, "+
+		"Exec:%s, "+
+		"Ansible:%s"+
 		"}",
 		c.Terraform.GoString(),
+		c.Exec.GoString(),
+		c.Ansible.GoString(),
 	)
 }
@@ -120,6 +120,12 @@ func TestDriverConfig_Merge(t *testing.T) {
 			&DriverConfig{Terraform: &TerraformConfig{Log: Bool(true)}},
 			&DriverConfig{Terraform: &TerraformConfig{Log: Bool(true)}},
 		},
+		{
+			"exec_overrides",
+			&DriverConfig{Exec: &ExecConfig{Log: Bool(true)}},
+			&DriverConfig{Exec: &ExecConfig{Log: Bool(false)}},
+			&DriverConfig{Exec: &ExecConfig{Log: Bool(false)}},
+		},
 		{
 			"terraform_same",
 			&DriverConfig{Terraform: &TerraformConfig{Log: Bool(true)}},
@@ -162,6 +168,17 @@ func TestDriverConfig_Finalize(t *testing.T) {
 					Path:              String(wd),
 					Backend:           map[string]interface{}{},
 					RequiredProviders: map[string]interface{}{},
+					Binary:            String(TerraformBinary),
+					ArchivePath:       String(""),
+					Mirror:            String(""),
+					Checksum:          String(""),
+					PluginCacheDir:    String(""),
+					ProviderInstallation: &ProviderInstallationConfig{
+						FilesystemMirror: String(""),
+						NetworkMirror:    String(""),
+						Direct:           Bool(false),
+					},
+					ModuleSourceRewrites: map[string]string{},
 				},
 			},
 		},
@@ -180,8 +197,43 @@ func TestDriverConfig_Finalize(t *testing.T) {
 					Path:              String(wd),
 					Backend:           map[string]interface{}{},
 					RequiredProviders: map[string]interface{}{},
+					Binary:            String(TerraformBinary),
+					ArchivePath:       String(""),
+					Mirror:            String(""),
+					Checksum:          String(""),
+					PluginCacheDir:    String(""),
+					ProviderInstallation: &ProviderInstallationConfig{
+						FilesystemMirror: String(""),
+						NetworkMirror:    String(""),
+						Direct:           Bool(false),
+					},
+					ModuleSourceRewrites: map[string]string{},
+				},
+			},
+		},
+		{
+			"with_exec",
+			&DriverConfig{
+				Exec: &ExecConfig{
+					PlanArgs: []string{"--check"},
 				},
 			},
+			&DriverConfig{
+				Exec: &ExecConfig{
+					Args:     []string{},
+					PlanArgs: []string{"--check"},
+					Log:      Bool(false),
+				},
+			},
+		},
+		{
+			"with_ansible",
+			&DriverConfig{
+				Ansible: &AnsibleConfig{},
+			},
+			&DriverConfig{
+				Ansible: DefaultAnsibleConfig(),
+			},
 		},
 	}
 
@@ -217,6 +269,29 @@ func TestDriverConfig_Validate(t *testing.T) {
 			"terraform_invalid",
 			&DriverConfig{Terraform: &TerraformConfig{}},
 			false,
+		}, {
+			"exec",
+			&DriverConfig{Exec: &ExecConfig{Args: []string{"apply"}}},
+			true,
+		}, {
+			"exec_invalid",
+			&DriverConfig{Exec: &ExecConfig{PlanArgs: []string{}}},
+			false,
+		}, {
+			"ansible",
+			&DriverConfig{Ansible: DefaultAnsibleConfig()},
+			true,
+		}, {
+			"exec_and_ansible",
+			&DriverConfig{Exec: &ExecConfig{}, Ansible: &AnsibleConfig{}},
+			false,
+		}, {
+			"terraform_and_exec",
+			&DriverConfig{
+				Terraform: &TerraformConfig{Backend: map[string]interface{}{"consul": nil}},
+				Exec:      &ExecConfig{},
+			},
+			false,
 		},
 	}
 
@@ -0,0 +1,127 @@
+package config
+
+import "fmt"
+
+// ExecConfig is the configuration for the exec driver. The exec driver runs
+// the executable configured as each task's module with the task's module
+// inputs encoded as JSON, instead of running a Terraform module.
+type ExecConfig struct {
+	// Args are the arguments passed to the executable to apply changes
+	Args []string `mapstructure:"args"`
+
+	// PlanArgs are the arguments passed to the executable to plan changes
+	// without applying them. Inspecting a task is not supported when no
+	// plan arguments are configured.
+	PlanArgs []string `mapstructure:"plan_args"`
+
+	// Log enables logging the output of the executable
+	Log *bool `mapstructure:"log"`
+}
+
+// DefaultExecConfig returns the default configuration struct.
+func DefaultExecConfig() *ExecConfig {
+	return &ExecConfig{
+		Args: []string{},
+		Log:  Bool(false),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ExecConfig) Copy() *ExecConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ExecConfig
+
+	if c.Args != nil {
+		o.Args = append([]string{}, c.Args...)
+	}
+
+	if c.PlanArgs != nil {
+		o.PlanArgs = append([]string{}, c.PlanArgs...)
+	}
+
+	o.Log = BoolCopy(c.Log)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// The arguments are overwritten rather than merged since their order matters.
+func (c *ExecConfig) Merge(o *ExecConfig) *ExecConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Args != nil {
+		r.Args = append([]string{}, o.Args...)
+	}
+
+	if o.PlanArgs != nil {
+		r.PlanArgs = append([]string{}, o.PlanArgs...)
+	}
+
+	if o.Log != nil {
+		r.Log = BoolCopy(o.Log)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers. PlanArgs is left nil when plan
+// arguments are not configured.
+func (c *ExecConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Args == nil {
+		c.Args = []string{}
+	}
+
+	if c.Log == nil {
+		c.Log = Bool(false)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *ExecConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	if c.PlanArgs != nil && len(c.PlanArgs) == 0 {
+		return fmt.Errorf("exec driver plan_args cannot be empty, remove " +
+			"plan_args to disable inspecting tasks")
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *ExecConfig) GoString() string {
+	if c == nil {
+		return "(*ExecConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ExecConfig{"+
+		"Args:%s, "+
+		"PlanArgs:%s, "+
+		"Log:%t"+
+		"}",
+		c.Args,
+		c.PlanArgs,
+		BoolVal(c.Log),
+	)
+}
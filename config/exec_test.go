@@ -0,0 +1,196 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ExecConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ExecConfig{},
+		},
+		{
+			"same_enabled",
+			&ExecConfig{
+				Args:     []string{"apply"},
+				PlanArgs: []string{"plan"},
+				Log:      Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestExecConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ExecConfig
+		b    *ExecConfig
+		r    *ExecConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ExecConfig{},
+			&ExecConfig{},
+		},
+		{
+			"nil_b",
+			&ExecConfig{},
+			nil,
+			&ExecConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ExecConfig{},
+			&ExecConfig{},
+			&ExecConfig{},
+		},
+		{
+			"args_overrides",
+			&ExecConfig{Args: []string{"a", "b"}},
+			&ExecConfig{Args: []string{"c"}},
+			&ExecConfig{Args: []string{"c"}},
+		},
+		{
+			"args_empty_one",
+			&ExecConfig{Args: []string{"a"}},
+			&ExecConfig{},
+			&ExecConfig{Args: []string{"a"}},
+		},
+		{
+			"plan_args_overrides",
+			&ExecConfig{PlanArgs: []string{"a"}},
+			&ExecConfig{PlanArgs: []string{"b", "c"}},
+			&ExecConfig{PlanArgs: []string{"b", "c"}},
+		},
+		{
+			"plan_args_empty_two",
+			&ExecConfig{},
+			&ExecConfig{PlanArgs: []string{"a"}},
+			&ExecConfig{PlanArgs: []string{"a"}},
+		},
+		{
+			"log_overrides",
+			&ExecConfig{Log: Bool(true)},
+			&ExecConfig{Log: Bool(false)},
+			&ExecConfig{Log: Bool(false)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestExecConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *ExecConfig
+		r    *ExecConfig
+	}{
+		{
+			"nil",
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ExecConfig{},
+			&ExecConfig{
+				Args: []string{},
+				Log:  Bool(false),
+			},
+		},
+		{
+			"plan_args",
+			&ExecConfig{PlanArgs: []string{"--check"}},
+			&ExecConfig{
+				Args:     []string{},
+				PlanArgs: []string{"--check"},
+				Log:      Bool(false),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestExecConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *ExecConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"default",
+			DefaultExecConfig(),
+			true,
+		},
+		{
+			"plan_args",
+			&ExecConfig{Args: []string{"apply"}, PlanArgs: []string{"plan"}},
+			true,
+		},
+		{
+			"empty_plan_args",
+			&ExecConfig{PlanArgs: []string{}},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
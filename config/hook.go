@@ -0,0 +1,409 @@
+package config
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// HookStagePrePlan runs hooks before the task's changes are planned
+	HookStagePrePlan = "pre-plan"
+
+	// HookStagePostPlan runs hooks after the task's changes are planned and
+	// before they are applied
+	HookStagePostPlan = "post-plan"
+
+	// HookStagePostApply runs hooks after the task's changes are applied
+	HookStagePostApply = "post-apply"
+
+	// HookStageOnFailure runs hooks when the task fails to run
+	HookStageOnFailure = "on-failure"
+
+	// DefaultHookTimeout is the default time a hook is allowed to run
+	DefaultHookTimeout = 30 * time.Second
+
+	hookTypeCommand  = "command"
+	hookTypeHTTP     = "http"
+	hookTypeConsulKV = "consul_kv"
+)
+
+// HookStages are the stages of a task run that hooks can be configured for
+var HookStages = []string{
+	HookStagePrePlan,
+	HookStagePostPlan,
+	HookStagePostApply,
+	HookStageOnFailure,
+}
+
+// HookConfig configures an action to run at a stage of a task run. Exactly one
+// of command, http, or consul_kv is configured for a hook. This block may be
+// specified multiple times within a task to configure multiple hooks.
+type HookConfig struct {
+	// Name is the name of the hook used to identify its results. Defaults to
+	// the stage and type of the hook.
+	Name *string `mapstructure:"name" json:"name"`
+
+	// Stage is the stage of the task run when the hook runs: pre-plan,
+	// post-plan, post-apply, or on-failure.
+	Stage *string `mapstructure:"stage" json:"stage"`
+
+	// Command is a local command and its arguments to run. The hook context is
+	// passed to the command through stdin.
+	Command []string `mapstructure:"command" json:"command"`
+
+	// HTTP configures an HTTP endpoint to send the hook context to.
+	HTTP *HTTPHookConfig `mapstructure:"http" json:"http"`
+
+	// ConsulKV configures a Consul KV key to write the hook context to.
+	ConsulKV *ConsulKVHookConfig `mapstructure:"consul_kv" json:"consul_kv"`
+
+	// Timeout is the maximum time the hook is allowed to run.
+	Timeout *time.Duration `mapstructure:"timeout" json:"timeout"`
+}
+
+// HTTPHookConfig configures a hook that calls an HTTP endpoint
+type HTTPHookConfig struct {
+	URL     *string           `mapstructure:"url" json:"url"`
+	Method  *string           `mapstructure:"method" json:"method"`
+	Headers map[string]string `mapstructure:"headers" json:"headers"`
+}
+
+// ConsulKVHookConfig configures a hook that writes a Consul KV key
+type ConsulKVHookConfig struct {
+	Path      *string `mapstructure:"path" json:"path"`
+	Namespace *string `mapstructure:"namespace" json:"namespace"`
+}
+
+// HookConfigs is a collection of HookConfig
+type HookConfigs []*HookConfig
+
+// Copy returns a deep copy of this configuration.
+func (c *HTTPHookConfig) Copy() *HTTPHookConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o HTTPHookConfig
+	o.URL = StringCopy(c.URL)
+	o.Method = StringCopy(c.Method)
+
+	if c.Headers != nil {
+		o.Headers = make(map[string]string, len(c.Headers))
+		for k, v := range c.Headers {
+			o.Headers[k] = v
+		}
+	}
+
+	return &o
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ConsulKVHookConfig) Copy() *ConsulKVHookConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ConsulKVHookConfig
+	o.Path = StringCopy(c.Path)
+	o.Namespace = StringCopy(c.Namespace)
+	return &o
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *HookConfig) Copy() *HookConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o HookConfig
+	o.Name = StringCopy(c.Name)
+	o.Stage = StringCopy(c.Stage)
+
+	if c.Command != nil {
+		o.Command = make([]string, 0, len(c.Command))
+		o.Command = append(o.Command, c.Command...)
+	}
+
+	o.HTTP = c.HTTP.Copy()
+	o.ConsulKV = c.ConsulKV.Copy()
+	o.Timeout = TimeDurationCopy(c.Timeout)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// The hook type is not merged, the type of the other configuration replaces
+// the type of this configuration.
+func (c *HookConfig) Merge(o *HookConfig) *HookConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Name != nil {
+		r.Name = StringCopy(o.Name)
+	}
+
+	if o.Stage != nil {
+		r.Stage = StringCopy(o.Stage)
+	}
+
+	if o.Command != nil || o.HTTP != nil || o.ConsulKV != nil {
+		r.Command = nil
+		if o.Command != nil {
+			r.Command = append([]string{}, o.Command...)
+		}
+		r.HTTP = o.HTTP.Copy()
+		r.ConsulKV = o.ConsulKV.Copy()
+	}
+
+	if o.Timeout != nil {
+		r.Timeout = TimeDurationCopy(o.Timeout)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *HookConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Stage == nil {
+		c.Stage = String("")
+	}
+
+	if c.Name == nil || *c.Name == "" {
+		c.Name = String(fmt.Sprintf("%s.%s", *c.Stage, c.hookType()))
+	}
+
+	if c.HTTP != nil {
+		if c.HTTP.URL == nil {
+			c.HTTP.URL = String("")
+		}
+		if c.HTTP.Method == nil || *c.HTTP.Method == "" {
+			c.HTTP.Method = String(http.MethodPost)
+		}
+		if c.HTTP.Headers == nil {
+			c.HTTP.Headers = make(map[string]string)
+		}
+	}
+
+	if c.ConsulKV != nil {
+		if c.ConsulKV.Path == nil {
+			c.ConsulKV.Path = String("")
+		}
+		if c.ConsulKV.Namespace == nil {
+			c.ConsulKV.Namespace = String("")
+		}
+	}
+
+	if c.Timeout == nil || *c.Timeout <= 0 {
+		c.Timeout = TimeDuration(DefaultHookTimeout)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *HookConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("missing hook configuration")
+	}
+
+	stage := StringVal(c.Stage)
+	validStage := false
+	for _, s := range HookStages {
+		if stage == s {
+			validStage = true
+			break
+		}
+	}
+	if !validStage {
+		return fmt.Errorf("unsupported stage '%s' for hook. Supported stages: %s",
+			stage, strings.Join(HookStages, ", "))
+	}
+
+	count := 0
+	if len(c.Command) > 0 {
+		count++
+	}
+	if c.HTTP != nil {
+		count++
+	}
+	if c.ConsulKV != nil {
+		count++
+	}
+	if count != 1 {
+		return fmt.Errorf("hook '%s' must configure exactly one of command, "+
+			"http, or consul_kv", StringVal(c.Name))
+	}
+
+	if c.HTTP != nil && StringVal(c.HTTP.URL) == "" {
+		return fmt.Errorf("url is required for http hook '%s'", StringVal(c.Name))
+	}
+
+	if c.ConsulKV != nil && StringVal(c.ConsulKV.Path) == "" {
+		return fmt.Errorf("path is required for consul_kv hook '%s'", StringVal(c.Name))
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+// Sensitive information, like HTTP headers, is redacted.
+func (c *HookConfig) GoString() string {
+	if c == nil {
+		return "(*HookConfig)(nil)"
+	}
+
+	var target string
+	switch c.hookType() {
+	case hookTypeCommand:
+		target = fmt.Sprintf("Command:%s", c.Command)
+	case hookTypeHTTP:
+		target = fmt.Sprintf("HTTP:&HTTPHookConfig{URL:%s, Method:%s, Headers:%s}",
+			StringVal(c.HTTP.URL), StringVal(c.HTTP.Method), redactedHeaders(c.HTTP.Headers))
+	case hookTypeConsulKV:
+		target = fmt.Sprintf("ConsulKV:&ConsulKVHookConfig{Path:%s, Namespace:%s}",
+			StringVal(c.ConsulKV.Path), StringVal(c.ConsulKV.Namespace))
+	}
+
+	return fmt.Sprintf("&HookConfig{"+
+		"Name:%s, "+
+		"Stage:%s, "+
+		"%s, "+
+		"Timeout:%s"+
+		"}",
+		StringVal(c.Name),
+		StringVal(c.Stage),
+		target,
+		TimeDurationVal(c.Timeout),
+	)
+}
+
+// hookType returns the type of the hook based on the configured action
+func (c *HookConfig) hookType() string {
+	switch {
+	case c.HTTP != nil:
+		return hookTypeHTTP
+	case c.ConsulKV != nil:
+		return hookTypeConsulKV
+	default:
+		return hookTypeCommand
+	}
+}
+
+// redactedHeaders returns the header names with their values redacted
+func redactedHeaders(headers map[string]string) map[string]string {
+	if headers == nil {
+		return nil
+	}
+	r := make(map[string]string, len(headers))
+	for k := range headers {
+		r[k] = "<redacted>"
+	}
+	return r
+}
+
+// DefaultHookConfigs returns a configuration that is populated with the
+// default values.
+func DefaultHookConfigs() *HookConfigs {
+	return &HookConfigs{}
+}
+
+// Len is a helper method to get the length of the underlying config list
+func (c *HookConfigs) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	return len(*c)
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *HookConfigs) Copy() *HookConfigs {
+	if c == nil {
+		return nil
+	}
+
+	o := make(HookConfigs, c.Len())
+	for i, h := range *c {
+		o[i] = h.Copy()
+	}
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *HookConfigs) Merge(o *HookConfigs) *HookConfigs {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	*r = append(*r, *o.Copy()...)
+
+	return r
+}
+
+// Finalize ensures the configuration has no nil pointers and sets default
+// values.
+func (c *HookConfigs) Finalize() {
+	if c == nil {
+		return
+	}
+
+	for _, h := range *c {
+		h.Finalize()
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *HookConfigs) Validate() error {
+	if c == nil {
+		return nil
+	}
+
+	for _, h := range *c {
+		if err := h.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *HookConfigs) GoString() string {
+	if c == nil {
+		return "(*HookConfigs)(nil)"
+	}
+
+	s := make([]string, len(*c))
+	for i, h := range *c {
+		s[i] = h.GoString()
+	}
+
+	return "{" + strings.Join(s, ", ") + "}"
+}
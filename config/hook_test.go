@@ -0,0 +1,342 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHookConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &HookConfig{HTTP: &HTTPHookConfig{}}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *HookConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&HookConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"command",
+			&HookConfig{
+				Name:    String("notify"),
+				Stage:   String(HookStagePostApply),
+				Command: []string{"./notify.sh", "--verbose"},
+				Timeout: TimeDuration(5 * time.Second),
+			},
+		},
+		{
+			"http",
+			&HookConfig{
+				Stage: String(HookStagePostPlan),
+				HTTP: &HTTPHookConfig{
+					URL:     String("https://example.com"),
+					Method:  String("PUT"),
+					Headers: map[string]string{"Authorization": "Bearer token"},
+				},
+			},
+		},
+		{
+			"consul_kv",
+			&HookConfig{
+				Stage:    String(HookStageOnFailure),
+				ConsulKV: &ConsulKVHookConfig{Path: String("cts/failures")},
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestHookConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *HookConfig
+		b    *HookConfig
+		r    *HookConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&HookConfig{},
+			&HookConfig{},
+		},
+		{
+			"nil_b",
+			&HookConfig{},
+			nil,
+			&HookConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"stage_overrides",
+			&HookConfig{Stage: String(HookStagePrePlan)},
+			&HookConfig{Stage: String(HookStagePostApply)},
+			&HookConfig{Stage: String(HookStagePostApply)},
+		},
+		{
+			"type_replaced",
+			&HookConfig{Command: []string{"echo"}},
+			&HookConfig{HTTP: &HTTPHookConfig{URL: String("https://example.com")}},
+			&HookConfig{HTTP: &HTTPHookConfig{URL: String("https://example.com")}},
+		},
+		{
+			"type_kept",
+			&HookConfig{Command: []string{"echo"}},
+			&HookConfig{Timeout: TimeDuration(time.Second)},
+			&HookConfig{Command: []string{"echo"}, Timeout: TimeDuration(time.Second)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestHookConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *HookConfig
+		r    *HookConfig
+	}{
+		{
+			"command",
+			&HookConfig{
+				Stage:   String(HookStagePrePlan),
+				Command: []string{"echo"},
+			},
+			&HookConfig{
+				Name:    String("pre-plan.command"),
+				Stage:   String(HookStagePrePlan),
+				Command: []string{"echo"},
+				Timeout: TimeDuration(DefaultHookTimeout),
+			},
+		},
+		{
+			"http",
+			&HookConfig{
+				Name:  String("webhook"),
+				Stage: String(HookStagePostApply),
+				HTTP:  &HTTPHookConfig{URL: String("https://example.com")},
+			},
+			&HookConfig{
+				Name:  String("webhook"),
+				Stage: String(HookStagePostApply),
+				HTTP: &HTTPHookConfig{
+					URL:     String("https://example.com"),
+					Method:  String("POST"),
+					Headers: map[string]string{},
+				},
+				Timeout: TimeDuration(DefaultHookTimeout),
+			},
+		},
+		{
+			"consul_kv",
+			&HookConfig{
+				Stage:    String(HookStageOnFailure),
+				ConsulKV: &ConsulKVHookConfig{Path: String("cts/failures")},
+				Timeout:  TimeDuration(time.Second),
+			},
+			&HookConfig{
+				Name:  String("on-failure.consul_kv"),
+				Stage: String(HookStageOnFailure),
+				ConsulKV: &ConsulKVHookConfig{
+					Path:      String("cts/failures"),
+					Namespace: String(""),
+				},
+				Timeout: TimeDuration(time.Second),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestHookConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *HookConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			false,
+		},
+		{
+			"valid_command",
+			&HookConfig{Stage: String(HookStagePrePlan), Command: []string{"echo"}},
+			true,
+		},
+		{
+			"valid_http",
+			&HookConfig{
+				Stage: String(HookStagePostPlan),
+				HTTP:  &HTTPHookConfig{URL: String("https://example.com")},
+			},
+			true,
+		},
+		{
+			"valid_consul_kv",
+			&HookConfig{
+				Stage:    String(HookStagePostApply),
+				ConsulKV: &ConsulKVHookConfig{Path: String("cts/key")},
+			},
+			true,
+		},
+		{
+			"invalid_stage",
+			&HookConfig{Stage: String("pre-apply"), Command: []string{"echo"}},
+			false,
+		},
+		{
+			"missing_type",
+			&HookConfig{Stage: String(HookStagePrePlan)},
+			false,
+		},
+		{
+			"multiple_types",
+			&HookConfig{
+				Stage:   String(HookStagePrePlan),
+				Command: []string{"echo"},
+				HTTP:    &HTTPHookConfig{URL: String("https://example.com")},
+			},
+			false,
+		},
+		{
+			"http_missing_url",
+			&HookConfig{Stage: String(HookStagePostPlan), HTTP: &HTTPHookConfig{}},
+			false,
+		},
+		{
+			"consul_kv_missing_path",
+			&HookConfig{Stage: String(HookStagePostPlan), ConsulKV: &ConsulKVHookConfig{}},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestHookConfig_GoString(t *testing.T) {
+	t.Parallel()
+
+	c := &HookConfig{
+		Name:  String("webhook"),
+		Stage: String(HookStagePostApply),
+		HTTP: &HTTPHookConfig{
+			URL:     String("https://example.com"),
+			Method:  String("POST"),
+			Headers: map[string]string{"Authorization": "Bearer secret"},
+		},
+		Timeout: TimeDuration(time.Second),
+	}
+
+	s := c.GoString()
+	assert.Contains(t, s, "https://example.com")
+	assert.NotContains(t, s, "secret")
+}
+
+func TestHookConfigs_Decode(t *testing.T) {
+	t.Parallel()
+
+	content := []byte(`
+task {
+  name = "task"
+  module = "path"
+  hook {
+    stage = "pre-plan"
+    command = ["./check.sh", "arg"]
+  }
+  hook {
+    name = "webhook"
+    stage = "post-apply"
+    timeout = "5s"
+    http {
+      url = "https://example.com/hook"
+      headers = {
+        Authorization = "Bearer token"
+      }
+    }
+  }
+  hook {
+    stage = "on-failure"
+    consul_kv {
+      path = "cts/failures/task"
+    }
+  }
+}`)
+
+	c, err := decodeConfig(content, "config.hcl")
+	require.NoError(t, err)
+	require.Equal(t, 1, c.Tasks.Len())
+
+	expected := &HookConfigs{
+		{
+			Stage:   String(HookStagePrePlan),
+			Command: []string{"./check.sh", "arg"},
+		},
+		{
+			Name:    String("webhook"),
+			Stage:   String(HookStagePostApply),
+			Timeout: TimeDuration(5 * time.Second),
+			HTTP: &HTTPHookConfig{
+				URL:     String("https://example.com/hook"),
+				Headers: map[string]string{"Authorization": "Bearer token"},
+			},
+		},
+		{
+			Stage:    String(HookStageOnFailure),
+			ConsulKV: &ConsulKVHookConfig{Path: String("cts/failures/task")},
+		},
+	}
+	assert.Equal(t, expected, (*c.Tasks)[0].Hooks)
+}
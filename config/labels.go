@@ -0,0 +1,107 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// labelKeyRegexp matches the valid keys of task labels
+var labelKeyRegexp = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9_./-]*[a-zA-Z0-9])?$`)
+
+// validateLabels validates the keys and values of task labels. Keys must
+// start and end with a letter or digit and may contain only letters, digits,
+// underscores, dots, dashes, and slashes. Values cannot contain the ',' and
+// '=' characters that separate the requirements of a label selector.
+func validateLabels(labels map[string]string) error {
+	for k, v := range labels {
+		if !labelKeyRegexp.MatchString(k) {
+			return fmt.Errorf("label key %q must start and end with a letter "+
+				"or digit and may contain only letters, digits, underscores, "+
+				"dots, dashes, and slashes", k)
+		}
+		if strings.ContainsAny(v, ",=") {
+			return fmt.Errorf("value of label %q cannot contain ',' or '='", k)
+		}
+	}
+	return nil
+}
+
+// LabelSelector selects tasks by their labels. A task is selected if its
+// labels meet all requirements of the selector.
+type LabelSelector []LabelRequirement
+
+// LabelRequirement requires that a task has the label with the value, or
+// that a task does not have the label with the value if it is negated.
+type LabelRequirement struct {
+	Key     string
+	Value   string
+	Negated bool
+}
+
+// ParseLabelSelector parses a comma-separated list of label requirements of
+// the form "key=value" or "key!=value", e.g. "team=payments,env!=dev". Values
+// can contain any character that is valid in the value of a task label.
+func ParseLabelSelector(s string) (LabelSelector, error) {
+	var selector LabelSelector
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		// the first '=' is the operator, which is negated if it follows a
+		// '!'. Keys cannot contain '!', so values may contain it.
+		var req LabelRequirement
+		kv := strings.SplitN(part, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid label selector requirement %q, "+
+				"expected 'key=value' or 'key!=value'", part)
+		}
+		key := strings.TrimSpace(kv[0])
+		if strings.HasSuffix(key, "!") {
+			key = strings.TrimSpace(strings.TrimSuffix(key, "!"))
+			req.Negated = true
+		}
+		req.Key = key
+		req.Value = strings.TrimSpace(kv[1])
+		if !labelKeyRegexp.MatchString(req.Key) {
+			return nil, fmt.Errorf("invalid label key %q in label selector", req.Key)
+		}
+		if strings.Contains(req.Value, "=") {
+			return nil, fmt.Errorf("invalid value %q for label %q in label "+
+				"selector", req.Value, req.Key)
+		}
+		selector = append(selector, req)
+	}
+
+	if len(selector) == 0 {
+		return nil, fmt.Errorf("label selector requires at least one requirement")
+	}
+	return selector, nil
+}
+
+// Matches returns true if the labels meet all requirements of the selector
+func (s LabelSelector) Matches(labels map[string]string) bool {
+	for _, req := range s {
+		v, ok := labels[req.Key]
+		matches := ok && v == req.Value
+		if matches == req.Negated {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the selector in the form that it is parsed from
+func (s LabelSelector) String() string {
+	parts := make([]string, len(s))
+	for i, req := range s {
+		op := "="
+		if req.Negated {
+			op = "!="
+		}
+		parts[i] = req.Key + op + req.Value
+	}
+	return strings.Join(parts, ",")
+}
@@ -0,0 +1,160 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLabels(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		labels  map[string]string
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"valid",
+			map[string]string{"team": "payments", "example.com/provider": "fw-1"},
+			true,
+		},
+		{
+			"empty_value",
+			map[string]string{"team": ""},
+			true,
+		},
+		{
+			"empty_key",
+			map[string]string{"": "payments"},
+			false,
+		},
+		{
+			"invalid_key",
+			map[string]string{"team!": "payments"},
+			false,
+		},
+		{
+			"invalid_value",
+			map[string]string{"team": "a,b"},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			err := validateLabels(tc.labels)
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestParseLabelSelector(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		selector string
+		expected LabelSelector
+	}{
+		{
+			"equality",
+			"team=payments",
+			LabelSelector{{Key: "team", Value: "payments"}},
+		},
+		{
+			"multiple",
+			"team = payments, env!=dev",
+			LabelSelector{
+				{Key: "team", Value: "payments"},
+				{Key: "env", Value: "dev", Negated: true},
+			},
+		},
+		{
+			"empty_value",
+			"team=",
+			LabelSelector{{Key: "team", Value: ""}},
+		},
+		{
+			"value_with_exclamation",
+			"env=!prod, tier != !web",
+			LabelSelector{
+				{Key: "env", Value: "!prod"},
+				{Key: "tier", Value: "!web", Negated: true},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := ParseLabelSelector(tc.selector)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+
+	invalid := []string{"", ",", "team", "team!", "=payments", "!=payments",
+		"team=a=b", "team!=a!=b"}
+	for _, s := range invalid {
+		t.Run("invalid_"+s, func(t *testing.T) {
+			_, err := ParseLabelSelector(s)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLabelSelector_Matches(t *testing.T) {
+	t.Parallel()
+
+	selector, err := ParseLabelSelector("team=payments,env!=dev")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		labels   map[string]string
+		expected bool
+	}{
+		{
+			"matches",
+			map[string]string{"team": "payments", "env": "prod"},
+			true,
+		},
+		{
+			"negated_label_missing",
+			map[string]string{"team": "payments"},
+			true,
+		},
+		{
+			"negated_label_matches",
+			map[string]string{"team": "payments", "env": "dev"},
+			false,
+		},
+		{
+			"label_missing",
+			map[string]string{"env": "prod"},
+			false,
+		},
+		{
+			"nil",
+			nil,
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, selector.Matches(tc.labels))
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultModuleUpgradesInterval is the default interval between checks
+	// of the module registry for newer module versions
+	DefaultModuleUpgradesInterval = 24 * time.Hour
+)
+
+// ModuleUpgradesConfig is the configuration for tracking newer versions of
+// task modules that are sourced from a module registry. When enabled, the
+// registry (or the mirror configured by module_source_rewrites) is checked
+// periodically for versions that satisfy the task's version constraint and
+// available upgrades are reported in the task status API.
+type ModuleUpgradesConfig struct {
+	Enabled *bool `mapstructure:"enabled"`
+
+	// Interval is the duration between checks for newer module versions
+	Interval *time.Duration `mapstructure:"interval"`
+
+	// AutoUpgrade rolls out an available upgrade to a task. The upgraded
+	// module is first inspected and only applied if the plan succeeds,
+	// otherwise the task continues to use the installed version.
+	AutoUpgrade *bool `mapstructure:"auto_upgrade"`
+
+	// AllowDestroy allows auto-upgrades with plans that destroy or replace
+	// resources. By default these upgrades are stopped and the task continues
+	// to use the installed version.
+	AllowDestroy *bool `mapstructure:"allow_destroy"`
+}
+
+// DefaultModuleUpgradesConfig returns the default configuration struct.
+func DefaultModuleUpgradesConfig() *ModuleUpgradesConfig {
+	return &ModuleUpgradesConfig{
+		Enabled:      Bool(false),
+		Interval:     TimeDuration(DefaultModuleUpgradesInterval),
+		AutoUpgrade:  Bool(false),
+		AllowDestroy: Bool(false),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ModuleUpgradesConfig) Copy() *ModuleUpgradesConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ModuleUpgradesConfig
+	o.Enabled = BoolCopy(c.Enabled)
+	o.Interval = TimeDurationCopy(c.Interval)
+	o.AutoUpgrade = BoolCopy(c.AutoUpgrade)
+	o.AllowDestroy = BoolCopy(c.AllowDestroy)
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *ModuleUpgradesConfig) Merge(o *ModuleUpgradesConfig) *ModuleUpgradesConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Enabled != nil {
+		r.Enabled = BoolCopy(o.Enabled)
+	}
+
+	if o.Interval != nil {
+		r.Interval = TimeDurationCopy(o.Interval)
+	}
+
+	if o.AutoUpgrade != nil {
+		r.AutoUpgrade = BoolCopy(o.AutoUpgrade)
+	}
+
+	if o.AllowDestroy != nil {
+		r.AllowDestroy = BoolCopy(o.AllowDestroy)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *ModuleUpgradesConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Enabled == nil {
+		c.Enabled = Bool(false)
+	}
+
+	if c.Interval == nil {
+		c.Interval = TimeDuration(DefaultModuleUpgradesInterval)
+	}
+
+	if c.AutoUpgrade == nil {
+		c.AutoUpgrade = Bool(false)
+	}
+
+	if c.AllowDestroy == nil {
+		c.AllowDestroy = Bool(false)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *ModuleUpgradesConfig) Validate() error {
+	if c == nil || !BoolVal(c.Enabled) {
+		return nil
+	}
+
+	if c.Interval != nil && *c.Interval <= 0 {
+		return fmt.Errorf("module_upgrades interval must be greater than 0, "+
+			"got %s", *c.Interval)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *ModuleUpgradesConfig) GoString() string {
+	if c == nil {
+		return "(*ModuleUpgradesConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ModuleUpgradesConfig{"+
+		"Enabled:%t, "+
+		"Interval:%s, "+
+		"AutoUpgrade:%t, "+
+		"AllowDestroy:%t"+
+		"}",
+		BoolVal(c.Enabled),
+		TimeDurationVal(c.Interval),
+		BoolVal(c.AutoUpgrade),
+		BoolVal(c.AllowDestroy),
+	)
+}
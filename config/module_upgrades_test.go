@@ -0,0 +1,187 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModuleUpgradesConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ModuleUpgradesConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ModuleUpgradesConfig{},
+		},
+		{
+			"default",
+			DefaultModuleUpgradesConfig(),
+		},
+		{
+			"fully_configured",
+			&ModuleUpgradesConfig{
+				Enabled:      Bool(true),
+				Interval:     TimeDuration(time.Hour),
+				AutoUpgrade:  Bool(true),
+				AllowDestroy: Bool(true),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestModuleUpgradesConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ModuleUpgradesConfig
+		b    *ModuleUpgradesConfig
+		r    *ModuleUpgradesConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ModuleUpgradesConfig{},
+			&ModuleUpgradesConfig{},
+		},
+		{
+			"nil_b",
+			&ModuleUpgradesConfig{},
+			nil,
+			&ModuleUpgradesConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"enabled_overrides",
+			&ModuleUpgradesConfig{Enabled: Bool(true)},
+			&ModuleUpgradesConfig{Enabled: Bool(false)},
+			&ModuleUpgradesConfig{Enabled: Bool(false)},
+		},
+		{
+			"interval_overrides",
+			&ModuleUpgradesConfig{Interval: TimeDuration(time.Hour)},
+			&ModuleUpgradesConfig{Interval: TimeDuration(time.Minute)},
+			&ModuleUpgradesConfig{Interval: TimeDuration(time.Minute)},
+		},
+		{
+			"auto_upgrade_merges",
+			&ModuleUpgradesConfig{Enabled: Bool(true)},
+			&ModuleUpgradesConfig{AutoUpgrade: Bool(true)},
+			&ModuleUpgradesConfig{Enabled: Bool(true), AutoUpgrade: Bool(true)},
+		},
+		{
+			"allow_destroy_overrides",
+			&ModuleUpgradesConfig{AllowDestroy: Bool(true)},
+			&ModuleUpgradesConfig{AllowDestroy: Bool(false)},
+			&ModuleUpgradesConfig{AllowDestroy: Bool(false)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestModuleUpgradesConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *ModuleUpgradesConfig
+		r    *ModuleUpgradesConfig
+	}{
+		{
+			"empty",
+			&ModuleUpgradesConfig{},
+			DefaultModuleUpgradesConfig(),
+		},
+		{
+			"enabled",
+			&ModuleUpgradesConfig{Enabled: Bool(true)},
+			&ModuleUpgradesConfig{
+				Enabled:      Bool(true),
+				Interval:     TimeDuration(DefaultModuleUpgradesInterval),
+				AutoUpgrade:  Bool(false),
+				AllowDestroy: Bool(false),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestModuleUpgradesConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *ModuleUpgradesConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"disabled",
+			&ModuleUpgradesConfig{Interval: TimeDuration(-time.Hour)},
+			true,
+		},
+		{
+			"enabled",
+			&ModuleUpgradesConfig{Enabled: Bool(true), AutoUpgrade: Bool(true)},
+			true,
+		},
+		{
+			"zero_interval",
+			&ModuleUpgradesConfig{
+				Enabled:  Bool(true),
+				Interval: TimeDuration(0),
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,269 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// PolicyEnforcementAdvisory logs and records policy violations without
+	// blocking the apply.
+	PolicyEnforcementAdvisory = "advisory"
+
+	// PolicyEnforcementMandatory blocks the apply when the policy has one or
+	// more violations.
+	PolicyEnforcementMandatory = "mandatory"
+
+	// DefaultPolicyQuery is the Rego query evaluated against a Terraform plan.
+	// The result is expected to be a set or array of violation messages.
+	DefaultPolicyQuery = "data.terraform.deny"
+
+	// DefaultPolicyEnforcementLevel is the enforcement level used when one is
+	// not configured.
+	DefaultPolicyEnforcementLevel = PolicyEnforcementMandatory
+)
+
+// PolicyConfig is the configuration for a policy check that is evaluated
+// against the Terraform plan of every task before the plan is applied. This
+// block may be specified multiple times to configure multiple policies.
+type PolicyConfig struct {
+	// Name is the unique name of the policy (required).
+	Name *string `mapstructure:"name"`
+
+	// Path is the path to a Rego policy file or a directory containing Rego
+	// policy files (required).
+	Path *string `mapstructure:"path"`
+
+	// Query is the Rego query to evaluate. The query must return the deny
+	// results of the policy as a set, array, or object of violation messages.
+	// Queries that are undefined or return a boolean fail the policy check.
+	Query *string `mapstructure:"query"`
+
+	// EnforcementLevel determines whether a violation of the policy blocks the
+	// apply ("mandatory") or is only recorded ("advisory").
+	EnforcementLevel *string `mapstructure:"enforcement_level"`
+}
+
+// PolicyConfigs is a collection of PolicyConfig
+type PolicyConfigs []*PolicyConfig
+
+// Copy returns a deep copy of this configuration.
+func (c *PolicyConfig) Copy() *PolicyConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o PolicyConfig
+	o.Name = StringCopy(c.Name)
+	o.Path = StringCopy(c.Path)
+	o.Query = StringCopy(c.Query)
+	o.EnforcementLevel = StringCopy(c.EnforcementLevel)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *PolicyConfig) Merge(o *PolicyConfig) *PolicyConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Name != nil {
+		r.Name = StringCopy(o.Name)
+	}
+
+	if o.Path != nil {
+		r.Path = StringCopy(o.Path)
+	}
+
+	if o.Query != nil {
+		r.Query = StringCopy(o.Query)
+	}
+
+	if o.EnforcementLevel != nil {
+		r.EnforcementLevel = StringCopy(o.EnforcementLevel)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *PolicyConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Name == nil {
+		c.Name = String("")
+	}
+
+	if c.Path == nil {
+		c.Path = String("")
+	}
+
+	if c.Query == nil || *c.Query == "" {
+		c.Query = String(DefaultPolicyQuery)
+	}
+
+	if c.EnforcementLevel == nil || *c.EnforcementLevel == "" {
+		c.EnforcementLevel = String(DefaultPolicyEnforcementLevel)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *PolicyConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("missing policy configuration")
+	}
+
+	if c.Name == nil || len(*c.Name) == 0 {
+		return fmt.Errorf("name for the policy is required")
+	}
+
+	if c.Path == nil || len(*c.Path) == 0 {
+		return fmt.Errorf("path for policy '%s' is required", *c.Name)
+	}
+
+	if c.EnforcementLevel != nil {
+		switch *c.EnforcementLevel {
+		case PolicyEnforcementAdvisory, PolicyEnforcementMandatory:
+		default:
+			return fmt.Errorf("unsupported enforcement_level '%s' for policy "+
+				"'%s'. Supported levels: %s, %s", *c.EnforcementLevel, *c.Name,
+				PolicyEnforcementAdvisory, PolicyEnforcementMandatory)
+		}
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *PolicyConfig) GoString() string {
+	if c == nil {
+		return "(*PolicyConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&PolicyConfig{"+
+		"Name:%s, "+
+		"Path:%s, "+
+		"Query:%s, "+
+		"EnforcementLevel:%s"+
+		"}",
+		StringVal(c.Name),
+		StringVal(c.Path),
+		StringVal(c.Query),
+		StringVal(c.EnforcementLevel),
+	)
+}
+
+// DefaultPolicyConfigs returns a configuration that is populated with the
+// default values.
+func DefaultPolicyConfigs() *PolicyConfigs {
+	return &PolicyConfigs{}
+}
+
+// Len is a helper method to get the length of the underlying config list
+func (c *PolicyConfigs) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	return len(*c)
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *PolicyConfigs) Copy() *PolicyConfigs {
+	if c == nil {
+		return nil
+	}
+
+	o := make(PolicyConfigs, c.Len())
+	for i, p := range *c {
+		o[i] = p.Copy()
+	}
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *PolicyConfigs) Merge(o *PolicyConfigs) *PolicyConfigs {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	*r = append(*r, *o...)
+
+	return r
+}
+
+// Finalize ensures the configuration has no nil pointers and sets default
+// values.
+func (c *PolicyConfigs) Finalize() {
+	if c == nil {
+		*c = *DefaultPolicyConfigs()
+	}
+
+	for _, p := range *c {
+		p.Finalize()
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *PolicyConfigs) Validate() error {
+	if c == nil {
+		// Policies are optional
+		return nil
+	}
+
+	unique := make(map[string]bool)
+	for _, p := range *c {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+
+		name := *p.Name
+		if unique[name] {
+			return fmt.Errorf("duplicate policy name: %s", name)
+		}
+		unique[name] = true
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *PolicyConfigs) GoString() string {
+	if c == nil {
+		return "(*PolicyConfigs)(nil)"
+	}
+
+	s := make([]string, len(*c))
+	for i, p := range *c {
+		s[i] = p.GoString()
+	}
+
+	return "{" + strings.Join(s, ", ") + "}"
+}
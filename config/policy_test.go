@@ -0,0 +1,229 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &PolicyConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *PolicyConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&PolicyConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&PolicyConfig{
+				Name:             String("name"),
+				Path:             String("path"),
+				Query:            String("data.example.deny"),
+				EnforcementLevel: String(PolicyEnforcementAdvisory),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestPolicyConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *PolicyConfig
+		b    *PolicyConfig
+		r    *PolicyConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&PolicyConfig{},
+			&PolicyConfig{},
+		},
+		{
+			"nil_b",
+			&PolicyConfig{},
+			nil,
+			&PolicyConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&PolicyConfig{},
+			&PolicyConfig{},
+			&PolicyConfig{},
+		},
+		{
+			"path_overrides",
+			&PolicyConfig{Path: String("a")},
+			&PolicyConfig{Path: String("b")},
+			&PolicyConfig{Path: String("b")},
+		},
+		{
+			"query_empty_one",
+			&PolicyConfig{Query: String("data.a.deny")},
+			&PolicyConfig{},
+			&PolicyConfig{Query: String("data.a.deny")},
+		},
+		{
+			"enforcement_level_empty_two",
+			&PolicyConfig{},
+			&PolicyConfig{EnforcementLevel: String(PolicyEnforcementAdvisory)},
+			&PolicyConfig{EnforcementLevel: String(PolicyEnforcementAdvisory)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestPolicyConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *PolicyConfig
+		r    *PolicyConfig
+	}{
+		{
+			"empty",
+			&PolicyConfig{},
+			&PolicyConfig{
+				Name:             String(""),
+				Path:             String(""),
+				Query:            String(DefaultPolicyQuery),
+				EnforcementLevel: String(DefaultPolicyEnforcementLevel),
+			},
+		},
+		{
+			"configured",
+			&PolicyConfig{
+				Name:             String("name"),
+				Path:             String("path"),
+				Query:            String("data.example.deny"),
+				EnforcementLevel: String(PolicyEnforcementAdvisory),
+			},
+			&PolicyConfig{
+				Name:             String("name"),
+				Path:             String("path"),
+				Query:            String("data.example.deny"),
+				EnforcementLevel: String(PolicyEnforcementAdvisory),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestPolicyConfigs_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *PolicyConfigs
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"empty",
+			&PolicyConfigs{},
+			true,
+		},
+		{
+			"valid",
+			&PolicyConfigs{
+				{
+					Name:             String("a"),
+					Path:             String("policies/a.rego"),
+					EnforcementLevel: String(PolicyEnforcementMandatory),
+				},
+				{
+					Name: String("b"),
+					Path: String("policies/b"),
+				},
+			},
+			true,
+		},
+		{
+			"missing_name",
+			&PolicyConfigs{{Path: String("policies")}},
+			false,
+		},
+		{
+			"missing_path",
+			&PolicyConfigs{{Name: String("a")}},
+			false,
+		},
+		{
+			"invalid_enforcement_level",
+			&PolicyConfigs{
+				{
+					Name:             String("a"),
+					Path:             String("policies"),
+					EnforcementLevel: String("soft"),
+				},
+			},
+			false,
+		},
+		{
+			"duplicate_names",
+			&PolicyConfigs{
+				{Name: String("a"), Path: String("policies/a")},
+				{Name: String("a"), Path: String("policies/b")},
+			},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// ProviderInstallationConfig configures the methods Terraform uses to install
+// the providers of tasks. When a mirror is configured, providers are installed
+// only from the mirrors unless direct installation is also enabled, which
+// allows tasks to run on hosts without access to the public registry.
+type ProviderInstallationConfig struct {
+	// FilesystemMirror is a local directory that mirrors providers in the
+	// layout of `terraform providers mirror`
+	FilesystemMirror *string `mapstructure:"filesystem_mirror"`
+
+	// NetworkMirror is the HTTPS URL of a provider network mirror
+	NetworkMirror *string `mapstructure:"network_mirror"`
+
+	// Direct allows installing providers from their origin registries in
+	// addition to the mirrors
+	Direct *bool `mapstructure:"direct"`
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *ProviderInstallationConfig) Copy() *ProviderInstallationConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o ProviderInstallationConfig
+	o.FilesystemMirror = StringCopy(c.FilesystemMirror)
+	o.NetworkMirror = StringCopy(c.NetworkMirror)
+	o.Direct = BoolCopy(c.Direct)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+func (c *ProviderInstallationConfig) Merge(o *ProviderInstallationConfig) *ProviderInstallationConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.FilesystemMirror != nil {
+		r.FilesystemMirror = StringCopy(o.FilesystemMirror)
+	}
+
+	if o.NetworkMirror != nil {
+		r.NetworkMirror = StringCopy(o.NetworkMirror)
+	}
+
+	if o.Direct != nil {
+		r.Direct = BoolCopy(o.Direct)
+	}
+
+	return r
+}
+
+// Finalize ensures that the receiver contains no nil pointers.
+func (c *ProviderInstallationConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.FilesystemMirror == nil {
+		c.FilesystemMirror = String("")
+	}
+
+	if c.NetworkMirror == nil {
+		c.NetworkMirror = String("")
+	}
+
+	if c.Direct == nil {
+		c.Direct = Bool(false)
+	}
+}
+
+// Validate validates the values and required options. This method is recommended
+// to run after Finalize() to ensure the configuration is safe to proceed.
+func (c *ProviderInstallationConfig) Validate() error {
+	if c == nil { // config not required, return early
+		return nil
+	}
+
+	if mirror := StringVal(c.NetworkMirror); mirror != "" {
+		u, err := url.Parse(mirror)
+		if err != nil {
+			return fmt.Errorf("error with provider network_mirror: %s", err)
+		}
+		// Terraform only supports network mirrors over HTTPS
+		if u.Scheme != "https" || u.Host == "" {
+			return fmt.Errorf("provider network_mirror must be an https URL: %s", mirror)
+		}
+	}
+
+	return nil
+}
+
+// IsMirrored returns whether providers are installed from a mirror
+func (c *ProviderInstallationConfig) IsMirrored() bool {
+	if c == nil {
+		return false
+	}
+	return StringVal(c.FilesystemMirror) != "" || StringVal(c.NetworkMirror) != ""
+}
+
+// GoString defines the printable version of this struct.
+func (c *ProviderInstallationConfig) GoString() string {
+	if c == nil {
+		return "(*ProviderInstallationConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&ProviderInstallationConfig{"+
+		"FilesystemMirror:%s, "+
+		"NetworkMirror:%s, "+
+		"Direct:%v"+
+		"}",
+		StringVal(c.FilesystemMirror),
+		StringVal(c.NetworkMirror),
+		BoolVal(c.Direct),
+	)
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProviderInstallationConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	finalizedConf := &ProviderInstallationConfig{}
+	finalizedConf.Finalize()
+
+	cases := []struct {
+		name string
+		a    *ProviderInstallationConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&ProviderInstallationConfig{},
+		},
+		{
+			"finalized",
+			finalizedConf,
+		},
+		{
+			"fully_configured",
+			&ProviderInstallationConfig{
+				FilesystemMirror: String("/opt/providers"),
+				NetworkMirror:    String("https://mirror.example.com/providers/"),
+				Direct:           Bool(true),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestProviderInstallationConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *ProviderInstallationConfig
+		b    *ProviderInstallationConfig
+		r    *ProviderInstallationConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&ProviderInstallationConfig{},
+			&ProviderInstallationConfig{},
+		},
+		{
+			"nil_b",
+			&ProviderInstallationConfig{},
+			nil,
+			&ProviderInstallationConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"empty",
+			&ProviderInstallationConfig{},
+			&ProviderInstallationConfig{},
+			&ProviderInstallationConfig{},
+		},
+		{
+			"filesystem_mirror_overrides",
+			&ProviderInstallationConfig{FilesystemMirror: String("/opt/a")},
+			&ProviderInstallationConfig{FilesystemMirror: String("/opt/b")},
+			&ProviderInstallationConfig{FilesystemMirror: String("/opt/b")},
+		},
+		{
+			"network_mirror_empty_one",
+			&ProviderInstallationConfig{NetworkMirror: String("https://a")},
+			&ProviderInstallationConfig{},
+			&ProviderInstallationConfig{NetworkMirror: String("https://a")},
+		},
+		{
+			"direct_overrides",
+			&ProviderInstallationConfig{Direct: Bool(true)},
+			&ProviderInstallationConfig{Direct: Bool(false)},
+			&ProviderInstallationConfig{Direct: Bool(false)},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestProviderInstallationConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	c := &ProviderInstallationConfig{}
+	c.Finalize()
+	assert.Equal(t, &ProviderInstallationConfig{
+		FilesystemMirror: String(""),
+		NetworkMirror:    String(""),
+		Direct:           Bool(false),
+	}, c)
+	assert.False(t, c.IsMirrored())
+}
+
+func TestProviderInstallationConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *ProviderInstallationConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"empty",
+			&ProviderInstallationConfig{},
+			true,
+		},
+		{
+			"valid",
+			&ProviderInstallationConfig{
+				FilesystemMirror: String("/opt/providers"),
+				NetworkMirror:    String("https://mirror.example.com/providers/"),
+			},
+			true,
+		},
+		{
+			"network mirror http",
+			&ProviderInstallationConfig{
+				NetworkMirror: String("http://mirror.example.com/providers/"),
+			},
+			false,
+		},
+		{
+			"network mirror without host",
+			&ProviderInstallationConfig{
+				NetworkMirror: String("/opt/providers"),
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
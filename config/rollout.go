@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultRolloutCanarySize is the default number of tasks that a
+	// configuration change is rolled out to first
+	DefaultRolloutCanarySize = 1
+
+	// DefaultRolloutBatchSize is the default number of tasks that a
+	// configuration change is rolled out to at a time after the canary tasks
+	DefaultRolloutBatchSize = 5
+
+	// DefaultRolloutSoakTime is the default duration to wait after a batch of
+	// tasks is updated before the next batch is started
+	DefaultRolloutSoakTime = 5 * time.Minute
+)
+
+// RolloutConfig is the configuration for rolling out changes to tasks from a
+// configuration reload in batches. When enabled, the tasks changed by a reload
+// are updated in a canary batch first and then in batches of batch_size. The
+// next batch starts once every task of the batch was updated successfully and
+// the tasks had no failed runs during the soak time. The rollout halts on
+// failure and the remaining tasks keep their previous configuration.
+type RolloutConfig struct {
+	Enabled *bool `mapstructure:"enabled"`
+
+	// CanarySize is the number of tasks in the first batch
+	CanarySize *int `mapstructure:"canary_size"`
+
+	// BatchSize is the number of tasks in each batch after the canary batch
+	BatchSize *int `mapstructure:"batch_size"`
+
+	// SoakTime is the duration to wait after a batch is updated before
+	// checking the tasks and starting the next batch
+	SoakTime *time.Duration `mapstructure:"soak_time"`
+}
+
+// DefaultRolloutConfig returns the default configuration struct.
+func DefaultRolloutConfig() *RolloutConfig {
+	return &RolloutConfig{
+		Enabled:    Bool(false),
+		CanarySize: Int(DefaultRolloutCanarySize),
+		BatchSize:  Int(DefaultRolloutBatchSize),
+		SoakTime:   TimeDuration(DefaultRolloutSoakTime),
+	}
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *RolloutConfig) Copy() *RolloutConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o RolloutConfig
+	o.Enabled = BoolCopy(c.Enabled)
+	o.CanarySize = IntCopy(c.CanarySize)
+	o.BatchSize = IntCopy(c.BatchSize)
+	o.SoakTime = TimeDurationCopy(c.SoakTime)
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *RolloutConfig) Merge(o *RolloutConfig) *RolloutConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Enabled != nil {
+		r.Enabled = BoolCopy(o.Enabled)
+	}
+
+	if o.CanarySize != nil {
+		r.CanarySize = IntCopy(o.CanarySize)
+	}
+
+	if o.BatchSize != nil {
+		r.BatchSize = IntCopy(o.BatchSize)
+	}
+
+	if o.SoakTime != nil {
+		r.SoakTime = TimeDurationCopy(o.SoakTime)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *RolloutConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Enabled == nil {
+		c.Enabled = Bool(false)
+	}
+
+	if c.CanarySize == nil {
+		c.CanarySize = Int(DefaultRolloutCanarySize)
+	}
+
+	if c.BatchSize == nil {
+		c.BatchSize = Int(DefaultRolloutBatchSize)
+	}
+
+	if c.SoakTime == nil {
+		c.SoakTime = TimeDuration(DefaultRolloutSoakTime)
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *RolloutConfig) Validate() error {
+	if c == nil || !BoolVal(c.Enabled) {
+		return nil
+	}
+
+	if c.CanarySize != nil && *c.CanarySize < 1 {
+		return fmt.Errorf("rollout canary_size must be at least 1, got %d",
+			*c.CanarySize)
+	}
+
+	if c.BatchSize != nil && *c.BatchSize < 1 {
+		return fmt.Errorf("rollout batch_size must be at least 1, got %d",
+			*c.BatchSize)
+	}
+
+	if c.SoakTime != nil && *c.SoakTime < 0 {
+		return fmt.Errorf("rollout soak_time cannot be negative, got %s",
+			*c.SoakTime)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *RolloutConfig) GoString() string {
+	if c == nil {
+		return "(*RolloutConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&RolloutConfig{"+
+		"Enabled:%t, "+
+		"CanarySize:%d, "+
+		"BatchSize:%d, "+
+		"SoakTime:%s"+
+		"}",
+		BoolVal(c.Enabled),
+		IntVal(c.CanarySize),
+		IntVal(c.BatchSize),
+		TimeDurationVal(c.SoakTime),
+	)
+}
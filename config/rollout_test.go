@@ -0,0 +1,193 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRolloutConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *RolloutConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&RolloutConfig{},
+		},
+		{
+			"default",
+			DefaultRolloutConfig(),
+		},
+		{
+			"fully_configured",
+			&RolloutConfig{
+				Enabled:    Bool(true),
+				CanarySize: Int(2),
+				BatchSize:  Int(10),
+				SoakTime:   TimeDuration(time.Minute),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestRolloutConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *RolloutConfig
+		b    *RolloutConfig
+		r    *RolloutConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&RolloutConfig{},
+			&RolloutConfig{},
+		},
+		{
+			"nil_b",
+			&RolloutConfig{},
+			nil,
+			&RolloutConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"enabled_overrides",
+			&RolloutConfig{Enabled: Bool(true)},
+			&RolloutConfig{Enabled: Bool(false)},
+			&RolloutConfig{Enabled: Bool(false)},
+		},
+		{
+			"sizes_merge",
+			&RolloutConfig{CanarySize: Int(2), BatchSize: Int(10)},
+			&RolloutConfig{BatchSize: Int(3)},
+			&RolloutConfig{CanarySize: Int(2), BatchSize: Int(3)},
+		},
+		{
+			"soak_time_overrides",
+			&RolloutConfig{SoakTime: TimeDuration(time.Minute)},
+			&RolloutConfig{SoakTime: TimeDuration(time.Hour)},
+			&RolloutConfig{SoakTime: TimeDuration(time.Hour)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestRolloutConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		i    *RolloutConfig
+		r    *RolloutConfig
+	}{
+		{
+			"empty",
+			&RolloutConfig{},
+			DefaultRolloutConfig(),
+		},
+		{
+			"enabled",
+			&RolloutConfig{Enabled: Bool(true), BatchSize: Int(2)},
+			&RolloutConfig{
+				Enabled:    Bool(true),
+				CanarySize: Int(DefaultRolloutCanarySize),
+				BatchSize:  Int(2),
+				SoakTime:   TimeDuration(DefaultRolloutSoakTime),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			assert.Equal(t, tc.r, tc.i)
+		})
+	}
+}
+
+func TestRolloutConfig_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *RolloutConfig
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"disabled",
+			&RolloutConfig{CanarySize: Int(0)},
+			true,
+		},
+		{
+			"enabled",
+			&RolloutConfig{Enabled: Bool(true)},
+			true,
+		},
+		{
+			"no_soak_time",
+			&RolloutConfig{Enabled: Bool(true), SoakTime: TimeDuration(0)},
+			true,
+		},
+		{
+			"invalid_canary_size",
+			&RolloutConfig{Enabled: Bool(true), CanarySize: Int(0)},
+			false,
+		},
+		{
+			"invalid_batch_size",
+			&RolloutConfig{Enabled: Bool(true), BatchSize: Int(-1)},
+			false,
+		},
+		{
+			"negative_soak_time",
+			&RolloutConfig{Enabled: Bool(true), SoakTime: TimeDuration(-time.Minute)},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			tc.i.Finalize()
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
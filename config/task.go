@@ -5,6 +5,7 @@ import (
 	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strings"
 	"time"
 
@@ -28,6 +29,15 @@ type TaskConfig struct {
 	// Name is the unique name of the task.
 	Name *string `mapstructure:"name" json:"name"`
 
+	// Template is the name of the task template that the task instantiates.
+	// The task inherits the options of the template that are not configured
+	// for the task.
+	Template *string `mapstructure:"template" json:"template"`
+
+	// Labels are key-value pairs to organize tasks, which select the tasks
+	// to change together with the bulk task API.
+	Labels map[string]string `mapstructure:"labels" json:"labels"`
+
 	// Providers is the list of provider names the task is dependent on. This is
 	// used to map provider configuration to the task.
 	Providers []string `mapstructure:"providers" json:"providers"`
@@ -94,6 +104,19 @@ type TaskConfig struct {
 	// will create a child directory with the task name in the global working
 	// directory.
 	WorkingDir *string `mapstructure:"working_dir" json:"working_dir"`
+
+	// Hooks configures actions to run at stages of the task run, such as
+	// running a command or calling an HTTP endpoint after changes are applied.
+	Hooks *HookConfigs `mapstructure:"hook" json:"hook"`
+
+	// Timeout is the maximum duration of a task execution. An execution that
+	// exceeds the timeout is cancelled. A timeout of 0 disables the timeout.
+	Timeout *time.Duration `mapstructure:"timeout" json:"timeout"`
+
+	// Backend overrides the backend of the Terraform driver to store the
+	// state of the task in a separate backend. The task uses the driver's
+	// backend when not configured.
+	Backend map[string]interface{} `mapstructure:"backend" json:"backend"`
 }
 
 // TaskConfigs is a collection of TaskConfig
@@ -108,6 +131,14 @@ func (c *TaskConfig) Copy() *TaskConfig {
 	var o TaskConfig
 	o.Description = StringCopy(c.Description)
 	o.Name = StringCopy(c.Name)
+	o.Template = StringCopy(c.Template)
+
+	if c.Labels != nil {
+		o.Labels = make(map[string]string, len(c.Labels))
+		for k, v := range c.Labels {
+			o.Labels[k] = v
+		}
+	}
 
 	if c.Providers != nil {
 		o.Providers = make([]string, 0, len(c.Providers))
@@ -157,6 +188,17 @@ func (c *TaskConfig) Copy() *TaskConfig {
 		o.WorkingDir = StringCopy(c.WorkingDir)
 	}
 
+	o.Hooks = c.Hooks.Copy()
+
+	o.Timeout = TimeDurationCopy(c.Timeout)
+
+	if c.Backend != nil {
+		o.Backend = make(map[string]interface{})
+		for k, v := range c.Backend {
+			o.Backend[k] = v
+		}
+	}
+
 	return &o
 }
 
@@ -186,6 +228,19 @@ func (c *TaskConfig) Merge(o *TaskConfig) *TaskConfig {
 		r.Name = StringCopy(o.Name)
 	}
 
+	if o.Template != nil {
+		r.Template = StringCopy(o.Template)
+	}
+
+	if o.Labels != nil {
+		if r.Labels == nil {
+			r.Labels = make(map[string]string, len(o.Labels))
+		}
+		for k, v := range o.Labels {
+			r.Labels[k] = v
+		}
+	}
+
 	r.Providers = mergeSlices(r.Providers, o.Providers)
 
 	r.DeprecatedServices = mergeSlices(r.DeprecatedServices, o.DeprecatedServices)
@@ -242,6 +297,23 @@ func (c *TaskConfig) Merge(o *TaskConfig) *TaskConfig {
 		r.WorkingDir = StringCopy(o.WorkingDir)
 	}
 
+	if o.Hooks != nil {
+		r.Hooks = r.Hooks.Merge(o.Hooks)
+	}
+
+	if o.Timeout != nil {
+		r.Timeout = TimeDurationCopy(o.Timeout)
+	}
+
+	// The backend is replaced rather than merged since the state of a task is
+	// stored in only one backend
+	if o.Backend != nil {
+		r.Backend = make(map[string]interface{})
+		for k, v := range o.Backend {
+			r.Backend[k] = v
+		}
+	}
+
 	return r
 }
 
@@ -329,6 +401,15 @@ func (c *TaskConfig) Finalize() error {
 	}
 	c.ModuleInputs.Finalize()
 
+	if c.Hooks == nil {
+		c.Hooks = DefaultHookConfigs()
+	}
+	c.Hooks.Finalize()
+
+	if c.Timeout == nil {
+		c.Timeout = TimeDuration(0)
+	}
+
 	// Scheduled conditions should never have buffer periods configured, since they are
 	// triggered through a different flow.
 	_, isScheduleCondition := c.Condition.(*ScheduleConditionConfig)
@@ -420,6 +501,10 @@ func (c *TaskConfig) Validate() error {
 		return err
 	}
 
+	if err := validateLabels(c.Labels); err != nil {
+		return fmt.Errorf("invalid labels for task %q: %s", *c.Name, err)
+	}
+
 	if c.Module == nil || len(*c.Module) == 0 {
 		return fmt.Errorf("module for the task is required")
 	}
@@ -457,6 +542,21 @@ func (c *TaskConfig) Validate() error {
 		return err
 	}
 
+	if err := c.Hooks.Validate(); err != nil {
+		return fmt.Errorf("invalid hook for task %q: %s", *c.Name, err)
+	}
+
+	if c.Timeout != nil && *c.Timeout < 0 {
+		return fmt.Errorf("timeout for task %q cannot be negative", *c.Name)
+	}
+
+	if len(c.Backend) > 1 {
+		return fmt.Errorf("only one backend can be configured for task %q", *c.Name)
+	}
+	if err := validateBackend(c.Backend); err != nil {
+		return fmt.Errorf("invalid backend for task %q: %s", *c.Name, err)
+	}
+
 	return nil
 }
 
@@ -489,6 +589,8 @@ func (c *TaskConfig) GoString() string {
 
 	return fmt.Sprintf("&TaskConfig{"+
 		"Name:%s, "+
+		"Template:%s, "+
+		"Labels:%s, "+
 		"Description:%s, "+
 		"Providers:%s, "+
 		"Services (deprecated):%s, "+
@@ -499,9 +601,14 @@ func (c *TaskConfig) GoString() string {
 		"BufferPeriod:%s, "+
 		"Enabled:%t, "+
 		"Condition:%s, "+
-		"ModuleInput:%s"+
+		"ModuleInput:%s, "+
+		"Hooks:%s, "+
+		"Timeout:%s, "+
+		"Backend:%s"+
 		"}",
 		StringVal(c.Name),
+		StringVal(c.Template),
+		c.Labels,
 		StringVal(c.Description),
 		c.Providers,
 		c.DeprecatedServices,
@@ -513,9 +620,23 @@ func (c *TaskConfig) GoString() string {
 		BoolVal(c.Enabled),
 		c.Condition.GoString(),
 		c.ModuleInputs.GoString(),
+		c.Hooks.GoString(),
+		TimeDurationVal(c.Timeout),
+		backendLabels(c.Backend),
 	)
 }
 
+// backendLabels returns the labels of the backend, redacting the backend
+// configuration which may contain credentials
+func backendLabels(backend map[string]interface{}) []string {
+	labels := make([]string, 0, len(backend))
+	for k := range backend {
+		labels = append(labels, k)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
 // DefaultTaskConfigs returns a configuration that is populated with the
 // default values.
 func DefaultTaskConfigs() *TaskConfigs {
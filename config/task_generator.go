@@ -0,0 +1,354 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTaskGeneratorModuleMetaKey is the default service meta key of
+	// the module for the generated task
+	DefaultTaskGeneratorModuleMetaKey = "cts-module"
+
+	// DefaultTaskGeneratorProvidersMetaKey is the default service meta key of
+	// the comma-separated providers for the generated task
+	DefaultTaskGeneratorProvidersMetaKey = "cts-providers"
+
+	// DefaultTaskGeneratorInterval is the default interval between checks of
+	// the Consul catalog for services to generate tasks for
+	DefaultTaskGeneratorInterval = 30 * time.Second
+)
+
+// TaskGeneratorConfig is the configuration for a task generator. A task
+// generator checks the Consul catalog for services that are registered with
+// the module meta key and creates a task for each of the services, which
+// runs the module from the service meta when the service changes. Generated
+// tasks are updated when the meta of their service changes and deleted when
+// the service is deregistered or no longer has the meta. Only the modules and
+// providers of the allow lists can be requested through service meta. This
+// block may be specified multiple times to configure multiple generators.
+type TaskGeneratorConfig struct {
+	// Name is the unique name of the task generator (required). Generated
+	// tasks are named "<name>-<service>".
+	Name *string `mapstructure:"name" json:"name"`
+
+	// Template is the name of the task template that the generated tasks
+	// instantiate.
+	Template *string `mapstructure:"template" json:"template"`
+
+	// ModuleMetaKey is the service meta key of the module for the task.
+	// Services without the meta key are ignored.
+	ModuleMetaKey *string `mapstructure:"module_meta_key" json:"module_meta_key"`
+
+	// ProvidersMetaKey is the service meta key of the comma-separated provider
+	// names for the task.
+	ProvidersMetaKey *string `mapstructure:"providers_meta_key" json:"providers_meta_key"`
+
+	// AllowedModules is the list of modules that services can request
+	// (required).
+	AllowedModules []string `mapstructure:"allowed_modules" json:"allowed_modules"`
+
+	// AllowedProviders is the list of provider names that services can
+	// request.
+	AllowedProviders []string `mapstructure:"allowed_providers" json:"allowed_providers"`
+
+	// Datacenter and Namespace are the datacenter and namespace of the
+	// services to generate tasks for.
+	Datacenter *string `mapstructure:"datacenter" json:"datacenter"`
+	Namespace  *string `mapstructure:"namespace" json:"namespace"`
+
+	// Interval is the duration between checks of the Consul catalog.
+	Interval *time.Duration `mapstructure:"interval" json:"interval"`
+}
+
+// TaskGeneratorConfigs is a collection of TaskGeneratorConfig
+type TaskGeneratorConfigs []*TaskGeneratorConfig
+
+// Copy returns a deep copy of this configuration.
+func (c *TaskGeneratorConfig) Copy() *TaskGeneratorConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o TaskGeneratorConfig
+	o.Name = StringCopy(c.Name)
+	o.Template = StringCopy(c.Template)
+	o.ModuleMetaKey = StringCopy(c.ModuleMetaKey)
+	o.ProvidersMetaKey = StringCopy(c.ProvidersMetaKey)
+
+	if c.AllowedModules != nil {
+		o.AllowedModules = make([]string, 0, len(c.AllowedModules))
+		o.AllowedModules = append(o.AllowedModules, c.AllowedModules...)
+	}
+
+	if c.AllowedProviders != nil {
+		o.AllowedProviders = make([]string, 0, len(c.AllowedProviders))
+		o.AllowedProviders = append(o.AllowedProviders, c.AllowedProviders...)
+	}
+
+	o.Datacenter = StringCopy(c.Datacenter)
+	o.Namespace = StringCopy(c.Namespace)
+	o.Interval = TimeDurationCopy(c.Interval)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *TaskGeneratorConfig) Merge(o *TaskGeneratorConfig) *TaskGeneratorConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Name != nil {
+		r.Name = StringCopy(o.Name)
+	}
+
+	if o.Template != nil {
+		r.Template = StringCopy(o.Template)
+	}
+
+	if o.ModuleMetaKey != nil {
+		r.ModuleMetaKey = StringCopy(o.ModuleMetaKey)
+	}
+
+	if o.ProvidersMetaKey != nil {
+		r.ProvidersMetaKey = StringCopy(o.ProvidersMetaKey)
+	}
+
+	r.AllowedModules = mergeSlices(r.AllowedModules, o.AllowedModules)
+	r.AllowedProviders = mergeSlices(r.AllowedProviders, o.AllowedProviders)
+
+	if o.Datacenter != nil {
+		r.Datacenter = StringCopy(o.Datacenter)
+	}
+
+	if o.Namespace != nil {
+		r.Namespace = StringCopy(o.Namespace)
+	}
+
+	if o.Interval != nil {
+		r.Interval = TimeDurationCopy(o.Interval)
+	}
+
+	return r
+}
+
+// Finalize ensures there no nil pointers.
+func (c *TaskGeneratorConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Name == nil {
+		c.Name = String("")
+	}
+
+	if c.Template == nil {
+		c.Template = String("")
+	}
+
+	if c.ModuleMetaKey == nil {
+		c.ModuleMetaKey = String(DefaultTaskGeneratorModuleMetaKey)
+	}
+
+	if c.ProvidersMetaKey == nil {
+		c.ProvidersMetaKey = String(DefaultTaskGeneratorProvidersMetaKey)
+	}
+
+	if c.AllowedModules == nil {
+		c.AllowedModules = []string{}
+	}
+
+	if c.AllowedProviders == nil {
+		c.AllowedProviders = []string{}
+	}
+
+	if c.Datacenter == nil {
+		c.Datacenter = String("")
+	}
+
+	if c.Namespace == nil {
+		c.Namespace = String("")
+	}
+
+	if c.Interval == nil {
+		c.Interval = TimeDuration(DefaultTaskGeneratorInterval)
+	}
+}
+
+// Validate validates the values and required options. This method is
+// recommended to run after Finalize() to ensure the configuration is safe to
+// proceed.
+func (c *TaskGeneratorConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("missing task generator configuration")
+	}
+
+	if c.Name == nil || len(*c.Name) == 0 {
+		return fmt.Errorf("unique name for the task generator is required")
+	}
+
+	if len(c.AllowedModules) == 0 {
+		return fmt.Errorf("task generator %q requires at least one allowed "+
+			"module", *c.Name)
+	}
+
+	if c.ModuleMetaKey != nil && len(*c.ModuleMetaKey) == 0 {
+		return fmt.Errorf("module_meta_key for task generator %q cannot be "+
+			"empty", *c.Name)
+	}
+
+	if c.ProvidersMetaKey != nil && len(*c.ProvidersMetaKey) == 0 {
+		return fmt.Errorf("providers_meta_key for task generator %q cannot be "+
+			"empty", *c.Name)
+	}
+
+	if c.Interval != nil && *c.Interval <= 0 {
+		return fmt.Errorf("interval for task generator %q must be greater "+
+			"than 0, got %s", *c.Name, *c.Interval)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *TaskGeneratorConfig) GoString() string {
+	if c == nil {
+		return "(*TaskGeneratorConfig)(nil)"
+	}
+
+	return fmt.Sprintf("&TaskGeneratorConfig{"+
+		"Name:%s, "+
+		"Template:%s, "+
+		"ModuleMetaKey:%s, "+
+		"ProvidersMetaKey:%s, "+
+		"AllowedModules:%s, "+
+		"AllowedProviders:%s, "+
+		"Datacenter:%s, "+
+		"Namespace:%s, "+
+		"Interval:%s"+
+		"}",
+		StringVal(c.Name),
+		StringVal(c.Template),
+		StringVal(c.ModuleMetaKey),
+		StringVal(c.ProvidersMetaKey),
+		c.AllowedModules,
+		c.AllowedProviders,
+		StringVal(c.Datacenter),
+		StringVal(c.Namespace),
+		TimeDurationVal(c.Interval),
+	)
+}
+
+// DefaultTaskGeneratorConfigs returns a configuration that is populated with
+// the default values.
+func DefaultTaskGeneratorConfigs() *TaskGeneratorConfigs {
+	return &TaskGeneratorConfigs{}
+}
+
+// Len is a helper method to get the length of the underlying config list
+func (c *TaskGeneratorConfigs) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	return len(*c)
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *TaskGeneratorConfigs) Copy() *TaskGeneratorConfigs {
+	if c == nil {
+		return nil
+	}
+
+	o := make(TaskGeneratorConfigs, c.Len())
+	for i, t := range *c {
+		o[i] = t.Copy()
+	}
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *TaskGeneratorConfigs) Merge(o *TaskGeneratorConfigs) *TaskGeneratorConfigs {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	*r = append(*r, *o...)
+
+	return r
+}
+
+// Finalize ensures the configuration has no nil pointers and sets default
+// values.
+func (c *TaskGeneratorConfigs) Finalize() {
+	if c == nil {
+		*c = *DefaultTaskGeneratorConfigs()
+	}
+
+	for _, t := range *c {
+		t.Finalize()
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *TaskGeneratorConfigs) Validate() error {
+	if c == nil {
+		// Task generators are optional
+		return nil
+	}
+
+	unique := make(map[string]bool)
+	for _, t := range *c {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+
+		name := *t.Name
+		if unique[name] {
+			return fmt.Errorf("duplicate task generator name: %s", name)
+		}
+		unique[name] = true
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *TaskGeneratorConfigs) GoString() string {
+	if c == nil {
+		return "(*TaskGeneratorConfigs)(nil)"
+	}
+
+	s := make([]string, len(*c))
+	for i, t := range *c {
+		s[i] = t.GoString()
+	}
+
+	return "{" + strings.Join(s, ", ") + "}"
+}
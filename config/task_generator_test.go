@@ -0,0 +1,209 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskGeneratorConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *TaskGeneratorConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&TaskGeneratorConfig{},
+		},
+		{
+			"fully_configured",
+			&TaskGeneratorConfig{
+				Name:             String("generator"),
+				Template:         String("template"),
+				ModuleMetaKey:    String("module"),
+				ProvidersMetaKey: String("providers"),
+				AllowedModules:   []string{"org/module"},
+				AllowedProviders: []string{"X"},
+				Datacenter:       String("dc1"),
+				Namespace:        String("ns1"),
+				Interval:         TimeDuration(time.Minute),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestTaskGeneratorConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *TaskGeneratorConfig
+		b    *TaskGeneratorConfig
+		r    *TaskGeneratorConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&TaskGeneratorConfig{},
+			&TaskGeneratorConfig{},
+		},
+		{
+			"nil_b",
+			&TaskGeneratorConfig{},
+			nil,
+			&TaskGeneratorConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"module_meta_key_overrides",
+			&TaskGeneratorConfig{ModuleMetaKey: String("a")},
+			&TaskGeneratorConfig{ModuleMetaKey: String("b")},
+			&TaskGeneratorConfig{ModuleMetaKey: String("b")},
+		},
+		{
+			"allowed_modules_merge",
+			&TaskGeneratorConfig{AllowedModules: []string{"a"}},
+			&TaskGeneratorConfig{AllowedModules: []string{"b"}},
+			&TaskGeneratorConfig{AllowedModules: []string{"a", "b"}},
+		},
+		{
+			"interval_overrides",
+			&TaskGeneratorConfig{Interval: TimeDuration(time.Minute)},
+			&TaskGeneratorConfig{Interval: TimeDuration(time.Hour)},
+			&TaskGeneratorConfig{Interval: TimeDuration(time.Hour)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestTaskGeneratorConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	c := &TaskGeneratorConfig{}
+	c.Finalize()
+
+	assert.Equal(t, &TaskGeneratorConfig{
+		Name:             String(""),
+		Template:         String(""),
+		ModuleMetaKey:    String(DefaultTaskGeneratorModuleMetaKey),
+		ProvidersMetaKey: String(DefaultTaskGeneratorProvidersMetaKey),
+		AllowedModules:   []string{},
+		AllowedProviders: []string{},
+		Datacenter:       String(""),
+		Namespace:        String(""),
+		Interval:         TimeDuration(DefaultTaskGeneratorInterval),
+	}, c)
+}
+
+func TestTaskGeneratorConfigs_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *TaskGeneratorConfigs
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"valid",
+			&TaskGeneratorConfigs{
+				{Name: String("a"), AllowedModules: []string{"path"}},
+				{Name: String("b"), AllowedModules: []string{"path"}},
+			},
+			true,
+		},
+		{
+			"missing_name",
+			&TaskGeneratorConfigs{{AllowedModules: []string{"path"}}},
+			false,
+		},
+		{
+			"missing_allowed_modules",
+			&TaskGeneratorConfigs{{Name: String("a")}},
+			false,
+		},
+		{
+			"empty_module_meta_key",
+			&TaskGeneratorConfigs{{
+				Name:           String("a"),
+				AllowedModules: []string{"path"},
+				ModuleMetaKey:  String(""),
+			}},
+			false,
+		},
+		{
+			"invalid_interval",
+			&TaskGeneratorConfigs{{
+				Name:           String("a"),
+				AllowedModules: []string{"path"},
+				Interval:       TimeDuration(0),
+			}},
+			false,
+		},
+		{
+			"duplicate_names",
+			&TaskGeneratorConfigs{
+				{Name: String("a"), AllowedModules: []string{"path"}},
+				{Name: String("a"), AllowedModules: []string{"path"}},
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			if tc.i != nil {
+				tc.i.Finalize()
+			}
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestConfig_Validate_TaskGenerators(t *testing.T) {
+	t.Parallel()
+
+	conf := DefaultConfig()
+	conf.TaskGenerators = &TaskGeneratorConfigs{{
+		Name:           String("generator"),
+		Template:       String("missing"),
+		AllowedModules: []string{"path"},
+	}}
+	assert.NoError(t, conf.Finalize())
+	assert.Error(t, conf.Validate())
+}
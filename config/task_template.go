@@ -0,0 +1,445 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// TaskTemplateConfig is the configuration for a task template, a reusable
+// blueprint for tasks that share a module, providers, condition type, and
+// defaults. Tasks instantiate the template by name with the `template` field
+// and configure the options that differ between the tasks, such as the
+// service names of the condition. This block may be specified multiple times
+// to configure multiple task templates.
+type TaskTemplateConfig struct {
+	// Name is the unique name of the template (required).
+	Name *string `mapstructure:"name" json:"name"`
+
+	// Description is the default description of the task instances.
+	Description *string `mapstructure:"description" json:"description"`
+
+	// Providers is the list of provider names the task instances are dependent
+	// on.
+	Providers []string `mapstructure:"providers" json:"providers"`
+
+	// Module is the path to fetch the Terraform Module (local or remote) for
+	// the task instances (required).
+	Module *string `mapstructure:"module" json:"module"`
+
+	// Version is the module version for the task instances to use.
+	Version *string `mapstructure:"version" json:"version"`
+
+	// Condition configures the type of the run condition of the task
+	// instances and its default values.
+	Condition ConditionConfig `mapstructure:"condition" json:"condition"`
+
+	// ModuleInputs defines the Consul objects whose values are provided as
+	// the module's input variables for the task instances.
+	ModuleInputs *ModuleInputConfigs `mapstructure:"module_input" json:"module_input"`
+
+	// VarFiles is a list of paths to files containing default variables for
+	// the task instances. Variable files of the tasks are read after the
+	// files of the template and override their values.
+	VarFiles []string `mapstructure:"variable_files" json:"variable_files"`
+
+	// BufferPeriod configures the buffer period of the task instances.
+	BufferPeriod *BufferPeriodConfig `mapstructure:"buffer_period" json:"buffer_period"`
+
+	// Hooks configures the actions to run at stages of the task instances'
+	// runs.
+	Hooks *HookConfigs `mapstructure:"hook" json:"hook"`
+
+	// Timeout is the maximum duration of an execution of the task instances.
+	Timeout *time.Duration `mapstructure:"timeout" json:"timeout"`
+}
+
+// TaskTemplateConfigs is a collection of TaskTemplateConfig
+type TaskTemplateConfigs []*TaskTemplateConfig
+
+// Copy returns a deep copy of this configuration.
+func (c *TaskTemplateConfig) Copy() *TaskTemplateConfig {
+	if c == nil {
+		return nil
+	}
+
+	var o TaskTemplateConfig
+	o.Name = StringCopy(c.Name)
+	o.Description = StringCopy(c.Description)
+
+	if c.Providers != nil {
+		o.Providers = make([]string, 0, len(c.Providers))
+		o.Providers = append(o.Providers, c.Providers...)
+	}
+
+	o.Module = StringCopy(c.Module)
+	o.Version = StringCopy(c.Version)
+
+	if !isConditionNil(c.Condition) {
+		o.Condition = c.Condition.Copy()
+	}
+
+	o.ModuleInputs = c.ModuleInputs.Copy()
+
+	if c.VarFiles != nil {
+		o.VarFiles = make([]string, 0, len(c.VarFiles))
+		o.VarFiles = append(o.VarFiles, c.VarFiles...)
+	}
+
+	o.BufferPeriod = c.BufferPeriod.Copy()
+	o.Hooks = c.Hooks.Copy()
+	o.Timeout = TimeDurationCopy(c.Timeout)
+
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *TaskTemplateConfig) Merge(o *TaskTemplateConfig) *TaskTemplateConfig {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	if o.Name != nil {
+		r.Name = StringCopy(o.Name)
+	}
+
+	if o.Description != nil {
+		r.Description = StringCopy(o.Description)
+	}
+
+	r.Providers = mergeSlices(r.Providers, o.Providers)
+
+	if o.Module != nil {
+		r.Module = StringCopy(o.Module)
+	}
+
+	if o.Version != nil {
+		r.Version = StringCopy(o.Version)
+	}
+
+	if !isConditionNil(o.Condition) {
+		if isConditionNil(r.Condition) {
+			r.Condition = o.Condition.Copy()
+		} else {
+			r.Condition = r.Condition.Merge(o.Condition)
+		}
+	}
+
+	if o.ModuleInputs != nil {
+		r.ModuleInputs = r.ModuleInputs.Merge(o.ModuleInputs)
+	}
+
+	r.VarFiles = mergeSlices(r.VarFiles, o.VarFiles)
+
+	if o.BufferPeriod != nil {
+		r.BufferPeriod = r.BufferPeriod.Merge(o.BufferPeriod)
+	}
+
+	if o.Hooks != nil {
+		r.Hooks = r.Hooks.Merge(o.Hooks)
+	}
+
+	if o.Timeout != nil {
+		r.Timeout = TimeDurationCopy(o.Timeout)
+	}
+
+	return r
+}
+
+// Finalize ensures the template has a name. The other options are left unset
+// when they are not configured so that the task instances only inherit the
+// options configured for the template.
+func (c *TaskTemplateConfig) Finalize() {
+	if c == nil {
+		return
+	}
+
+	if c.Name == nil {
+		c.Name = String("")
+	}
+}
+
+// Validate validates the values and required options. The options that are
+// inherited by the tasks are validated with the task configuration.
+func (c *TaskTemplateConfig) Validate() error {
+	if c == nil {
+		return fmt.Errorf("missing task template configuration")
+	}
+
+	if c.Name == nil || len(*c.Name) == 0 {
+		return fmt.Errorf("unique name for the task template is required")
+	}
+
+	if c.Module == nil || len(*c.Module) == 0 {
+		return fmt.Errorf("module for task template %q is required", *c.Name)
+	}
+
+	if c.Timeout != nil && *c.Timeout < 0 {
+		return fmt.Errorf("timeout for task template %q cannot be negative", *c.Name)
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *TaskTemplateConfig) GoString() string {
+	if c == nil {
+		return "(*TaskTemplateConfig)(nil)"
+	}
+
+	condition := "(ConditionConfig)(nil)"
+	if !isConditionNil(c.Condition) {
+		condition = c.Condition.GoString()
+	}
+
+	return fmt.Sprintf("&TaskTemplateConfig{"+
+		"Name:%s, "+
+		"Description:%s, "+
+		"Providers:%s, "+
+		"Module:%s, "+
+		"Version:%s, "+
+		"Condition:%s, "+
+		"ModuleInput:%s, "+
+		"VarFiles:%s, "+
+		"BufferPeriod:%s, "+
+		"Hooks:%s, "+
+		"Timeout:%s"+
+		"}",
+		StringVal(c.Name),
+		StringVal(c.Description),
+		c.Providers,
+		StringVal(c.Module),
+		StringVal(c.Version),
+		condition,
+		c.ModuleInputs.GoString(),
+		c.VarFiles,
+		c.BufferPeriod.GoString(),
+		c.Hooks.GoString(),
+		TimeDurationVal(c.Timeout),
+	)
+}
+
+// DefaultTaskTemplateConfigs returns a configuration that is populated with
+// the default values.
+func DefaultTaskTemplateConfigs() *TaskTemplateConfigs {
+	return &TaskTemplateConfigs{}
+}
+
+// Len is a helper method to get the length of the underlying config list
+func (c *TaskTemplateConfigs) Len() int {
+	if c == nil {
+		return 0
+	}
+
+	return len(*c)
+}
+
+// Get returns the task template with the name
+func (c *TaskTemplateConfigs) Get(name string) (*TaskTemplateConfig, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	for _, t := range *c {
+		if StringVal(t.Name) == name {
+			return t, true
+		}
+	}
+	return nil, false
+}
+
+// Copy returns a deep copy of this configuration.
+func (c *TaskTemplateConfigs) Copy() *TaskTemplateConfigs {
+	if c == nil {
+		return nil
+	}
+
+	o := make(TaskTemplateConfigs, c.Len())
+	for i, t := range *c {
+		o[i] = t.Copy()
+	}
+	return &o
+}
+
+// Merge combines all values in this configuration with the values in the other
+// configuration, with values in the other configuration taking precedence.
+// Maps and slices are merged, most other values are overwritten. Complex
+// structs define their own merge functionality.
+func (c *TaskTemplateConfigs) Merge(o *TaskTemplateConfigs) *TaskTemplateConfigs {
+	if c == nil {
+		if o == nil {
+			return nil
+		}
+		return o.Copy()
+	}
+
+	if o == nil {
+		return c.Copy()
+	}
+
+	r := c.Copy()
+
+	*r = append(*r, *o...)
+
+	return r
+}
+
+// Finalize ensures the configuration has no nil pointers and sets default
+// values.
+func (c *TaskTemplateConfigs) Finalize() {
+	if c == nil {
+		*c = *DefaultTaskTemplateConfigs()
+	}
+
+	for _, t := range *c {
+		t.Finalize()
+	}
+}
+
+// Validate validates the values and nested values of the configuration struct
+func (c *TaskTemplateConfigs) Validate() error {
+	if c == nil {
+		// Task templates are optional
+		return nil
+	}
+
+	unique := make(map[string]bool)
+	for _, t := range *c {
+		if err := t.Validate(); err != nil {
+			return err
+		}
+
+		name := *t.Name
+		if unique[name] {
+			return fmt.Errorf("duplicate task template name: %s", name)
+		}
+		unique[name] = true
+	}
+
+	return nil
+}
+
+// GoString defines the printable version of this struct.
+func (c *TaskTemplateConfigs) GoString() string {
+	if c == nil {
+		return "(*TaskTemplateConfigs)(nil)"
+	}
+
+	s := make([]string, len(*c))
+	for i, t := range *c {
+		s[i] = t.GoString()
+	}
+
+	return "{" + strings.Join(s, ", ") + "}"
+}
+
+// InheritTemplate returns a copy of the task configuration that inherits the
+// options of the task template which are not configured for the task. The
+// variable files of the template are read before the variable files of the
+// task, and the condition of the task is merged with the condition of the
+// template, which must be of the same type. Inheriting a template again does
+// not change a task that already inherited it. This should be called before
+// the task configuration is finalized.
+func (c *TaskConfig) InheritTemplate(t *TaskTemplateConfig) (*TaskConfig, error) {
+	conf := c.Copy()
+	if t == nil {
+		return conf, nil
+	}
+
+	if conf.Description == nil {
+		conf.Description = StringCopy(t.Description)
+	}
+
+	if len(conf.Providers) == 0 && t.Providers != nil {
+		conf.Providers = make([]string, 0, len(t.Providers))
+		conf.Providers = append(conf.Providers, t.Providers...)
+	}
+
+	if conf.Module == nil || *conf.Module == "" {
+		conf.Module = StringCopy(t.Module)
+	}
+
+	if conf.Version == nil {
+		conf.Version = StringCopy(t.Version)
+	}
+
+	if !isConditionNil(t.Condition) {
+		_, noCondition := conf.Condition.(*NoConditionConfig)
+		switch {
+		case isConditionNil(conf.Condition) || noCondition:
+			conf.Condition = t.Condition.Copy()
+		case reflect.TypeOf(conf.Condition) != reflect.TypeOf(t.Condition):
+			return nil, fmt.Errorf("task %q must configure the %q condition "+
+				"type of task template %q", StringVal(conf.Name),
+				t.Condition.VariableType(), StringVal(t.Name))
+		default:
+			conf.Condition = t.Condition.Merge(conf.Condition)
+		}
+	}
+
+	if conf.ModuleInputs.Len() == 0 && t.ModuleInputs != nil {
+		conf.ModuleInputs = t.ModuleInputs.Copy()
+	}
+
+	if t.VarFiles != nil {
+		varFiles := make([]string, 0, len(t.VarFiles))
+		varFiles = append(varFiles, t.VarFiles...)
+		conf.VarFiles = mergeSlices(varFiles, conf.VarFiles)
+	}
+
+	if t.BufferPeriod != nil {
+		conf.BufferPeriod = t.BufferPeriod.Merge(conf.BufferPeriod)
+	}
+
+	if conf.Hooks.Len() == 0 && t.Hooks != nil {
+		conf.Hooks = t.Hooks.Copy()
+	}
+
+	if conf.Timeout == nil {
+		conf.Timeout = TimeDurationCopy(t.Timeout)
+	}
+
+	return conf, nil
+}
+
+// InheritTemplates replaces the tasks that instantiate a task template with
+// tasks that inherit the options of the template. Returns an error if a task
+// instantiates a template that is not configured.
+func (c *TaskConfigs) InheritTemplates(templates *TaskTemplateConfigs) error {
+	if c == nil {
+		return nil
+	}
+
+	for i, t := range *c {
+		name := StringVal(t.Template)
+		if name == "" {
+			continue
+		}
+
+		tmpl, ok := templates.Get(name)
+		if !ok {
+			return fmt.Errorf("task template %q of task %q is not configured",
+				name, StringVal(t.Name))
+		}
+		conf, err := t.InheritTemplate(tmpl)
+		if err != nil {
+			return err
+		}
+		(*c)[i] = conf
+	}
+
+	return nil
+}
@@ -0,0 +1,353 @@
+package config
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskTemplateConfig_Copy(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *TaskTemplateConfig
+	}{
+		{
+			"nil",
+			nil,
+		},
+		{
+			"empty",
+			&TaskTemplateConfig{},
+		},
+		{
+			"fully_configured",
+			&TaskTemplateConfig{
+				Name:        String("template"),
+				Description: String("description"),
+				Providers:   []string{"X"},
+				Module:      String("path"),
+				Version:     String("1.0.0"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Datacenter: String("dc1"),
+					},
+				},
+				ModuleInputs: &ModuleInputConfigs{
+					&ConsulKVModuleInputConfig{
+						ConsulKVMonitorConfig{Path: String("path")},
+					},
+				},
+				VarFiles:     []string{"defaults.tfvars"},
+				BufferPeriod: DefaultBufferPeriodConfig(),
+				Hooks:        &HookConfigs{{Stage: String(HookStagePostApply), Command: []string{"echo"}}},
+				Timeout:      TimeDuration(time.Minute),
+			},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Copy()
+			assert.Equal(t, tc.a, r)
+		})
+	}
+}
+
+func TestTaskTemplateConfig_Merge(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name string
+		a    *TaskTemplateConfig
+		b    *TaskTemplateConfig
+		r    *TaskTemplateConfig
+	}{
+		{
+			"nil_a",
+			nil,
+			&TaskTemplateConfig{},
+			&TaskTemplateConfig{},
+		},
+		{
+			"nil_b",
+			&TaskTemplateConfig{},
+			nil,
+			&TaskTemplateConfig{},
+		},
+		{
+			"nil_both",
+			nil,
+			nil,
+			nil,
+		},
+		{
+			"module_overrides",
+			&TaskTemplateConfig{Module: String("a")},
+			&TaskTemplateConfig{Module: String("b")},
+			&TaskTemplateConfig{Module: String("b")},
+		},
+		{
+			"providers_merge",
+			&TaskTemplateConfig{Providers: []string{"X"}},
+			&TaskTemplateConfig{Providers: []string{"Y"}},
+			&TaskTemplateConfig{Providers: []string{"X", "Y"}},
+		},
+		{
+			"timeout_overrides",
+			&TaskTemplateConfig{Timeout: TimeDuration(time.Minute)},
+			&TaskTemplateConfig{Timeout: TimeDuration(time.Hour)},
+			&TaskTemplateConfig{Timeout: TimeDuration(time.Hour)},
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			r := tc.a.Merge(tc.b)
+			assert.Equal(t, tc.r, r)
+		})
+	}
+}
+
+func TestTaskTemplateConfig_Finalize(t *testing.T) {
+	t.Parallel()
+
+	c := &TaskTemplateConfig{Module: String("path")}
+	c.Finalize()
+
+	// options of the template that are not configured are not set so that
+	// they are not inherited by tasks
+	assert.Equal(t, &TaskTemplateConfig{
+		Name:   String(""),
+		Module: String("path"),
+	}, c)
+}
+
+func TestTaskTemplateConfigs_Validate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name    string
+		i       *TaskTemplateConfigs
+		isValid bool
+	}{
+		{
+			"nil",
+			nil,
+			true,
+		},
+		{
+			"valid",
+			&TaskTemplateConfigs{
+				{Name: String("a"), Module: String("path")},
+				{Name: String("b"), Module: String("path")},
+			},
+			true,
+		},
+		{
+			"missing_name",
+			&TaskTemplateConfigs{{Module: String("path")}},
+			false,
+		},
+		{
+			"missing_module",
+			&TaskTemplateConfigs{{Name: String("a")}},
+			false,
+		},
+		{
+			"negative_timeout",
+			&TaskTemplateConfigs{{
+				Name:    String("a"),
+				Module:  String("path"),
+				Timeout: TimeDuration(-time.Minute),
+			}},
+			false,
+		},
+		{
+			"duplicate_names",
+			&TaskTemplateConfigs{
+				{Name: String("a"), Module: String("path")},
+				{Name: String("a"), Module: String("path")},
+			},
+			false,
+		},
+	}
+
+	for i, tc := range cases {
+		t.Run(fmt.Sprintf("%d_%s", i, tc.name), func(t *testing.T) {
+			if tc.i != nil {
+				tc.i.Finalize()
+			}
+			err := tc.i.Validate()
+			if tc.isValid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestTaskConfig_InheritTemplate(t *testing.T) {
+	t.Parallel()
+
+	tmpl := &TaskTemplateConfig{
+		Name:        String("template"),
+		Description: String("template description"),
+		Providers:   []string{"X"},
+		Module:      String("path"),
+		Version:     String("1.0.0"),
+		Condition: &ServicesConditionConfig{
+			ServicesMonitorConfig: ServicesMonitorConfig{
+				Datacenter: String("dc1"),
+			},
+		},
+		VarFiles: []string{"defaults.tfvars"},
+		Timeout:  TimeDuration(time.Minute),
+	}
+
+	cases := []struct {
+		name     string
+		task     *TaskConfig
+		expected *TaskConfig
+	}{
+		{
+			"inherits_template",
+			&TaskConfig{
+				Name:     String("task"),
+				Template: String("template"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				VarFiles: []string{"task.tfvars"},
+			},
+			&TaskConfig{
+				Name:        String("task"),
+				Template:    String("template"),
+				Description: String("template description"),
+				Providers:   []string{"X"},
+				Module:      String("path"),
+				Version:     String("1.0.0"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names:      []string{"api"},
+						Datacenter: String("dc1"),
+					},
+				},
+				VarFiles: []string{"defaults.tfvars", "task.tfvars"},
+				Timeout:  TimeDuration(time.Minute),
+			},
+		},
+		{
+			"task_overrides_template",
+			&TaskConfig{
+				Name:        String("task"),
+				Template:    String("template"),
+				Description: String("task description"),
+				Providers:   []string{"Y"},
+				Module:      String("other"),
+				Version:     String("2.0.0"),
+				Timeout:     TimeDuration(time.Hour),
+			},
+			&TaskConfig{
+				Name:        String("task"),
+				Template:    String("template"),
+				Description: String("task description"),
+				Providers:   []string{"Y"},
+				Module:      String("other"),
+				Version:     String("2.0.0"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Datacenter: String("dc1"),
+					},
+				},
+				VarFiles: []string{"defaults.tfvars"},
+				Timeout:  TimeDuration(time.Hour),
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := tc.task.InheritTemplate(tmpl)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, actual)
+
+			// inheriting the template again does not change the task
+			again, err := actual.InheritTemplate(tmpl)
+			require.NoError(t, err)
+			assert.Equal(t, actual, again)
+		})
+	}
+
+	t.Run("condition_type_mismatch", func(t *testing.T) {
+		task := &TaskConfig{
+			Name:     String("task"),
+			Template: String("template"),
+			Condition: &ScheduleConditionConfig{
+				ScheduleMonitorConfig{Cron: String("* * * * * * *")},
+			},
+		}
+		_, err := task.InheritTemplate(tmpl)
+		assert.Error(t, err)
+	})
+}
+
+func TestConfig_Finalize_TaskTemplates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("instances", func(t *testing.T) {
+		conf := DefaultConfig()
+		conf.TaskTemplates = &TaskTemplateConfigs{{
+			Name:      String("template"),
+			Module:    String("path"),
+			Providers: []string{"X"},
+			Condition: &ServicesConditionConfig{},
+		}}
+		conf.Tasks = &TaskConfigs{
+			{
+				Name:     String("api"),
+				Template: String("template"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+			},
+			{
+				Name:     String("web"),
+				Template: String("template"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"web"},
+					},
+				},
+			},
+		}
+
+		require.NoError(t, conf.Finalize())
+		require.NoError(t, conf.Validate())
+		for _, task := range *conf.Tasks {
+			assert.Equal(t, "path", StringVal(task.Module))
+			assert.Equal(t, []string{"X"}, task.Providers)
+			cond, ok := task.Condition.(*ServicesConditionConfig)
+			require.True(t, ok)
+			assert.Equal(t, []string{StringVal(task.Name)}, cond.Names)
+		}
+	})
+
+	t.Run("template_not_configured", func(t *testing.T) {
+		conf := DefaultConfig()
+		conf.Tasks = &TaskConfigs{{
+			Name:     String("api"),
+			Template: String("missing"),
+		}}
+		assert.Error(t, conf.Finalize())
+	})
+}
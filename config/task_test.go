@@ -80,6 +80,10 @@ func TestTaskConfig_Copy(t *testing.T) {
 					AgentPoolID:   String("apool-1"),
 					AgentPoolName: String("test"),
 				},
+				Timeout: TimeDuration(10 * time.Minute),
+				Backend: map[string]interface{}{
+					"local": map[string]interface{}{"path": "task.tfstate"},
+				},
 			},
 		},
 	}
@@ -153,6 +157,12 @@ func TestTaskConfig_Merge(t *testing.T) {
 			&TaskConfig{Name: String("service")},
 			&TaskConfig{Name: String("service")},
 		},
+		{
+			"labels_merge",
+			&TaskConfig{Labels: map[string]string{"team": "a", "env": "prod"}},
+			&TaskConfig{Labels: map[string]string{"team": "b"}},
+			&TaskConfig{Labels: map[string]string{"team": "b", "env": "prod"}},
+		},
 		{
 			"name_empty_one",
 			&TaskConfig{Name: String("name")},
@@ -499,6 +509,40 @@ func TestTaskConfig_Merge(t *testing.T) {
 				AgentPoolName: String("test"),
 			}},
 		},
+		{
+			"timeout_overrides",
+			&TaskConfig{Timeout: TimeDuration(time.Minute)},
+			&TaskConfig{Timeout: TimeDuration(time.Hour)},
+			&TaskConfig{Timeout: TimeDuration(time.Hour)},
+		},
+		{
+			"timeout_empty_two",
+			&TaskConfig{Timeout: TimeDuration(time.Minute)},
+			&TaskConfig{},
+			&TaskConfig{Timeout: TimeDuration(time.Minute)},
+		},
+		{
+			"backend_replaced",
+			&TaskConfig{Backend: map[string]interface{}{
+				"local": map[string]interface{}{"path": "task.tfstate"},
+			}},
+			&TaskConfig{Backend: map[string]interface{}{
+				"pg": map[string]interface{}{"conn_str": "postgres://db.example.com"},
+			}},
+			&TaskConfig{Backend: map[string]interface{}{
+				"pg": map[string]interface{}{"conn_str": "postgres://db.example.com"},
+			}},
+		},
+		{
+			"backend_empty_two",
+			&TaskConfig{Backend: map[string]interface{}{
+				"local": map[string]interface{}{"path": "task.tfstate"},
+			}},
+			&TaskConfig{},
+			&TaskConfig{Backend: map[string]interface{}{
+				"local": map[string]interface{}{"path": "task.tfstate"},
+			}},
+		},
 	}
 
 	for i, tc := range cases {
@@ -534,6 +578,8 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				Condition:           EmptyConditionConfig(),
 				WorkingDir:          nil,
 				ModuleInputs:        DefaultModuleInputConfigs(),
+				Hooks:               DefaultHookConfigs(),
+				Timeout:             TimeDuration(0),
 			},
 		},
 		{
@@ -557,6 +603,8 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				Condition:           EmptyConditionConfig(),
 				WorkingDir:          nil,
 				ModuleInputs:        DefaultModuleInputConfigs(),
+				Hooks:               DefaultHookConfigs(),
+				Timeout:             TimeDuration(0),
 			},
 		},
 		{
@@ -585,6 +633,8 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				},
 				WorkingDir:   nil,
 				ModuleInputs: DefaultModuleInputConfigs(),
+				Hooks:        DefaultHookConfigs(),
+				Timeout:      TimeDuration(0),
 			},
 		},
 		{
@@ -625,6 +675,8 @@ func TestTaskConfig_Finalize(t *testing.T) {
 						Filter:             String(""),
 						CTSUserDefinedMeta: map[string]string{},
 					}}},
+				Hooks:   DefaultHookConfigs(),
+				Timeout: TimeDuration(0),
 			},
 		},
 		{
@@ -654,6 +706,8 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				Condition:           EmptyConditionConfig(),
 				WorkingDir:          nil,
 				ModuleInputs:        DefaultModuleInputConfigs(),
+				Hooks:               DefaultHookConfigs(),
+				Timeout:             TimeDuration(0),
 			},
 		},
 		{
@@ -696,6 +750,8 @@ func TestTaskConfig_Finalize(t *testing.T) {
 				Condition:           EmptyConditionConfig(),
 				WorkingDir:          nil,
 				ModuleInputs:        DefaultModuleInputConfigs(),
+				Hooks:               DefaultHookConfigs(),
+				Timeout:             TimeDuration(0),
 			},
 		},
 	}
@@ -966,6 +1022,34 @@ func TestTaskConfig_Validate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"valid: timeout",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:  String("path"),
+				Timeout: TimeDuration(30 * time.Minute),
+			},
+			true,
+		},
+		{
+			"invalid: timeout: negative",
+			&TaskConfig{
+				Name: String("task"),
+				Condition: &ServicesConditionConfig{
+					ServicesMonitorConfig: ServicesMonitorConfig{
+						Names: []string{"api"},
+					},
+				},
+				Module:  String("path"),
+				Timeout: TimeDuration(-1 * time.Minute),
+			},
+			false,
+		},
 		{
 			"invalid: provider: duplicate",
 			&TaskConfig{
@@ -994,6 +1078,43 @@ func TestTaskConfig_Validate(t *testing.T) {
 			},
 			false,
 		},
+		{
+			"valid: backend",
+			&TaskConfig{
+				Name:      String("task"),
+				Module:    String("path"),
+				Condition: &ScheduleConditionConfig{ScheduleMonitorConfig{Cron: String("* * * * * * *")}},
+				Backend: map[string]interface{}{
+					"s3": map[string]interface{}{"bucket": "tfstate"},
+				},
+			},
+			true,
+		},
+		{
+			"invalid: backend: unsupported",
+			&TaskConfig{
+				Name:      String("task"),
+				Module:    String("path"),
+				Condition: &ScheduleConditionConfig{ScheduleMonitorConfig{Cron: String("* * * * * * *")}},
+				Backend: map[string]interface{}{
+					"remote": map[string]interface{}{},
+				},
+			},
+			false,
+		},
+		{
+			"invalid: backend: multiple",
+			&TaskConfig{
+				Name:      String("task"),
+				Module:    String("path"),
+				Condition: &ScheduleConditionConfig{ScheduleMonitorConfig{Cron: String("* * * * * * *")}},
+				Backend: map[string]interface{}{
+					"local": map[string]interface{}{},
+					"s3":    map[string]interface{}{},
+				},
+			},
+			false,
+		},
 	}
 
 	for i, tc := range cases {
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"encoding/hex"
 	"fmt"
 	"os"
 	"path"
@@ -18,14 +19,49 @@ const (
 	logSystemName          = "config"
 )
 
+// Binaries of the Terraform CLI supported by the Terraform driver
+const (
+	TerraformBinary = "terraform"
+	OpenTofuBinary  = "tofu"
+)
+
 // TerraformConfig is the configuration for the Terraform driver.
 type TerraformConfig struct {
+	// Binary is the Terraform CLI binary run by the driver, either terraform
+	// for HashiCorp Terraform or tofu for OpenTofu
+	Binary            *string                `mapstructure:"binary"`
 	Version           *string                `mapstructure:"version"`
 	Log               *bool                  `mapstructure:"log"`
 	PersistLog        *bool                  `mapstructure:"persist_log"`
 	Path              *string                `mapstructure:"path"`
 	Backend           map[string]interface{} `mapstructure:"backend"`
 	RequiredProviders map[string]interface{} `mapstructure:"required_providers"`
+
+	// ArchivePath is the path of a local zip archive of the binary to install
+	// instead of downloading the binary
+	ArchivePath *string `mapstructure:"archive_path"`
+
+	// Mirror is a URL or local directory mirroring the release archives of
+	// the binary to install from instead of the official releases. Archives
+	// are expected at <mirror>/<version>/<binary>_<version>_<os>_<arch>.zip
+	Mirror *string `mapstructure:"mirror"`
+
+	// Checksum is the SHA256 checksum of the binary. The binary in the path
+	// is verified against the checksum before it is used, whether it was
+	// installed by CTS or provisioned beforehand.
+	Checksum *string `mapstructure:"checksum"`
+
+	// PluginCacheDir is a directory that providers are cached in and shared
+	// by the workspaces of all tasks
+	PluginCacheDir *string `mapstructure:"plugin_cache_dir"`
+
+	// ProviderInstallation configures mirrors to install providers from
+	ProviderInstallation *ProviderInstallationConfig `mapstructure:"provider_installation"`
+
+	// ModuleSourceRewrites rewrites the module sources of tasks that start
+	// with a key to start with the value instead, such as to install modules
+	// from an internal registry
+	ModuleSourceRewrites map[string]string `mapstructure:"module_source_rewrites"`
 }
 
 // DefaultTerraformConfig returns the default configuration struct.
@@ -38,11 +74,22 @@ func DefaultTerraformConfig() *TerraformConfig {
 	}
 
 	return &TerraformConfig{
+		Binary:            String(TerraformBinary),
 		Log:               Bool(false),
 		PersistLog:        Bool(false),
 		Path:              String(wd),
 		Backend:           make(map[string]interface{}),
 		RequiredProviders: make(map[string]interface{}),
+		ArchivePath:       String(""),
+		Mirror:            String(""),
+		Checksum:          String(""),
+		PluginCacheDir:    String(""),
+		ProviderInstallation: &ProviderInstallationConfig{
+			FilesystemMirror: String(""),
+			NetworkMirror:    String(""),
+			Direct:           Bool(false),
+		},
+		ModuleSourceRewrites: make(map[string]string),
 	}
 }
 
@@ -97,6 +144,8 @@ func (c *TerraformConfig) Copy() *TerraformConfig {
 
 	var o TerraformConfig
 
+	o.Binary = StringCopy(c.Binary)
+
 	if c.Version != nil {
 		o.Version = StringCopy(c.Version)
 	}
@@ -127,6 +176,19 @@ func (c *TerraformConfig) Copy() *TerraformConfig {
 		}
 	}
 
+	o.ArchivePath = StringCopy(c.ArchivePath)
+	o.Mirror = StringCopy(c.Mirror)
+	o.Checksum = StringCopy(c.Checksum)
+	o.PluginCacheDir = StringCopy(c.PluginCacheDir)
+	o.ProviderInstallation = c.ProviderInstallation.Copy()
+
+	if c.ModuleSourceRewrites != nil {
+		o.ModuleSourceRewrites = make(map[string]string)
+		for k, v := range c.ModuleSourceRewrites {
+			o.ModuleSourceRewrites[k] = v
+		}
+	}
+
 	return &o
 }
 
@@ -148,6 +210,10 @@ func (c *TerraformConfig) Merge(o *TerraformConfig) *TerraformConfig {
 
 	r := c.Copy()
 
+	if o.Binary != nil {
+		r.Binary = StringCopy(o.Binary)
+	}
+
 	if o.Version != nil {
 		r.Version = StringCopy(o.Version)
 	}
@@ -185,6 +251,35 @@ func (c *TerraformConfig) Merge(o *TerraformConfig) *TerraformConfig {
 		}
 	}
 
+	if o.ArchivePath != nil {
+		r.ArchivePath = StringCopy(o.ArchivePath)
+	}
+
+	if o.Mirror != nil {
+		r.Mirror = StringCopy(o.Mirror)
+	}
+
+	if o.Checksum != nil {
+		r.Checksum = StringCopy(o.Checksum)
+	}
+
+	if o.PluginCacheDir != nil {
+		r.PluginCacheDir = StringCopy(o.PluginCacheDir)
+	}
+
+	if o.ProviderInstallation != nil {
+		r.ProviderInstallation = r.ProviderInstallation.Merge(o.ProviderInstallation)
+	}
+
+	if o.ModuleSourceRewrites != nil {
+		if r.ModuleSourceRewrites == nil {
+			r.ModuleSourceRewrites = make(map[string]string)
+		}
+		for k, v := range o.ModuleSourceRewrites {
+			r.ModuleSourceRewrites[k] = v
+		}
+	}
+
 	return r
 }
 
@@ -202,6 +297,10 @@ func (c *TerraformConfig) Finalize(consul *ConsulConfig) {
 		panic(err)
 	}
 
+	if c.Binary == nil || *c.Binary == "" {
+		c.Binary = String(TerraformBinary)
+	}
+
 	if c.Version == nil {
 		c.Version = String("")
 	}
@@ -234,6 +333,31 @@ func (c *TerraformConfig) Finalize(consul *ConsulConfig) {
 	if c.RequiredProviders == nil {
 		c.RequiredProviders = make(map[string]interface{})
 	}
+
+	if c.ArchivePath == nil {
+		c.ArchivePath = String("")
+	}
+
+	if c.Mirror == nil {
+		c.Mirror = String("")
+	}
+
+	if c.Checksum == nil {
+		c.Checksum = String("")
+	}
+
+	if c.PluginCacheDir == nil {
+		c.PluginCacheDir = String("")
+	}
+
+	if c.ProviderInstallation == nil {
+		c.ProviderInstallation = &ProviderInstallationConfig{}
+	}
+	c.ProviderInstallation.Finalize()
+
+	if c.ModuleSourceRewrites == nil {
+		c.ModuleSourceRewrites = make(map[string]string)
+	}
 }
 
 // Validate validates the values and nested values of the configuration struct
@@ -242,6 +366,20 @@ func (c *TerraformConfig) Validate() error {
 		return fmt.Errorf("missing Terraform driver configuration")
 	}
 
+	binary := TerraformBinary
+	if c.Binary != nil && *c.Binary != "" {
+		binary = *c.Binary
+	}
+	constraintStr, constraint := ctsVersion.CompatibleTerraformVersionConstraint, ctsVersion.TerraformConstraint
+	switch binary {
+	case TerraformBinary:
+	case OpenTofuBinary:
+		constraintStr, constraint = ctsVersion.CompatibleOpenTofuVersionConstraint, ctsVersion.OpenTofuConstraint
+	default:
+		return fmt.Errorf("unsupported Terraform driver binary %q, binary must "+
+			"be %q or %q", binary, TerraformBinary, OpenTofuBinary)
+	}
+
 	if c.Version != nil && *c.Version != "" {
 		v, err := goVersion.NewSemver(*c.Version)
 		if err != nil {
@@ -249,13 +387,35 @@ func (c *TerraformConfig) Validate() error {
 		}
 
 		if len(strings.Split(*c.Version, ".")) < 3 {
-			return fmt.Errorf("provide the exact Terraform version to install: %s", *c.Version)
+			return fmt.Errorf("provide the exact %s version to install: %s", binary, *c.Version)
 		}
 
-		if !ctsVersion.TerraformConstraint.Check(v) {
-			return fmt.Errorf("Terraform version is not supported by Consul-"+
+		if !constraint.Check(v) {
+			return fmt.Errorf("%s version is not supported by Consul-"+
 				"Terraform-Sync, try updating to a different version (%s): %s",
-				ctsVersion.CompatibleTerraformVersionConstraint, *c.Version)
+				binary, constraintStr, *c.Version)
+		}
+	}
+
+	if StringVal(c.ArchivePath) != "" && StringVal(c.Mirror) != "" {
+		return fmt.Errorf("only one of archive_path and mirror can be " +
+			"configured to install the Terraform driver binary")
+	}
+
+	if checksum := StringVal(c.Checksum); checksum != "" {
+		if _, err := hex.DecodeString(checksum); err != nil || len(checksum) != 64 {
+			return fmt.Errorf("checksum of the Terraform driver binary must "+
+				"be a hex-encoded SHA256 checksum: %s", checksum)
+		}
+	}
+
+	if err := c.ProviderInstallation.Validate(); err != nil {
+		return err
+	}
+
+	for k := range c.ModuleSourceRewrites {
+		if k == "" {
+			return fmt.Errorf("module_source_rewrites cannot rewrite an empty module source prefix")
 		}
 	}
 
@@ -263,10 +423,14 @@ func (c *TerraformConfig) Validate() error {
 		return fmt.Errorf("missing Terraform backend configuration")
 	}
 
-	// Backend is only validated for supported backend label. The backend
-	// configuration options are verified at run time. The allowed backends
-	// for state store have state locking and workspace suppport.
-	for k := range c.Backend {
+	return validateBackend(c.Backend)
+}
+
+// validateBackend validates the backend for a supported backend label. The
+// backend configuration options are verified at run time. The allowed
+// backends for state store have state locking and workspace suppport.
+func validateBackend(backend map[string]interface{}) error {
+	for k := range backend {
 		switch k {
 		case "azurerm",
 			"consul",
@@ -285,6 +449,35 @@ func (c *TerraformConfig) Validate() error {
 	return nil
 }
 
+// TaskBackend returns the backend that stores the state of a task. A task's
+// backend overrides the backend of the Terraform driver, and a task's Consul
+// backend defaults to the Consul connection of CTS for options that are not
+// configured.
+func (c *TerraformConfig) TaskBackend(taskBackend map[string]interface{}, consul *ConsulConfig) map[string]interface{} {
+	if len(taskBackend) == 0 {
+		if c == nil {
+			return nil
+		}
+		return c.Backend
+	}
+
+	b, ok := taskBackend["consul"]
+	if !ok || consul == nil {
+		return taskBackend
+	}
+	defaultBackend, err := DefaultTerraformBackend(consul)
+	if err != nil {
+		return taskBackend
+	}
+	conf, ok := b.(map[string]interface{})
+	if !ok {
+		return taskBackend
+	}
+	return map[string]interface{}{
+		"consul": mergeMaps(defaultBackend["consul"].(map[string]interface{}), conf),
+	}
+}
+
 // GoString defines the printable version of this struct.
 func (c *TerraformConfig) GoString() string {
 	if c == nil {
@@ -292,26 +485,40 @@ func (c *TerraformConfig) GoString() string {
 	}
 
 	return fmt.Sprintf("&TerraformConfig{"+
+		"Binary:%s, "+
 		"Version:%s, "+
 		"Log:%v, "+
 		"PersistLog:%v, "+
 		"Path:%s, "+
 		"Backend:%+v, "+
-		"RequiredProviders:%+v"+
+		"RequiredProviders:%+v, "+
+		"ArchivePath:%s, "+
+		"Mirror:%s, "+
+		"Checksum:%s, "+
+		"PluginCacheDir:%s, "+
+		"ProviderInstallation:%s, "+
+		"ModuleSourceRewrites:%+v"+
 		"}",
+		StringVal(c.Binary),
 		StringVal(c.Version),
 		BoolVal(c.Log),
 		BoolVal(c.PersistLog),
 		StringVal(c.Path),
 		c.Backend,
 		c.RequiredProviders,
+		StringVal(c.ArchivePath),
+		StringVal(c.Mirror),
+		StringVal(c.Checksum),
+		StringVal(c.PluginCacheDir),
+		c.ProviderInstallation.GoString(),
+		c.ModuleSourceRewrites,
 	)
 }
 
 // IsConsulBackend returns if the Terraform backend is using Consul KV for
 // remote state store.
 func (c *TerraformConfig) IsConsulBackend() bool {
-	if c.Backend == nil {
+	if c == nil || c.Backend == nil {
 		return false
 	}
 
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -33,8 +34,18 @@ func TestTerraformConfig_Copy(t *testing.T) {
 		}, {
 			"same_enabled",
 			&TerraformConfig{
-				Log:  Bool(true),
-				Path: String("path"),
+				Binary:      String(OpenTofuBinary),
+				Log:         Bool(true),
+				Path:        String("path"),
+				ArchivePath: String("tofu.zip"),
+				Mirror:      String(""),
+				Checksum:    String(strings.Repeat("a", 64)),
+				ProviderInstallation: &ProviderInstallationConfig{
+					FilesystemMirror: String("/opt/providers"),
+				},
+				ModuleSourceRewrites: map[string]string{
+					"registry.terraform.io/": "registry.example.com/",
+				},
 				Backend: map[string]interface{}{"consul": map[string]interface{}{
 					"path": "consul-terraform-sync/terraform",
 				}},
@@ -90,6 +101,51 @@ func TestTerraformConfig_Merge(t *testing.T) {
 			&TerraformConfig{},
 			&TerraformConfig{},
 		},
+		{
+			"binary_overrides",
+			&TerraformConfig{Binary: String(TerraformBinary)},
+			&TerraformConfig{Binary: String(OpenTofuBinary)},
+			&TerraformConfig{Binary: String(OpenTofuBinary)},
+		},
+		{
+			"mirror_overrides",
+			&TerraformConfig{Mirror: String("https://mirror.example.com")},
+			&TerraformConfig{Mirror: String("/opt/mirror")},
+			&TerraformConfig{Mirror: String("/opt/mirror")},
+		},
+		{
+			"provider_installation_merges",
+			&TerraformConfig{ProviderInstallation: &ProviderInstallationConfig{
+				FilesystemMirror: String("/opt/providers"),
+			}},
+			&TerraformConfig{ProviderInstallation: &ProviderInstallationConfig{
+				NetworkMirror: String("https://mirror.example.com/providers/"),
+			}},
+			&TerraformConfig{ProviderInstallation: &ProviderInstallationConfig{
+				FilesystemMirror: String("/opt/providers"),
+				NetworkMirror:    String("https://mirror.example.com/providers/"),
+			}},
+		},
+		{
+			"module_source_rewrites_merges",
+			&TerraformConfig{ModuleSourceRewrites: map[string]string{
+				"a/": "b/",
+				"c/": "d/",
+			}},
+			&TerraformConfig{ModuleSourceRewrites: map[string]string{
+				"c/": "e/",
+			}},
+			&TerraformConfig{ModuleSourceRewrites: map[string]string{
+				"a/": "b/",
+				"c/": "e/",
+			}},
+		},
+		{
+			"archive_path_empty_one",
+			&TerraformConfig{ArchivePath: String("terraform.zip")},
+			&TerraformConfig{},
+			&TerraformConfig{ArchivePath: String("terraform.zip")},
+		},
 		{
 			"version_overrides",
 			&TerraformConfig{Version: String("version")},
@@ -406,6 +462,17 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 				Path:              String(wd),
 				Backend:           map[string]interface{}{},
 				RequiredProviders: map[string]interface{}{},
+				Binary:            String(TerraformBinary),
+				ArchivePath:       String(""),
+				Mirror:            String(""),
+				Checksum:          String(""),
+				PluginCacheDir:    String(""),
+				ProviderInstallation: &ProviderInstallationConfig{
+					FilesystemMirror: String(""),
+					NetworkMirror:    String(""),
+					Direct:           Bool(false),
+				},
+				ModuleSourceRewrites: map[string]string{},
 			},
 		},
 		{
@@ -425,6 +492,17 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 					},
 				},
 				RequiredProviders: map[string]interface{}{},
+				Binary:            String(TerraformBinary),
+				ArchivePath:       String(""),
+				Mirror:            String(""),
+				Checksum:          String(""),
+				PluginCacheDir:    String(""),
+				ProviderInstallation: &ProviderInstallationConfig{
+					FilesystemMirror: String(""),
+					NetworkMirror:    String(""),
+					Direct:           Bool(false),
+				},
+				ModuleSourceRewrites: map[string]string{},
 			},
 		},
 		{
@@ -454,6 +532,17 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 					},
 				},
 				RequiredProviders: map[string]interface{}{},
+				Binary:            String(TerraformBinary),
+				ArchivePath:       String(""),
+				Mirror:            String(""),
+				Checksum:          String(""),
+				PluginCacheDir:    String(""),
+				ProviderInstallation: &ProviderInstallationConfig{
+					FilesystemMirror: String(""),
+					NetworkMirror:    String(""),
+					Direct:           Bool(false),
+				},
+				ModuleSourceRewrites: map[string]string{},
 			},
 		},
 		{
@@ -476,6 +565,17 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 					},
 				},
 				RequiredProviders: map[string]interface{}{},
+				Binary:            String(TerraformBinary),
+				ArchivePath:       String(""),
+				Mirror:            String(""),
+				Checksum:          String(""),
+				PluginCacheDir:    String(""),
+				ProviderInstallation: &ProviderInstallationConfig{
+					FilesystemMirror: String(""),
+					NetworkMirror:    String(""),
+					Direct:           Bool(false),
+				},
+				ModuleSourceRewrites: map[string]string{},
 			},
 		},
 		{
@@ -494,6 +594,17 @@ func TestTerraformConfig_Finalize(t *testing.T) {
 				Path:              String(wd),
 				Backend:           map[string]interface{}{},
 				RequiredProviders: map[string]interface{}{},
+				Binary:            String(TerraformBinary),
+				ArchivePath:       String(""),
+				Mirror:            String(""),
+				Checksum:          String(""),
+				PluginCacheDir:    String(""),
+				ProviderInstallation: &ProviderInstallationConfig{
+					FilesystemMirror: String(""),
+					NetworkMirror:    String(""),
+					Direct:           Bool(false),
+				},
+				ModuleSourceRewrites: map[string]string{},
 			},
 		},
 	}
@@ -542,6 +653,82 @@ func TestTerraformConfig_Validate(t *testing.T) {
 			"backend_invalid",
 			&TerraformConfig{Backend: map[string]interface{}{"unsupported": nil}},
 			false,
+		}, {
+			"opentofu",
+			&TerraformConfig{
+				Binary:  String(OpenTofuBinary),
+				Version: String("1.6.2"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			true,
+		}, {
+			"opentofu unsupported version",
+			&TerraformConfig{
+				Binary:  String(OpenTofuBinary),
+				Version: String("1.1.8"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"terraform unsupported version",
+			&TerraformConfig{
+				Version: String("1.6.2"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"unsupported binary",
+			&TerraformConfig{
+				Binary:  String("terragrunt"),
+				Backend: map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"archive_path and mirror",
+			&TerraformConfig{
+				ArchivePath: String("terraform.zip"),
+				Mirror:      String("/opt/mirror"),
+				Backend:     map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"air-gapped",
+			&TerraformConfig{
+				Checksum:       String(strings.Repeat("0f", 32)),
+				PluginCacheDir: String("/opt/plugin-cache"),
+				ProviderInstallation: &ProviderInstallationConfig{
+					FilesystemMirror: String("/opt/providers"),
+					NetworkMirror:    String("https://mirror.example.com/providers/"),
+				},
+				ModuleSourceRewrites: map[string]string{
+					"registry.terraform.io/": "registry.example.com/",
+				},
+				Backend: map[string]interface{}{"local": nil},
+			},
+			true,
+		}, {
+			"invalid checksum",
+			&TerraformConfig{
+				Checksum: String("sha256:abc"),
+				Backend:  map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"invalid provider network mirror",
+			&TerraformConfig{
+				ProviderInstallation: &ProviderInstallationConfig{
+					NetworkMirror: String("http://mirror.example.com/providers/"),
+				},
+				Backend: map[string]interface{}{"local": nil},
+			},
+			false,
+		}, {
+			"empty module source rewrite",
+			&TerraformConfig{
+				ModuleSourceRewrites: map[string]string{"": "registry.example.com/"},
+				Backend:              map[string]interface{}{"local": nil},
+			},
+			false,
 		},
 	}
 
@@ -557,6 +744,57 @@ func TestTerraformConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestTerraformConfig_TaskBackend(t *testing.T) {
+	t.Parallel()
+
+	consul := DefaultConsulConfig()
+	consul.Finalize()
+	conf := &TerraformConfig{
+		Backend: map[string]interface{}{"local": map[string]interface{}{}},
+	}
+
+	cases := []struct {
+		name        string
+		taskBackend map[string]interface{}
+		expected    map[string]interface{}
+	}{
+		{
+			"driver backend",
+			nil,
+			conf.Backend,
+		},
+		{
+			"task backend",
+			map[string]interface{}{
+				"s3": map[string]interface{}{"bucket": "tfstate"},
+			},
+			map[string]interface{}{
+				"s3": map[string]interface{}{"bucket": "tfstate"},
+			},
+		},
+		{
+			"task consul backend",
+			map[string]interface{}{
+				"consul": map[string]interface{}{"path": "team-a/terraform"},
+			},
+			map[string]interface{}{
+				"consul": map[string]interface{}{
+					"address": *consul.Address,
+					"path":    "team-a/terraform",
+					"gzip":    true,
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := conf.TaskBackend(tc.taskBackend, consul)
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
 func TestDefaultTerraformBackend(t *testing.T) {
 	testCases := []struct {
 		name     string
@@ -1,7 +1,12 @@
 log_level = "ERR"
+log_format = "json"
+log_levels = {
+  templates = "TRACE"
+}
 port = 8502
 working_dir = "working"
 id = "cts-123"
+drain_timeout = "2m"
 
 syslog {
   enabled = true
@@ -21,6 +26,35 @@ tls {
   ca_cert = "../testutils/certs/consul_cert.pem"
 }
 
+api_auth {
+  token {
+    name = "ops"
+    secret = "ops-secret"
+    role = "operator"
+  }
+  consul_acl {
+    roles = {
+      "cts-admin" = "admin"
+    }
+  }
+}
+
+audit {
+  sink = "file"
+  path = "/var/log/cts/audit.log"
+}
+
+module_upgrades {
+  enabled = true
+  interval = "12h"
+}
+
+rollout {
+  enabled = true
+  canary_size = 2
+  soak_time = "10m"
+}
+
 consul {
   address = "consul-example.com"
   auth {
@@ -88,9 +122,37 @@ service {
 
 terraform_provider "X" {}
 
+policy {
+  name = "no_open_ingress"
+  path = "policies/security"
+  query = "data.terraform.security.deny"
+  enforcement_level = "advisory"
+}
+
+task_template {
+  name = "template"
+  providers = ["X"]
+  module = "Z"
+  condition "services" {
+    datacenter = "dc1"
+  }
+}
+
+task_generator {
+  name = "generator"
+  template = "template"
+  allowed_modules = ["Z"]
+  allowed_providers = ["X"]
+  datacenter = "dc1"
+  interval = "1m"
+}
+
 task {
   name = "task"
   description = "automate services for X to do Y"
+  labels = {
+    team = "payments"
+  }
   services = ["serviceA", "serviceB", "serviceC"]
   providers = ["X"]
   module = "Y"
@@ -43,7 +43,7 @@ func Test_ConditionMonitor_runDynamicTask(t *testing.T) {
 		d.On("Task").Return(enabledTestTask(t, validTaskName)).
 			On("TemplateIDs").Return(nil).
 			On("RenderTemplate", mock.Anything).Return(true, nil).
-			On("ApplyTask", ctx).Return(nil)
+			On("ApplyTask", mock.Anything).Return(nil)
 		tm.drivers.Add(validTaskName, d)
 
 		cm := newTestConditionMonitor(tm)
@@ -32,5 +32,12 @@ func InstallDriver(ctx context.Context, conf *config.Config) error {
 	if conf.Driver.Terraform != nil {
 		return driver.InstallTerraform(ctx, conf.Driver.Terraform)
 	}
+	if conf.Driver.Exec != nil {
+		// the executables run by the exec driver are managed by the operator
+		return nil
+	}
+	if conf.Driver.Ansible != nil {
+		return driver.CheckAnsible(*conf.Driver.Ansible.Path)
+	}
 	return errors.New("unsupported driver")
 }
@@ -70,17 +70,40 @@ func (ctrl *Daemon) Init(ctx context.Context) error {
 	return ctrl.tasksManager.Init(ctx)
 }
 
+// Run runs the controller until ctx is cancelled. On cancellation, the
+// controller shuts down gracefully: it stops accepting task triggers, drains
+// active task runs, and then deregisters CTS from Consul.
 func (ctrl *Daemon) Run(ctx context.Context) error {
 	exitBufLen := 2 // api & run tasks exit
 	exitCh := make(chan error, exitBufLen)
 
-	// Configure API
 	conf := ctrl.tasksManager.state.GetConfig()
+	registrationEnabled := *conf.Consul.ServiceRegistration.Enabled
+	consulACLEnabled := conf.APIAuth != nil && config.BoolVal(conf.APIAuth.Enabled) &&
+		config.BoolVal(conf.APIAuth.ConsulACL.Enabled)
+
+	taskGeneratorsEnabled := conf.TaskGenerators.Len() > 0
+
+	// Configure Consul client if not already
+	if ctrl.consulClient == nil && (registrationEnabled || consulACLEnabled ||
+		taskGeneratorsEnabled) {
+		c, err := client.NewConsulClient(conf.Consul, client.ConsulDefaultMaxRetry)
+		if err != nil {
+			ctrl.logger.Error("error setting up Consul client", "error", err)
+			return err
+		}
+		ctrl.consulClient = c
+	}
+
+	// Configure API
 	s, err := api.NewAPI(ctx, api.Config{
-		Controller: ctrl.tasksManager,
-		Health:     &health.BasicChecker{},
-		Port:       config.IntVal(conf.Port),
-		TLS:        conf.TLS,
+		Controller:   ctrl.tasksManager,
+		Health:       &health.BasicChecker{},
+		Port:         config.IntVal(conf.Port),
+		TLS:          conf.TLS,
+		Auth:         conf.APIAuth,
+		ConsulClient: ctrl.consulClient,
+		Auditor:      ctrl.tasksManager.auditor,
 	})
 	if err != nil {
 		return err
@@ -92,24 +115,19 @@ func (ctrl *Daemon) Run(ctx context.Context) error {
 		exitCh <- err
 	}()
 
-	var rm *registration.ServiceRegistrationManager
-	if *conf.Consul.ServiceRegistration.Enabled {
-		// Expect one more long-running goroutine
-		exitBufLen++
-		exitCh = make(chan error, exitBufLen)
-
-		// Configure Consul client if not already
-		if ctrl.consulClient == nil {
-			c, err := client.NewConsulClient(conf.Consul, client.ConsulDefaultMaxRetry)
-			if err != nil {
-				ctrl.logger.Error("error setting up Consul client", "error", err)
-				return err
-			}
-			ctrl.consulClient = c
-		}
+	// Service registration is stopped only once active task runs are
+	// drained, so it is not cancelled with ctx
+	regCtx, stopRegistration := context.WithCancel(context.Background())
+	regDoneCh := make(chan struct{})
+	defer func() {
+		ctrl.tasksManager.Drain(config.TimeDurationVal(conf.DrainTimeout))
+		stopRegistration()
+		<-regDoneCh
+	}()
 
+	if registrationEnabled {
 		// Configure and start service registration manager
-		rm = registration.NewServiceRegistrationManager(
+		rm := registration.NewServiceRegistrationManager(
 			&registration.ServiceRegistrationManagerConfig{
 				ID:                  *conf.ID,
 				Port:                *conf.Port,
@@ -119,9 +137,11 @@ func (ctrl *Daemon) Run(ctx context.Context) error {
 			ctrl.consulClient)
 
 		go func() {
-			rm.Start(ctx)
-			exitCh <- nil // registration errors are logged only
+			rm.Start(regCtx) // registration errors are logged only
+			close(regDoneCh)
 		}()
+	} else {
+		close(regDoneCh)
 	}
 
 	// Run tasks once through once-mode
@@ -139,6 +159,16 @@ func (ctrl *Daemon) Run(ctx context.Context) error {
 		exitCh <- err
 	}()
 
+	if conf.ModuleUpgrades != nil && config.BoolVal(conf.ModuleUpgrades.Enabled) {
+		ctrl.logger.Info("start checking for module upgrades")
+		go ctrl.tasksManager.WatchModuleUpgrades(ctx)
+	}
+
+	if taskGeneratorsEnabled {
+		ctrl.logger.Info("start generating tasks for services")
+		go ctrl.tasksManager.WatchTaskGenerators(ctx, ctrl.consulClient)
+	}
+
 	counter := 0
 	for {
 		err := <-exitCh
@@ -175,6 +205,20 @@ func (ctrl *Daemon) Once(ctx context.Context) error {
 
 func (ctrl *Daemon) Stop() {
 	ctrl.watcher.Stop()
+	ctrl.tasksManager.Stop()
+}
+
+// SetConfigLoader sets the function to load the configuration files, which
+// enables reloading the configuration
+func (ctrl *Daemon) SetConfigLoader(load func() (*config.Config, error)) {
+	ctrl.tasksManager.SetConfigLoader(load)
+}
+
+// Reload reloads the configuration files and applies the changes to the
+// running tasks
+func (ctrl *Daemon) Reload(ctx context.Context) error {
+	_, _, _, err := ctrl.tasksManager.Reload(ctx)
+	return err
 }
 
 func (ctrl *Daemon) EnableTaskRanNotify() <-chan string {
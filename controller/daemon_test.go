@@ -123,6 +123,72 @@ func Test_Daemon_Run_long(t *testing.T) {
 	})
 }
 
+func Test_Daemon_Run_drain(t *testing.T) {
+	t.Parallel()
+
+	port := testutils.FreePort(t)
+	deregisteredCh := make(chan struct{})
+	mockConsul := new(mocksC.ConsulClientInterface)
+	mockConsul.On("RegisterService", mock.Anything, mock.Anything).Return(nil)
+	mockConsul.On("DeregisterService", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Run(func(mock.Arguments) { close(deregisteredCh) })
+
+	tm := newTestTasksManager()
+	consulConf := config.DefaultConsulConfig()
+	consulConf.Finalize()
+	tm.state = state.NewInMemoryStore(&config.Config{
+		ID:           config.String("cts-test"),
+		Port:         config.Int(port),
+		Consul:       consulConf,
+		DrainTimeout: config.TimeDuration(time.Minute),
+	})
+
+	cm := newTestConditionMonitor(tm)
+	w := new(mocksTmpl.Watcher)
+	w.On("Watch", mock.Anything, mock.Anything).Return(nil)
+	cm.watcher = w
+
+	ctl := Daemon{
+		once:         true,
+		consulClient: mockConsul,
+		logger:       logging.NewNullLogger(),
+		tasksManager: tm,
+		monitor:      cm,
+	}
+
+	// Simulate an active task run
+	tm.drivers.SetActive("task")
+
+	errCh := make(chan error)
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		errCh <- ctl.Run(ctx)
+	}()
+	time.Sleep(100 * time.Millisecond)
+	cancel()
+
+	select {
+	case <-errCh:
+		t.Fatal("Run should not exit while a task is active")
+	case <-deregisteredCh:
+		t.Fatal("CTS should not deregister while a task is active")
+	case <-time.After(500 * time.Millisecond):
+	}
+
+	tm.drivers.SetInactive("task")
+	select {
+	case err := <-errCh:
+		assert.Equal(t, context.Canceled, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run did not exit once the active task completed")
+	}
+	select {
+	case <-deregisteredCh:
+	default:
+		t.Fatal("CTS was not deregistered on shutdown")
+	}
+}
+
 func Test_Daemon_Run_once_long_Terraform(t *testing.T) {
 	// Tests long-running mode behaves as expected with triggers after once
 	// completes
@@ -8,9 +8,12 @@ import (
 	"sync"
 	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/hook"
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/policy"
 	"github.com/hashicorp/consul-terraform-sync/templates"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
 	"github.com/hashicorp/hcat"
@@ -25,13 +28,29 @@ type driverFactory struct {
 	watcher   templates.Watcher
 	resolver  templates.Resolver
 	logger    logging.Logger
+
+	// mu protects the providers and policies that are replaced on reload and
+	// the Consul client of hooks
+	mu        sync.RWMutex
 	providers []driver.TerraformProviderBlock
+	policies  []policy.Evaluator
+
+	// consulClient is used by hooks that write to Consul KV. It is created
+	// when a task with a Consul KV hook is first created.
+	consulClient client.ConsulClientInterface
 
 	// config that CTS is initialized with i.e. only used by driver factory.
 	// subsequent access to the configs should be through the state store.
 	initConf *config.Config
 }
 
+// factorySnapshot is the provider and policy configuration of a driver
+// factory at a point in time
+type factorySnapshot struct {
+	providers []driver.TerraformProviderBlock
+	policies  []policy.Evaluator
+}
+
 // NewDriverFactory configures a new driver factory
 func NewDriverFactory(conf *config.Config, watcher templates.Watcher) (*driverFactory, error) {
 	nd, err := newDriverFunc(conf)
@@ -56,22 +75,71 @@ func (f *driverFactory) Init(ctx context.Context) error {
 
 	// Load provider configuration and evaluate dynamic values
 	var err error
-	f.providers, err = f.loadProviderConfigs(ctx)
+	f.providers, err = f.loadProviderConfigs(ctx, f.initConf)
+	if err != nil {
+		return err
+	}
+
+	// Load and compile policies to check task changes against
+	f.policies, err = f.loadPolicies(ctx, f.initConf)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// Reload loads the provider and policy configuration from a reloaded
+// configuration. Drivers made afterwards use the reloaded configuration. The
+// current configuration is kept if the reloaded configuration fails to load.
+func (f *driverFactory) Reload(ctx context.Context, conf *config.Config) error {
+	f.logger.Info("reloading driver factory")
+
+	providers, err := f.loadProviderConfigs(ctx, conf)
 	if err != nil {
 		return err
 	}
 
+	policies, err := f.loadPolicies(ctx, conf)
+	if err != nil {
+		return err
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.initConf = conf
+	f.providers = providers
+	f.policies = policies
 	return nil
 }
 
+// snapshot returns the current provider and policy configuration so that
+// drivers can be made with it after the configuration is reloaded
+func (f *driverFactory) snapshot() factorySnapshot {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+	return factorySnapshot{
+		providers: f.providers,
+		policies:  f.policies,
+	}
+}
+
 // Make makes a new driver for a task
 func (f *driverFactory) Make(ctx context.Context, conf *config.Config,
 	taskConf config.TaskConfig) (driver.Driver, error) {
 
+	return f.makeFromSnapshot(ctx, conf, taskConf, f.snapshot())
+}
+
+// makeFromSnapshot makes a new driver for a task with the provider and
+// policy configuration of the snapshot
+func (f *driverFactory) makeFromSnapshot(ctx context.Context, conf *config.Config,
+	taskConf config.TaskConfig, snap factorySnapshot) (driver.Driver, error) {
+
 	taskName := *taskConf.Name
 	logger := f.logger.With(taskNameLogKey, taskName)
 
-	d, err := f.createNewTaskDriver(ctx, conf, taskConf)
+	d, err := f.createNewTaskDriver(ctx, conf, taskConf, snap)
 	if err != nil {
 		logger.Error("error creating new task driver")
 		return nil, err
@@ -92,10 +160,16 @@ func (f *driverFactory) Make(ctx context.Context, conf *config.Config,
 	return d, nil
 }
 
-func (f *driverFactory) createNewTaskDriver(ctx context.Context, conf *config.Config, taskConfig config.TaskConfig) (driver.Driver, error) {
+func (f *driverFactory) createNewTaskDriver(ctx context.Context, conf *config.Config,
+	taskConfig config.TaskConfig, snap factorySnapshot) (driver.Driver, error) {
 	logger := f.logger.With("task_name", *taskConfig.Name)
 	logger.Trace("creating new task driver")
-	task, err := newDriverTask(conf, &taskConfig, f.providers)
+	hooks, err := f.loadHooks(conf, taskConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := newDriverTask(conf, &taskConfig, snap.providers, snap.policies, hooks)
 	if err != nil {
 		return nil, err
 	}
@@ -111,14 +185,14 @@ func (f *driverFactory) createNewTaskDriver(ctx context.Context, conf *config.Co
 
 // loadProviderConfigs loads provider configs and evaluates provider blocks
 // for dynamic values in parallel.
-func (f *driverFactory) loadProviderConfigs(ctx context.Context) ([]driver.TerraformProviderBlock, error) {
-	numBlocks := len(*f.initConf.TerraformProviders)
+func (f *driverFactory) loadProviderConfigs(ctx context.Context, conf *config.Config) ([]driver.TerraformProviderBlock, error) {
+	numBlocks := len(*conf.TerraformProviders)
 	var wg sync.WaitGroup
 	wg.Add(numBlocks)
 
 	var lastErr error
 	providerConfigs := make([]driver.TerraformProviderBlock, numBlocks)
-	for i, providerConf := range *f.initConf.TerraformProviders {
+	for i, providerConf := range *conf.TerraformProviders {
 		go func(i int, initConf map[string]interface{}) {
 			ctxTimeout, cancel := context.WithTimeout(ctx, 5*time.Second)
 			defer cancel()
@@ -142,11 +216,120 @@ func (f *driverFactory) loadProviderConfigs(ctx context.Context) ([]driver.Terra
 	return providerConfigs, nil
 }
 
+// loadPolicies loads the configured policies
+func (f *driverFactory) loadPolicies(ctx context.Context, conf *config.Config) ([]policy.Evaluator, error) {
+	if conf.Policies == nil {
+		return nil, nil
+	}
+
+	policies := make([]policy.Evaluator, 0, conf.Policies.Len())
+	for _, p := range *conf.Policies {
+		r, err := policy.NewRego(ctx, &policy.RegoConfig{
+			Name:             *p.Name,
+			Path:             *p.Path,
+			Query:            *p.Query,
+			EnforcementLevel: *p.EnforcementLevel,
+		})
+		if err != nil {
+			f.logger.Error("error loading policy", "policy", *p.Name, "error", err)
+			return nil, err
+		}
+		policies = append(policies, r)
+	}
+	return policies, nil
+}
+
+// loadHooks creates the hooks configured for a task
+func (f *driverFactory) loadHooks(conf *config.Config, taskConfig config.TaskConfig) ([]hook.Hook, error) {
+	if taskConfig.Hooks.Len() == 0 {
+		return nil, nil
+	}
+
+	// Hook commands run in the task's working directory
+	var workingDir string
+	if conf != nil && conf.WorkingDir != nil && conf.BufferPeriod != nil {
+		tc := taskConfig.InheritParentConfig(*conf.WorkingDir, *conf.BufferPeriod)
+		workingDir = config.StringVal(tc.WorkingDir)
+	}
+
+	hooks := make([]hook.Hook, 0, taskConfig.Hooks.Len())
+	for _, hc := range *taskConfig.Hooks {
+		name := config.StringVal(hc.Name)
+		stage := config.StringVal(hc.Stage)
+		timeout := config.TimeDurationVal(hc.Timeout)
+
+		var h hook.Hook
+		var err error
+		switch {
+		case hc.HTTP != nil:
+			h, err = hook.NewHTTP(hook.HTTPConfig{
+				Name:    name,
+				Stage:   stage,
+				URL:     config.StringVal(hc.HTTP.URL),
+				Method:  config.StringVal(hc.HTTP.Method),
+				Headers: hc.HTTP.Headers,
+				Timeout: timeout,
+			})
+		case hc.ConsulKV != nil:
+			var c client.ConsulClientInterface
+			c, err = f.hookConsulClient(conf)
+			if err != nil {
+				return nil, err
+			}
+			h, err = hook.NewConsulKV(hook.ConsulKVConfig{
+				Name:      name,
+				Stage:     stage,
+				Path:      config.StringVal(hc.ConsulKV.Path),
+				Namespace: config.StringVal(hc.ConsulKV.Namespace),
+				Timeout:   timeout,
+			}, c)
+		default:
+			h, err = hook.NewCommand(hook.CommandConfig{
+				Name:       name,
+				Stage:      stage,
+				Command:    hc.Command,
+				WorkingDir: workingDir,
+				Env:        map[string]string{"CTS_TASK_NAME": *taskConfig.Name},
+				Timeout:    timeout,
+			})
+		}
+		if err != nil {
+			f.logger.Error("error creating hook", taskNameLogKey, *taskConfig.Name,
+				"hook", name, "error", err)
+			return nil, err
+		}
+		hooks = append(hooks, h)
+	}
+	return hooks, nil
+}
+
+// hookConsulClient returns the Consul client used by hooks, which is created
+// on first use. Tasks are created concurrently, so the client is created
+// while holding the lock.
+func (f *driverFactory) hookConsulClient(conf *config.Config) (client.ConsulClientInterface, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.consulClient == nil {
+		c, err := client.NewConsulClient(conf.Consul, client.ConsulDefaultMaxRetry)
+		if err != nil {
+			return nil, err
+		}
+		f.consulClient = c
+	}
+	return f.consulClient, nil
+}
+
 // newDriverFunc is a constructor abstraction for all of supported drivers
 func newDriverFunc(conf *config.Config) (driverFactoryFunc, error) {
 	if conf.Driver.Terraform != nil {
 		return newTerraformDriver, nil
 	}
+	if conf.Driver.Exec != nil {
+		return newExecDriver, nil
+	}
+	if conf.Driver.Ansible != nil {
+		return newAnsibleDriver, nil
+	}
 	return nil, errors.New("unsupported driver")
 }
 
@@ -155,19 +338,64 @@ func newDriverFunc(conf *config.Config) (driverFactoryFunc, error) {
 func newTerraformDriver(_ context.Context, conf *config.Config, task *driver.Task, w templates.Watcher) (driver.Driver, error) {
 	tfConf := *conf.Driver.Terraform
 	return driver.NewTerraform(&driver.TerraformConfig{
-		Task:              task,
-		Watcher:           w,
-		Log:               *tfConf.Log,
-		PersistLog:        *tfConf.PersistLog,
-		Path:              *tfConf.Path,
-		Backend:           tfConf.Backend,
-		RequiredProviders: tfConf.RequiredProviders,
-		ClientType:        *conf.ClientType,
+		Task:                 task,
+		Watcher:              w,
+		Binary:               *tfConf.Binary,
+		PluginCacheDir:       config.StringVal(tfConf.PluginCacheDir),
+		ProviderInstallation: providerInstallation(tfConf.ProviderInstallation),
+		ModuleSourceRewrites: tfConf.ModuleSourceRewrites,
+		Log:                  *tfConf.Log,
+		PersistLog:           *tfConf.PersistLog,
+		Path:                 *tfConf.Path,
+		Backend:              tfConf.TaskBackend(task.Backend(), conf.Consul),
+		RequiredProviders:    tfConf.RequiredProviders,
+		ClientType:           *conf.ClientType,
+		PlanChanges:          conf.Audit != nil && config.BoolVal(conf.Audit.Enabled),
+	})
+}
+
+// providerInstallation maps the provider installation configuration to the
+// client's provider installation, which is nil when no mirror is configured
+func providerInstallation(conf *config.ProviderInstallationConfig) *client.ProviderInstallation {
+	if !conf.IsMirrored() {
+		return nil
+	}
+	return &client.ProviderInstallation{
+		FilesystemMirror: config.StringVal(conf.FilesystemMirror),
+		NetworkMirror:    config.StringVal(conf.NetworkMirror),
+		Direct:           config.BoolVal(conf.Direct),
+	}
+}
+
+// newExecDriver maps user configuration to initialize an exec driver for a
+// task
+func newExecDriver(_ context.Context, conf *config.Config, task *driver.Task, w templates.Watcher) (driver.Driver, error) {
+	execConf := *conf.Driver.Exec
+	return driver.NewExec(&driver.ExecConfig{
+		Task:     task,
+		Watcher:  w,
+		Args:     execConf.Args,
+		PlanArgs: execConf.PlanArgs,
+		Log:      *execConf.Log,
+	})
+}
+
+// newAnsibleDriver maps user configuration to initialize an Ansible driver
+// for a task
+func newAnsibleDriver(_ context.Context, conf *config.Config, task *driver.Task, w templates.Watcher) (driver.Driver, error) {
+	ansibleConf := *conf.Driver.Ansible
+	return driver.NewAnsible(&driver.AnsibleConfig{
+		Task:    task,
+		Watcher: w,
+		Path:    *ansibleConf.Path,
+		Args:    ansibleConf.Args,
+		Log:     *ansibleConf.Log,
 	})
 }
 
 func newDriverTask(conf *config.Config, taskConfig *config.TaskConfig,
-	providerConfigs driver.TerraformProviderBlocks) (*driver.Task, error) {
+	providerConfigs driver.TerraformProviderBlocks, policies []policy.Evaluator,
+	hooks []hook.Hook) (*driver.Task, error) {
 	if conf == nil || conf.Driver == nil {
 		// only expected for testing
 		return nil, nil
@@ -226,6 +454,10 @@ func newDriverTask(conf *config.Config, taskConfig *config.TaskConfig,
 		Condition:    tc.Condition,
 		ModuleInputs: *tc.ModuleInputs,
 		WorkingDir:   *tc.WorkingDir,
+		Policies:     policies,
+		Hooks:        hooks,
+		Timeout:      config.TimeDurationVal(tc.Timeout),
+		Backend:      tc.Backend,
 
 		// Enterprise
 		DeprecatedTFVersion: *tc.DeprecatedTFVersion,
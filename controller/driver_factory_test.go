@@ -3,12 +3,19 @@ package controller
 import (
 	"context"
 	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/hook"
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocksC "github.com/hashicorp/consul-terraform-sync/mocks/client"
 	mocksD "github.com/hashicorp/consul-terraform-sync/mocks/driver"
 	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
 	"github.com/hashicorp/consul-terraform-sync/templates"
@@ -23,6 +30,25 @@ func Test_driverFactory_Init(t *testing.T) {
 
 	conf := singleTaskConfig(t)
 
+	policyFile := filepath.Join(t.TempDir(), "policy.rego")
+	err := os.WriteFile(policyFile, []byte("package terraform\n\ndeny[msg] {\n"+
+		"\tinput.resource_changes[_].type == \"null_resource\"\n\tmsg := \"denied\"\n}\n"), 0644)
+	require.NoError(t, err)
+
+	policyConf := conf.Copy()
+	policyConf.Policies = &config.PolicyConfigs{{
+		Name: config.String("policy"),
+		Path: config.String(policyFile),
+	}}
+	policyConf.Policies.Finalize()
+
+	invalidPolicyConf := conf.Copy()
+	invalidPolicyConf.Policies = &config.PolicyConfigs{{
+		Name: config.String("invalid policy"),
+		Path: config.String(filepath.Join(t.TempDir(), "missing.rego")),
+	}}
+	invalidPolicyConf.Policies.Finalize()
+
 	cases := []struct {
 		name        string
 		expectError bool
@@ -36,6 +62,16 @@ func Test_driverFactory_Init(t *testing.T) {
 			false,
 			conf,
 		},
+		{
+			"with policies",
+			false,
+			policyConf,
+		},
+		{
+			"invalid policy",
+			true,
+			invalidPolicyConf,
+		},
 	}
 
 	ctx := context.Background()
@@ -58,6 +94,83 @@ func Test_driverFactory_Init(t *testing.T) {
 	}
 }
 
+func Test_driverFactory_loadHooks(t *testing.T) {
+	t.Parallel()
+
+	conf := singleTaskConfig(t)
+	require.NoError(t, conf.Finalize())
+
+	t.Run("no_hooks", func(t *testing.T) {
+		f := driverFactory{logger: logging.NewNullLogger()}
+		hooks, err := f.loadHooks(conf, *(*conf.Tasks)[0])
+		require.NoError(t, err)
+		assert.Empty(t, hooks)
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		taskConf := (*conf.Tasks)[0].Copy()
+		taskConf.Hooks = &config.HookConfigs{
+			{
+				Stage:   config.String(hook.StagePrePlan),
+				Command: []string{"./check.sh"},
+			},
+			{
+				Stage: config.String(hook.StagePostApply),
+				HTTP:  &config.HTTPHookConfig{URL: config.String("https://example.com")},
+			},
+			{
+				Stage:    config.String(hook.StageOnFailure),
+				ConsulKV: &config.ConsulKVHookConfig{Path: config.String("cts/failure")},
+			},
+		}
+		taskConf.Hooks.Finalize()
+
+		f := driverFactory{
+			logger:       logging.NewNullLogger(),
+			consulClient: new(mocksC.ConsulClientInterface),
+		}
+		hooks, err := f.loadHooks(conf, *taskConf)
+		require.NoError(t, err)
+		require.Len(t, hooks, 3)
+		assert.IsType(t, &hook.Command{}, hooks[0])
+		assert.IsType(t, &hook.HTTP{}, hooks[1])
+		assert.IsType(t, &hook.ConsulKV{}, hooks[2])
+		assert.Equal(t, "pre-plan.command", hooks[0].Name())
+		assert.Equal(t, hook.StageOnFailure, hooks[2].Stage())
+	})
+
+	t.Run("concurrent_consul_client", func(t *testing.T) {
+		taskConf := (*conf.Tasks)[0].Copy()
+		taskConf.Hooks = &config.HookConfigs{{
+			Stage:    config.String(hook.StagePostApply),
+			ConsulKV: &config.ConsulKVHookConfig{Path: config.String("cts/applied")},
+		}}
+		taskConf.Hooks.Finalize()
+
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.Write([]byte(`"127.0.0.1:8300"`))
+		}))
+		defer srv.Close()
+		consulConf := singleTaskConfig(t)
+		consulConf.Consul.Address = config.String(srv.URL)
+		require.NoError(t, consulConf.Finalize())
+
+		// tasks are created concurrently, which creates the client once
+		f := driverFactory{logger: logging.NewNullLogger()}
+		var wg sync.WaitGroup
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := f.loadHooks(consulConf, *taskConf)
+				assert.NoError(t, err)
+			}()
+		}
+		wg.Wait()
+		assert.NotNil(t, f.consulClient)
+	})
+}
+
 func Test_driverFactory_Make(t *testing.T) {
 	t.Parallel()
 
@@ -379,7 +492,7 @@ func newTestDriverTasks(conf *config.Config, providerConfigs driver.TerraformPro
 	tasks := make([]*driver.Task, len(*conf.Tasks))
 	for i, t := range *conf.Tasks {
 		var err error
-		tasks[i], err = newDriverTask(conf, t, providerConfigs)
+		tasks[i], err = newDriverTask(conf, t, providerConfigs, nil, nil)
 		if err != nil {
 			return nil, err
 		}
@@ -0,0 +1,224 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/audit"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+)
+
+// moduleUpgrades stores the result of the most recent check for a newer
+// version of each task's module, keyed by task name
+type moduleUpgrades struct {
+	mu       sync.RWMutex
+	upgrades map[string]registry.ModuleUpgrade
+}
+
+func newModuleUpgrades() *moduleUpgrades {
+	return &moduleUpgrades{upgrades: make(map[string]registry.ModuleUpgrade)}
+}
+
+func (m *moduleUpgrades) get(name string) (registry.ModuleUpgrade, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	u, ok := m.upgrades[name]
+	return u, ok
+}
+
+func (m *moduleUpgrades) set(name string, u registry.ModuleUpgrade) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.upgrades[name] = u
+}
+
+func (m *moduleUpgrades) delete(name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.upgrades, name)
+}
+
+// TaskModuleUpgrade returns the result of the most recent check for a newer
+// version of the task's module. Returns false if the module of the task has
+// not been checked.
+func (tm *TasksManager) TaskModuleUpgrade(_ context.Context, name string) (registry.ModuleUpgrade, bool) {
+	return tm.moduleUpgrades.get(name)
+}
+
+// WatchModuleUpgrades checks for newer versions of the tasks' modules on the
+// configured interval until ctx is cancelled
+func (tm *TasksManager) WatchModuleUpgrades(ctx context.Context) {
+	for {
+		tm.CheckModuleUpgrades(ctx)
+
+		conf := tm.state.GetConfig()
+		interval := config.DefaultModuleUpgradesInterval
+		if conf.ModuleUpgrades != nil && conf.ModuleUpgrades.Interval != nil {
+			interval = *conf.ModuleUpgrades.Interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// CheckModuleUpgrades checks the module registry for a newer version of the
+// module of each task that meets the task's version constraint. Tasks with
+// modules that are not from a module registry are skipped. If auto-upgrade is
+// enabled, available upgrades are rolled out to the tasks.
+func (tm *TasksManager) CheckModuleUpgrades(ctx context.Context) {
+	conf := tm.state.GetConfig()
+	autoUpgrade := conf.ModuleUpgrades != nil && config.BoolVal(conf.ModuleUpgrades.AutoUpgrade)
+	allowDestroy := conf.ModuleUpgrades != nil && config.BoolVal(conf.ModuleUpgrades.AllowDestroy)
+	c := registry.NewClient(nil)
+
+	drivers := tm.drivers.Map()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if ctx.Err() != nil {
+			return
+		}
+		logger := tm.logger.With(taskNameLogKey, name)
+		d := drivers[name]
+
+		u, err := d.CheckModuleUpgrade(ctx, c)
+		if errors.Is(err, registry.ErrNotRegistrySource) {
+			tm.moduleUpgrades.delete(name)
+			continue
+		}
+		if err != nil {
+			logger.Warn("error checking for module upgrade", "error", err)
+			u.Error = err.Error()
+			tm.moduleUpgrades.set(name, u)
+			continue
+		}
+
+		if !u.Available() {
+			tm.moduleUpgrades.set(name, u)
+			continue
+		}
+		logger.Info("module upgrade available", "source", u.Source,
+			"installed_version", u.InstalledVersion, "latest_version", u.LatestVersion)
+
+		// A failed upgrade is not retried until a newer version is available
+		prev, ok := tm.moduleUpgrades.get(name)
+		failed := ok && prev.Error != "" && prev.LatestVersion == u.LatestVersion
+		if failed {
+			u.Error = prev.Error
+		}
+		if !autoUpgrade || failed {
+			tm.moduleUpgrades.set(name, u)
+			continue
+		}
+
+		upgraded, err := tm.upgradeModule(ctx, d, u.InstalledVersion,
+			u.LatestVersion, allowDestroy)
+		if upgraded {
+			u.InstalledVersion = u.LatestVersion
+		}
+		if err != nil {
+			logger.Error("error upgrading module", "version", u.LatestVersion, "error", err)
+			u.Error = err.Error()
+		}
+		tm.moduleUpgrades.set(name, u)
+	}
+}
+
+// upgradeModule rolls out the version of the module to an enabled task. The
+// upgraded module is inspected first and the task continues to use the
+// installed version if the inspection fails or, unless allowDestroy is true,
+// the plan destroys or replaces resources. Otherwise the changes are applied,
+// which is recorded as an event of the task and in the audit log. If the apply
+// fails, the task is reverted to the installed version. Disabled and active
+// tasks are skipped until the next check.
+func (tm *TasksManager) upgradeModule(ctx context.Context, d driver.Driver,
+	installed, version string, allowDestroy bool) (bool, error) {
+	task := d.Task()
+	taskName := task.Name()
+	logger := tm.logger.With(taskNameLogKey, taskName)
+
+	if !task.IsEnabled() {
+		logger.Debug("task is disabled, skipping module upgrade")
+		return false, nil
+	}
+	if tm.drivers.IsActive(taskName) {
+		logger.Debug("task is active, skipping module upgrade")
+		return false, nil
+	}
+	tm.drivers.SetActive(taskName)
+	defer tm.drivers.SetInactive(taskName)
+
+	if tm.isDraining() {
+		return false, nil
+	}
+
+	// the module is reverted with the parent context since the run context
+	// may have timed out
+	revertCtx := ctx
+	ctx, cancel := tm.runContext(ctx, task)
+	defer cancel()
+
+	plan, err := d.UpgradeModule(ctx, version, allowDestroy)
+	if err != nil {
+		return false, err
+	}
+	logger.Info("inspected module upgrade", "version", version,
+		"changes_present", plan.ChangesPresent)
+
+	ev, err := event.NewEvent(taskName, &event.Config{
+		Providers: task.ProviderIDs(),
+		Services:  task.ServiceNames(),
+		Source:    task.Module(),
+	})
+	if err != nil {
+		tm.revertModule(revertCtx, d, installed)
+		return false, fmt.Errorf("error creating event for task %s: %s", taskName, err)
+	}
+	ev.Start()
+
+	desc := fmt.Sprintf("ApplyTask %s", taskName)
+	err = tm.retry.Do(event.WithContext(ctx, ev), d.ApplyTask, desc)
+	err = runError(ctx, task, ev, err)
+	ev.End(err)
+	logger.Trace("adding event", "event", ev.GoString())
+	if serr := tm.state.AddTaskEvent(*ev); serr != nil {
+		logger.Error("error storing event", "event", ev.GoString())
+	}
+	tm.auditor.Record(audit.ApplyEntry(ctx, ev))
+
+	if err != nil {
+		tm.revertModule(revertCtx, d, installed)
+		return false, fmt.Errorf("could not apply changes for task %s with "+
+			"module version %s: %s", taskName, version, err)
+	}
+	logger.Info("module upgraded", "version", version)
+	return true, nil
+}
+
+// revertModule reinstalls the previously installed version of the module
+// after a failed upgrade. Destroying resources is allowed since the changes
+// of a partially applied upgrade are reverted.
+func (tm *TasksManager) revertModule(ctx context.Context, d driver.Driver, version string) {
+	taskName := d.Task().Name()
+	logger := tm.logger.With(taskNameLogKey, taskName)
+	logger.Info("reverting module upgrade", "version", version)
+	if _, err := d.UpgradeModule(ctx, version, true); err != nil {
+		logger.Error("error reverting module upgrade", "version", version,
+			"error", err)
+	}
+}
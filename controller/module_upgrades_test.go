@@ -0,0 +1,199 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	mocksD "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/consul-terraform-sync/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TasksManager_CheckModuleUpgrades(t *testing.T) {
+	t.Parallel()
+
+	available := registry.ModuleUpgrade{
+		Source:           "example/basic/local",
+		Constraint:       "~> 1.2",
+		InstalledVersion: "1.2.0",
+		LatestVersion:    "1.4.0",
+	}
+
+	newTasksManager := func(autoUpgrade bool) *TasksManager {
+		conf := config.DefaultConfig()
+		conf.ModuleUpgrades.AutoUpgrade = config.Bool(autoUpgrade)
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		return tm
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		tm := newTasksManager(false)
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(available, nil)
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		u, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		require.True(t, ok)
+		assert.Equal(t, available, u)
+		d.AssertNotCalled(t, "UpgradeModule", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("not registry", func(t *testing.T) {
+		tm := newTasksManager(true)
+		tm.moduleUpgrades.set("task_a", available)
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(
+			registry.ModuleUpgrade{}, fmt.Errorf("module: %w", registry.ErrNotRegistrySource))
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		_, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		assert.False(t, ok)
+	})
+
+	t.Run("check error", func(t *testing.T) {
+		tm := newTasksManager(true)
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(
+			registry.ModuleUpgrade{}, errors.New("registry unavailable"))
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		u, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		require.True(t, ok)
+		assert.Equal(t, "registry unavailable", u.Error)
+	})
+
+	t.Run("auto upgrade", func(t *testing.T) {
+		tm := newTasksManager(true)
+		d := new(mocksD.Driver)
+		d.On("Task").Return(enabledTestTask(t, "task_a"))
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(available, nil)
+		d.On("UpgradeModule", mock.Anything, "1.4.0", false).Return(
+			driver.InspectPlan{ChangesPresent: true}, nil).Once()
+		d.On("ApplyTask", mock.Anything).Return(nil).Once()
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		u, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		require.True(t, ok)
+		assert.Equal(t, "1.4.0", u.InstalledVersion)
+		assert.False(t, u.Available())
+		assert.Empty(t, u.Error)
+		assert.False(t, tm.drivers.IsActive("task_a"))
+
+		events := tm.state.GetTaskEvents("task_a")["task_a"]
+		require.Len(t, events, 1)
+		assert.True(t, events[0].Success)
+		d.AssertExpectations(t)
+	})
+
+	t.Run("auto upgrade inspect error", func(t *testing.T) {
+		tm := newTasksManager(true)
+		d := new(mocksD.Driver)
+		d.On("Task").Return(enabledTestTask(t, "task_a"))
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(available, nil)
+		d.On("UpgradeModule", mock.Anything, "1.4.0", false).Return(
+			driver.InspectPlan{}, errors.New("plan error")).Once()
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		u, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		require.True(t, ok)
+		assert.Equal(t, "1.2.0", u.InstalledVersion)
+		assert.True(t, u.Available())
+		assert.Equal(t, "plan error", u.Error)
+		d.AssertNotCalled(t, "ApplyTask", mock.Anything)
+
+		// the failed upgrade is not retried for the same version
+		tm.CheckModuleUpgrades(context.Background())
+		u, _ = tm.TaskModuleUpgrade(context.Background(), "task_a")
+		assert.Equal(t, "plan error", u.Error)
+		d.AssertExpectations(t)
+	})
+
+	t.Run("auto upgrade apply error reverts", func(t *testing.T) {
+		tm := newTasksManager(true)
+		d := new(mocksD.Driver)
+		d.On("Task").Return(enabledTestTask(t, "task_a"))
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(available, nil)
+		d.On("UpgradeModule", mock.Anything, "1.4.0", false).Return(
+			driver.InspectPlan{ChangesPresent: true}, nil).Once()
+		d.On("ApplyTask", mock.Anything).Return(errors.New("apply error"))
+		d.On("UpgradeModule", mock.Anything, "1.2.0", true).Return(
+			driver.InspectPlan{}, nil).Once()
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		u, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		require.True(t, ok)
+		assert.Equal(t, "1.2.0", u.InstalledVersion)
+		assert.True(t, u.Available())
+		assert.Contains(t, u.Error, "apply error")
+
+		events := tm.state.GetTaskEvents("task_a")["task_a"]
+		require.Len(t, events, 1)
+		assert.False(t, events[0].Success)
+		d.AssertExpectations(t)
+	})
+
+	t.Run("auto upgrade allow destroy", func(t *testing.T) {
+		tm := newTasksManager(true)
+		conf := tm.state.GetConfig()
+		conf.ModuleUpgrades.AllowDestroy = config.Bool(true)
+		tm.state = state.NewInMemoryStore(&conf)
+		d := new(mocksD.Driver)
+		d.On("Task").Return(enabledTestTask(t, "task_a"))
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(available, nil)
+		d.On("UpgradeModule", mock.Anything, "1.4.0", true).Return(
+			driver.InspectPlan{ChangesPresent: true}, nil).Once()
+		d.On("ApplyTask", mock.Anything).Return(nil).Once()
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		u, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		require.True(t, ok)
+		assert.Equal(t, "1.4.0", u.InstalledVersion)
+		d.AssertExpectations(t)
+	})
+
+	t.Run("auto upgrade disabled task", func(t *testing.T) {
+		tm := newTasksManager(true)
+		d := new(mocksD.Driver)
+		d.On("Task").Return(disabledTestTask(t, "task_a"))
+		d.On("TemplateIDs").Return(nil)
+		d.On("CheckModuleUpgrade", mock.Anything, mock.Anything).Return(available, nil)
+		tm.drivers.Add("task_a", d)
+
+		tm.CheckModuleUpgrades(context.Background())
+
+		u, ok := tm.TaskModuleUpgrade(context.Background(), "task_a")
+		require.True(t, ok)
+		assert.True(t, u.Available())
+		assert.Empty(t, u.Error)
+		d.AssertNotCalled(t, "UpgradeModule", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -141,4 +141,5 @@ func (ctrl *Once) onceConsecutive(ctx context.Context) error {
 
 func (ctrl *Once) Stop() {
 	ctrl.watcher.Stop()
+	ctrl.tasksManager.Stop()
 }
@@ -0,0 +1,189 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/rollout"
+)
+
+// rollouts tracks the most recent rollout of configuration changes across
+// tasks
+type rollouts struct {
+	mu      sync.RWMutex
+	current *rollout.Rollout
+	cancel  context.CancelFunc
+}
+
+func (r *rollouts) get() (*rollout.Rollout, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.current == nil {
+		return nil, false
+	}
+	return r.current.Copy(), true
+}
+
+func (r *rollouts) start(ro *rollout.Rollout, cancel context.CancelFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.current = ro
+	r.cancel = cancel
+}
+
+func (r *rollouts) update(f func(*rollout.Rollout)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	f(r.current)
+}
+
+func (r *rollouts) inProgress() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.current != nil && r.current.Status == rollout.StatusInProgress
+}
+
+// stop cancels the rollout in progress, if any
+func (r *rollouts) stop() {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.cancel != nil {
+		r.cancel()
+	}
+}
+
+// Rollout returns the progress of the most recent rollout of configuration
+// changes. Returns false if no rollout has started.
+func (tm *TasksManager) Rollout(_ context.Context) (rollout.Rollout, bool) {
+	r, ok := tm.rollouts.get()
+	if !ok {
+		return rollout.Rollout{}, false
+	}
+	return *r, true
+}
+
+// startRollout rolls out the configuration changes of a reload to the tasks
+// in the background. The reload lock is held until the rollout ends so that
+// the configuration is not reloaded while tasks are rolled out.
+func (tm *TasksManager) startRollout(ctx context.Context, conf *config.Config,
+	prev *reloadSnapshot, tasks []string) {
+
+	rc := conf.Rollout
+	if rc == nil {
+		rc = config.DefaultRolloutConfig()
+	}
+	r := rollout.New(tasks, config.IntVal(rc.CanarySize), config.IntVal(rc.BatchSize))
+
+	// the rollout outlives the reload request, so it is only cancelled on
+	// shutdown
+	ctx, cancel := context.WithCancel(detachedContext{Context: tm.runCtx, values: ctx})
+	tm.rollouts.start(r, cancel)
+	tm.logger.Info("starting rollout", "rollout_id", r.ID, "tasks", tasks,
+		"batches", len(r.Batches))
+
+	go func() {
+		defer atomic.StoreInt32(&tm.reloading, 0)
+		defer cancel()
+		tm.runRollout(ctx, conf, prev, config.TimeDurationVal(rc.SoakTime))
+	}()
+}
+
+// runRollout recreates the tasks of the current rollout one batch at a time.
+// Once every task of a batch is recreated and has run successfully, the
+// rollout waits for the soak time and checks that the tasks had no failed
+// runs before it starts the next batch. The rollout halts if a batch fails,
+// and the tasks that fail to be recreated are restored with the configuration
+// from before the reload.
+func (tm *TasksManager) runRollout(ctx context.Context, conf *config.Config,
+	prev *reloadSnapshot, soakTime time.Duration) {
+
+	r, _ := tm.rollouts.get()
+	logger := tm.logger.With("rollout_id", r.ID)
+
+	for i, batch := range r.Batches {
+		tm.rollouts.update(func(r *rollout.Rollout) {
+			r.Batches[i].Status = rollout.StatusInProgress
+		})
+		logger.Info("rolling out batch", "batch", i, "canary", batch.Canary,
+			"tasks", batch.Tasks)
+
+		var failed, errs []string
+		for _, name := range batch.Tasks {
+			logger.Info("recreating task changed by reloaded configuration",
+				taskNameLogKey, name)
+			if err := tm.recreateTask(ctx, conf, prev, name); err != nil {
+				logger.Error("error rolling out task", taskNameLogKey, name, "error", err)
+				failed = append(failed, name)
+				errs = append(errs, fmt.Sprintf("task '%s': %s", name, err))
+			}
+		}
+
+		// tasks are soaked before the next batch only
+		if len(failed) == 0 && i < len(r.Batches)-1 && soakTime > 0 {
+			tm.rollouts.update(func(r *rollout.Rollout) {
+				r.Batches[i].Status = rollout.StatusSoaking
+			})
+			logger.Info("soaking batch", "batch", i, "soak_time", soakTime)
+			select {
+			case <-ctx.Done():
+				tm.haltRollout(i, rollout.StatusHalted, nil,
+					fmt.Errorf("rollout was cancelled"))
+				return
+			case <-time.After(soakTime):
+			}
+
+			for _, name := range tm.failedTasks(batch.Tasks) {
+				failed = append(failed, name)
+				errs = append(errs, fmt.Sprintf("task '%s' had a failed run "+
+					"during the soak time", name))
+			}
+		}
+
+		if len(failed) > 0 {
+			tm.haltRollout(i, rollout.StatusFailed, failed,
+				fmt.Errorf("batch %d failed: %s", i, strings.Join(errs, "; ")))
+			return
+		}
+
+		tm.rollouts.update(func(r *rollout.Rollout) {
+			r.Batches[i].Status = rollout.StatusCompleted
+		})
+	}
+
+	tm.rollouts.update(func(r *rollout.Rollout) {
+		r.Complete()
+	})
+	logger.Info("rollout completed")
+}
+
+// failedTasks returns the tasks whose most recent run failed
+func (tm *TasksManager) failedTasks(names []string) []string {
+	var failed []string
+	for _, name := range names {
+		events := tm.state.GetTaskEvents(name)[name]
+		if len(events) > 0 && !events[0].Success {
+			failed = append(failed, name)
+		}
+	}
+	return failed
+}
+
+// haltRollout stops the current rollout at the batch. The failed tasks and the
+// tasks of the batches that have not started keep their previous
+// configuration and are recreated on the next reload.
+func (tm *TasksManager) haltRollout(batch int, status string, failed []string, err error) {
+	tm.rollouts.update(func(r *rollout.Rollout) {
+		r.Batches[batch].Status = status
+		r.Batches[batch].FailedTasks = failed
+		for _, name := range append(failed, r.PendingTasks()...) {
+			tm.markPending(name)
+		}
+		r.Halt(err)
+	})
+	tm.logger.Error("rollout halted", "error", err)
+}
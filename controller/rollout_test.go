@@ -0,0 +1,198 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	mocksD "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	mocksPolicy "github.com/hashicorp/consul-terraform-sync/mocks/policy"
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/policy"
+	"github.com/hashicorp/consul-terraform-sync/rollout"
+	"github.com/hashicorp/consul-terraform-sync/state"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TasksManager_Reload_Rollout(t *testing.T) {
+	ctx := context.Background()
+
+	taskConf := func(name, module string) *config.TaskConfig {
+		tc := validTaskConf.Copy()
+		tc.Name = config.String(name)
+		tc.Module = config.String(module)
+		return tc
+	}
+	newConf := func(module string) *config.Config {
+		conf := config.DefaultConfig()
+		conf.Rollout = &config.RolloutConfig{
+			Enabled:    config.Bool(true),
+			CanarySize: config.Int(1),
+			BatchSize:  config.Int(2),
+			SoakTime:   config.TimeDuration(10 * time.Millisecond),
+		}
+		conf.Tasks = &config.TaskConfigs{}
+		for _, name := range []string{"a", "b", "c", "d"} {
+			*conf.Tasks = append(*conf.Tasks, taskConf(name, module))
+		}
+		require.NoError(t, conf.Finalize())
+		return conf
+	}
+
+	// newTasksManager returns a tasks manager with the tasks of the module
+	// and records the modules that tasks are recreated with. Applying a task
+	// fails for the tasks and module of failApply.
+	newTasksManager := func(t *testing.T, failApply map[string]string) (*TasksManager, func() map[string]string) {
+		conf := newConf("module")
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		tm.configTasks = configTaskSet(conf)
+		tm.factory.initConf = conf
+		tm.factory.watcher = new(mocksTmpl.Watcher)
+		for _, name := range []string{"a", "b", "c", "d"} {
+			d := new(mocksD.Driver)
+			d.On("Task").Return(enabledTestTask(t, name))
+			d.On("TemplateIDs").Return(nil)
+			d.On("DestroyTask", mock.Anything).Return()
+			tm.drivers.Add(name, d)
+			require.NoError(t, tm.state.SetTask(*taskConf(name, "module")))
+		}
+
+		var mu sync.Mutex
+		made := make(map[string]string)
+		tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+			mu.Lock()
+			made[task.Name()] = task.Module()
+			mu.Unlock()
+
+			var applyErr error
+			if failApply[task.Name()] == task.Module() {
+				applyErr = errors.New("apply error")
+			}
+			d := new(mocksD.Driver)
+			d.On("SetBufferPeriod").Return()
+			d.On("Task").Return(task).
+				On("InitTask", mock.Anything).Return(nil).
+				On("TemplateIDs").Return(nil).
+				On("DestroyTask", mock.Anything).Return().
+				On("RenderTemplate", mock.Anything).Return(true, nil).
+				On("ApplyTask", mock.Anything).Return(applyErr)
+			return d, nil
+		}
+		return tm, func() map[string]string {
+			mu.Lock()
+			defer mu.Unlock()
+			cp := make(map[string]string, len(made))
+			for k, v := range made {
+				cp[k] = v
+			}
+			return cp
+		}
+	}
+
+	waitForRollout := func(t *testing.T, tm *TasksManager) rollout.Rollout {
+		var r rollout.Rollout
+		require.Eventually(t, func() bool {
+			var ok bool
+			r, ok = tm.Rollout(ctx)
+			return ok && r.Status != rollout.StatusInProgress
+		}, 5*time.Second, 10*time.Millisecond)
+		return r
+	}
+
+	t.Run("completed", func(t *testing.T) {
+		tm, made := newTasksManager(t, nil)
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf("module/v2"), nil
+		})
+
+		_, updated, _, err := tm.Reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, updated)
+
+		r := waitForRollout(t, tm)
+		assert.Equal(t, rollout.StatusCompleted, r.Status)
+		require.Len(t, r.Batches, 3)
+		assert.True(t, r.Batches[0].Canary)
+		assert.Equal(t, []string{"a"}, r.Batches[0].Tasks)
+		assert.Equal(t, []string{"b", "c"}, r.Batches[1].Tasks)
+		assert.Equal(t, []string{"d"}, r.Batches[2].Tasks)
+		for _, b := range r.Batches {
+			assert.Equal(t, rollout.StatusCompleted, b.Status)
+		}
+		assert.Equal(t, map[string]string{"a": "module/v2", "b": "module/v2",
+			"c": "module/v2", "d": "module/v2"}, made())
+
+		// the configuration can be reloaded once the rollout ended
+		_, updated, _, err = tm.Reload(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, updated)
+	})
+
+	t.Run("canary_failed", func(t *testing.T) {
+		tm, made := newTasksManager(t, map[string]string{"a": "module/v2"})
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf("module/v2"), nil
+		})
+		// the policies from before the reload are removed by the reload
+		prevPolicies := []policy.Evaluator{new(mocksPolicy.Evaluator)}
+		tm.factory.policies = prevPolicies
+
+		_, _, _, err := tm.Reload(ctx)
+		require.NoError(t, err)
+
+		r := waitForRollout(t, tm)
+		assert.Equal(t, rollout.StatusHalted, r.Status)
+		assert.Contains(t, r.Error, "task 'a'")
+		assert.Equal(t, rollout.StatusFailed, r.Batches[0].Status)
+		assert.Equal(t, []string{"a"}, r.Batches[0].FailedTasks)
+		assert.Equal(t, rollout.StatusPending, r.Batches[1].Status)
+
+		// the canary task is restored and run with its previous configuration
+		// and policies, and the other tasks are not changed
+		assert.Equal(t, map[string]string{"a": "module"}, made())
+		d, ok := tm.drivers.Get("a")
+		require.True(t, ok)
+		d.(*mocksD.Driver).AssertCalled(t, "ApplyTask", mock.Anything)
+		assert.Equal(t, prevPolicies, d.Task().Policies())
+		assert.True(t, tm.pendingTasks["b"])
+
+		// the halted tasks are rolled out on the next reload
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf("module/v3"), nil
+		})
+		_, updated, _, err := tm.Reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"a", "b", "c", "d"}, updated)
+		r = waitForRollout(t, tm)
+		assert.Equal(t, rollout.StatusCompleted, r.Status)
+		assert.Empty(t, tm.pendingTasks)
+	})
+
+	t.Run("reload_during_rollout", func(t *testing.T) {
+		tm, _ := newTasksManager(t, nil)
+		conf := newConf("module/v2")
+		conf.Rollout.SoakTime = config.TimeDuration(time.Minute)
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return conf, nil
+		})
+
+		_, _, _, err := tm.Reload(ctx)
+		require.NoError(t, err)
+
+		_, _, _, err = tm.Reload(ctx)
+		assert.Error(t, err)
+
+		// stopping the rollout halts it
+		tm.rollouts.stop()
+		r := waitForRollout(t, tm)
+		assert.Equal(t, rollout.StatusHalted, r.Status)
+	})
+}
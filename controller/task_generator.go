@@ -0,0 +1,368 @@
+package controller
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/client"
+	"github.com/hashicorp/consul-terraform-sync/config"
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+const taskGeneratorLogKey = "task_generator"
+
+// generatedTasks tracks the tasks created by task generators with the
+// configuration that they were generated with, keyed by task name
+type generatedTasks struct {
+	mu    sync.RWMutex
+	tasks map[string]generatedTask
+}
+
+type generatedTask struct {
+	generator string
+	conf      config.TaskConfig
+}
+
+func newGeneratedTasks() *generatedTasks {
+	return &generatedTasks{
+		tasks: make(map[string]generatedTask),
+	}
+}
+
+func (g *generatedTasks) get(name string) (generatedTask, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	t, ok := g.tasks[name]
+	return t, ok
+}
+
+func (g *generatedTasks) set(generator string, tc config.TaskConfig) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.tasks[config.StringVal(tc.Name)] = generatedTask{
+		generator: generator,
+		conf:      *tc.Copy(),
+	}
+}
+
+func (g *generatedTasks) delete(name string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	delete(g.tasks, name)
+}
+
+// names returns the sorted names of the tasks generated by the generators
+// for which the filter returns true
+func (g *generatedTasks) names(filter func(generator string) bool) []string {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	var names []string
+	for name, t := range g.tasks {
+		if filter(t.generator) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// WatchTaskGenerators runs the configured task generators on their intervals
+// until ctx is cancelled. The tasks of generators that are removed from the
+// configuration on reload are deleted.
+func (tm *TasksManager) WatchTaskGenerators(ctx context.Context, c client.ConsulClientInterface) {
+	next := make(map[string]time.Time)
+	for {
+		conf := tm.state.GetConfig()
+		wait := config.DefaultTaskGeneratorInterval
+		configured := make(map[string]bool)
+		if conf.TaskGenerators != nil {
+			for _, g := range *conf.TaskGenerators {
+				name := config.StringVal(g.Name)
+				configured[name] = true
+
+				if !time.Now().Before(next[name]) {
+					if err := tm.GenerateTasks(ctx, c, *g); err != nil {
+						tm.logger.Warn("error generating tasks",
+							taskGeneratorLogKey, name, "error", err)
+					}
+					next[name] = time.Now().Add(config.TimeDurationVal(g.Interval))
+				}
+				if d := time.Until(next[name]); d < wait {
+					wait = d
+				}
+			}
+		}
+
+		for name := range next {
+			if !configured[name] {
+				delete(next, name)
+			}
+		}
+		removed := tm.generatedTasks.names(func(generator string) bool {
+			return !configured[generator]
+		})
+		for _, name := range removed {
+			tm.logger.Info("deleting task of removed task generator",
+				taskNameLogKey, name)
+			tm.deleteGeneratedTask(ctx, name)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// GenerateTasks checks the Consul catalog for the services with the module
+// meta key of the task generator and creates a task for each service. The
+// catalog is filtered by the meta key so that only the instances of services
+// that request a task are fetched. Tasks
+// are recreated when the meta of their service changes and deleted when their
+// service no longer requests a task. Services that request a module or
+// provider that is not allowed by the generator are skipped.
+func (tm *TasksManager) GenerateTasks(ctx context.Context, c client.ConsulClientInterface,
+	g config.TaskGeneratorConfig) error {
+
+	generator := config.StringVal(g.Name)
+	logger := tm.logger.With(taskGeneratorLogKey, generator)
+
+	q := (&consulapi.QueryOptions{
+		Datacenter: config.StringVal(g.Datacenter),
+		Namespace:  config.StringVal(g.Namespace),
+		Filter:     metaKeyFilter(config.StringVal(g.ModuleMetaKey)),
+	}).WithContext(ctx)
+	services, _, err := c.CatalogServices(ctx, q)
+	if err != nil {
+		return fmt.Errorf("error listing catalog services: %s", err)
+	}
+	serviceNames := make([]string, 0, len(services))
+	for name := range services {
+		serviceNames = append(serviceNames, name)
+	}
+	sort.Strings(serviceNames)
+
+	// Tasks are only deleted once all services are fetched so that the tasks
+	// are not deleted when Consul is unavailable
+	desired := make(map[string]config.TaskConfig)
+	for _, service := range serviceNames {
+		instances, _, err := c.CatalogService(ctx, service, q)
+		if err != nil {
+			return fmt.Errorf("error listing instances of service '%s': %s",
+				service, err)
+		}
+
+		tc, ok, err := generateTaskConfig(g, service, instances)
+		if err != nil {
+			logger.Warn("not generating task for service", "service", service,
+				"error", err)
+			continue
+		}
+		if ok {
+			desired[config.StringVal(tc.Name)] = tc
+		}
+	}
+
+	names := make([]string, 0, len(desired))
+	for name := range desired {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := tm.generateTask(ctx, generator, desired[name]); err != nil {
+			logger.Warn("error generating task", taskNameLogKey, name,
+				"error", err)
+		}
+	}
+
+	stale := tm.generatedTasks.names(func(gen string) bool {
+		return gen == generator
+	})
+	for _, name := range stale {
+		if _, ok := desired[name]; ok {
+			continue
+		}
+		logger.Info("deleting task of service that no longer requests a task",
+			taskNameLogKey, name)
+		tm.deleteGeneratedTask(ctx, name)
+	}
+
+	return nil
+}
+
+// generateTask creates the generated task, or recreates it if it was
+// generated with a different configuration. Tasks that were not created by
+// the generator are not changed. The changed configuration is validated
+// before the task is deleted, and a task that cannot be recreated is restored
+// with the configuration it was previously generated with.
+func (tm *TasksManager) generateTask(ctx context.Context, generator string, tc config.TaskConfig) error {
+	name := config.StringVal(tc.Name)
+	if tm.drivers.IsMarkedForDeletion(name) {
+		// generated again once the deletion completes
+		return nil
+	}
+
+	_, exists := tm.drivers.Get(name)
+	prev, ok := tm.generatedTasks.get(name)
+	switch {
+	case ok && prev.generator != generator:
+		return fmt.Errorf("task '%s' was generated by task generator '%s'",
+			name, prev.generator)
+	case !ok && exists:
+		return fmt.Errorf("a task with name '%s' already exists", name)
+	case ok && exists && reflect.DeepEqual(prev.conf, tc):
+		return nil
+	}
+
+	logger := tm.logger.With(taskNameLogKey, name, taskGeneratorLogKey, generator)
+	var snap *reloadSnapshot
+	if exists {
+		conf := tm.state.GetConfig()
+		if _, err := tm.finalizeTaskConfig(conf, *tc.Copy()); err != nil {
+			return fmt.Errorf("invalid task configuration for changed service "+
+				"meta: %s", err)
+		}
+		snap = &reloadSnapshot{conf: conf, factory: tm.factory.snapshot()}
+
+		logger.Info("recreating task for changed service meta")
+		tm.drivers.MarkForDeletion(name)
+		if err := tm.deleteTask(ctx, name); err != nil {
+			if _, ok := tm.drivers.Get(name); ok {
+				tm.drivers.UnmarkForDeletion(name)
+			}
+			return fmt.Errorf("error deleting task to update: %s", err)
+		}
+	} else {
+		logger.Info("creating task for service")
+	}
+
+	// the task is tracked as it was generated, before it is finalized on create
+	generated := *tc.Copy()
+	_, err := tm.TaskCreate(ctx, tc)
+	if err == nil {
+		tm.generatedTasks.set(generator, generated)
+		return nil
+	}
+	if snap == nil {
+		tm.generatedTasks.delete(name)
+		return err
+	}
+
+	logger.Info("restoring task with its previous configuration")
+	if rerr := tm.restoreTask(ctx, snap, *prev.conf.Copy()); rerr != nil {
+		logger.Error("error restoring task", "error", rerr)
+		tm.generatedTasks.delete(name)
+		return fmt.Errorf("%s; error restoring task with its previous "+
+			"configuration: %s", err, rerr)
+	}
+	return err
+}
+
+// deleteGeneratedTask deletes the generated task and stops tracking it
+func (tm *TasksManager) deleteGeneratedTask(ctx context.Context, name string) {
+	tm.generatedTasks.delete(name)
+	if _, ok := tm.drivers.Get(name); !ok {
+		return
+	}
+	if err := tm.TaskDelete(ctx, name, false, false); err != nil {
+		tm.logger.Warn("error deleting generated task", taskNameLogKey, name,
+			"error", err)
+	}
+}
+
+// generateTaskConfig returns the configuration of the task requested by the
+// meta of the service instances. Returns false if no instance of the service
+// has the module meta key. Returns an error if the instances request
+// different tasks or if the module or providers are not allowed.
+func generateTaskConfig(g config.TaskGeneratorConfig, service string,
+	instances []*consulapi.CatalogService) (config.TaskConfig, bool, error) {
+
+	moduleKey := config.StringVal(g.ModuleMetaKey)
+	providersKey := config.StringVal(g.ProvidersMetaKey)
+
+	var module, providers string
+	found := false
+	for _, i := range instances {
+		m, ok := i.ServiceMeta[moduleKey]
+		if !ok {
+			continue
+		}
+		p := i.ServiceMeta[providersKey]
+		if found && (m != module || p != providers) {
+			return config.TaskConfig{}, false, fmt.Errorf("instances of the "+
+				"service have different '%s' or '%s' meta", moduleKey, providersKey)
+		}
+		module, providers, found = m, p, true
+	}
+	if !found {
+		return config.TaskConfig{}, false, nil
+	}
+
+	if !stringInSlice(module, g.AllowedModules) {
+		return config.TaskConfig{}, false, fmt.Errorf("module '%s' is not "+
+			"allowed by the task generator", module)
+	}
+
+	var providerNames []string
+	for _, p := range strings.Split(providers, ",") {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+		if !stringInSlice(p, g.AllowedProviders) {
+			return config.TaskConfig{}, false, fmt.Errorf("provider '%s' is "+
+				"not allowed by the task generator", p)
+		}
+		providerNames = append(providerNames, p)
+	}
+
+	generator := config.StringVal(g.Name)
+	cond := &config.ServicesConditionConfig{
+		ServicesMonitorConfig: config.ServicesMonitorConfig{
+			Names: []string{service},
+		},
+	}
+	// the datacenter and namespace are only set if configured so that they
+	// do not override the condition of the task template
+	if dc := config.StringVal(g.Datacenter); dc != "" {
+		cond.Datacenter = config.String(dc)
+	}
+	if ns := config.StringVal(g.Namespace); ns != "" {
+		cond.Namespace = config.String(ns)
+	}
+
+	tc := config.TaskConfig{
+		Name: config.String(fmt.Sprintf("%s-%s", generator, service)),
+		Description: config.String(fmt.Sprintf("generated by task generator "+
+			"'%s' for service '%s'", generator, service)),
+		Module:    config.String(module),
+		Providers: providerNames,
+		Condition: cond,
+	}
+	if tmpl := config.StringVal(g.Template); tmpl != "" {
+		tc.Template = config.String(tmpl)
+	}
+	return tc, true, nil
+}
+
+// metaKeyFilter returns the Consul filter expression for service instances
+// that have the service meta key. Consul only allows letters, digits, dashes
+// and underscores in meta keys, so the key does not need to be escaped.
+func metaKeyFilter(key string) string {
+	return fmt.Sprintf(`"%s" in ServiceMeta`, key)
+}
+
+func stringInSlice(s string, list []string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+	return false
+}
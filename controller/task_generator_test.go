@@ -0,0 +1,315 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	mocksC "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	mocksD "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/state"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/hashicorp/go-bexpr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TasksManager_GenerateTasks(t *testing.T) {
+	ctx := context.Background()
+
+	conf := config.DefaultConfig()
+	conf.TaskGenerators = &config.TaskGeneratorConfigs{{
+		Name:             config.String("gen"),
+		AllowedModules:   []string{"org/module", "org/module/v2", "org/broken"},
+		AllowedProviders: []string{"X"},
+	}}
+	require.NoError(t, conf.Finalize())
+	g := *(*conf.TaskGenerators)[0]
+
+	tm := newTestTasksManager()
+	tm.state = state.NewInMemoryStore(conf)
+	tm.factory.initConf = conf
+	tm.factory.watcher = new(mocksTmpl.Watcher)
+
+	var modules []string
+	tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+		modules = append(modules, task.Module())
+		if task.Module() == "org/broken" {
+			return nil, errors.New("error creating driver")
+		}
+		d := new(mocksD.Driver)
+		d.On("SetBufferPeriod").Return()
+		d.On("Task").Return(task).
+			On("InitTask", mock.Anything).Return(nil).
+			On("ApplyTask", mock.Anything).Return(nil).
+			On("TemplateIDs").Return(nil).
+			On("DestroyTask", mock.Anything).Return().
+			On("RenderTemplate", mock.Anything).Return(true, nil)
+		return d, nil
+	}
+
+	// the catalog is filtered by the module meta key
+	filtered := mock.MatchedBy(func(q *consulapi.QueryOptions) bool {
+		return q.Filter == `"cts-module" in ServiceMeta`
+	})
+	newClient := func(services map[string]map[string]string) *mocksC.ConsulClientInterface {
+		c := new(mocksC.ConsulClientInterface)
+		list := make(map[string][]string)
+		for name, meta := range services {
+			list[name] = nil
+			c.On("CatalogService", mock.Anything, name, filtered).Return(
+				[]*consulapi.CatalogService{{ServiceName: name, ServiceMeta: meta}},
+				&consulapi.QueryMeta{}, nil)
+		}
+		c.On("CatalogServices", mock.Anything, filtered).Return(
+			list, &consulapi.QueryMeta{}, nil)
+		return c
+	}
+
+	t.Run("create", func(t *testing.T) {
+		c := newClient(map[string]map[string]string{
+			"api": {"cts-module": "org/module", "cts-providers": "X"},
+			"web": {},
+			"db":  {"cts-module": "org/other"},
+			"app": {"cts-module": "org/module", "cts-providers": "X, Y"},
+		})
+		require.NoError(t, tm.GenerateTasks(ctx, c, g))
+		assert.Equal(t, []string{"org/module"}, modules)
+
+		tc, err := tm.Task(ctx, "gen-api")
+		require.NoError(t, err)
+		assert.Equal(t, "org/module", config.StringVal(tc.Module))
+		assert.Equal(t, []string{"X"}, tc.Providers)
+		cond, ok := tc.Condition.(*config.ServicesConditionConfig)
+		require.True(t, ok)
+		assert.Equal(t, []string{"api"}, cond.Names)
+
+		for _, name := range []string{"gen-web", "gen-db", "gen-app"} {
+			_, ok := tm.drivers.Get(name)
+			assert.False(t, ok, name)
+		}
+	})
+
+	t.Run("unchanged", func(t *testing.T) {
+		modules = nil
+		c := newClient(map[string]map[string]string{
+			"api": {"cts-module": "org/module", "cts-providers": "X"},
+		})
+		require.NoError(t, tm.GenerateTasks(ctx, c, g))
+		assert.Empty(t, modules)
+	})
+
+	t.Run("meta_changed", func(t *testing.T) {
+		c := newClient(map[string]map[string]string{
+			"api": {"cts-module": "org/module/v2", "cts-providers": "X"},
+		})
+		require.NoError(t, tm.GenerateTasks(ctx, c, g))
+		assert.Equal(t, []string{"org/module/v2"}, modules)
+
+		tc, err := tm.Task(ctx, "gen-api")
+		require.NoError(t, err)
+		assert.Equal(t, "org/module/v2", config.StringVal(tc.Module))
+	})
+
+	t.Run("meta_changed_invalid", func(t *testing.T) {
+		modules = nil
+		c := newClient(map[string]map[string]string{
+			"api": {"cts-module": "org/module", "cts-providers": "X, X"},
+		})
+		require.NoError(t, tm.GenerateTasks(ctx, c, g))
+		assert.Empty(t, modules, "task should not be recreated")
+
+		// the task is not deleted and is still tracked
+		tc, err := tm.Task(ctx, "gen-api")
+		require.NoError(t, err)
+		assert.Equal(t, "org/module/v2", config.StringVal(tc.Module))
+		generated, ok := tm.generatedTasks.get("gen-api")
+		require.True(t, ok)
+		assert.Equal(t, "org/module/v2", config.StringVal(generated.conf.Module))
+	})
+
+	t.Run("meta_changed_create_error", func(t *testing.T) {
+		modules = nil
+		c := newClient(map[string]map[string]string{
+			"api": {"cts-module": "org/broken", "cts-providers": "X"},
+		})
+		require.NoError(t, tm.GenerateTasks(ctx, c, g))
+		assert.Equal(t, []string{"org/broken", "org/module/v2"}, modules)
+
+		// the task is restored with its previous configuration
+		tc, err := tm.Task(ctx, "gen-api")
+		require.NoError(t, err)
+		assert.Equal(t, "org/module/v2", config.StringVal(tc.Module))
+		generated, ok := tm.generatedTasks.get("gen-api")
+		require.True(t, ok)
+		assert.Equal(t, "org/module/v2", config.StringVal(generated.conf.Module))
+	})
+
+	t.Run("catalog_error", func(t *testing.T) {
+		c := new(mocksC.ConsulClientInterface)
+		c.On("CatalogServices", mock.Anything, mock.Anything).Return(
+			nil, nil, errors.New("error"))
+		assert.Error(t, tm.GenerateTasks(ctx, c, g))
+
+		_, ok := tm.drivers.Get("gen-api")
+		assert.True(t, ok, "task should not be deleted when Consul errors")
+	})
+
+	t.Run("deregistered", func(t *testing.T) {
+		c := newClient(map[string]map[string]string{})
+		require.NoError(t, tm.GenerateTasks(ctx, c, g))
+		assert.Eventually(t, func() bool {
+			_, ok := tm.drivers.Get("gen-api")
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+		_, ok := tm.generatedTasks.get("gen-api")
+		assert.False(t, ok)
+	})
+}
+
+func Test_generateTaskConfig(t *testing.T) {
+	t.Parallel()
+
+	g := config.TaskGeneratorConfig{
+		Name:             config.String("gen"),
+		Template:         config.String("template"),
+		AllowedModules:   []string{"org/module"},
+		AllowedProviders: []string{"X", "Y"},
+		Datacenter:       config.String("dc1"),
+	}
+	g.Finalize()
+
+	cases := []struct {
+		name      string
+		instances []*consulapi.CatalogService
+		expected  *config.TaskConfig
+		expectErr bool
+	}{
+		{
+			"generated",
+			[]*consulapi.CatalogService{
+				{ServiceMeta: map[string]string{
+					"cts-module":    "org/module",
+					"cts-providers": "X, Y",
+				}},
+				{ServiceMeta: map[string]string{}},
+			},
+			&config.TaskConfig{
+				Name:        config.String("gen-api"),
+				Template:    config.String("template"),
+				Description: config.String("generated by task generator 'gen' for service 'api'"),
+				Module:      config.String("org/module"),
+				Providers:   []string{"X", "Y"},
+				Condition: &config.ServicesConditionConfig{
+					ServicesMonitorConfig: config.ServicesMonitorConfig{
+						Names:      []string{"api"},
+						Datacenter: config.String("dc1"),
+					},
+				},
+			},
+			false,
+		},
+		{
+			"no_meta",
+			[]*consulapi.CatalogService{{ServiceMeta: map[string]string{}}},
+			nil,
+			false,
+		},
+		{
+			"module_not_allowed",
+			[]*consulapi.CatalogService{
+				{ServiceMeta: map[string]string{"cts-module": "org/other"}},
+			},
+			nil,
+			true,
+		},
+		{
+			"provider_not_allowed",
+			[]*consulapi.CatalogService{
+				{ServiceMeta: map[string]string{
+					"cts-module":    "org/module",
+					"cts-providers": "Z",
+				}},
+			},
+			nil,
+			true,
+		},
+		{
+			"conflicting_instances",
+			[]*consulapi.CatalogService{
+				{ServiceMeta: map[string]string{"cts-module": "org/module"}},
+				{ServiceMeta: map[string]string{
+					"cts-module":    "org/module",
+					"cts-providers": "X",
+				}},
+			},
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, ok, err := generateTaskConfig(g, "api", tc.instances)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.False(t, ok)
+				return
+			}
+			require.NoError(t, err)
+			if tc.expected == nil {
+				assert.False(t, ok)
+				return
+			}
+			assert.True(t, ok)
+			assert.Equal(t, *tc.expected, actual)
+		})
+	}
+}
+
+func Test_metaKeyFilter(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		key      string
+		meta     map[string]string
+		expected bool
+	}{
+		{
+			"has key",
+			"cts-module",
+			map[string]string{"cts-module": "org/module"},
+			true,
+		},
+		{
+			"missing key",
+			"cts-module",
+			map[string]string{"cts-providers": "X"},
+			false,
+		},
+		{
+			"custom key",
+			"my_module-key",
+			map[string]string{"my_module-key": ""},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter := metaKeyFilter(tc.key)
+			eval, err := bexpr.CreateEvaluator(filter)
+			require.NoError(t, err)
+			ok, err := eval.Evaluate(consulapi.CatalogService{ServiceMeta: tc.meta})
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, ok)
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package controller
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+)
+
+// templateInstances tracks the configuration of tasks that instantiate a task
+// template as it was requested, before the task inherited the template, so
+// that the task can inherit the changes to its template on reload
+type templateInstances struct {
+	mu    sync.RWMutex
+	tasks map[string]config.TaskConfig
+}
+
+func newTemplateInstances() *templateInstances {
+	return &templateInstances{
+		tasks: make(map[string]config.TaskConfig),
+	}
+}
+
+func (t *templateInstances) get(name string) (config.TaskConfig, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	tc, ok := t.tasks[name]
+	if !ok {
+		return config.TaskConfig{}, false
+	}
+	return *tc.Copy(), true
+}
+
+// set tracks the task if it instantiates a task template
+func (t *templateInstances) set(tc config.TaskConfig) {
+	if config.StringVal(tc.Template) == "" {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.tasks[config.StringVal(tc.Name)] = *tc.Copy()
+}
+
+func (t *templateInstances) delete(name string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.tasks, name)
+}
+
+// names returns the sorted names of the tracked tasks
+func (t *templateInstances) names() []string {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	names := make([]string, 0, len(t.tasks))
+	for name := range t.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// inheritTaskTemplate returns the task configuration with the options of its
+// task template inherited. The configuration is returned as-is if the task
+// does not instantiate a template.
+func inheritTaskTemplate(conf config.Config, tc config.TaskConfig) (config.TaskConfig, error) {
+	name := config.StringVal(tc.Template)
+	if name == "" {
+		return tc, nil
+	}
+
+	tmpl, ok := conf.TaskTemplates.Get(name)
+	if !ok {
+		return config.TaskConfig{}, fmt.Errorf("task template %q is not configured", name)
+	}
+	inherited, err := tc.InheritTemplate(tmpl)
+	if err != nil {
+		return config.TaskConfig{}, err
+	}
+	return *inherited, nil
+}
@@ -0,0 +1,114 @@
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/driver"
+	mocksD "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/state"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TasksManager_TaskTemplates(t *testing.T) {
+	ctx := context.Background()
+
+	newConf := func(module string) *config.Config {
+		conf := config.DefaultConfig()
+		conf.TaskTemplates = &config.TaskTemplateConfigs{{
+			Name:   config.String("template"),
+			Module: config.String(module),
+			Condition: &config.CatalogServicesConditionConfig{
+				CatalogServicesMonitorConfig: config.CatalogServicesMonitorConfig{
+					Datacenter: config.String("dc1"),
+				},
+			},
+		}}
+		require.NoError(t, conf.Finalize())
+		return conf
+	}
+	instance := config.TaskConfig{
+		Name:     config.String("api"),
+		Template: config.String("template"),
+		Condition: &config.CatalogServicesConditionConfig{
+			CatalogServicesMonitorConfig: config.CatalogServicesMonitorConfig{
+				Regexp: config.String("^api$"),
+			},
+		},
+	}
+
+	conf := newConf("module")
+	tm := newTestTasksManager()
+	tm.state = state.NewInMemoryStore(conf)
+	tm.factory.initConf = conf
+	tm.factory.watcher = new(mocksTmpl.Watcher)
+
+	var modules []string
+	tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+		modules = append(modules, task.Module())
+		d := new(mocksD.Driver)
+		d.On("SetBufferPeriod").Return()
+		d.On("Task").Return(task).
+			On("InitTask", mock.Anything).Return(nil).
+			On("TemplateIDs").Return(nil).
+			On("DestroyTask", mock.Anything).Return().
+			On("RenderTemplate", mock.Anything).Return(true, nil).
+			On("ApplyTask", mock.Anything).Return(nil)
+		return d, nil
+	}
+
+	t.Run("create", func(t *testing.T) {
+		tc, err := tm.TaskCreate(ctx, instance)
+		require.NoError(t, err)
+		assert.Equal(t, "template", config.StringVal(tc.Template))
+		assert.Equal(t, "module", config.StringVal(tc.Module))
+
+		cond, ok := tc.Condition.(*config.CatalogServicesConditionConfig)
+		require.True(t, ok)
+		assert.Equal(t, "^api$", config.StringVal(cond.Regexp))
+		assert.Equal(t, "dc1", config.StringVal(cond.Datacenter))
+	})
+
+	t.Run("template_not_configured", func(t *testing.T) {
+		tc := *instance.Copy()
+		tc.Name = config.String("web")
+		tc.Template = config.String("missing")
+		_, err := tm.TaskCreate(ctx, tc)
+		assert.Error(t, err)
+	})
+
+	t.Run("reload_template_changed", func(t *testing.T) {
+		modules = nil
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf("module/v2"), nil
+		})
+
+		_, updated, _, err := tm.Reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"api"}, updated)
+		assert.Equal(t, []string{"module/v2"}, modules)
+
+		tc, err := tm.Task(ctx, "api")
+		require.NoError(t, err)
+		assert.Equal(t, "module/v2", config.StringVal(tc.Module))
+
+		// the task is not recreated when the template did not change
+		_, updated, _, err = tm.Reload(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, updated)
+	})
+
+	t.Run("deleted", func(t *testing.T) {
+		require.NoError(t, tm.TaskDelete(ctx, "api", false, false))
+		assert.Eventually(t, func() bool {
+			_, ok := tm.templateInstances.get("api")
+			return !ok
+		}, time.Second, 10*time.Millisecond)
+	})
+}
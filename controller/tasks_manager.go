@@ -3,8 +3,13 @@ package controller
 import (
 	"context"
 	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/audit"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/logging"
@@ -12,6 +17,7 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/state"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/templates"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/pkg/errors"
 )
 
@@ -27,6 +33,49 @@ type TasksManager struct {
 
 	retry retry.Retry
 
+	// auditor records task applies to the audit log, if enabled
+	auditor *audit.Auditor
+
+	// moduleUpgrades are the results of the most recent checks for newer
+	// versions of the tasks' modules
+	moduleUpgrades *moduleUpgrades
+
+	// loadConfig loads the configuration from the configuration files to
+	// reload. Reloading is only supported if it is set.
+	loadConfig func() (*config.Config, error)
+
+	// reloading is set to 1 while the configuration is reloading so that only
+	// one reload runs at a time. configTasks are the tasks from the
+	// configuration files as of the last load, with the parent configuration
+	// inherited, which are compared with the tasks on reload.
+	reloading   int32
+	configTasks map[string]*config.TaskConfig
+
+	// rollouts is the rollout of the changes of the most recent reload when
+	// rollouts are enabled. pendingTasks are the tasks that were not updated
+	// since their rollout halted, which are recreated on the next reload.
+	rollouts     *rollouts
+	pendingTasks map[string]bool
+
+	// templateInstances are the tasks created through the API that
+	// instantiate a task template, which are recreated on reload when their
+	// template changes
+	templateInstances *templateInstances
+
+	// generatedTasks are the tasks created by task generators for the
+	// services in the Consul catalog that request a task
+	generatedTasks *generatedTasks
+
+	// runCtx is the parent context of the runs of added tasks. It is only
+	// cancelled once a drain timeout elapses so that active runs are not
+	// interrupted on shutdown.
+	runCtx   context.Context
+	stopRuns context.CancelFunc
+
+	// draining is set to 1 once the tasks manager is draining and no longer
+	// starts task runs
+	draining int32
+
 	// createdScheduleCh sends the task name of newly created scheduled tasks
 	// that will need to be monitored
 	createdScheduleCh chan string
@@ -53,12 +102,26 @@ func NewTasksManager(conf *config.Config, state state.Store, watcher templates.W
 		return nil, err
 	}
 
+	auditor, err := audit.New(conf.Audit)
+	if err != nil {
+		return nil, err
+	}
+
+	runCtx, stopRuns := context.WithCancel(context.Background())
 	return &TasksManager{
 		logger:            logger,
 		factory:           factory,
 		state:             state,
 		drivers:           driver.NewDrivers(),
 		retry:             retry.NewRetry(defaultRetry, time.Now().UnixNano()),
+		auditor:           auditor,
+		moduleUpgrades:    newModuleUpgrades(),
+		rollouts:          &rollouts{},
+		templateInstances: newTemplateInstances(),
+		generatedTasks:    newGeneratedTasks(),
+		configTasks:       configTaskSet(conf),
+		runCtx:            runCtx,
+		stopRuns:          stopRuns,
 		createdScheduleCh: make(chan string, 10), // arbitrarily chosen size
 		deletedScheduleCh: make(chan string, 10), // arbitrarily chosen size
 	}, nil
@@ -103,16 +166,23 @@ func (tm *TasksManager) Tasks(_ context.Context) config.TaskConfigs {
 // TaskCreate creates a new task and adds it to the managed tasks
 // Note: This will not run the task after creation, see TaskCreateAndRun for this behavior
 func (tm *TasksManager) TaskCreate(ctx context.Context, taskConfig config.TaskConfig) (config.TaskConfig, error) {
+	requested := *taskConfig.Copy()
 	tc, d, err := tm.createTask(ctx, taskConfig)
 	if err != nil {
 		return config.TaskConfig{}, err
 	}
 
-	return tm.addTask(ctx, *tc, d)
+	addedConf, err := tm.addTask(ctx, *tc, d)
+	if err != nil {
+		return config.TaskConfig{}, err
+	}
+	tm.templateInstances.set(requested)
+	return addedConf, nil
 }
 
 // TaskCreateAndRun creates a new task and then runs it. If successful it then adds the task to the managed tasks.
 func (tm *TasksManager) TaskCreateAndRun(ctx context.Context, taskConfig config.TaskConfig) (config.TaskConfig, error) {
+	requested := *taskConfig.Copy()
 	tc, d, err := tm.createTask(ctx, taskConfig)
 	if err != nil {
 		return config.TaskConfig{}, err
@@ -128,6 +198,7 @@ func (tm *TasksManager) TaskCreateAndRun(ctx context.Context, taskConfig config.
 	if err != nil {
 		return config.TaskConfig{}, nil
 	}
+	tm.templateInstances.set(requested)
 
 	// Store event from runNewTask now that the task has been successfully added
 	if ev != nil {
@@ -143,19 +214,170 @@ func (tm *TasksManager) TaskCreateAndRun(ctx context.Context, taskConfig config.
 }
 
 // TaskDelete marks an existing task that has been added to CTS for deletion
-// then asynchronously deletes the task.
-func (tm *TasksManager) TaskDelete(_ context.Context, name string) error {
+// then asynchronously deletes the task. If destroy is set, the resources
+// managed by the task are destroyed before the task is deleted. The task is
+// not deleted if destroying its resources fails. If cancel is set, the
+// running execution of the task is cancelled instead of waiting for it to
+// complete.
+func (tm *TasksManager) TaskDelete(ctx context.Context, name string, destroy, cancel bool) error {
 	logger := tm.logger.With(taskNameLogKey, name)
 	if tm.drivers.IsMarkedForDeletion(name) {
 		logger.Debug("task is already marked for deletion")
+		tm.cancelForDeletion(name, cancel)
 		return nil
 	}
 	tm.drivers.MarkForDeletion(name)
-	logger.Debug("task marked for deletion")
+	logger.Debug("task marked for deletion", "destroy", destroy, "cancel", cancel)
+
+	// Cancel after the task is marked for deletion so that the task does not
+	// run again once the running execution is cancelled, and before the task
+	// is deleted asynchronously
+	tm.cancelForDeletion(name, cancel)
 
 	// Use new context. For runtime task deletions, deleteTask() would get
-	// canceled when the API request completes if shared context.
-	go tm.deleteTask(context.Background(), name)
+	// canceled when the API request completes if shared context. The origin
+	// of the request is kept so that the destroy is audited for the request.
+	dctx := context.Background()
+	if o, ok := audit.OriginFromContext(ctx); ok {
+		dctx = audit.WithOrigin(dctx, o)
+	}
+
+	go func() {
+		if destroy {
+			if err := tm.destroyTaskResources(dctx, name); err != nil {
+				tm.drivers.UnmarkForDeletion(name)
+				return
+			}
+		}
+		if err := tm.deleteTask(dctx, name); err == nil {
+			tm.templateInstances.delete(name)
+		}
+	}()
+	return nil
+}
+
+// cancelForDeletion cancels the running execution of a task marked for
+// deletion if cancel is set. A task that is not running or was already
+// removed has nothing to cancel.
+func (tm *TasksManager) cancelForDeletion(name string, cancel bool) {
+	if cancel && tm.drivers.Cancel(name) {
+		tm.logger.Info("cancelled running task for deletion", taskNameLogKey, name)
+	}
+}
+
+// TaskInspectDestroy inspects the resources managed by an existing task that
+// would be destroyed when deleting the task with its resources destroyed.
+func (tm *TasksManager) TaskInspectDestroy(ctx context.Context, name string) (bool, string, string, error) {
+	d, ok := tm.drivers.Get(name)
+	if !ok {
+		return false, "", "", fmt.Errorf("task '%s' does not exist", name)
+	}
+
+	plan, err := d.InspectDestroy(ctx)
+	if err != nil {
+		tm.logger.Error("error inspecting destroy for task", taskNameLogKey, name,
+			"error", err)
+		return false, "", "", err
+	}
+	return plan.ChangesPresent, plan.Plan, plan.URL, nil
+}
+
+// TaskCancel cancels the running execution of an existing task. The cancelled
+// execution records an event and releases the task to run again. Returns
+// false if the task was not running.
+func (tm *TasksManager) TaskCancel(_ context.Context, name string) (bool, error) {
+	logger := tm.logger.With(taskNameLogKey, name)
+	if _, ok := tm.drivers.Get(name); !ok {
+		return false, fmt.Errorf("task '%s' does not exist", name)
+	}
+
+	if !tm.drivers.Cancel(name) {
+		logger.Debug("task is not running, nothing to cancel")
+		return false, nil
+	}
+
+	logger.Info("cancelled running task")
+	return true, nil
+}
+
+// TaskExport returns the files of a standalone root module for an existing
+// task with the Consul data most recently rendered for the task, keyed by
+// file name
+func (tm *TasksManager) TaskExport(ctx context.Context, name string) (map[string][]byte, error) {
+	d, ok := tm.drivers.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("task '%s' does not exist", name)
+	}
+
+	files, err := d.ExportTask(ctx)
+	if err != nil {
+		tm.logger.Error("error exporting task", taskNameLogKey, name, "error", err)
+		return nil, err
+	}
+	return files, nil
+}
+
+// TaskState returns the state of the resources managed by an existing task
+func (tm *TasksManager) TaskState(ctx context.Context, name string) (*tfjson.State, error) {
+	d, ok := tm.drivers.Get(name)
+	if !ok {
+		return nil, fmt.Errorf("task '%s' does not exist", name)
+	}
+
+	s, err := d.ShowState(ctx)
+	if err != nil {
+		tm.logger.Error("error showing task state", taskNameLogKey, name, "error", err)
+		return nil, err
+	}
+	return s, nil
+}
+
+// TaskMigrateState migrates the state of an existing task to the backend and
+// configures the task to store its state in the backend. The task must be
+// disabled so that it does not run while its state is migrated.
+func (tm *TasksManager) TaskMigrateState(ctx context.Context, name string, backend map[string]interface{}) error {
+	logger := tm.logger.With(taskNameLogKey, name)
+	d, ok := tm.drivers.Get(name)
+	if !ok {
+		return fmt.Errorf("task '%s' does not exist", name)
+	}
+	if d.Task().IsEnabled() {
+		return fmt.Errorf("task '%s' must be disabled to migrate its state", name)
+	}
+
+	conf, ok := tm.state.GetTask(name)
+	if !ok {
+		return fmt.Errorf("task '%s' does not exist", name)
+	}
+	if len(backend) == 0 {
+		return fmt.Errorf("backend is required to migrate the state of task '%s'", name)
+	}
+	conf.Backend = backend
+	if err := conf.Validate(); err != nil {
+		return err
+	}
+
+	if tm.drivers.IsActive(name) {
+		return fmt.Errorf("task '%s' is active and its state cannot be migrated at this time", name)
+	}
+	tm.drivers.SetActive(name)
+	defer tm.drivers.SetInactive(name)
+
+	cfg := tm.state.GetConfig()
+	var tfConf *config.TerraformConfig
+	if cfg.Driver != nil {
+		tfConf = cfg.Driver.Terraform
+	}
+	logger.Info("migrating task state")
+	if err := d.MigrateState(ctx, tfConf.TaskBackend(backend, cfg.Consul)); err != nil {
+		logger.Error("error migrating task state", "error", err)
+		return err
+	}
+
+	if err := tm.state.SetTask(conf); err != nil {
+		logger.Error("error while setting task state", "error", err)
+		return err
+	}
 	return nil
 }
 
@@ -191,15 +413,25 @@ func (tm *TasksManager) TaskUpdate(ctx context.Context, updateConf config.TaskCo
 	tm.drivers.SetActive(taskName)
 	defer tm.drivers.SetInactive(taskName)
 
+	if tm.drivers.IsMarkedForDeletion(taskName) {
+		return false, "", "", fmt.Errorf("task '%s' is marked for deletion and "+
+			"cannot be updated", taskName)
+	}
+
 	d, ok := tm.drivers.Get(taskName)
 	if !ok {
 		return false, "", "", fmt.Errorf("task %s does not exist to run", taskName)
 	}
 
+	ctx, cancel := tm.runContext(ctx, d.Task())
+	defer cancel()
+
 	var storedErr error
+	var ev *event.Event
 	if runOp == driver.RunOptionNow {
 		task := d.Task()
-		ev, err := event.NewEvent(taskName, &event.Config{
+		var err error
+		ev, err = event.NewEvent(taskName, &event.Config{
 			Providers: task.ProviderIDs(),
 			Services:  task.ServiceNames(),
 			Source:    task.Module(),
@@ -217,8 +449,14 @@ func (tm *TasksManager) TaskUpdate(ctx context.Context, updateConf config.TaskCo
 				// only log error since update task occurred successfully by now
 				logger.Error("error storing event", "event", ev.GoString(), "error", err)
 			}
+			if *updateConf.Enabled {
+				tm.auditor.Record(audit.ApplyEntry(ctx, ev))
+			}
 		}()
 		ev.Start()
+
+		// pass the event to the driver to record details of the run
+		ctx = event.WithContext(ctx, ev)
 	}
 
 	if runOp != driver.RunOptionInspect {
@@ -235,6 +473,7 @@ func (tm *TasksManager) TaskUpdate(ctx context.Context, updateConf config.TaskCo
 	}
 	var plan driver.InspectPlan
 	plan, storedErr = d.UpdateTask(ctx, patch)
+	storedErr = runError(ctx, d.Task(), ev, storedErr)
 	if storedErr != nil {
 		logger.Trace("error while updating task", "error", storedErr)
 		return false, "", "", storedErr
@@ -297,7 +536,7 @@ Create Task API / CLI. https://www.consul.io/docs/nia/cli/task#task-create
 // Assumes that the task driver has already been successfully created. On any
 // error, the task will be cleaned up. Returns a copy of the added task's
 // config
-func (tm TasksManager) addTask(ctx context.Context, tc config.TaskConfig, d driver.Driver) (config.TaskConfig, error) {
+func (tm *TasksManager) addTask(ctx context.Context, tc config.TaskConfig, d driver.Driver) (config.TaskConfig, error) {
 	d.SetBufferPeriod()
 
 	name := d.Task().Name()
@@ -320,7 +559,7 @@ func (tm TasksManager) addTask(ctx context.Context, tc config.TaskConfig, d driv
 
 // cleanupTask cleans up a newly created task that has not yet been added to CTS
 // and started monitoring. Use TaskDelete for added and monitored tasks
-func (tm TasksManager) cleanupTask(ctx context.Context, d driver.Driver) {
+func (tm *TasksManager) cleanupTask(ctx context.Context, d driver.Driver) {
 	// at the moment, only the driver needs to destroy its dependencies
 	d.DestroyTask(ctx)
 }
@@ -367,6 +606,22 @@ func (tm *TasksManager) TaskRunNow(ctx context.Context, taskName string) error {
 	tm.drivers.SetActive(taskName)
 	defer tm.drivers.SetInactive(taskName)
 
+	// Check after setting the task active so that the task is either drained
+	// or not run at all
+	if tm.isDraining() {
+		return fmt.Errorf("task '%s' cannot be run while shutting down", taskName)
+	}
+
+	// Check again after waiting for the task to become inactive since the
+	// task may have been marked for deletion, e.g. to destroy its resources
+	if tm.drivers.IsMarkedForDeletion(taskName) {
+		logger.Trace("task is marked for deletion, skipping")
+		return nil
+	}
+
+	ctx, cancel := tm.runContext(ctx, task)
+	defer cancel()
+
 	// Note: order of these checks matters. Must check task.enabled after the
 	// in/active checks. It's possible that the task becomes disabled during the
 	// active period.
@@ -409,6 +664,7 @@ func (tm *TasksManager) TaskRunNow(ctx context.Context, taskName string) error {
 
 	var rendered bool
 	rendered, storedErr = d.RenderTemplate(ctx)
+	storedErr = runError(ctx, task, ev, storedErr)
 	if storedErr != nil {
 		defer storeEvent()
 		return fmt.Errorf("error rendering template for task %s: %s",
@@ -430,10 +686,14 @@ func (tm *TasksManager) TaskRunNow(ctx context.Context, taskName string) error {
 	// new data
 	if rendered {
 		logger.Info("executing task")
-		defer storeEvent()
+		defer func() {
+			storeEvent()
+			tm.auditor.Record(audit.ApplyEntry(ctx, ev))
+		}()
 
 		desc := fmt.Sprintf("ApplyTask %s", taskName)
-		storedErr = tm.retry.Do(ctx, d.ApplyTask, desc)
+		storedErr = tm.retry.Do(event.WithContext(ctx, ev), d.ApplyTask, desc)
+		storedErr = runError(ctx, task, ev, storedErr)
 		if storedErr != nil {
 			return fmt.Errorf("could not apply changes for task %s: %s",
 				taskName, storedErr)
@@ -451,7 +711,7 @@ func (tm *TasksManager) TaskRunNow(ctx context.Context, taskName string) error {
 
 // TaskByTemplate returns the name of the task associated with a template id.
 // If no task is associated with the template id, returns false.
-func (tm TasksManager) TaskByTemplate(tmplID string) (string, bool) {
+func (tm *TasksManager) TaskByTemplate(tmplID string) (string, bool) {
 	d, ok := tm.drivers.GetTaskByTemplate(tmplID)
 	if !ok {
 		return "", false
@@ -481,26 +741,28 @@ func (tm *TasksManager) EnableTaskDeletedNotify() <-chan string {
 
 // WatchCreatedScheduleTasks returns a channel to inform any watcher that a new
 // scheduled task has been created and added to CTS.
-func (tm TasksManager) WatchCreatedScheduleTasks() <-chan string {
+func (tm *TasksManager) WatchCreatedScheduleTasks() <-chan string {
 	return tm.createdScheduleCh
 }
 
 // WatchDeletedScheduleTask returns a channel to inform any watcher that a new
 // scheduled task has been deleted and removed from CTS.
-func (tm TasksManager) WatchDeletedScheduleTask() <-chan string {
+func (tm *TasksManager) WatchDeletedScheduleTask() <-chan string {
 	return tm.deletedScheduleCh
 }
 
 // createTask creates and initializes a singular task from configuration
 func (tm *TasksManager) createTask(ctx context.Context, taskConfig config.TaskConfig) (*config.TaskConfig, driver.Driver, error) {
-	conf := tm.state.GetConfig()
-	if err := taskConfig.Finalize(); err != nil {
-		tm.logger.Trace("invalid config to create task", "error", err)
-		return nil, nil, err
-	}
+	return tm.createTaskFrom(ctx, tm.state.GetConfig(), tm.factory.snapshot(), taskConfig)
+}
 
-	if err := taskConfig.Validate(); err != nil {
-		tm.logger.Trace("invalid config to create task", "error", err)
+// createTaskFrom creates a task like createTask with the configuration and
+// the provider and policy configuration of the driver factory snapshot
+func (tm *TasksManager) createTaskFrom(ctx context.Context, conf config.Config,
+	snap factorySnapshot, taskConfig config.TaskConfig) (*config.TaskConfig, driver.Driver, error) {
+
+	taskConfig, err := tm.finalizeTaskConfig(conf, taskConfig)
+	if err != nil {
 		return nil, nil, err
 	}
 
@@ -518,7 +780,7 @@ func (tm *TasksManager) createTask(ctx context.Context, taskConfig config.TaskCo
 		return nil, nil, fmt.Errorf("task with name %s already exists", taskName)
 	}
 
-	d, err := tm.factory.Make(ctx, &conf, taskConfig)
+	d, err := tm.factory.makeFromSnapshot(ctx, &conf, taskConfig, snap)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -552,6 +814,27 @@ func (tm *TasksManager) createTask(ctx context.Context, taskConfig config.TaskCo
 	}
 }
 
+// finalizeTaskConfig inherits the task template of the task configuration,
+// then finalizes and validates it
+func (tm *TasksManager) finalizeTaskConfig(conf config.Config, taskConfig config.TaskConfig) (config.TaskConfig, error) {
+	taskConfig, err := inheritTaskTemplate(conf, taskConfig)
+	if err != nil {
+		tm.logger.Trace("invalid task template to create task", "error", err)
+		return config.TaskConfig{}, err
+	}
+
+	if err := taskConfig.Finalize(); err != nil {
+		tm.logger.Trace("invalid config to create task", "error", err)
+		return config.TaskConfig{}, err
+	}
+
+	if err := taskConfig.Validate(); err != nil {
+		tm.logger.Trace("invalid config to create task", "error", err)
+		return config.TaskConfig{}, err
+	}
+	return taskConfig, nil
+}
+
 // runNewTask runs a new task that has not been added to CTS yet. This differs
 // from TaskRunNow which runs existing tasks that have been added to CTS.
 // runNewTask has reduced complexity because the task driver has not been added
@@ -585,8 +868,15 @@ func (tm *TasksManager) runNewTask(ctx context.Context, d driver.Driver,
 	}
 	ev.Start()
 
-	// Apply task
-	err = d.ApplyTask(ctx)
+	// Apply task. The task is not added to CTS yet so the run can only be
+	// stopped by the task's timeout
+	if timeout, ok := task.Timeout(); ok {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	err = d.ApplyTask(event.WithContext(ctx, ev))
+	err = runError(ctx, task, ev, err)
 	if err != nil {
 		logger.Error("error applying task", "error", err)
 		if !allowApplyErr {
@@ -595,6 +885,7 @@ func (tm *TasksManager) runNewTask(ctx context.Context, d driver.Driver,
 	}
 
 	ev.End(err)
+	tm.auditor.Record(audit.ApplyEntry(ctx, ev))
 
 	if tm.ranTaskNotify != nil {
 		tm.ranTaskNotify <- taskName
@@ -640,6 +931,8 @@ func (tm *TasksManager) deleteTask(ctx context.Context, name string) error {
 		return err
 	}
 
+	tm.moduleUpgrades.delete(name)
+
 	// Delete task from state only after driver successfully deleted
 	if err = tm.state.DeleteTask(name); err != nil {
 		logger.Error("error while deleting task state", "error", err)
@@ -658,6 +951,57 @@ func (tm *TasksManager) deleteTask(ctx context.Context, name string) error {
 	return nil
 }
 
+// destroyTaskResources destroys the resources managed by an existing task
+// once the task is inactive. The task is active for the destroy so that it is
+// not run or updated while its resources are destroyed. The destroy is
+// recorded as an event of the task and in the audit log. The event is stored
+// in the state if the destroy fails since the task is then not deleted.
+func (tm *TasksManager) destroyTaskResources(ctx context.Context, name string) error {
+	logger := tm.logger.With(taskNameLogKey, name)
+
+	d, ok := tm.drivers.Get(name)
+	if !ok {
+		logger.Debug("task does not exist")
+		return nil
+	}
+
+	if err := tm.waitForTaskInactive(ctx, name); err != nil {
+		logger.Error("error destroying task resources: error waiting for task "+
+			"to become inactive", "error", err)
+		return err
+	}
+	tm.drivers.SetActive(name)
+	defer tm.drivers.SetInactive(name)
+
+	task := d.Task()
+	ev, err := event.NewEvent(name, &event.Config{
+		Providers: task.ProviderIDs(),
+		Services:  task.ServiceNames(),
+		Source:    task.Module(),
+	})
+	if err != nil {
+		logger.Error("error initializing destroy task event", "error", err)
+		return err
+	}
+	ev.Start()
+
+	logger.Info("destroying task resources")
+	err = d.DestroyResources(event.WithContext(ctx, ev))
+	ev.End(err)
+	tm.auditor.Record(audit.DestroyEntry(ctx, ev))
+	if err != nil {
+		logger.Error("error destroying task resources, task will not be deleted",
+			"error", err)
+		if err := tm.state.AddTaskEvent(*ev); err != nil {
+			logger.Error("error storing event", "event", ev.GoString(), "error", err)
+		}
+		return err
+	}
+
+	logger.Info("task resources destroyed")
+	return nil
+}
+
 func (tm *TasksManager) waitForTaskInactive(ctx context.Context, name string) error {
 	// Check first if inactive, return early and don't log
 	if !tm.drivers.IsActive(name) {
@@ -677,3 +1021,517 @@ func (tm *TasksManager) waitForTaskInactive(ctx context.Context, name string) er
 		}
 	}
 }
+
+// SetConfigLoader sets the function to load the configuration from the
+// configuration files, which enables reloading the configuration
+func (tm *TasksManager) SetConfigLoader(load func() (*config.Config, error)) {
+	tm.loadConfig = load
+}
+
+// Reload loads the configuration files and applies the changes without a
+// restart. The log levels, provider blocks, and policies are updated live.
+// Tasks from the configuration files are compared with the tasks in the
+// state: new tasks are created and run, changed tasks are recreated and run,
+// and tasks removed from the configuration files are deleted. Tasks using a
+// changed provider block are recreated. Tasks created through the API are not
+// changed, except tasks that instantiate a task template which changed, and a
+// task from the configuration files with the same name as a task created
+// through the API is an error. The reloaded configuration of a changed task is
+// validated before the task is deleted, and a task that cannot be recreated is
+// restored with its previous configuration. Returns the names of the created,
+// updated, and deleted tasks.
+//
+// When rollouts are enabled, the changed tasks are recreated in batches by a
+// rollout that continues in the background, and the configuration cannot be
+// reloaded again until the rollout ends.
+func (tm *TasksManager) Reload(ctx context.Context) ([]string, []string, []string, error) {
+	if tm.loadConfig == nil {
+		return nil, nil, nil, fmt.Errorf("reloading the configuration is not supported")
+	}
+	if tm.isDraining() {
+		return nil, nil, nil, fmt.Errorf("configuration cannot be reloaded while shutting down")
+	}
+	if tm.rollouts.inProgress() {
+		return nil, nil, nil, fmt.Errorf("configuration cannot be reloaded while a rollout is in progress")
+	}
+
+	if !atomic.CompareAndSwapInt32(&tm.reloading, 0, 1) {
+		return nil, nil, nil, fmt.Errorf("configuration is already reloading")
+	}
+	rollingOut := false
+	defer func() {
+		// the rollout releases the lock once it ends
+		if !rollingOut {
+			atomic.StoreInt32(&tm.reloading, 0)
+		}
+	}()
+
+	tm.logger.Info("reloading configuration")
+	conf, err := tm.loadConfig()
+	if err != nil {
+		tm.logger.Error("error loading configuration to reload", "error", err)
+		return nil, nil, nil, err
+	}
+
+	if err := logging.SetLevels(config.StringVal(conf.LogLevel), conf.LogLevels); err != nil {
+		return nil, nil, nil, err
+	}
+
+	prevConf := tm.state.GetConfig()
+	prev := &reloadSnapshot{
+		conf:    prevConf,
+		factory: tm.factory.snapshot(),
+		tasks:   tm.configTasks,
+	}
+	changedProviders := changedProviderIDs(prevConf.TerraformProviders,
+		conf.TerraformProviders)
+	if err := tm.factory.Reload(ctx, conf); err != nil {
+		tm.logger.Error("error reloading provider and policy configuration", "error", err)
+		return nil, nil, nil, err
+	}
+	if err := tm.state.SetConfig(*conf); err != nil {
+		return nil, nil, nil, err
+	}
+
+	rolloutEnabled := conf.Rollout != nil && config.BoolVal(conf.Rollout.Enabled)
+	var created, updated, deleted, errs, rolloutTasks []string
+	newTasks := configTaskSet(conf)
+	for _, name := range sortedTaskNames(newTasks) {
+		logger := tm.logger.With(taskNameLogKey, name)
+		if _, ok := tm.state.GetTask(name); !ok {
+			logger.Info("creating task from reloaded configuration")
+			if err := tm.reloadCreateTask(ctx, conf, name); err != nil {
+				errs = append(errs, fmt.Sprintf("error creating task '%s': %s", name, err))
+				continue
+			}
+			created = append(created, name)
+			continue
+		}
+
+		if _, ok := tm.configTasks[name]; !ok {
+			// the task was created through the API, so it is not replaced
+			// and the task of the configuration files is not tracked
+			delete(newTasks, name)
+			errs = append(errs, fmt.Sprintf("task '%s' of the configuration "+
+				"files has the same name as a task that was created through "+
+				"the API", name))
+			continue
+		}
+
+		if !tm.pendingTasks[name] &&
+			!taskConfigChanged(tm.configTasks[name], newTasks[name], changedProviders) {
+			continue
+		}
+		delete(tm.pendingTasks, name)
+
+		if rolloutEnabled {
+			rolloutTasks = append(rolloutTasks, name)
+			updated = append(updated, name)
+			continue
+		}
+
+		logger.Info("recreating task changed by reloaded configuration")
+		if err := tm.recreateTask(ctx, conf, prev, name); err != nil {
+			errs = append(errs, fmt.Sprintf("error recreating task '%s': %s", name, err))
+			continue
+		}
+		updated = append(updated, name)
+	}
+
+	for _, name := range sortedTaskNames(tm.configTasks) {
+		if _, ok := newTasks[name]; ok {
+			continue
+		}
+		if _, ok := tm.drivers.Get(name); !ok {
+			continue
+		}
+
+		if err := ctx.Err(); err != nil {
+			// the task is kept to be deleted on the next reload
+			newTasks[name] = tm.configTasks[name]
+			errs = append(errs, fmt.Sprintf("error deleting task '%s': reload "+
+				"was cancelled: %s", name, err))
+			continue
+		}
+
+		tm.logger.Info("deleting task removed from reloaded configuration",
+			taskNameLogKey, name)
+		tm.drivers.MarkForDeletion(name)
+		if err := tm.deleteTask(ctx, name); err != nil {
+			errs = append(errs, fmt.Sprintf("error deleting task '%s': %s", name, err))
+			continue
+		}
+		delete(tm.pendingTasks, name)
+		deleted = append(deleted, name)
+	}
+
+	for _, name := range tm.templateInstances.names() {
+		if _, ok := newTasks[name]; ok {
+			// tasks from the configuration files inherit the changes to their
+			// template with the configuration
+			tm.templateInstances.delete(name)
+			continue
+		}
+		if _, ok := tm.drivers.Get(name); !ok {
+			continue
+		}
+
+		tc, _ := tm.templateInstances.get(name)
+		tmplName := config.StringVal(tc.Template)
+		prevTmpl, _ := prevConf.TaskTemplates.Get(tmplName)
+		tmpl, ok := conf.TaskTemplates.Get(tmplName)
+		if !ok {
+			if prevTmpl != nil {
+				errs = append(errs, fmt.Sprintf("task template '%s' of task '%s' "+
+					"was removed", tmplName, name))
+			}
+			continue
+		}
+		if !tm.pendingTasks[name] && reflect.DeepEqual(prevTmpl, tmpl) {
+			continue
+		}
+		delete(tm.pendingTasks, name)
+
+		if rolloutEnabled {
+			rolloutTasks = append(rolloutTasks, name)
+			updated = append(updated, name)
+			continue
+		}
+
+		tm.logger.Info("recreating task with changed task template",
+			taskNameLogKey, name, "template", tmplName)
+		if err := tm.recreateTask(ctx, conf, prev, name); err != nil {
+			errs = append(errs, fmt.Sprintf("error recreating task '%s': %s", name, err))
+			continue
+		}
+		updated = append(updated, name)
+	}
+	tm.configTasks = newTasks
+
+	if len(rolloutTasks) > 0 {
+		rollingOut = true
+		tm.startRollout(ctx, conf, prev, rolloutTasks)
+	}
+
+	tm.logger.Info("configuration reloaded", "created", created, "updated", updated,
+		"deleted", deleted)
+	if len(errs) > 0 {
+		return created, updated, deleted, fmt.Errorf("error reloading tasks: %s",
+			strings.Join(errs, "; "))
+	}
+	return created, updated, deleted, nil
+}
+
+// restoreTaskTimeout is the timeout for restoring a task that could not be
+// recreated. Restoring is not cancelled with the reload, since the task was
+// already deleted, and is only stopped early once the drain timeout elapses.
+const restoreTaskTimeout = 2 * time.Minute
+
+// reloadSnapshot is the configuration from before a reload, which tasks are
+// restored with if they cannot be recreated with the reloaded configuration
+type reloadSnapshot struct {
+	conf    config.Config
+	factory factorySnapshot
+	tasks   map[string]*config.TaskConfig
+}
+
+// reloadCreateTask creates and runs a task from the reloaded configuration.
+func (tm *TasksManager) reloadCreateTask(ctx context.Context, conf *config.Config, name string) error {
+	tc, err := tm.reloadTaskConfig(conf, name)
+	if err != nil {
+		return err
+	}
+	_, err = tm.TaskCreateAndRun(ctx, tc)
+	return err
+}
+
+// reloadTaskConfig returns the configuration of a task from the reloaded
+// configuration. Tasks created through the API that instantiate a task
+// template are returned as they were requested so that they inherit the
+// reloaded template.
+func (tm *TasksManager) reloadTaskConfig(conf *config.Config, name string) (config.TaskConfig, error) {
+	for _, tc := range *conf.Tasks {
+		if config.StringVal(tc.Name) == name {
+			return *tc.Copy(), nil
+		}
+	}
+	if tc, ok := tm.templateInstances.get(name); ok {
+		return tc, nil
+	}
+	return config.TaskConfig{}, fmt.Errorf("task '%s' is not configured", name)
+}
+
+// recreateTask recreates a task with its reloaded configuration and runs it.
+// The reloaded configuration is validated before the task is deleted. If the
+// task cannot be recreated, it is restored and run with its configuration and
+// the provider blocks and policies from before the reload, and it is marked
+// pending so that it is recreated again on the next reload.
+func (tm *TasksManager) recreateTask(ctx context.Context, conf *config.Config,
+	prev *reloadSnapshot, name string) error {
+
+	logger := tm.logger.With(taskNameLogKey, name)
+	tc, err := tm.reloadTaskConfig(conf, name)
+	if err != nil {
+		return err
+	}
+	if _, err := tm.finalizeTaskConfig(*conf, *tc.Copy()); err != nil {
+		return fmt.Errorf("invalid reloaded task configuration: %s", err)
+	}
+	if err := ctx.Err(); err != nil {
+		// the task is not deleted once the reload is cancelled, such as on
+		// shutdown, and is recreated on the next reload
+		tm.markPending(name)
+		return fmt.Errorf("reload was cancelled: %s", err)
+	}
+
+	tm.drivers.MarkForDeletion(name)
+	if err := tm.deleteTask(ctx, name); err != nil {
+		if _, ok := tm.drivers.Get(name); ok {
+			tm.drivers.UnmarkForDeletion(name)
+		}
+		return fmt.Errorf("error deleting task to update: %s", err)
+	}
+
+	_, err = tm.TaskCreateAndRun(ctx, tc)
+	if err == nil {
+		return nil
+	}
+	tm.markPending(name)
+
+	prevTask, ok := prev.tasks[name]
+	if !ok {
+		if instance, ok := tm.templateInstances.get(name); ok {
+			prevTask = &instance
+		}
+	}
+	if prevTask == nil {
+		return err
+	}
+
+	logger.Info("restoring task with its previous configuration")
+	if rerr := tm.restoreTask(ctx, prev, *prevTask.Copy()); rerr != nil {
+		logger.Error("error restoring task", "error", rerr)
+		return fmt.Errorf("%s; error restoring task with its previous "+
+			"configuration: %s", err, rerr)
+	}
+	return err
+}
+
+// restoreTask creates a task with the configuration and the provider blocks
+// and policies from before a reload, and runs it. The task is added even if
+// the run fails so that the task is not lost.
+func (tm *TasksManager) restoreTask(ctx context.Context, prev *reloadSnapshot, taskConfig config.TaskConfig) error {
+	ctx, cancel := context.WithTimeout(detachedContext{Context: tm.runCtx, values: ctx},
+		restoreTaskTimeout)
+	defer cancel()
+
+	requested := *taskConfig.Copy()
+	tc, d, err := tm.createTaskFrom(ctx, prev.conf, prev.factory, taskConfig)
+	if err != nil {
+		return err
+	}
+
+	logger := tm.logger.With(taskNameLogKey, *tc.Name)
+	ev, err := tm.runNewTask(ctx, d, true)
+	if err != nil {
+		logger.Error("error running restored task", "error", err)
+	}
+
+	if _, err := tm.addTask(ctx, *tc, d); err != nil {
+		return err
+	}
+	tm.templateInstances.set(requested)
+
+	if ev != nil {
+		if err := tm.state.AddTaskEvent(*ev); err != nil {
+			logger.Error("error storing event", "event", ev.GoString(), "error", err)
+		}
+	}
+	return nil
+}
+
+// markPending marks a task to be recreated on the next reload
+func (tm *TasksManager) markPending(name string) {
+	if tm.pendingTasks == nil {
+		tm.pendingTasks = make(map[string]bool)
+	}
+	tm.pendingTasks[name] = true
+}
+
+// configTaskSet returns the tasks of the configuration by name with the parent
+// configuration inherited so that changes to the parent configuration are
+// compared as changes to the task
+func configTaskSet(conf *config.Config) map[string]*config.TaskConfig {
+	tasks := make(map[string]*config.TaskConfig)
+	if conf == nil || conf.Tasks == nil {
+		return tasks
+	}
+
+	for _, tc := range *conf.Tasks {
+		name := config.StringVal(tc.Name)
+		if conf.WorkingDir != nil && conf.BufferPeriod != nil {
+			tasks[name] = tc.InheritParentConfig(*conf.WorkingDir, *conf.BufferPeriod)
+		} else {
+			tasks[name] = tc.Copy()
+		}
+	}
+	return tasks
+}
+
+func sortedTaskNames(tasks map[string]*config.TaskConfig) []string {
+	names := make([]string, 0, len(tasks))
+	for name := range tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// taskConfigChanged returns true if the task configuration changed or if the
+// task uses a provider block that changed
+func taskConfigChanged(prev, next *config.TaskConfig, changedProviders map[string]bool) bool {
+	if !reflect.DeepEqual(prev, next) {
+		return true
+	}
+	for _, p := range next.Providers {
+		if changedProviders[p] {
+			return true
+		}
+	}
+	return false
+}
+
+// changedProviderIDs returns the IDs of the provider blocks, the name or the
+// name and alias, that were added, changed, or removed
+func changedProviderIDs(prev, next *config.TerraformProviderConfigs) map[string]bool {
+	prevBlocks := providerBlocksByID(prev)
+	nextBlocks := providerBlocksByID(next)
+
+	changed := make(map[string]bool)
+	for id, block := range nextBlocks {
+		if !reflect.DeepEqual(prevBlocks[id], block) {
+			changed[id] = true
+		}
+	}
+	for id := range prevBlocks {
+		if _, ok := nextBlocks[id]; !ok {
+			changed[id] = true
+		}
+	}
+	return changed
+}
+
+func providerBlocksByID(c *config.TerraformProviderConfigs) map[string]interface{} {
+	blocks := make(map[string]interface{})
+	if c == nil {
+		return blocks
+	}
+
+	for _, p := range *c {
+		for name, values := range *p {
+			id := name
+			if m, ok := values.(map[string]interface{}); ok {
+				if alias, ok := m["alias"]; ok {
+					id = fmt.Sprintf("%s.%v", name, alias)
+				}
+			}
+			blocks[id] = values
+		}
+	}
+	return blocks
+}
+
+// Drain stops the tasks manager from starting new task runs and waits for
+// active task runs to complete. Task runs that are still active once the
+// timeout has elapsed are cancelled. Drain returns once no task runs are
+// active.
+func (tm *TasksManager) Drain(timeout time.Duration) {
+	atomic.StoreInt32(&tm.draining, 1)
+	tm.rollouts.stop()
+
+	active := tm.drivers.ActiveNames()
+	if len(active) == 0 {
+		return
+	}
+	tm.logger.Info("waiting for active tasks to complete", "tasks", active,
+		"drain_timeout", timeout)
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(100 * time.Millisecond)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-timer.C:
+			tm.logger.Warn("drain timeout elapsed, cancelling active tasks",
+				"tasks", tm.drivers.ActiveNames())
+			tm.stopRuns()
+		case <-ticker.C:
+			if len(tm.drivers.ActiveNames()) == 0 {
+				tm.logger.Info("active tasks completed")
+				return
+			}
+		}
+	}
+}
+
+// Stop releases the resources of the tasks manager, such as the audit log
+func (tm *TasksManager) Stop() {
+	if tm == nil {
+		return
+	}
+	if err := tm.auditor.Close(); err != nil {
+		tm.logger.Error("error closing audit log", "error", err)
+	}
+}
+
+func (tm *TasksManager) isDraining() bool {
+	return atomic.LoadInt32(&tm.draining) == 1
+}
+
+// runContext returns the context for a run of an added task. The context is
+// cancelled when the task's timeout is exceeded, when the run is cancelled
+// with TaskCancel, or when the drain timeout elapses on shutdown. It is not
+// cancelled with ctx so that active runs can be drained, but it carries the
+// values of ctx.
+func (tm *TasksManager) runContext(ctx context.Context, task *driver.Task) (context.Context, context.CancelFunc) {
+	ctx = detachedContext{Context: tm.runCtx, values: ctx}
+
+	var cancel context.CancelFunc
+	if timeout, ok := task.Timeout(); ok {
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+	} else {
+		ctx, cancel = context.WithCancel(ctx)
+	}
+	tm.drivers.SetCancel(task.Name(), cancel)
+	return ctx, cancel
+}
+
+// runError returns the error for a task run. If the run errored because it was
+// cancelled or timed out, the event, if any, is marked as cancelled and the
+// error describes why the run was stopped.
+func runError(ctx context.Context, task *driver.Task, ev *event.Event, err error) error {
+	if err == nil || ctx.Err() == nil {
+		return err
+	}
+
+	if ev != nil {
+		ev.Cancelled = true
+	}
+	if ctx.Err() == context.DeadlineExceeded {
+		timeout, _ := task.Timeout()
+		return fmt.Errorf("task run timed out after %s: %s", timeout, err)
+	}
+	return fmt.Errorf("task run was cancelled: %s", err)
+}
+
+// detachedContext is a context that is cancelled with its embedded context
+// but returns the values of another context
+type detachedContext struct {
+	context.Context
+	values context.Context
+}
+
+func (c detachedContext) Value(key interface{}) interface{} {
+	return c.values.Value(key)
+}
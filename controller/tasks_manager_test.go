@@ -1,21 +1,27 @@
 package controller
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/hashicorp/consul-terraform-sync/audit"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/driver"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocksD "github.com/hashicorp/consul-terraform-sync/mocks/driver"
+	mocksPolicy "github.com/hashicorp/consul-terraform-sync/mocks/policy"
 	mocksS "github.com/hashicorp/consul-terraform-sync/mocks/state"
 	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/policy"
 	"github.com/hashicorp/consul-terraform-sync/state"
 	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/templates"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -263,7 +269,7 @@ func Test_TasksManager_TaskCreateAndRun(t *testing.T) {
 		mockD.On("Task").Return(task).
 			On("InitTask", ctx).Return(nil).
 			On("RenderTemplate", mock.Anything).Return(true, nil).
-			On("ApplyTask", ctx).Return(fmt.Errorf("apply err"))
+			On("ApplyTask", mock.Anything).Return(fmt.Errorf("apply err"))
 		tm.state = state.NewInMemoryStore(conf)
 		tm.drivers = driver.NewDrivers()
 		tm.factory.newDriver = func(context.Context, *config.Config, *driver.Task, templates.Watcher) (driver.Driver, error) {
@@ -298,7 +304,7 @@ func Test_TasksManager_TaskDelete(t *testing.T) {
 
 		tm.drivers = drivers
 
-		go tm.TaskDelete(ctx, taskName)
+		go tm.TaskDelete(ctx, taskName, false, false)
 		select {
 		case n := <-deletedCh:
 			assert.Equal(t, taskName, n)
@@ -314,10 +320,825 @@ func Test_TasksManager_TaskDelete(t *testing.T) {
 		taskName := "delete_task"
 		tm.drivers = drivers
 		tm.drivers.MarkForDeletion(taskName)
-		err := tm.TaskDelete(ctx, taskName)
+		err := tm.TaskDelete(ctx, taskName, false, false)
 		assert.NoError(t, err)
 		assert.True(t, tm.drivers.IsMarkedForDeletion(taskName))
 	})
+
+	t.Run("cancel", func(t *testing.T) {
+		drivers := driver.NewDrivers()
+		taskName := "cancel_task"
+
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("Task").Return(enabledTestTask(t, taskName))
+		mockD.On("DestroyTask", ctx).Return()
+		drivers.Add(taskName, mockD)
+
+		// running execution is cancelled and released as done by a task run
+		runCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		drivers.SetActive(taskName)
+		drivers.SetCancel(taskName, cancel)
+		go func() {
+			<-runCtx.Done()
+			drivers.SetInactive(taskName)
+		}()
+
+		tm.drivers = drivers
+
+		err := tm.TaskDelete(ctx, taskName, false, true)
+		require.NoError(t, err)
+		assert.Error(t, runCtx.Err(), "running execution was not cancelled")
+		select {
+		case n := <-deletedCh:
+			assert.Equal(t, taskName, n)
+		case <-time.After(1 * time.Second):
+			t.Fatal("delete channel did not receive message")
+		}
+		assert.Equal(t, 0, drivers.Len())
+	})
+
+	t.Run("cancel removed task", func(t *testing.T) {
+		// the driver is removed by a previous delete before the cancel
+		drivers := driver.NewDrivers()
+		taskName := "removed_task"
+		tm.drivers = drivers
+		tm.drivers.MarkForDeletion(taskName)
+
+		err := tm.TaskDelete(ctx, taskName, false, true)
+		assert.NoError(t, err)
+	})
+
+	t.Run("destroy", func(t *testing.T) {
+		drivers := driver.NewDrivers()
+		taskName := "destroy_task"
+
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("Task").Return(enabledTestTask(t, taskName))
+		mockD.On("DestroyResources", mock.Anything).Return(nil).Once().
+			Run(func(mock.Arguments) {
+				// the task cannot be run or updated during the destroy
+				assert.True(t, drivers.IsActive(taskName))
+				_, _, _, err := tm.TaskUpdate(ctx, config.TaskConfig{
+					Name:    config.String(taskName),
+					Enabled: config.Bool(true),
+				}, driver.RunOptionNow)
+				assert.Error(t, err)
+			})
+		mockD.On("DestroyTask", ctx).Return()
+		drivers.Add(taskName, mockD)
+
+		tm.drivers = drivers
+
+		err := tm.TaskDelete(ctx, taskName, true, false)
+		require.NoError(t, err)
+		select {
+		case n := <-deletedCh:
+			assert.Equal(t, taskName, n)
+		case <-time.After(1 * time.Second):
+			t.Fatal("delete channel did not receive message")
+		}
+		assert.Equal(t, 0, drivers.Len())
+		mockD.AssertExpectations(t)
+	})
+
+	t.Run("destroy error", func(t *testing.T) {
+		drivers := driver.NewDrivers()
+		taskName := "destroy_error_task"
+
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("Task").Return(enabledTestTask(t, taskName))
+		mockD.On("DestroyResources", mock.Anything).Return(errors.New("error")).Once()
+		drivers.Add(taskName, mockD)
+
+		tm.drivers = drivers
+
+		err := tm.TaskDelete(ctx, taskName, true, false)
+		require.NoError(t, err)
+
+		// the task is not deleted and the failed destroy is stored as an event
+		assert.Eventually(t, func() bool {
+			return len(tm.state.GetTaskEvents(taskName)[taskName]) == 1
+		}, time.Second, 10*time.Millisecond)
+		assert.Eventually(t, func() bool {
+			return !drivers.IsMarkedForDeletion(taskName)
+		}, time.Second, 10*time.Millisecond)
+		assert.Equal(t, 1, drivers.Len())
+		mockD.AssertNotCalled(t, "DestroyTask", mock.Anything)
+
+		events := tm.state.GetTaskEvents(taskName)[taskName]
+		assert.False(t, events[0].Success)
+	})
+}
+
+func Test_TasksManager_TaskInspectDestroy(t *testing.T) {
+	ctx := context.Background()
+	tm := newTestTasksManager()
+
+	t.Run("happy path", func(t *testing.T) {
+		mockD := new(mocksD.Driver)
+		mockD.On("TemplateIDs").Return(nil)
+		mockD.On("InspectDestroy", ctx).Return(driver.InspectPlan{
+			ChangesPresent: true,
+			Plan:           "plan",
+		}, nil).Once()
+		tm.drivers.Add("task", mockD)
+
+		changes, plan, _, err := tm.TaskInspectDestroy(ctx, "task")
+		require.NoError(t, err)
+		assert.True(t, changes)
+		assert.Equal(t, "plan", plan)
+	})
+
+	t.Run("task does not exist", func(t *testing.T) {
+		_, _, _, err := tm.TaskInspectDestroy(ctx, "nonexistent")
+		assert.Error(t, err)
+	})
+}
+
+func Test_TasksManager_TaskExport(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("task does not exist", func(t *testing.T) {
+		tm := newTestTasksManager()
+		_, err := tm.TaskExport(ctx, "task_a")
+		assert.Error(t, err)
+	})
+
+	t.Run("exported", func(t *testing.T) {
+		tm := newTestTasksManager()
+		files := map[string][]byte{"main.tf": []byte("module {}")}
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		d.On("ExportTask", mock.Anything).Return(files, nil).Once()
+		tm.drivers.Add("task_a", d)
+
+		actual, err := tm.TaskExport(ctx, "task_a")
+		assert.NoError(t, err)
+		assert.Equal(t, files, actual)
+		d.AssertExpectations(t)
+	})
+
+	t.Run("export error", func(t *testing.T) {
+		tm := newTestTasksManager()
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		d.On("ExportTask", mock.Anything).Return(nil, errors.New("error")).Once()
+		tm.drivers.Add("task_a", d)
+
+		_, err := tm.TaskExport(ctx, "task_a")
+		assert.Error(t, err)
+	})
+}
+
+func Test_TasksManager_TaskState(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("task does not exist", func(t *testing.T) {
+		tm := newTestTasksManager()
+		_, err := tm.TaskState(ctx, "task_a")
+		assert.Error(t, err)
+	})
+
+	t.Run("shown", func(t *testing.T) {
+		tm := newTestTasksManager()
+		s := &tfjson.State{FormatVersion: "1.0"}
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		d.On("ShowState", mock.Anything).Return(s, nil).Once()
+		tm.drivers.Add("task_a", d)
+
+		actual, err := tm.TaskState(ctx, "task_a")
+		assert.NoError(t, err)
+		assert.Equal(t, s, actual)
+		d.AssertExpectations(t)
+	})
+
+	t.Run("show error", func(t *testing.T) {
+		tm := newTestTasksManager()
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		d.On("ShowState", mock.Anything).Return(nil, errors.New("error")).Once()
+		tm.drivers.Add("task_a", d)
+
+		_, err := tm.TaskState(ctx, "task_a")
+		assert.Error(t, err)
+	})
+}
+
+func Test_TasksManager_TaskMigrateState(t *testing.T) {
+	ctx := context.Background()
+	backend := map[string]interface{}{
+		"local": map[string]interface{}{"path": "task_a.tfstate"},
+	}
+
+	newTasksManager := func(t *testing.T, task *driver.Task) (*TasksManager, *mocksD.Driver) {
+		tm := newTestTasksManager()
+		conf := &config.TaskConfig{
+			Name:   config.String("task_a"),
+			Module: config.String("path"),
+			Condition: &config.ScheduleConditionConfig{
+				ScheduleMonitorConfig: config.ScheduleMonitorConfig{Cron: config.String("* * * * * * *")},
+			},
+		}
+		require.NoError(t, conf.Finalize())
+		require.NoError(t, tm.state.SetTask(*conf))
+
+		d := new(mocksD.Driver)
+		d.On("Task").Return(task)
+		d.On("TemplateIDs").Return(nil)
+		tm.drivers.Add("task_a", d)
+		return tm, d
+	}
+
+	t.Run("migrated", func(t *testing.T) {
+		tm, d := newTasksManager(t, disabledTestTask(t, "task_a"))
+		d.On("MigrateState", ctx, backend).Return(nil).Once()
+
+		err := tm.TaskMigrateState(ctx, "task_a", backend)
+		require.NoError(t, err)
+		d.AssertExpectations(t)
+
+		conf, err := tm.Task(ctx, "task_a")
+		require.NoError(t, err)
+		assert.Equal(t, backend, conf.Backend)
+		assert.False(t, tm.drivers.IsActive("task_a"))
+	})
+
+	t.Run("task does not exist", func(t *testing.T) {
+		tm := newTestTasksManager()
+		err := tm.TaskMigrateState(ctx, "task_a", backend)
+		assert.Error(t, err)
+	})
+
+	t.Run("task enabled", func(t *testing.T) {
+		tm, d := newTasksManager(t, enabledTestTask(t, "task_a"))
+		err := tm.TaskMigrateState(ctx, "task_a", backend)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "must be disabled")
+		d.AssertNotCalled(t, "MigrateState", mock.Anything, mock.Anything)
+	})
+
+	t.Run("invalid backend", func(t *testing.T) {
+		tm, d := newTasksManager(t, disabledTestTask(t, "task_a"))
+		err := tm.TaskMigrateState(ctx, "task_a", map[string]interface{}{
+			"remote": map[string]interface{}{},
+		})
+		assert.Error(t, err)
+		d.AssertNotCalled(t, "MigrateState", mock.Anything, mock.Anything)
+	})
+
+	t.Run("migrate error", func(t *testing.T) {
+		tm, d := newTasksManager(t, disabledTestTask(t, "task_a"))
+		d.On("MigrateState", ctx, backend).Return(errors.New("error")).Once()
+
+		err := tm.TaskMigrateState(ctx, "task_a", backend)
+		assert.Error(t, err)
+
+		conf, err := tm.Task(ctx, "task_a")
+		require.NoError(t, err)
+		assert.Nil(t, conf.Backend)
+	})
+}
+
+func Test_TasksManager_TaskCancel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("task does not exist", func(t *testing.T) {
+		tm := newTestTasksManager()
+		cancelled, err := tm.TaskCancel(ctx, "task_a")
+		assert.Error(t, err)
+		assert.False(t, cancelled)
+	})
+
+	t.Run("task not running", func(t *testing.T) {
+		tm := newTestTasksManager()
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		tm.drivers.Add("task_a", d)
+
+		cancelled, err := tm.TaskCancel(ctx, "task_a")
+		assert.NoError(t, err)
+		assert.False(t, cancelled)
+	})
+
+	t.Run("task running", func(t *testing.T) {
+		tm := newTestTasksManager()
+		task := enabledTestTask(t, "task_a")
+		started := make(chan struct{})
+
+		d := new(mocksD.Driver)
+		d.On("Task").Return(task)
+		d.On("TemplateIDs").Return(nil)
+		d.On("RenderTemplate", mock.Anything).Return(true, nil)
+		d.On("ApplyTask", mock.Anything).Return(func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		tm.drivers.Add("task_a", d)
+
+		errCh := make(chan error)
+		go func() {
+			errCh <- tm.TaskRunNow(ctx, "task_a")
+		}()
+		<-started
+
+		cancelled, err := tm.TaskCancel(ctx, "task_a")
+		assert.NoError(t, err)
+		assert.True(t, cancelled)
+
+		select {
+		case err := <-errCh:
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), "cancelled")
+		case <-time.After(time.Second):
+			t.Fatal("task run was not cancelled")
+		}
+		assert.False(t, tm.drivers.IsActive("task_a"))
+
+		events := tm.state.GetTaskEvents("task_a")["task_a"]
+		require.Len(t, events, 1)
+		assert.True(t, events[0].Cancelled)
+		assert.False(t, events[0].Success)
+	})
+}
+
+func Test_TasksManager_TaskRunNow_Timeout(t *testing.T) {
+	task, err := driver.NewTask(driver.TaskConfig{
+		Name:    "task_a",
+		Enabled: true,
+		Timeout: 10 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	d := new(mocksD.Driver)
+	d.On("Task").Return(task)
+	d.On("TemplateIDs").Return(nil)
+	d.On("RenderTemplate", mock.Anything).Return(true, nil)
+	d.On("ApplyTask", mock.Anything).Return(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	tm := newTestTasksManager()
+	tm.drivers.Add("task_a", d)
+
+	err = tm.TaskRunNow(context.Background(), "task_a")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timed out after 10ms")
+	assert.False(t, tm.drivers.IsActive("task_a"))
+
+	events := tm.state.GetTaskEvents("task_a")["task_a"]
+	require.Len(t, events, 1)
+	assert.True(t, events[0].Cancelled)
+}
+
+func Test_TasksManager_TaskRunNow_Audit(t *testing.T) {
+	d := new(mocksD.Driver)
+	d.On("Task").Return(enabledTestTask(t, "task_a"))
+	d.On("TemplateIDs").Return(nil)
+	d.On("RenderTemplate", mock.Anything).Return(true, nil)
+	d.On("ApplyTask", mock.Anything).Return(func(ctx context.Context) error {
+		event.FromContext(ctx).Changes = &event.ChangeSummary{Add: 1}
+		return nil
+	})
+
+	var buf bytes.Buffer
+	tm := newTestTasksManager()
+	tm.auditor = audit.NewWriter(&buf)
+	tm.drivers.Add("task_a", d)
+
+	actor := &audit.Actor{TokenName: "ops"}
+	ctx := audit.WithOrigin(context.Background(), audit.Origin{RequestID: "req", Actor: actor})
+	err := tm.TaskRunNow(ctx, "task_a")
+	require.NoError(t, err)
+
+	var entry audit.Entry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entry))
+	assert.Equal(t, audit.ActionApply, entry.Action)
+	assert.Equal(t, "task_a", entry.TaskName)
+	assert.Equal(t, "req", entry.RequestID)
+	assert.Equal(t, actor, entry.Actor)
+	assert.True(t, entry.Result.Success)
+	require.NotNil(t, entry.Apply)
+	assert.Equal(t, &event.ChangeSummary{Add: 1}, entry.Apply.Changes)
+
+	events := tm.state.GetTaskEvents("task_a")["task_a"]
+	require.Len(t, events, 1)
+	assert.Equal(t, events[0].ID, entry.Apply.EventID)
+}
+
+func Test_TasksManager_Drain(t *testing.T) {
+	newDriver := func(t *testing.T, applyTask func(ctx context.Context) error) *mocksD.Driver {
+		d := new(mocksD.Driver)
+		d.On("Task").Return(enabledTestTask(t, "task_a"))
+		d.On("TemplateIDs").Return(nil)
+		d.On("RenderTemplate", mock.Anything).Return(true, nil)
+		d.On("ApplyTask", mock.Anything).Return(applyTask)
+		return d
+	}
+
+	t.Run("no active tasks", func(t *testing.T) {
+		tm := newTestTasksManager()
+		d := newDriver(t, func(context.Context) error { return nil })
+		tm.drivers.Add("task_a", d)
+
+		tm.Drain(time.Minute)
+
+		// no new task runs once draining
+		err := tm.TaskRunNow(context.Background(), "task_a")
+		assert.Error(t, err)
+		d.AssertNotCalled(t, "ApplyTask", mock.Anything)
+	})
+
+	t.Run("waits for active tasks", func(t *testing.T) {
+		tm := newTestTasksManager()
+		started := make(chan struct{})
+		release := make(chan struct{})
+		d := newDriver(t, func(ctx context.Context) error {
+			close(started)
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})
+		tm.drivers.Add("task_a", d)
+
+		// cancelling the caller's context does not interrupt the run
+		ctx, cancel := context.WithCancel(context.Background())
+		errCh := make(chan error)
+		go func() {
+			errCh <- tm.TaskRunNow(ctx, "task_a")
+		}()
+		<-started
+		cancel()
+
+		drained := make(chan struct{})
+		go func() {
+			tm.Drain(time.Minute)
+			close(drained)
+		}()
+
+		select {
+		case <-drained:
+			t.Fatal("drain returned while a task was active")
+		case <-time.After(200 * time.Millisecond):
+		}
+
+		close(release)
+		assert.NoError(t, <-errCh)
+		select {
+		case <-drained:
+		case <-time.After(time.Second):
+			t.Fatal("drain did not return once the task completed")
+		}
+	})
+
+	t.Run("timeout cancels active tasks", func(t *testing.T) {
+		tm := newTestTasksManager()
+		started := make(chan struct{})
+		d := newDriver(t, func(ctx context.Context) error {
+			close(started)
+			<-ctx.Done()
+			return ctx.Err()
+		})
+		tm.drivers.Add("task_a", d)
+
+		errCh := make(chan error)
+		go func() {
+			errCh <- tm.TaskRunNow(context.Background(), "task_a")
+		}()
+		<-started
+
+		tm.Drain(10 * time.Millisecond)
+		err := <-errCh
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "cancelled")
+		assert.False(t, tm.drivers.IsActive("task_a"))
+	})
+}
+
+func Test_TasksManager_Reload(t *testing.T) {
+	ctx := context.Background()
+
+	taskConf := func(name, module string) *config.TaskConfig {
+		tc := validTaskConf.Copy()
+		tc.Name = config.String(name)
+		tc.Module = config.String(module)
+		return tc
+	}
+	newConf := func(tasks ...*config.TaskConfig) *config.Config {
+		conf := config.DefaultConfig()
+		conf.Tasks = &config.TaskConfigs{}
+		*conf.Tasks = append(*conf.Tasks, tasks...)
+		require.NoError(t, conf.Finalize())
+		return conf
+	}
+	existingDriver := func(name string) *mocksD.Driver {
+		d := new(mocksD.Driver)
+		d.On("Task").Return(enabledTestTask(t, name))
+		d.On("TemplateIDs").Return(nil)
+		d.On("DestroyTask", mock.Anything).Return()
+		return d
+	}
+
+	t.Run("not_supported", func(t *testing.T) {
+		tm := newTestTasksManager()
+		_, _, _, err := tm.Reload(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("load_error", func(t *testing.T) {
+		tm := newTestTasksManager()
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return nil, errors.New("invalid config")
+		})
+		_, _, _, err := tm.Reload(ctx)
+		assert.Error(t, err)
+	})
+
+	t.Run("tasks_changed", func(t *testing.T) {
+		conf := newConf(taskConf("keep", "module"), taskConf("change", "module"),
+			taskConf("remove", "module"))
+
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		tm.configTasks = configTaskSet(conf)
+		tm.factory.initConf = conf
+		tm.factory.watcher = new(mocksTmpl.Watcher)
+		for _, name := range []string{"keep", "change", "remove", "api"} {
+			tm.drivers.Add(name, existingDriver(name))
+		}
+
+		// a task created with the API is not in the configuration files
+		require.NoError(t, tm.state.SetTask(*taskConf("api", "module")))
+
+		var made []string
+		tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+			made = append(made, task.Name())
+			d := new(mocksD.Driver)
+			d.On("SetBufferPeriod").Return()
+			mockDriver(ctx, d, task)
+			return d, nil
+		}
+
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf(taskConf("keep", "module"), taskConf("change", "module/v2"),
+				taskConf("add", "module")), nil
+		})
+
+		created, updated, deleted, err := tm.Reload(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"add"}, created)
+		assert.Equal(t, []string{"change"}, updated)
+		assert.Equal(t, []string{"remove"}, deleted)
+		assert.ElementsMatch(t, []string{"add", "change"}, made)
+
+		for _, name := range []string{"keep", "change", "add", "api"} {
+			_, ok := tm.drivers.Get(name)
+			assert.True(t, ok, "expected task %q to exist", name)
+		}
+		_, ok := tm.drivers.Get("remove")
+		assert.False(t, ok, "expected removed task to be deleted")
+
+		tc, ok := tm.state.GetTask("change")
+		require.True(t, ok)
+		assert.Equal(t, "module/v2", config.StringVal(tc.Module))
+
+		// reloading the same configuration does not change tasks
+		made = nil
+		created, updated, deleted, err = tm.Reload(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, created)
+		assert.Empty(t, updated)
+		assert.Empty(t, deleted)
+		assert.Empty(t, made)
+	})
+
+	t.Run("api_task_conflict", func(t *testing.T) {
+		conf := newConf(taskConf("keep", "module"))
+
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		tm.configTasks = configTaskSet(conf)
+		tm.factory.initConf = conf
+		tm.factory.watcher = new(mocksTmpl.Watcher)
+		for _, name := range []string{"keep", "api"} {
+			tm.drivers.Add(name, existingDriver(name))
+		}
+		require.NoError(t, tm.state.SetTask(*taskConf("api", "api-module")))
+		tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+			t.Fatalf("unexpected task %q created", task.Name())
+			return nil, nil
+		}
+
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf(taskConf("keep", "module"), taskConf("api", "module")), nil
+		})
+		_, updated, _, err := tm.Reload(ctx)
+		assert.Error(t, err)
+		assert.Empty(t, updated)
+
+		tc, ok := tm.state.GetTask("api")
+		require.True(t, ok)
+		assert.Equal(t, "api-module", config.StringVal(tc.Module))
+		_, ok = tm.configTasks["api"]
+		assert.False(t, ok)
+	})
+
+	t.Run("recreate_failed", func(t *testing.T) {
+		conf := newConf(taskConf("change", "module"))
+
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		tm.configTasks = configTaskSet(conf)
+		tm.factory.initConf = conf
+		tm.factory.watcher = new(mocksTmpl.Watcher)
+		tm.drivers.Add("change", existingDriver("change"))
+		require.NoError(t, tm.state.SetTask(*taskConf("change", "module")))
+
+		// the policies from before the reload are removed by the reload
+		prevPolicies := []policy.Evaluator{new(mocksPolicy.Evaluator)}
+		tm.factory.policies = prevPolicies
+
+		var made []string
+		var restored *driver.Task
+		tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+			made = append(made, task.Module())
+			var applyErr error
+			if task.Module() == "module/v2" {
+				applyErr = errors.New("apply error")
+			} else {
+				restored = task
+			}
+			d := new(mocksD.Driver)
+			d.On("SetBufferPeriod").Return()
+			d.On("Task").Return(task).
+				On("InitTask", mock.Anything).Return(nil).
+				On("TemplateIDs").Return(nil).
+				On("DestroyTask", mock.Anything).Return().
+				On("RenderTemplate", mock.Anything).Return(true, nil).
+				On("ApplyTask", mock.Anything).Return(applyErr).Once()
+			return d, nil
+		}
+
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf(taskConf("change", "module/v2")), nil
+		})
+		_, _, _, err := tm.Reload(ctx)
+		assert.Error(t, err)
+
+		// the task is restored and run with its previous configuration
+		assert.Equal(t, []string{"module/v2", "module"}, made)
+		d, ok := tm.drivers.Get("change")
+		require.True(t, ok)
+		d.(*mocksD.Driver).AssertCalled(t, "ApplyTask", mock.Anything)
+		require.NotNil(t, restored)
+		assert.Equal(t, prevPolicies, restored.Policies())
+		tc, ok := tm.state.GetTask("change")
+		require.True(t, ok)
+		assert.Equal(t, "module", config.StringVal(tc.Module))
+		assert.True(t, tm.pendingTasks["change"])
+	})
+
+	t.Run("invalid_reloaded_task", func(t *testing.T) {
+		conf := newConf(taskConf("change", "module"))
+
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		tm.configTasks = configTaskSet(conf)
+		tm.factory.initConf = conf
+		tm.factory.watcher = new(mocksTmpl.Watcher)
+		prevDriver := existingDriver("change")
+		tm.drivers.Add("change", prevDriver)
+
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			// the task references a task template that is not configured
+			conf := newConf(taskConf("change", "module/v2"))
+			(*conf.Tasks)[0].Template = config.String("missing")
+			return conf, nil
+		})
+		_, _, _, err := tm.Reload(ctx)
+		assert.Error(t, err)
+
+		// the task is not deleted
+		d, ok := tm.drivers.Get("change")
+		require.True(t, ok)
+		assert.Equal(t, prevDriver, d)
+		assert.False(t, tm.drivers.IsMarkedForDeletion("change"))
+	})
+
+	t.Run("cancelled_while_recreating", func(t *testing.T) {
+		conf := newConf(taskConf("change", "module"))
+
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		tm.configTasks = configTaskSet(conf)
+		tm.factory.initConf = conf
+		tm.factory.watcher = new(mocksTmpl.Watcher)
+		tm.drivers.Add("change", existingDriver("change"))
+		require.NoError(t, tm.state.SetTask(*taskConf("change", "module")))
+
+		// shutdown cancels the reload once the task is deleted
+		reloadCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		var made []string
+		tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+			made = append(made, task.Module())
+			if task.Module() == "module/v2" {
+				cancel()
+			}
+			d := new(mocksD.Driver)
+			d.On("SetBufferPeriod").Return()
+			d.On("Task").Return(task).
+				On("InitTask", mock.Anything).Return(nil).
+				On("TemplateIDs").Return(nil).
+				On("DestroyTask", mock.Anything).Return().
+				On("RenderTemplate", mock.Anything).Return(true, nil).
+				On("ApplyTask", mock.Anything).Return(nil).Run(func(args mock.Arguments) {
+				assert.NoError(t, args.Get(0).(context.Context).Err(),
+					"restored task should not run with the cancelled context")
+			})
+			return d, nil
+		}
+
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf(taskConf("change", "module/v2")), nil
+		})
+		_, _, _, err := tm.Reload(reloadCtx)
+		assert.Error(t, err)
+
+		// the task is restored with its previous configuration
+		assert.Equal(t, []string{"module/v2", "module"}, made)
+		_, ok := tm.drivers.Get("change")
+		require.True(t, ok)
+		tc, ok := tm.state.GetTask("change")
+		require.True(t, ok)
+		assert.Equal(t, "module", config.StringVal(tc.Module))
+		assert.True(t, tm.pendingTasks["change"])
+	})
+
+	t.Run("cancelled", func(t *testing.T) {
+		conf := newConf(taskConf("change", "module"), taskConf("remove", "module"))
+
+		tm := newTestTasksManager()
+		tm.state = state.NewInMemoryStore(conf)
+		tm.configTasks = configTaskSet(conf)
+		tm.factory.initConf = conf
+		tm.factory.watcher = new(mocksTmpl.Watcher)
+		prevDrivers := map[string]*mocksD.Driver{}
+		for _, name := range []string{"change", "remove"} {
+			prevDrivers[name] = existingDriver(name)
+			tm.drivers.Add(name, prevDrivers[name])
+			require.NoError(t, tm.state.SetTask(*taskConf(name, "module")))
+		}
+		tm.factory.newDriver = func(_ context.Context, _ *config.Config, task *driver.Task, _ templates.Watcher) (driver.Driver, error) {
+			t.Fatalf("unexpected task %q created", task.Name())
+			return nil, nil
+		}
+
+		tm.SetConfigLoader(func() (*config.Config, error) {
+			return newConf(taskConf("change", "module/v2")), nil
+		})
+		cancelledCtx, cancel := context.WithCancel(ctx)
+		cancel()
+		_, _, _, err := tm.Reload(cancelledCtx)
+		assert.Error(t, err)
+
+		// tasks are not deleted once the reload is cancelled and are changed
+		// on the next reload
+		for name, prevDriver := range prevDrivers {
+			d, ok := tm.drivers.Get(name)
+			require.True(t, ok, name)
+			assert.Equal(t, prevDriver, d)
+			assert.False(t, tm.drivers.IsMarkedForDeletion(name))
+		}
+		assert.True(t, tm.pendingTasks["change"])
+		_, ok := tm.configTasks["remove"]
+		assert.True(t, ok)
+	})
+}
+
+func Test_changedProviderIDs(t *testing.T) {
+	prev := &config.TerraformProviderConfigs{
+		{"a": map[string]interface{}{"token": "1"}},
+		{"b": map[string]interface{}{"alias": "x", "token": "1"}},
+		{"c": map[string]interface{}{}},
+	}
+	next := &config.TerraformProviderConfigs{
+		{"a": map[string]interface{}{"token": "1"}},
+		{"b": map[string]interface{}{"alias": "x", "token": "2"}},
+		{"d": map[string]interface{}{}},
+	}
+
+	assert.Equal(t, map[string]bool{"b.x": true, "c": true, "d": true},
+		changedProviderIDs(prev, next))
 }
 
 func Test_TasksManager_TaskUpdate(t *testing.T) {
@@ -343,7 +1164,7 @@ func Test_TasksManager_TaskUpdate(t *testing.T) {
 		}
 		err = taskConf.Finalize()
 		require.NoError(t, err)
-		task, err := newDriverTask(conf, &taskConf, nil)
+		task, err := newDriverTask(conf, &taskConf, nil, nil, nil)
 		require.NoError(t, err)
 
 		d := new(mocksD.Driver)
@@ -377,6 +1198,21 @@ func Test_TasksManager_TaskUpdate(t *testing.T) {
 		assert.Empty(t, events)
 	})
 
+	t.Run("marked-for-deletion-error", func(t *testing.T) {
+		taskName := "task_deleted"
+		d := new(mocksD.Driver)
+		d.On("TemplateIDs").Return(nil)
+		require.NoError(t, tm.drivers.Add(taskName, d))
+		tm.drivers.MarkForDeletion(taskName)
+
+		_, _, _, err := tm.TaskUpdate(ctx, config.TaskConfig{
+			Name:    config.String(taskName),
+			Enabled: config.Bool(true),
+		}, driver.RunOptionNow)
+		assert.Error(t, err)
+		d.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+	})
+
 	t.Run("task-not-found-error", func(t *testing.T) {
 		taskConf := config.TaskConfig{
 			Name:    config.String("non-existent-task"),
@@ -769,7 +1605,7 @@ func Test_TasksManager_TaskRunNow(t *testing.T) {
 		d.On("Task").Return(enabledTestTask(t, validTaskName)).
 			On("TemplateIDs").Return(nil).
 			On("RenderTemplate", mock.Anything).Return(true, nil).
-			On("ApplyTask", ctx).Return(nil)
+			On("ApplyTask", mock.Anything).Return(nil)
 		drivers := tm.drivers
 		drivers.Add(validTaskName, d)
 		drivers.SetActive(validTaskName)
@@ -878,7 +1714,7 @@ func Test_TasksManager_TaskCreateAndRunAllowFail(t *testing.T) {
 		mockD.On("Task").Return(task).
 			On("InitTask", ctx).Return(nil).
 			On("RenderTemplate", mock.Anything).Return(true, nil).
-			On("ApplyTask", ctx).Return(fmt.Errorf("apply err")).
+			On("ApplyTask", mock.Anything).Return(fmt.Errorf("apply err")).
 			On("SetBufferPeriod").Return().Once().
 			On("TemplateIDs").Return(nil).Once()
 
@@ -1081,16 +1917,23 @@ func mockDriver(ctx context.Context, d *mocksD.Driver, task *driver.Task) {
 		On("InitTask", ctx).Return(nil).
 		On("TemplateIDs").Return(nil).
 		On("RenderTemplate", mock.Anything).Return(true, nil).
-		On("ApplyTask", ctx).Return(nil)
+		On("ApplyTask", mock.Anything).Return(nil)
 }
 
 func newTestTasksManager() *TasksManager {
+	runCtx, stopRuns := context.WithCancel(context.Background())
 	return &TasksManager{
 		logger: logging.NewNullLogger(),
 		factory: &driverFactory{
 			logger: logging.NewNullLogger(),
 		},
-		drivers: driver.NewDrivers(),
-		state:   state.NewInMemoryStore(nil),
+		drivers:           driver.NewDrivers(),
+		state:             state.NewInMemoryStore(nil),
+		moduleUpgrades:    newModuleUpgrades(),
+		rollouts:          &rollouts{},
+		templateInstances: newTemplateInstances(),
+		generatedTasks:    newGeneratedTasks(),
+		runCtx:            runCtx,
+		stopRuns:          stopRuns,
 	}
 }
@@ -0,0 +1,191 @@
+package driver
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+)
+
+const (
+	ansibleSubsystemName = "ansible"
+
+	// AnsibleInventoryFilename is the file in the task's working directory
+	// that the inventory generated from the services of the task is written to
+	AnsibleInventoryFilename = "cts-inventory.json"
+)
+
+// ansibleRecapRegexp matches the counts of a host in the PLAY RECAP of the
+// ansible-playbook output, e.g. `web : ok=3 changed=1 unreachable=0 failed=0`
+var ansibleRecapRegexp = regexp.MustCompile(
+	`(?m)^\S+\s+:\s+ok=\d+\s+changed=(\d+)\s`)
+
+// AnsibleConfig configures the Ansible driver
+type AnsibleConfig struct {
+	Task    *Task
+	Watcher templates.Watcher
+
+	// Path is the path of the ansible-playbook executable
+	Path string
+
+	// Args are additional arguments for ansible-playbook
+	Args []string
+
+	// Log logs the output of ansible-playbook
+	Log bool
+}
+
+// NewAnsible configures and initializes a new Ansible driver for a task. The
+// Ansible driver is an exec driver that runs the task's module as a playbook
+// with ansible-playbook.
+//
+// The module inputs of the task are passed to the playbook as extra
+// variables. The instances of the services of the task are the hosts of the
+// inventory, grouped by service name. Tasks are inspected by running the
+// playbook with --check and --diff.
+func NewAnsible(config *AnsibleConfig) (*Exec, error) {
+	logger := logging.Global().Named(logSystemName).Named(ansibleSubsystemName)
+	return newExec(config.Task, config.Watcher, &ansibleRunner{
+		path: config.Path,
+		args: config.Args,
+	}, config.Log, logger)
+}
+
+// CheckAnsible checks that the ansible-playbook executable is installed. The
+// Ansible driver does not install Ansible.
+func CheckAnsible(path string) error {
+	if _, err := exec.LookPath(path); err != nil {
+		return fmt.Errorf("ansible-playbook executable %q was not found, install "+
+			"Ansible or configure the path of ansible-playbook for the ansible "+
+			"driver: %s", path, err)
+	}
+	return nil
+}
+
+// ansibleRunner runs the task's module as a playbook with ansible-playbook
+type ansibleRunner struct {
+	path string
+	args []string
+}
+
+func (r *ansibleRunner) plans() bool {
+	return true
+}
+
+// command writes the inventory for the module inputs and returns the
+// ansible-playbook command for the task's playbook
+func (r *ansibleRunner) command(task *Task, input []byte, plan bool) (string, []string, error) {
+	wd := task.WorkingDir()
+
+	inventory, err := ansibleInventory(input)
+	if err != nil {
+		return "", nil, err
+	}
+	inventoryFilepath := filepath.Join(wd, AnsibleInventoryFilename)
+	if err = ioutil.WriteFile(inventoryFilepath, inventory, filePerms); err != nil {
+		return "", nil, err
+	}
+
+	// the playbook is run in the task's working directory, so relative paths
+	// are resolved against the working directory of CTS
+	playbook, err := filepath.Abs(task.Module())
+	if err != nil {
+		return "", nil, err
+	}
+
+	args := []string{
+		"--inventory", inventoryFilepath,
+		"--extra-vars", "@" + filepath.Join(wd, ExecInputFilename),
+	}
+	args = append(args, r.args...)
+	if plan {
+		args = append(args, "--check", "--diff")
+	}
+	args = append(args, playbook)
+
+	return r.path, args, nil
+}
+
+// result summarizes the hosts changed by the playbook from the PLAY RECAP of
+// the output. Each changed host is counted as one change.
+func (r *ansibleRunner) result(_ bool, output []byte, err error) (execResult, error) {
+	if err != nil {
+		return execResult{}, err
+	}
+
+	summary := &event.ChangeSummary{}
+	for _, m := range ansibleRecapRegexp.FindAllSubmatch(output, -1) {
+		if n, _ := strconv.Atoi(string(m[1])); n > 0 {
+			summary.Change++
+		}
+	}
+
+	return execResult{
+		changesPresent: summary.Change > 0,
+		summary:        summary,
+	}, nil
+}
+
+// ansibleService is the subset of a service instance of the module inputs
+// used to generate the inventory
+type ansibleService struct {
+	Name        string `json:"name"`
+	Address     string `json:"address"`
+	NodeAddress string `json:"node_address"`
+}
+
+// ansibleInventory generates an Ansible inventory in the YAML inventory
+// format, encoded as JSON, from the services of the module inputs. Each
+// service instance is a host that is a member of the group of its service
+// name. The instance is available to the playbook as the `cts_service` host
+// variable.
+func ansibleInventory(input []byte) ([]byte, error) {
+	var inputs struct {
+		Services map[string]json.RawMessage `json:"services"`
+	}
+	if err := json.Unmarshal(input, &inputs); err != nil {
+		return nil, err
+	}
+
+	hosts := make(map[string]interface{}, len(inputs.Services))
+	groups := make(map[string]map[string]interface{})
+	for id, raw := range inputs.Services {
+		var s ansibleService
+		if err := json.Unmarshal(raw, &s); err != nil {
+			return nil, fmt.Errorf("invalid service instance %q: %s", id, err)
+		}
+
+		address := s.Address
+		if address == "" {
+			address = s.NodeAddress
+		}
+		hosts[id] = map[string]interface{}{
+			"ansible_host": address,
+			"cts_service":  raw,
+		}
+
+		if groups[s.Name] == nil {
+			groups[s.Name] = make(map[string]interface{})
+		}
+		groups[s.Name][id] = nil
+	}
+
+	children := make(map[string]interface{}, len(groups))
+	for name, members := range groups {
+		children[name] = map[string]interface{}{"hosts": members}
+	}
+
+	return json.MarshalIndent(map[string]interface{}{
+		"all": map[string]interface{}{
+			"hosts":    hosts,
+			"children": children,
+		},
+	}, "", "  ")
+}
@@ -0,0 +1,169 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ansiblePlaybookScript is run by the tests in place of ansible-playbook. It
+// prints its arguments and a PLAY RECAP with the number of changes for the
+// host, which is 1 unless --check is passed.
+const ansiblePlaybookScript = `#!/bin/sh
+echo "args: $@"
+changed=1
+for arg in "$@"; do
+  if [ "$arg" = "--check" ]; then changed=0; fi
+done
+echo "PLAY RECAP *********************************************************************"
+echo "api-1                      : ok=2    changed=$changed    unreachable=0    failed=0    skipped=0"
+echo "localhost                  : ok=1    changed=0    unreachable=0    failed=0    skipped=0"
+`
+
+func TestAnsible_ApplyTask(t *testing.T) {
+	t.Parallel()
+
+	e := newTestExec(t, nil, nil, nil)
+	wd := e.task.WorkingDir()
+	path := filepath.Join(wd, "ansible-playbook")
+	require.NoError(t, ioutil.WriteFile(path, []byte(ansiblePlaybookScript), 0700))
+	e.runner = &ansibleRunner{path: path, args: []string{"--forks", "5"}}
+
+	t.Run("apply", func(t *testing.T) {
+		ev := &event.Event{TaskName: "test"}
+		ctx := event.WithContext(context.Background(), ev)
+
+		err := e.ApplyTask(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, &event.ChangeSummary{Change: 1}, ev.Changes)
+		assert.Contains(t, ev.Logs, "--forks 5 "+e.task.Module())
+		assert.FileExists(t, filepath.Join(wd, AnsibleInventoryFilename))
+	})
+
+	t.Run("inspect", func(t *testing.T) {
+		plan, err := e.InspectTask(context.Background())
+		require.NoError(t, err)
+		assert.False(t, plan.ChangesPresent)
+		assert.Contains(t, plan.Plan, "--check --diff")
+		assert.Contains(t, plan.Plan, "PLAY RECAP")
+	})
+}
+
+func TestAnsibleRunner_Command(t *testing.T) {
+	t.Parallel()
+
+	e := newTestExec(t, nil, nil, nil)
+	wd := e.task.WorkingDir()
+	r := &ansibleRunner{path: "ansible-playbook", args: []string{"--user", "deploy"}}
+
+	t.Run("apply", func(t *testing.T) {
+		name, args, err := r.command(e.task, []byte(`{}`), false)
+		require.NoError(t, err)
+		assert.Equal(t, "ansible-playbook", name)
+		assert.Equal(t, []string{
+			"--inventory", filepath.Join(wd, AnsibleInventoryFilename),
+			"--extra-vars", "@" + filepath.Join(wd, ExecInputFilename),
+			"--user", "deploy",
+			e.task.Module(),
+		}, args)
+	})
+
+	t.Run("plan", func(t *testing.T) {
+		_, args, err := r.command(e.task, []byte(`{}`), true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"--check", "--diff", e.task.Module()}, args[6:])
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, _, err := r.command(e.task, []byte(`{"services": []}`), false)
+		assert.Error(t, err)
+	})
+}
+
+func TestAnsibleRunner_Result(t *testing.T) {
+	t.Parallel()
+
+	r := &ansibleRunner{}
+
+	t.Run("changes", func(t *testing.T) {
+		output := []byte(`PLAY RECAP ***
+web-1 : ok=3    changed=2    unreachable=0    failed=0
+web-2 : ok=3    changed=0    unreachable=0    failed=0
+web-3 : ok=3    changed=1    unreachable=0    failed=0
+`)
+		result, err := r.result(false, output, nil)
+		require.NoError(t, err)
+		assert.True(t, result.changesPresent)
+		assert.Equal(t, &event.ChangeSummary{Change: 2}, result.summary)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		result, err := r.result(true, []byte("PLAY RECAP ***\n"), nil)
+		require.NoError(t, err)
+		assert.False(t, result.changesPresent)
+		assert.Equal(t, &event.ChangeSummary{}, result.summary)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := r.result(false, nil, assert.AnError)
+		assert.Error(t, err)
+	})
+}
+
+func TestAnsibleInventory(t *testing.T) {
+	t.Parallel()
+
+	input := []byte(`{
+  "services": {
+    "api-1.node.dc1": {"name": "api", "address": "10.0.0.1", "port": 8080},
+    "api-2.node.dc1": {"name": "api", "address": "", "node_address": "10.0.0.2"},
+    "web.node.dc1": {"name": "web", "address": "10.0.0.3"}
+  },
+  "count": 1
+}`)
+
+	b, err := ansibleInventory(input)
+	require.NoError(t, err)
+
+	var inventory struct {
+		All struct {
+			Hosts    map[string]map[string]interface{} `json:"hosts"`
+			Children map[string]struct {
+				Hosts map[string]interface{} `json:"hosts"`
+			} `json:"children"`
+		} `json:"all"`
+	}
+	require.NoError(t, json.Unmarshal(b, &inventory))
+
+	hosts := inventory.All.Hosts
+	require.Len(t, hosts, 3)
+	assert.Equal(t, "10.0.0.1", hosts["api-1.node.dc1"]["ansible_host"])
+	assert.Equal(t, "10.0.0.2", hosts["api-2.node.dc1"]["ansible_host"])
+	assert.Equal(t, map[string]interface{}{
+		"name": "api", "address": "10.0.0.1", "port": float64(8080),
+	}, hosts["api-1.node.dc1"]["cts_service"])
+
+	children := inventory.All.Children
+	require.Len(t, children, 2)
+	assert.Len(t, children["api"].Hosts, 2)
+	assert.Contains(t, children["web"].Hosts, "web.node.dc1")
+
+	t.Run("no services", func(t *testing.T) {
+		b, err := ansibleInventory([]byte(`{"consul_kv": {}}`))
+		require.NoError(t, err)
+		assert.JSONEq(t, `{"all": {"hosts": {}, "children": {}}}`, string(b))
+	})
+}
+
+func TestCheckAnsible(t *testing.T) {
+	t.Parallel()
+
+	assert.NoError(t, CheckAnsible("sh"))
+	assert.Error(t, CheckAnsible(filepath.Join(t.TempDir(), "ansible-playbook")))
+}
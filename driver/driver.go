@@ -2,6 +2,9 @@ package driver
 
 import (
 	"context"
+
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
 //go:generate mockery --name=Driver --filename=driver.go  --output=../mocks/driver
@@ -35,6 +38,35 @@ type Driver interface {
 	// DestroyTask destroys task dependencies so that it can be safely deleted
 	DestroyTask(ctx context.Context)
 
+	// InspectDestroy inspects the resources managed by the task that would be
+	// destroyed by DestroyResources
+	InspectDestroy(ctx context.Context) (InspectPlan, error)
+
+	// DestroyResources destroys the resources managed by the task and cleans
+	// up the task's working directory and state
+	DestroyResources(ctx context.Context) error
+
+	// MigrateState migrates the state of the task to the backend and
+	// configures the task to store its state in the backend
+	MigrateState(ctx context.Context, backend map[string]interface{}) error
+
+	// ShowState returns the state of the resources managed by the task
+	ShowState(ctx context.Context) (*tfjson.State, error)
+
+	// ExportTask returns the files of a standalone root module for the task
+	// with the most recently rendered Consul data, keyed by file name
+	ExportTask(ctx context.Context) (map[string][]byte, error)
+
+	// CheckModuleUpgrade checks the module registry for a newer version of
+	// the task's module that meets the task's version constraint
+	CheckModuleUpgrade(ctx context.Context, c *registry.Client) (registry.ModuleUpgrade, error)
+
+	// UpgradeModule installs the version of the task's module from the
+	// module registry and inspects the changes of the upgraded module. The
+	// upgrade fails if it destroys or replaces resources, unless allowDestroy
+	// is true.
+	UpgradeModule(ctx context.Context, version string, allowDestroy bool) (InspectPlan, error)
+
 	// Task returns the task information of the driver
 	Task() *Task
 
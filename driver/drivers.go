@@ -23,6 +23,9 @@ type Drivers struct {
 	// Tracks which driver is currently active
 	active sync.Map
 
+	// Map of task name to the function that cancels the active run
+	cancels sync.Map
+
 	// Tracks if a driver is marked for deletion
 	deletion map[string]bool
 }
@@ -96,6 +99,7 @@ func (d *Drivers) Reset(ctx context.Context) {
 		driver.DestroyTask(ctx)
 		delete(d.drivers, taskName)
 		d.active.Delete(taskName)
+		d.cancels.Delete(taskName)
 	}
 }
 
@@ -131,6 +135,7 @@ func (d *Drivers) SetActive(name string) bool {
 }
 
 func (d *Drivers) SetInactive(name string) bool {
+	d.cancels.Delete(name)
 	_, ok := d.active.Load(name)
 	if ok {
 		d.active.Delete(name)
@@ -138,11 +143,38 @@ func (d *Drivers) SetInactive(name string) bool {
 	return ok
 }
 
+// SetCancel sets the function that cancels the active run of the driver. It
+// is cleared once the driver is set inactive.
+func (d *Drivers) SetCancel(name string, cancel context.CancelFunc) {
+	d.cancels.Store(name, cancel)
+}
+
+// Cancel cancels the active run of the driver. Returns false if the driver
+// does not have an active run that can be cancelled.
+func (d *Drivers) Cancel(name string) bool {
+	v, ok := d.cancels.LoadAndDelete(name)
+	if !ok {
+		return false
+	}
+	v.(context.CancelFunc)()
+	return true
+}
+
 func (d *Drivers) IsActive(name string) bool {
 	_, ok := d.active.Load(name)
 	return ok
 }
 
+// ActiveNames returns the names of the drivers that are currently active
+func (d *Drivers) ActiveNames() []string {
+	var names []string
+	d.active.Range(func(k, _ interface{}) bool {
+		names = append(names, k.(string))
+		return true
+	})
+	return names
+}
+
 // Delete removes the driver for the given task name from
 // the map of drivers.
 func (d *Drivers) Delete(taskName string) error {
@@ -179,6 +211,14 @@ func (d *Drivers) MarkForDeletion(name string) {
 	d.deletion[name] = true
 }
 
+// UnmarkForDeletion removes the deletion mark of a task that could not be
+// deleted so that the task can run again
+func (d *Drivers) UnmarkForDeletion(name string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.deletion, name)
+}
+
 func (d *Drivers) IsMarkedForDeletion(name string) bool {
 	d.mu.RLock()
 	defer d.mu.RUnlock()
@@ -259,6 +259,36 @@ func TestDrivers_SetInactive(t *testing.T) {
 	}
 }
 
+func TestDrivers_Cancel(t *testing.T) {
+	d := NewDrivers()
+
+	t.Run("no active run", func(t *testing.T) {
+		assert.False(t, d.Cancel("task"))
+	})
+
+	t.Run("active run", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		d.SetActive("task")
+		d.SetCancel("task", cancel)
+
+		assert.True(t, d.Cancel("task"))
+		assert.Error(t, ctx.Err())
+
+		// cancel function is only called once
+		assert.False(t, d.Cancel("task"))
+	})
+
+	t.Run("cleared when inactive", func(t *testing.T) {
+		_, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		d.SetActive("task")
+		d.SetCancel("task", cancel)
+		d.SetInactive("task")
+
+		assert.False(t, d.Cancel("task"))
+	})
+}
+
 func TestDrivers_IsActive(t *testing.T) {
 	d := NewDrivers()
 	driverType := "terraform"
@@ -289,6 +319,18 @@ func TestDrivers_IsActive(t *testing.T) {
 	}
 }
 
+func TestDrivers_ActiveNames(t *testing.T) {
+	d := NewDrivers()
+	assert.Empty(t, d.ActiveNames())
+
+	d.SetActive("task_a")
+	d.SetActive("task_b")
+	assert.ElementsMatch(t, []string{"task_a", "task_b"}, d.ActiveNames())
+
+	d.SetInactive("task_a")
+	assert.Equal(t, []string{"task_b"}, d.ActiveNames())
+}
+
 func TestDrivers_Delete(t *testing.T) {
 	cases := []struct {
 		name      string
@@ -355,6 +397,14 @@ func TestDrivers_MarkForDeletion(t *testing.T) {
 	assert.True(t, drivers.deletion[name])
 }
 
+func TestDrivers_UnmarkForDeletion(t *testing.T) {
+	drivers := NewDrivers()
+	name := "test_task"
+	drivers.MarkForDeletion(name)
+	drivers.UnmarkForDeletion(name)
+	assert.False(t, drivers.IsMarkedForDeletion(name))
+}
+
 func TestDrivers_IsMarkedForDeletion(t *testing.T) {
 	name := "test_task"
 
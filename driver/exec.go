@@ -0,0 +1,775 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/hook"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/consul-terraform-sync/templates"
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
+	"github.com/hashicorp/consul-terraform-sync/version"
+	"github.com/hashicorp/hcat"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/pkg/errors"
+	ctyjson "github.com/zclconf/go-cty/cty/json"
+)
+
+const (
+	execSubsystemName = "exec"
+
+	// ExecInputFilename is the file in the task's working directory that the
+	// module inputs of the task are written to as JSON for the executable
+	ExecInputFilename = "cts-input.json"
+
+	// Environment variables set for the executable
+	execEnvTaskName  = "CTS_TASK_NAME"
+	execEnvInputFile = "CTS_INPUT_FILE"
+
+	// execChangesExitCode is the exit code of the executable when it is run
+	// with the plan arguments and there are changes to apply. Exit code 0
+	// means there are no changes and any other exit code is an error.
+	execChangesExitCode = 2
+)
+
+var _ Driver = (*Exec)(nil)
+
+// Exec is a CTS driver that runs an executable, such as a script or a
+// configuration management tool, with the module inputs of the task. By
+// default, the task's module is the path of the executable. The Ansible
+// driver instead runs the task's module as a playbook with ansible-playbook.
+// Commands are run in the task's working directory.
+//
+// The module inputs are rendered the same way as for the Terraform driver
+// and written as a JSON object to a file. The path of the file is set in the
+// CTS_INPUT_FILE environment variable.
+type Exec struct {
+	mu sync.RWMutex
+
+	task   *Task
+	runner execRunner
+
+	resolver   templates.Resolver
+	template   templates.Template
+	watcher    templates.Watcher
+	fileReader func(string) ([]byte, error)
+
+	logOutput bool
+	hooks     []hook.Hook
+
+	logger logging.Logger
+
+	onceNotifier *notifier.OnceNotifier
+}
+
+// ExecConfig configures the exec driver
+type ExecConfig struct {
+	Task    *Task
+	Watcher templates.Watcher
+
+	// Args are the arguments to run the executable with to apply changes
+	Args []string
+
+	// PlanArgs are the arguments to run the executable with to plan changes.
+	// Tasks cannot be inspected when no plan arguments are configured.
+	PlanArgs []string
+
+	// Log logs the output of the executable
+	Log bool
+}
+
+// NewExec configures and initializes a new exec driver for a task.
+func NewExec(config *ExecConfig) (*Exec, error) {
+	logger := logging.Global().Named(logSystemName).Named(execSubsystemName)
+	return newExec(config.Task, config.Watcher, &scriptRunner{
+		args:     config.Args,
+		planArgs: config.PlanArgs,
+	}, config.Log, logger)
+}
+
+// newExec returns an exec driver for the task that runs the commands of the
+// runner
+func newExec(task *Task, w templates.Watcher, runner execRunner, logOutput bool,
+	logger logging.Logger) (*Exec, error) {
+
+	wd := task.WorkingDir()
+	if _, err := os.Stat(wd); os.IsNotExist(err) {
+		if err := os.MkdirAll(wd, workingDirPerms); err != nil {
+			logger.Error("error creating task work directory", "error", err)
+			return nil, err
+		}
+	}
+
+	return &Exec{
+		task:       task,
+		runner:     runner,
+		resolver:   hcat.NewResolver(),
+		watcher:    w,
+		fileReader: ioutil.ReadFile,
+		logOutput:  logOutput,
+		hooks:      task.Hooks(),
+		logger:     logger,
+	}, nil
+}
+
+// Version returns the version of the exec driver, which is the CTS version.
+func (e *Exec) Version() string {
+	return version.Version
+}
+
+// Task returns the task config info
+func (e *Exec) Task() *Task {
+	return e.task
+}
+
+func (e *Exec) OnceDone() bool {
+	if e.onceNotifier == nil {
+		return false
+	}
+	return e.onceNotifier.OnceDone()
+}
+
+// InitTask initializes the task by creating the template for the module
+// inputs of the task.
+func (e *Exec) InitTask(_ context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	return e.initTask()
+}
+
+// DestroyTask destroys task dependencies so that it is safe for deletion
+func (e *Exec) DestroyTask(_ context.Context) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.deregisterTemplate()
+}
+
+// ExportTask is not supported by the exec driver since the task does not
+// have a Terraform root module.
+func (e *Exec) ExportTask(_ context.Context) (map[string][]byte, error) {
+	return nil, fmt.Errorf("task '%s' cannot be exported, exporting tasks "+
+		"is only supported by the Terraform driver", e.task.Name())
+}
+
+// InspectDestroy is not supported by the exec driver since the resources
+// managed by the task are not tracked in a Terraform state.
+func (e *Exec) InspectDestroy(_ context.Context) (InspectPlan, error) {
+	return InspectPlan{}, e.errDestroyUnsupported()
+}
+
+// DestroyResources is not supported by the exec driver since the resources
+// managed by the task are not tracked in a Terraform state.
+func (e *Exec) DestroyResources(_ context.Context) error {
+	return e.errDestroyUnsupported()
+}
+
+// MigrateState is not supported by the exec driver since the task does not
+// store a Terraform state.
+func (e *Exec) MigrateState(_ context.Context, _ map[string]interface{}) error {
+	return fmt.Errorf("state of task '%s' cannot be migrated, migrating state "+
+		"is only supported by the Terraform driver", e.task.Name())
+}
+
+// ShowState is not supported by the exec driver since the resources managed
+// by the task are not tracked in a Terraform state.
+func (e *Exec) ShowState(_ context.Context) (*tfjson.State, error) {
+	return nil, fmt.Errorf("state of task '%s' cannot be shown, showing state "+
+		"is only supported by the Terraform driver", e.task.Name())
+}
+
+// CheckModuleUpgrade is not supported by the exec driver since tasks do not
+// have a module from a module registry.
+func (e *Exec) CheckModuleUpgrade(_ context.Context, _ *registry.Client) (registry.ModuleUpgrade, error) {
+	return registry.ModuleUpgrade{}, fmt.Errorf("task '%s' does not have a "+
+		"module: %w", e.task.Name(), registry.ErrNotRegistrySource)
+}
+
+// UpgradeModule is not supported by the exec driver since tasks do not have
+// a module.
+func (e *Exec) UpgradeModule(_ context.Context, _ string, _ bool) (InspectPlan, error) {
+	return InspectPlan{}, e.errModuleUpgradeUnsupported()
+}
+
+func (e *Exec) errModuleUpgradeUnsupported() error {
+	return fmt.Errorf("module of task '%s' cannot be upgraded, upgrading "+
+		"modules is only supported by the Terraform driver", e.task.Name())
+}
+
+func (e *Exec) errDestroyUnsupported() error {
+	return fmt.Errorf("resources of task '%s' cannot be destroyed, destroying "+
+		"resources is only supported by the Terraform driver", e.task.Name())
+}
+
+// SetBufferPeriod sets the buffer period for the task. Do not set this when
+// task needs to immediately render a template and run.
+func (e *Exec) SetBufferPeriod() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	taskName := e.task.Name()
+	if !e.task.IsEnabled() {
+		e.logger.Trace("task disabled. skip setting buffer period", taskNameLogKey, taskName)
+		return
+	}
+
+	if e.template == nil {
+		e.logger.Warn("attempted to set buffer for task which does not have a template", taskNameLogKey, taskName)
+		return
+	}
+
+	bp, ok := e.task.BufferPeriod()
+	if !ok {
+		e.logger.Trace("no buffer period for task", taskNameLogKey, taskName)
+		return
+	}
+
+	e.logger.Trace("set buffer period for task", taskNameLogKey, taskName, "buffer_period", bp)
+	e.watcher.SetBufferPeriod(bp.Min, bp.Max, e.template.ID())
+}
+
+func (e *Exec) TemplateIDs() []string {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.template == nil {
+		return nil
+	}
+	return []string{e.template.ID()}
+}
+
+// RenderTemplate fetches data for the template. If the data is complete fetched,
+// renders the template. Returns a boolean whether the template was rendered
+func (e *Exec) RenderTemplate(_ context.Context) (bool, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	taskName := e.task.Name()
+
+	if !e.task.IsEnabled() {
+		e.logger.Trace("task disabled. skip rendering template", taskNameLogKey, taskName)
+		return true, nil
+	}
+
+	e.logger.Trace("checking dependency changes for task", taskNameLogKey, taskName)
+	re, err := e.renderTemplate()
+	return re.Complete && !re.NoChange, err
+}
+
+// InspectTask inspects for any differences pertaining to the task by running
+// the command to plan changes
+func (e *Exec) InspectTask(ctx context.Context) (InspectPlan, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.task.IsEnabled() {
+		e.logger.Trace(
+			"task disabled. skip inspecting", taskNameLogKey, e.task.Name())
+		return InspectPlan{
+			Plan: "Task is disabled, inspection was skipped.",
+		}, nil
+	}
+
+	plan, err := e.inspectTask(ctx)
+	e.deregisterTemplate()
+	return plan, err
+}
+
+// ApplyTask applies the task changes.
+func (e *Exec) ApplyTask(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if !e.task.IsEnabled() {
+		e.logger.Trace(
+			"task disabled. skip applying", taskNameLogKey, e.task.Name())
+		return nil
+	}
+
+	return e.applyTask(ctx)
+}
+
+// UpdateTask updates the task on the driver. If update task is requested
+// with the inspect run option, then dry run the updates by returning the
+// inspected plan for the expected updates but do not update the task
+func (e *Exec) UpdateTask(ctx context.Context, patch PatchTask) (InspectPlan, error) {
+	taskName := e.task.Name()
+	switch patch.RunOption {
+	case "", RunOptionInspect, RunOptionNow:
+		// valid options
+	default:
+		return InspectPlan{}, fmt.Errorf("Invalid run option '%s'. Please select a valid "+
+			"option", patch.RunOption)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	originalEnabled := e.task.IsEnabled()
+
+	// for inspect, dry-run the task with the planned change and then make sure
+	// to reset the task back to the way it was
+	if patch.RunOption == RunOptionInspect && originalEnabled != patch.Enabled {
+		defer func() {
+			if originalEnabled {
+				e.task.Enable()
+			} else {
+				e.task.Disable()
+			}
+		}()
+	}
+
+	reinit := false
+	if originalEnabled != patch.Enabled {
+		if patch.Enabled {
+			e.task.Enable()
+			reinit = true
+		} else {
+			e.task.Disable()
+		}
+	}
+
+	if !patch.Enabled {
+		return InspectPlan{}, nil
+	}
+
+	if reinit {
+		if err := e.initTask(); err != nil {
+			return InspectPlan{}, fmt.Errorf("Error updating task '%s'. Unable to init "+
+				"task: %s", taskName, err)
+		}
+
+		for {
+			result, err := e.renderTemplate()
+			if err != nil {
+				return InspectPlan{}, fmt.Errorf("Error updating task '%s'. Unable to "+
+					"render template for task: %s", taskName, err)
+			}
+			if (result.Complete && !result.NoChange) || (result.Complete && result.NoChange && e.OnceDone()) {
+				break
+			}
+		}
+	}
+
+	if patch.RunOption == RunOptionInspect {
+		e.logger.Trace("update task. inspect run option", taskNameLogKey, taskName)
+		plan, err := e.inspectTask(ctx)
+		if err != nil {
+			return InspectPlan{}, fmt.Errorf("Error updating task '%s'. Unable to inspect "+
+				"task: %s", taskName, err)
+		}
+		return plan, nil
+	}
+
+	if patch.RunOption == RunOptionNow {
+		e.logger.Trace("update task. run now option", taskNameLogKey, taskName)
+		return InspectPlan{}, e.applyTask(ctx)
+	}
+
+	return InspectPlan{}, nil
+}
+
+// initTask writes the template for the module inputs of the task and
+// registers it to be rendered
+func (e *Exec) initTask() error {
+	// convert relative paths to absolute paths for local executables
+	module := e.task.module
+	if strings.HasPrefix(module, "./") || strings.HasPrefix(module, "../") {
+		wd, err := os.Getwd()
+		if err != nil {
+			e.logger.Error("unable to retrieve current working directory to determine path to local executable",
+				"error", err)
+			return err
+		}
+		e.task.module = filepath.Join(wd, module)
+	}
+
+	input := tftmpl.RootModuleInputData{
+		Path:      e.task.WorkingDir(),
+		FilePerms: filePerms,
+	}
+	if err := e.task.configureRootModuleInput(&input); err != nil {
+		return err
+	}
+
+	if err := tftmpl.InitTFVarsTemplate(&input); err != nil {
+		return err
+	}
+
+	return e.initTaskTemplate()
+}
+
+// initTaskTemplate creates the template to be monitored and rendered.
+func (e *Exec) initTaskTemplate() error {
+	wd := e.task.WorkingDir()
+	tmplFullpath := filepath.Join(wd, tftmpl.TFVarsTmplFilename)
+	tfvarsFilepath := filepath.Join(wd, tftmpl.TFVarsFilename)
+	logger := e.logger.With(taskNameLogKey, e.task.Name())
+
+	content, err := e.fileReader(tmplFullpath)
+	if err != nil {
+		logger.Error("unable to read for task", "error", err)
+		return err
+	}
+
+	servicesMeta, err := getServicesMetaData(e.logger, e.task)
+	if err != nil {
+		return err
+	}
+
+	tmpl := hcat.NewTemplate(hcat.TemplateInput{
+		Contents: string(content),
+		Renderer: hcat.NewFileRenderer(hcat.FileRendererInput{
+			Path:  tfvarsFilepath,
+			Perms: filePerms,
+		}),
+		FuncMapMerge: tmplfunc.HCLMap(servicesMeta),
+	})
+
+	if e.template != nil {
+		if e.template.ID() == tmpl.ID() {
+			// the template content is unchanged, reset the buffer period to
+			// render the latest content
+			e.watcher.BufferReset(e.template)
+			return nil
+		}
+
+		e.watcher.MarkForSweep(e.template)
+		e.watcher.Sweep(e.template)
+	}
+
+	e.onceNotifier = newOnceNotifier(e.task, tmpl)
+	e.template = e.onceNotifier
+
+	logger.Debug("validating template")
+	if err = validateTemplate(tmpl, e.watcher.Clients()); err != nil {
+		logger.Error("error validating template", "error", err)
+		return errors.Wrap(err, "unable to retrieve data from Consul")
+	}
+
+	err = e.watcher.Register(e.template)
+	if err != nil && err != hcat.ErrRegistry {
+		logger.Error("unable to register template", "error", err)
+		return err
+	}
+
+	return nil
+}
+
+// deregisterTemplate attempts to deregister the hashicat template
+func (e *Exec) deregisterTemplate() {
+	e.watcher.Deregister(e.template)
+}
+
+// renderTemplate attempts to render the hashicat template
+func (e *Exec) renderTemplate() (hcat.ResolveEvent, error) {
+	taskName := e.task.Name()
+	tnlog := e.logger.With(taskNameLogKey, taskName)
+
+	result, err := e.resolver.Run(e.template, e.watcher)
+	if err != nil {
+		tnlog.Error("error checking dependency changes for task", "error", err)
+		return hcat.ResolveEvent{}, fmt.Errorf("error fetching template dependencies for task %s: %s",
+			taskName, err)
+	}
+
+	if result.Complete && result.NoChange && e.OnceDone() {
+		tnlog.Trace("no changes detected for task")
+		return result, nil
+	}
+
+	if result.Complete && !result.NoChange {
+		tnlog.Debug("change detected for task")
+
+		rendered, err := e.template.Render(result.Contents)
+		if err != nil {
+			tnlog.Error("rendering template for task", "error", err)
+			return hcat.ResolveEvent{}, err
+		}
+		tnlog.Trace("template for task rendered", "rendered_template", rendered)
+		e.onceNotifier.SetOnceDone()
+	}
+
+	return result, nil
+}
+
+// inspectTask runs the command to plan changes and returns its output as the
+// plan
+func (e *Exec) inspectTask(ctx context.Context) (InspectPlan, error) {
+	taskName := e.task.Name()
+	if !e.runner.plans() {
+		return InspectPlan{}, fmt.Errorf("task '%s' cannot be inspected, the "+
+			"exec driver is not configured with plan_args", taskName)
+	}
+
+	var buf bytes.Buffer
+	e.logger.Trace("plan", taskNameLogKey, taskName)
+	result, err := e.run(ctx, true, &buf)
+	if err != nil {
+		return InspectPlan{}, err
+	}
+
+	return InspectPlan{
+		ChangesPresent: result.changesPresent,
+		Plan:           buf.String(),
+	}, nil
+}
+
+// applyTask runs the command to apply the task changes. When post-plan hooks
+// are configured and the runner can plan changes, the changes are planned
+// first and the hooks run before they are applied. Hooks configured for the
+// task run at each stage of applying the changes. The output and the summary
+// of the applied changes, if the runner reports one, are recorded on the
+// event in the context, if any.
+func (e *Exec) applyTask(ctx context.Context) (err error) {
+	taskName := e.task.Name()
+	out, done := e.captureOutput(ctx)
+	defer done()
+
+	defer func() {
+		if err != nil && hook.HasStage(e.hooks, hook.StageOnFailure) {
+			// errors from on-failure hooks are recorded and logged only
+			e.runHooks(ctx, hook.StageOnFailure, err)
+		}
+	}()
+
+	if err = e.runHooks(ctx, hook.StagePrePlan, nil); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error running hooks for '%s'", taskName))
+	}
+
+	if e.runner.plans() && hook.HasStage(e.hooks, hook.StagePostPlan) {
+		e.logger.Trace("plan", taskNameLogKey, taskName)
+		if _, err = e.run(ctx, true, out); err != nil {
+			return err
+		}
+
+		if err = e.runHooks(ctx, hook.StagePostPlan, nil); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("error running hooks for '%s'", taskName))
+		}
+	}
+
+	e.logger.Trace("apply", taskNameLogKey, taskName)
+	result, err := e.run(ctx, false, out)
+	if err != nil {
+		return err
+	}
+	if ev := event.FromContext(ctx); ev != nil && result.summary != nil {
+		ev.Changes = result.summary
+	}
+
+	if err = e.runHooks(ctx, hook.StagePostApply, nil); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error running hooks for '%s'", taskName))
+	}
+
+	return nil
+}
+
+// run writes the module inputs and runs the command of the runner to apply
+// or plan changes in the task's working directory
+func (e *Exec) run(ctx context.Context, plan bool, out io.Writer) (execResult, error) {
+	taskName := e.task.Name()
+	action := "apply"
+	if plan {
+		action = "plan"
+	}
+
+	input, inputFile, err := e.writeInput()
+	if err != nil {
+		return execResult{}, err
+	}
+
+	name, args, err := e.runner.command(e.task, input, plan)
+	if err != nil {
+		return execResult{}, errors.Wrap(err, fmt.Sprintf("error preparing to "+
+			"%s '%s'", action, taskName))
+	}
+
+	env := envMap(os.Environ())
+	for k, v := range e.task.Env() {
+		env[k] = v
+	}
+	env[execEnvTaskName] = taskName
+	env[execEnvInputFile] = inputFile
+
+	var output bytes.Buffer
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = e.task.WorkingDir()
+	cmd.Stdout = io.MultiWriter(out, &output)
+	cmd.Stderr = cmd.Stdout
+	for k, v := range env {
+		cmd.Env = append(cmd.Env, fmt.Sprintf("%s=%s", k, v))
+	}
+
+	err = cmd.Run()
+	result, err := e.runner.result(plan, output.Bytes(), err)
+	if err != nil {
+		return execResult{}, errors.Wrap(err, fmt.Sprintf("error running "+
+			"'%s' to %s '%s'", name, action, taskName))
+	}
+	return result, nil
+}
+
+// writeInput writes the module inputs of the task as JSON to the input file
+// and returns the JSON and the path of the file. The inputs are the Consul
+// data most recently rendered for the task and the task's variables.
+func (e *Exec) writeInput() ([]byte, string, error) {
+	wd := e.task.WorkingDir()
+	tfvarsFilepath := filepath.Join(wd, tftmpl.TFVarsFilename)
+	tfvars, err := e.fileReader(tfvarsFilepath)
+	if err != nil {
+		return nil, "", errors.Wrap(err, fmt.Sprintf("error reading module "+
+			"inputs for '%s'", e.task.Name()))
+	}
+
+	input, err := execInput(tfvars, tfvarsFilepath, e.task.Variables())
+	if err != nil {
+		return nil, "", errors.Wrap(err, fmt.Sprintf("error encoding module "+
+			"inputs for '%s'", e.task.Name()))
+	}
+
+	inputFilepath := filepath.Join(wd, ExecInputFilename)
+	if err = ioutil.WriteFile(inputFilepath, input, filePerms); err != nil {
+		return nil, "", err
+	}
+	return input, inputFilepath, nil
+}
+
+// execRunner builds the commands that the exec driver runs for a task and
+// interprets their results
+type execRunner interface {
+	// plans returns whether the runner has a command to plan changes
+	plans() bool
+
+	// command returns the name and arguments of the command to apply or plan
+	// the changes of the task with the JSON encoded module inputs
+	command(task *Task, input []byte, plan bool) (string, []string, error)
+
+	// result interprets the output and the error of a finished command
+	result(plan bool, output []byte, err error) (execResult, error)
+}
+
+// execResult is the result of a command run by the exec driver
+type execResult struct {
+	changesPresent bool
+
+	// summary counts the changes, if the command reports them
+	summary *event.ChangeSummary
+}
+
+// scriptRunner runs the task's module as the executable with the configured
+// arguments
+type scriptRunner struct {
+	args     []string
+	planArgs []string
+}
+
+func (r *scriptRunner) plans() bool {
+	return r.planArgs != nil
+}
+
+func (r *scriptRunner) command(task *Task, _ []byte, plan bool) (string, []string, error) {
+	if plan {
+		return task.Module(), r.planArgs, nil
+	}
+	return task.Module(), r.args, nil
+}
+
+// result reports changes when the executable exits with the changes exit
+// code while planning. Any other non-zero exit code is an error.
+func (r *scriptRunner) result(plan bool, _ []byte, err error) (execResult, error) {
+	if err == nil {
+		return execResult{}, nil
+	}
+
+	var exitErr *exec.ExitError
+	if plan && errors.As(err, &exitErr) && exitErr.ExitCode() == execChangesExitCode {
+		return execResult{changesPresent: true}, nil
+	}
+	return execResult{}, err
+}
+
+// execInput encodes the rendered module inputs and the variables as a JSON
+// object. The rendered module inputs take precedence over variables of the
+// same name, as the module inputs are passed to a Terraform module after
+// the variables.
+func execInput(tfvars []byte, filename string, variables hcltmpl.Variables) ([]byte, error) {
+	rendered, err := tftmpl.ParseModuleVariables(tfvars, filename)
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]json.RawMessage, len(variables)+len(rendered))
+	for _, vars := range []hcltmpl.Variables{variables, rendered} {
+		for k, v := range vars {
+			b, err := ctyjson.Marshal(v, v.Type())
+			if err != nil {
+				return nil, fmt.Errorf("invalid value for %q: %s", k, err)
+			}
+			values[k] = b
+		}
+	}
+
+	return json.MarshalIndent(values, "", "  ")
+}
+
+// captureOutput returns the writer for the output of the executable while
+// the task runs. When there is an event in the context, the output is
+// captured and the returned function records the redacted output on the
+// event.
+func (e *Exec) captureOutput(ctx context.Context) (io.Writer, func()) {
+	var base io.Writer = ioutil.Discard
+	if e.logOutput {
+		base = log.Writer()
+	}
+
+	ev := event.FromContext(ctx)
+	if ev == nil {
+		return base, func() {}
+	}
+
+	capture := &logCapture{}
+	return io.MultiWriter(base, capture), func() {
+		ev.Logs = capture.String(taskSecrets(e.task))
+	}
+}
+
+// runHooks runs the task's hooks for the stage and records the results on the
+// event stored in the context.
+func (e *Exec) runHooks(ctx context.Context, stage string, taskErr error) error {
+	if !hook.HasStage(e.hooks, stage) {
+		return nil
+	}
+
+	hctx := hook.Context{
+		Task: hook.Task{
+			Name:        e.task.Name(),
+			Description: e.task.Description(),
+			Module:      e.task.Module(),
+			Providers:   e.task.ProviderIDs(),
+			Services:    e.task.ServiceNames(),
+		},
+		Event: event.FromContext(ctx),
+	}
+	if taskErr != nil {
+		hctx.Error = taskErr.Error()
+	}
+
+	results, err := hook.Run(ctx, e.hooks, stage, hctx)
+	recordHookResults(ctx, results)
+	return err
+}
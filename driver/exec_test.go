@@ -0,0 +1,276 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/hook"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocksHook "github.com/hashicorp/consul-terraform-sync/mocks/hook"
+	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// execScript is run by the tests as the task's executable. It prints the
+// task name and the input, and exits with the exit code passed as the
+// first argument.
+const execScript = `#!/bin/sh
+echo "task: $CTS_TASK_NAME"
+cat "$CTS_INPUT_FILE"
+exit $1
+`
+
+// newTestExec returns an exec driver for a task that runs execScript with
+// the rendered Consul data
+func newTestExec(t *testing.T, args, planArgs []string, hooks []hook.Hook) *Exec {
+	wd := t.TempDir()
+	module := filepath.Join(wd, "run.sh")
+	require.NoError(t, ioutil.WriteFile(module, []byte(execScript), 0700))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(wd, tftmpl.TFVarsFilename),
+		[]byte("services = {\n  \"api\" = {\n    name = \"api\"\n  }\n}\n"), 0600))
+
+	task, err := NewTask(TaskConfig{
+		Name:       "test",
+		Enabled:    true,
+		Module:     module,
+		WorkingDir: wd,
+		Variables:  map[string]string{"count": "1"},
+		Hooks:      hooks,
+	})
+	require.NoError(t, err)
+
+	w := new(mocksTmpl.Watcher)
+	w.On("Deregister", mock.Anything).Return()
+
+	return &Exec{
+		task:       task,
+		runner:     &scriptRunner{args: args, planArgs: planArgs},
+		watcher:    w,
+		fileReader: ioutil.ReadFile,
+		hooks:      hooks,
+		logger:     logging.NewNullLogger(),
+	}
+}
+
+func TestExec_ApplyTask(t *testing.T) {
+	t.Parallel()
+
+	t.Run("happy path", func(t *testing.T) {
+		e := newTestExec(t, []string{"0"}, nil, nil)
+		ev := &event.Event{TaskName: "test"}
+		ctx := event.WithContext(context.Background(), ev)
+
+		err := e.ApplyTask(ctx)
+		require.NoError(t, err)
+		assert.Contains(t, ev.Logs, "task: test")
+		assert.Contains(t, ev.Logs, `"count": 1`)
+		assert.Contains(t, ev.Logs, `"name": "api"`)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		e := newTestExec(t, []string{"1"}, nil, nil)
+		ev := &event.Event{TaskName: "test"}
+		ctx := event.WithContext(context.Background(), ev)
+
+		err := e.ApplyTask(ctx)
+		assert.Error(t, err)
+		assert.Contains(t, ev.Logs, "task: test")
+	})
+
+	t.Run("hooks", func(t *testing.T) {
+		newHook := func(stage string) *mocksHook.Hook {
+			h := new(mocksHook.Hook)
+			h.On("Name").Return(stage + "-hook")
+			h.On("Stage").Return(stage)
+			h.On("Run", mock.Anything, mock.Anything).Return("output", nil)
+			return h
+		}
+		hooks := []hook.Hook{
+			newHook(hook.StagePrePlan),
+			newHook(hook.StagePostPlan),
+			newHook(hook.StagePostApply),
+			newHook(hook.StageOnFailure),
+		}
+		e := newTestExec(t, []string{"0"}, []string{"2"}, hooks)
+		ev := &event.Event{TaskName: "test"}
+		ctx := event.WithContext(context.Background(), ev)
+
+		err := e.ApplyTask(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []event.HookResult{
+			{Name: "pre-plan-hook", Stage: hook.StagePrePlan, Success: true, Output: "output"},
+			{Name: "post-plan-hook", Stage: hook.StagePostPlan, Success: true, Output: "output"},
+			{Name: "post-apply-hook", Stage: hook.StagePostApply, Success: true, Output: "output"},
+		}, ev.Hooks)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		e := newTestExec(t, []string{"1"}, nil, nil)
+		e.task.Disable()
+
+		err := e.ApplyTask(context.Background())
+		assert.NoError(t, err)
+	})
+}
+
+func TestExec_InspectTask(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		planArgs    []string
+		changes     bool
+		expectError bool
+	}{
+		{
+			"no changes",
+			[]string{"0"},
+			false,
+			false,
+		},
+		{
+			"changes",
+			[]string{"2"},
+			true,
+			false,
+		},
+		{
+			"error",
+			[]string{"1"},
+			false,
+			true,
+		},
+		{
+			"no plan args",
+			nil,
+			false,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			e := newTestExec(t, []string{"1"}, tc.planArgs, nil)
+
+			plan, err := e.InspectTask(context.Background())
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.changes, plan.ChangesPresent)
+			assert.Contains(t, plan.Plan, "task: test")
+		})
+	}
+}
+
+func TestExec_ExportTask(t *testing.T) {
+	t.Parallel()
+
+	e := newTestExec(t, nil, nil, nil)
+	_, err := e.ExportTask(context.Background())
+	assert.Error(t, err)
+}
+
+func TestExec_UpgradeModule(t *testing.T) {
+	t.Parallel()
+
+	e := newTestExec(t, nil, nil, nil)
+	_, err := e.CheckModuleUpgrade(context.Background(), registry.NewClient(nil))
+	assert.ErrorIs(t, err, registry.ErrNotRegistrySource)
+
+	_, err = e.UpgradeModule(context.Background(), "1.0.0", false)
+	assert.Error(t, err)
+}
+
+func TestExec_DestroyResources(t *testing.T) {
+	t.Parallel()
+
+	e := newTestExec(t, nil, nil, nil)
+	_, err := e.InspectDestroy(context.Background())
+	assert.Error(t, err)
+
+	err = e.DestroyResources(context.Background())
+	assert.Error(t, err)
+	assert.DirExists(t, e.task.WorkingDir())
+}
+
+func TestExecInput(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name        string
+		tfvars      string
+		variables   hcltmpl.Variables
+		expected    map[string]interface{}
+		expectError bool
+	}{
+		{
+			"rendered and variables",
+			`services = { "api" = { name = "api", tags = ["a"] } }`,
+			hcltmpl.Variables{"count": cty.NumberIntVal(1)},
+			map[string]interface{}{
+				"count": float64(1),
+				"services": map[string]interface{}{
+					"api": map[string]interface{}{
+						"name": "api",
+						"tags": []interface{}{"a"},
+					},
+				},
+			},
+			false,
+		},
+		{
+			"rendered takes precedence",
+			`services = {}`,
+			hcltmpl.Variables{"services": cty.StringVal("variable")},
+			map[string]interface{}{
+				"services": map[string]interface{}{},
+			},
+			false,
+		},
+		{
+			"null values",
+			`consul_kv = null`,
+			nil,
+			map[string]interface{}{
+				"consul_kv": nil,
+			},
+			false,
+		},
+		{
+			"invalid tfvars",
+			`services = {`,
+			nil,
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b, err := execInput([]byte(tc.tfvars), "terraform.tfvars", tc.variables)
+			if tc.expectError {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			var actual map[string]interface{}
+			require.NoError(t, json.Unmarshal(b, &actual))
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
@@ -9,8 +9,10 @@ import (
 
 	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/hashicorp/consul-terraform-sync/hook"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	"github.com/hashicorp/consul-terraform-sync/policy"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
 )
@@ -67,6 +69,10 @@ type Task struct {
 	condition    config.ConditionConfig
 	moduleInputs config.ModuleInputConfigs
 	workingDir   string
+	policies     []policy.Evaluator     // policies checked before applying
+	hooks        []hook.Hook            // hooks run at stages of a task run
+	timeout      time.Duration          // 0 when disabled
+	backend      map[string]interface{} // nil to use the driver's backend
 	logger       logging.Logger
 
 	// Enterprise
@@ -89,6 +95,10 @@ type TaskConfig struct {
 	Condition    config.ConditionConfig
 	ModuleInputs config.ModuleInputConfigs
 	WorkingDir   string
+	Policies     []policy.Evaluator
+	Hooks        []hook.Hook
+	Timeout      time.Duration
+	Backend      map[string]interface{}
 
 	// Enterprise
 	DeprecatedTFVersion string
@@ -123,6 +133,10 @@ func NewTask(conf TaskConfig) (*Task, error) {
 		condition:    conf.Condition,
 		moduleInputs: conf.ModuleInputs,
 		workingDir:   conf.WorkingDir,
+		policies:     conf.Policies,
+		hooks:        conf.Hooks,
+		timeout:      conf.Timeout,
+		backend:      conf.Backend,
 		logger:       logging.Global().Named(logSystemName),
 
 		// Enterprise
@@ -291,6 +305,37 @@ func (t *Task) WorkingDir() string {
 	return t.workingDir
 }
 
+// Policies returns the policies to evaluate against the task's changes before
+// they are applied.
+func (t *Task) Policies() []policy.Evaluator {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.policies
+}
+
+// Timeout returns the maximum duration of a task run. If the timeout is not
+// configured, the second parameter returns false.
+func (t *Task) Timeout() (time.Duration, bool) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.timeout, t.timeout > 0
+}
+
+// Backend returns the backend configured for the task to store its state.
+// The backend is nil if the task uses the backend of the driver.
+func (t *Task) Backend() map[string]interface{} {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.backend
+}
+
+// Hooks returns the hooks to run at stages of a task run.
+func (t *Task) Hooks() []hook.Hook {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.hooks
+}
+
 // DeprecatedTFVersion returns the Terraform version to use when using the Terraform Cloud
 // driver. Enterprise.
 // Deprecated, use the Terraform Version from TFCWorkspace() instead.
@@ -478,7 +523,11 @@ type clientConfig struct {
 	taskName   string
 	persistLog bool
 	path       string
+	binary     string
 	workingDir string
+
+	pluginCacheDir       string
+	providerInstallation *client.ProviderInstallation
 }
 
 // newClient initializes a specific type of client given a task
@@ -506,8 +555,12 @@ func newClient(conf *clientConfig) (client.Client, error) {
 			Log:        conf.log,
 			PersistLog: conf.persistLog,
 			ExecPath:   conf.path,
+			Binary:     conf.binary,
 			WorkingDir: conf.workingDir,
 			Workspace:  taskName,
+
+			PluginCacheDir:       conf.pluginCacheDir,
+			ProviderInstallation: conf.providerInstallation,
 		})
 	}
 
@@ -3,9 +3,9 @@ package driver
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io/ioutil"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
@@ -14,13 +14,19 @@ import (
 	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/handler"
+	"github.com/hashicorp/consul-terraform-sync/hook"
 	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/policy"
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/templates"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/notifier"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 	"github.com/hashicorp/hcat"
 	"github.com/hashicorp/hcat/dep"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/pkg/errors"
 )
 
@@ -36,6 +42,10 @@ const (
 	errSuggestion = "remove Terraform from the configured path or specify a new path to safely install a compatible version."
 
 	taskNameLogKey = "task_name"
+
+	// planFilename is the file the plan is saved to when the plan needs to be
+	// checked before it is applied
+	planFilename = "cts.tfplan"
 )
 
 var (
@@ -51,9 +61,16 @@ type Terraform struct {
 	mu sync.RWMutex
 
 	task              *Task
+	binary            string
 	backend           map[string]interface{}
 	requiredProviders map[string]interface{}
 
+	moduleSourceRewrites map[string]string
+
+	// moduleVersion overrides the version constraint of the task's module
+	// once the module is upgraded to a newer version from the registry
+	moduleVersion string
+
 	resolver   templates.Resolver
 	template   templates.Template
 	watcher    templates.Watcher
@@ -62,6 +79,12 @@ type Terraform struct {
 	client    client.Client
 	logClient bool
 	postApply handler.Handler
+	policies  []policy.Evaluator
+	hooks     []hook.Hook
+
+	// planChanges is true if changes are always planned before they are
+	// applied so that a summary of the changes is recorded for each run
+	planChanges bool
 
 	inited bool
 
@@ -81,6 +104,19 @@ type TerraformConfig struct {
 	Watcher           templates.Watcher
 	// empty/unknown string will default to TerraformCLI client
 	ClientType string
+	// PlanChanges plans the changes of every task run before applying them
+	// so that a summary of the changes is recorded on the run's event
+	PlanChanges bool
+	// Binary is the name of the Terraform CLI binary in the path, terraform
+	// or tofu. Defaults to terraform.
+	Binary string
+
+	// PluginCacheDir and ProviderInstallation configure how providers are
+	// installed when the workspace is initialized
+	PluginCacheDir       string
+	ProviderInstallation *client.ProviderInstallation
+	// ModuleSourceRewrites rewrites the prefix of the task's module source
+	ModuleSourceRewrites map[string]string
 }
 
 // NewTerraform configures and initializes a new Terraform driver for a task.
@@ -103,7 +139,11 @@ func NewTerraform(config *TerraformConfig) (*Terraform, error) {
 		taskName:   taskName,
 		persistLog: config.PersistLog,
 		path:       config.Path,
+		binary:     config.Binary,
 		workingDir: wd,
+
+		pluginCacheDir:       config.PluginCacheDir,
+		providerInstallation: config.ProviderInstallation,
 	})
 	if err != nil {
 		logger.Error("init client type error", "client_type", config.ClientType, "error", err)
@@ -134,16 +174,21 @@ func NewTerraform(config *TerraformConfig) (*Terraform, error) {
 	}
 
 	return &Terraform{
-		task:              config.Task,
-		backend:           config.Backend,
-		requiredProviders: config.RequiredProviders,
-		client:            tfClient,
-		logClient:         config.Log,
-		postApply:         h,
-		resolver:          hcat.NewResolver(),
-		watcher:           config.Watcher,
-		fileReader:        ioutil.ReadFile,
-		logger:            logger,
+		task:                 config.Task,
+		binary:               config.Binary,
+		backend:              config.Backend,
+		requiredProviders:    config.RequiredProviders,
+		moduleSourceRewrites: config.ModuleSourceRewrites,
+		client:               tfClient,
+		logClient:            config.Log,
+		postApply:            h,
+		policies:             task.Policies(),
+		hooks:                task.Hooks(),
+		planChanges:          config.PlanChanges,
+		resolver:             hcat.NewResolver(),
+		watcher:              config.Watcher,
+		fileReader:           ioutil.ReadFile,
+		logger:               logger,
 	}, nil
 }
 
@@ -181,6 +226,370 @@ func (tf *Terraform) DestroyTask(_ context.Context) {
 	tf.deregisterTemplate()
 }
 
+// InspectDestroy inspects the resources managed by the task that would be
+// destroyed using the Terraform plan command in destroy mode
+func (tf *Terraform) InspectDestroy(ctx context.Context) (InspectPlan, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+	if err := tf.init(ctx); err != nil {
+		return InspectPlan{}, err
+	}
+
+	var buf bytes.Buffer
+	tf.client.SetStdout(&buf)
+	defer tf.client.SetStdout(tf.clientLogWriter())
+
+	tf.logger.Trace("plan destroy", taskNameLogKey, taskName)
+	c, err := tf.client.PlanDestroy(ctx)
+	if err != nil {
+		return InspectPlan{}, errors.Wrap(err,
+			fmt.Sprintf("error tf-plan -destroy for '%s'", taskName))
+	}
+
+	return InspectPlan{
+		ChangesPresent: c,
+		Plan:           buf.String(),
+	}, nil
+}
+
+// DestroyResources destroys the resources managed by the task using the
+// Terraform destroy command. Once the resources are destroyed, the task's
+// workspace and its state are deleted from the backend and the task's
+// working directory is removed. The Terraform output is recorded on the event
+// in the context, if any.
+func (tf *Terraform) DestroyResources(ctx context.Context) error {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+	defer tf.captureLogs(ctx)()
+
+	if err := tf.init(ctx); err != nil {
+		return err
+	}
+
+	tf.logger.Trace("destroy", taskNameLogKey, taskName)
+	if err := tf.client.Destroy(ctx); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error tf-destroy for '%s'", taskName))
+	}
+
+	tf.logger.Trace("delete workspace", taskNameLogKey, taskName)
+	if err := tf.client.DeleteWorkspace(ctx); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error deleting workspace for '%s'", taskName))
+	}
+	tf.inited = false
+
+	wd := tf.task.WorkingDir()
+	tf.logger.Trace("remove working directory", taskNameLogKey, taskName,
+		"working_dir", wd)
+	if err := os.RemoveAll(wd); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error removing working directory for '%s'", taskName))
+	}
+
+	return nil
+}
+
+// MigrateState migrates the state of the task to the backend and configures the
+// task to store its state in the backend. The state of the task's workspace is
+// pulled from the current backend and pushed to the workspace in the new
+// backend, which must not already manage resources. Only the task's workspace
+// is migrated, unlike `terraform init -migrate-state` which copies all
+// workspaces of a backend shared by tasks. The state in the previous backend
+// is left unchanged.
+func (tf *Terraform) MigrateState(ctx context.Context, backend map[string]interface{}) error {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+	defer tf.captureLogs(ctx)()
+
+	if err := tf.init(ctx); err != nil {
+		return err
+	}
+
+	tf.logger.Trace("pull state", taskNameLogKey, taskName)
+	state, err := tf.client.PullState(ctx)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error pulling state for '%s'", taskName))
+	}
+
+	// A workspace without state has nothing to migrate
+	var stateFile string
+	if strings.TrimSpace(state) != "" {
+		f, err := ioutil.TempFile(tf.task.WorkingDir(), "migrate-*.tfstate")
+		if err != nil {
+			return err
+		}
+		defer os.Remove(f.Name())
+		_, err = f.WriteString(state)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return err
+		}
+		stateFile = f.Name()
+	}
+
+	prevBackend := tf.backend
+	tf.backend = backend
+	if err = tf.pushState(ctx, stateFile); err != nil {
+		tf.logger.Error("error migrating state, reverting to the previous backend",
+			taskNameLogKey, taskName, "error", err)
+		tf.backend = prevBackend
+		if revertErr := tf.reconfigure(ctx); revertErr != nil {
+			tf.logger.Error("error reverting to the previous backend",
+				taskNameLogKey, taskName, "error", revertErr)
+		}
+		return err
+	}
+
+	tf.logger.Info("migrated state to the backend", taskNameLogKey, taskName)
+	return nil
+}
+
+// pushState initializes the workspace with the current backend and pushes the
+// state file, if any, to the workspace if it does not already manage resources
+func (tf *Terraform) pushState(ctx context.Context, stateFile string) error {
+	taskName := tf.task.Name()
+	if err := tf.reconfigure(ctx); err != nil {
+		return err
+	}
+	if stateFile == "" {
+		return nil
+	}
+
+	state, err := tf.client.PullState(ctx)
+	if err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error pulling state from the "+
+			"backend for '%s'", taskName))
+	}
+	if stateHasResources(state) {
+		return fmt.Errorf("state for '%s' already manages resources in the "+
+			"backend and cannot be overwritten", taskName)
+	}
+
+	tf.logger.Trace("push state", taskNameLogKey, taskName)
+	if err := tf.client.PushState(ctx, stateFile); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error pushing state for '%s'", taskName))
+	}
+	return nil
+}
+
+// reconfigure renders the root module with the current backend and
+// initializes the workspace with it
+func (tf *Terraform) reconfigure(ctx context.Context) error {
+	tf.inited = false
+	if err := tf.initRootModule(); err != nil {
+		return err
+	}
+
+	if err := tf.client.Reconfigure(ctx); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error tf-init for '%s'", tf.task.Name()))
+	}
+	tf.inited = true
+	return nil
+}
+
+// stateHasResources returns whether the JSON state has any resources
+func stateHasResources(state string) bool {
+	var s struct {
+		Resources []json.RawMessage `json:"resources"`
+	}
+	if err := json.Unmarshal([]byte(state), &s); err != nil {
+		return false
+	}
+	return len(s.Resources) > 0
+}
+
+// ShowState returns the state of the resources managed by the task using the
+// Terraform show command. The state is not written to the client log since it
+// contains the values of sensitive attributes.
+func (tf *Terraform) ShowState(ctx context.Context) (*tfjson.State, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+	if err := tf.init(ctx); err != nil {
+		return nil, err
+	}
+
+	tf.client.SetStdout(ioutil.Discard)
+	defer tf.client.SetStdout(tf.clientLogWriter())
+
+	tf.logger.Trace("show state", taskNameLogKey, taskName)
+	state, err := tf.client.ShowState(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error tf-show for '%s'", taskName))
+	}
+	return state, nil
+}
+
+// CheckModuleUpgrade checks the module registry, or the mirror that the
+// module source is rewritten to, for the latest version of the task's module
+// that meets the task's version constraint. The installed version is read
+// from the modules manifest of the task's working directory.
+func (tf *Terraform) CheckModuleUpgrade(ctx context.Context, c *registry.Client) (registry.ModuleUpgrade, error) {
+	tf.mu.RLock()
+	taskName := tf.task.Name()
+	source := rewriteModuleSource(tf.task.Module(), tf.moduleSourceRewrites)
+	installed, ok := registry.FindInstalledModule(tf.task.WorkingDir(), taskName)
+	tf.mu.RUnlock()
+
+	if _, isRegistry := registry.ParseSource(source); !isRegistry {
+		return registry.ModuleUpgrade{}, fmt.Errorf("module of task '%s': %w",
+			taskName, registry.ErrNotRegistrySource)
+	}
+	if !ok {
+		return registry.ModuleUpgrade{}, fmt.Errorf("module of task '%s' is "+
+			"not installed yet", taskName)
+	}
+
+	// An upgraded module is pinned to its version, so the latest version is
+	// checked against the task's configured constraint
+	return c.CheckUpgrade(ctx, source, tf.task.Version(), installed.Version)
+}
+
+// UpgradeModule pins the task's module to the version, re-initializes the
+// workspace to install it, and plans the changes of the upgraded module with
+// the most recently rendered Consul data. The previous version is restored if
+// the version cannot be installed, the plan fails, or the plan destroys or
+// replaces resources and allowDestroy is false.
+func (tf *Terraform) UpgradeModule(ctx context.Context, version string, allowDestroy bool) (InspectPlan, error) {
+	tf.mu.Lock()
+	defer tf.mu.Unlock()
+
+	taskName := tf.task.Name()
+	previous := tf.moduleVersion
+	tf.moduleVersion = version
+
+	tf.logger.Info("upgrading module", taskNameLogKey, taskName, "version", version)
+	err := tf.installModule(ctx)
+	if err == nil {
+		var plan InspectPlan
+		if plan, err = tf.inspectUpgrade(ctx, allowDestroy); err == nil {
+			return plan, nil
+		}
+	}
+
+	tf.moduleVersion = previous
+	if rerr := tf.installModule(ctx); rerr != nil {
+		tf.logger.Error("error restoring module version", taskNameLogKey,
+			taskName, "error", rerr)
+	}
+	return InspectPlan{}, errors.Wrap(err, fmt.Sprintf("error upgrading "+
+		"module of task '%s' to version %s", taskName, version))
+}
+
+// inspectUpgrade plans the changes of the upgraded module. An error is returned
+// if the plan destroys or replaces resources and allowDestroy is false.
+func (tf *Terraform) inspectUpgrade(ctx context.Context, allowDestroy bool) (InspectPlan, error) {
+	taskName := tf.task.Name()
+	planFile := filepath.Join(tf.task.WorkingDir(), planFilename)
+	defer os.Remove(planFile)
+
+	var buf bytes.Buffer
+	tf.client.SetStdout(&buf)
+	defer tf.client.SetStdout(tf.clientLogWriter())
+
+	tf.logger.Trace("plan", taskNameLogKey, taskName, "plan_file", planFile)
+	c, err := tf.client.SavePlan(ctx, planFile)
+	if err != nil {
+		return InspectPlan{}, errors.Wrap(err,
+			fmt.Sprintf("error tf-plan for '%s'", taskName))
+	}
+
+	if !allowDestroy {
+		plan, err := tf.client.ShowPlan(ctx, planFile)
+		if err != nil {
+			return InspectPlan{}, errors.Wrap(err,
+				fmt.Sprintf("error tf-show for '%s'", taskName))
+		}
+		if addrs := destroyedResources(plan); len(addrs) > 0 {
+			return InspectPlan{}, fmt.Errorf("plan destroys or replaces "+
+				"resources, which module upgrades are not allowed to do: %s",
+				strings.Join(addrs, ", "))
+		}
+	}
+
+	return InspectPlan{
+		ChangesPresent: c,
+		Plan:           buf.String(),
+	}, nil
+}
+
+// destroyedResources returns the addresses of the resources that the plan
+// destroys or replaces
+func destroyedResources(plan *tfjson.Plan) []string {
+	if plan == nil {
+		return nil
+	}
+	var addrs []string
+	for _, rc := range plan.ResourceChanges {
+		if rc == nil || rc.Change == nil {
+			continue
+		}
+		if rc.Change.Actions.Delete() || rc.Change.Actions.Replace() {
+			addrs = append(addrs, rc.Address)
+		}
+	}
+	return addrs
+}
+
+// installModule renders the root module and re-initializes the workspace to
+// install the task's module
+func (tf *Terraform) installModule(ctx context.Context) error {
+	tf.inited = false
+	if err := tf.initRootModule(); err != nil {
+		return err
+	}
+	return tf.init(ctx)
+}
+
+// ExportTask returns the files of a standalone Terraform root module for the
+// task, which can be run with the Terraform CLI without CTS. The generated
+// root module files are rendered with the task's current configuration and
+// terraform.tfvars contains the Consul data most recently rendered for the
+// task.
+func (tf *Terraform) ExportTask(_ context.Context) (map[string][]byte, error) {
+	tf.mu.RLock()
+	defer tf.mu.RUnlock()
+
+	input := tftmpl.RootModuleInputData{
+		TerraformVersion: TerraformVersion,
+		RequiredVersion:  tf.requiredVersion(),
+		Backend:          tf.backend,
+		Path:             tf.task.WorkingDir(),
+	}
+	if err := tf.task.configureRootModuleInput(&input); err != nil {
+		return nil, err
+	}
+	input.Task.Module = rewriteModuleSource(input.Task.Module, tf.moduleSourceRewrites)
+	if tf.moduleVersion != "" {
+		input.Task.Version = tf.moduleVersion
+	}
+
+	files, err := tftmpl.RenderRootModule(&input)
+	if err != nil {
+		return nil, err
+	}
+
+	tfvarsFilepath := filepath.Join(tf.task.WorkingDir(), tftmpl.TFVarsFilename)
+	tfvars, err := tf.fileReader(tfvarsFilepath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("task '%s' has not rendered Consul data "+
+				"yet, try again once the task has run", tf.task.Name())
+		}
+		return nil, err
+	}
+	files[tftmpl.TFVarsFilename] = tfvars
+
+	return files, nil
+}
+
 // SetBufferPeriod sets the buffer period for the task. Do not set this when
 // task needs to immediately render a template and run.
 func (tf *Terraform) SetBufferPeriod() {
@@ -385,8 +794,39 @@ func (tf *Terraform) init(ctx context.Context) error {
 
 // initTask initializes the task
 func (tf *Terraform) initTask(ctx context.Context) error {
+	if err := tf.initRootModule(); err != nil {
+		return err
+	}
+
+	if err := tf.initTaskTemplate(); err != nil {
+		return err
+	}
+
+	// initTask() can be called more than once. It's very likely initializing a
+	// task will require re-initializing terraform. Reset to false so terraform
+	// will reinit
+	tf.inited = false
+
+	// initialize workspace
+	taskName := tf.task.Name()
+	if err := tf.init(ctx); err != nil {
+		tf.logger.Error("error initializing workspace for task", taskNameLogKey, taskName)
+		return err
+	}
+
+	// validate workspace
+	if err := tf.validateTask(ctx); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// initRootModule creates the Terraform root module files for the task
+func (tf *Terraform) initRootModule() error {
 	input := tftmpl.RootModuleInputData{
 		TerraformVersion: TerraformVersion,
+		RequiredVersion:  tf.requiredVersion(),
 		Backend:          tf.backend,
 		Path:             tf.task.WorkingDir(),
 		FilePerms:        filePerms,
@@ -408,33 +848,36 @@ func (tf *Terraform) initTask(ctx context.Context) error {
 	if err := tf.task.configureRootModuleInput(&input); err != nil {
 		return err
 	}
-
-	if err := tftmpl.InitRootModule(&input); err != nil {
-		return err
-	}
-
-	if err := tf.initTaskTemplate(); err != nil {
-		return err
+	input.Task.Module = rewriteModuleSource(input.Task.Module, tf.moduleSourceRewrites)
+	if tf.moduleVersion != "" {
+		input.Task.Version = tf.moduleVersion
 	}
 
-	// initTask() can be called more than once. It's very likely initializing a
-	// task will require re-initializing terraform. Reset to false so terraform
-	// will reinit
-	tf.inited = false
+	return tftmpl.InitRootModule(&input)
+}
 
-	// initialize workspace
-	taskName := tf.task.Name()
-	if err := tf.init(ctx); err != nil {
-		tf.logger.Error("error initializing workspace for task", taskNameLogKey, taskName)
-		return err
+// rewriteModuleSource rewrites the module source with the longest matching
+// prefix of the rewrites, or returns the source unchanged if none match
+func rewriteModuleSource(source string, rewrites map[string]string) string {
+	var prefix string
+	for p := range rewrites {
+		if strings.HasPrefix(source, p) && len(p) > len(prefix) {
+			prefix = p
+		}
 	}
-
-	// validate workspace
-	if err := tf.validateTask(ctx); err != nil {
-		return err
+	if prefix == "" {
+		return source
 	}
+	return rewrites[prefix] + strings.TrimPrefix(source, prefix)
+}
 
-	return nil
+// requiredVersion returns the version constraint of the root module for the
+// binary that runs the task
+func (tf *Terraform) requiredVersion() string {
+	if tf.binary == config.OpenTofuBinary {
+		return tftmpl.OpenTofuRequiredVersion
+	}
+	return tftmpl.TerraformRequiredVersion
 }
 
 // deregisterTemplate attempts to deregister the hashicat template
@@ -488,14 +931,7 @@ func (tf *Terraform) inspectTask(ctx context.Context, returnPlan bool) (InspectP
 	var buf bytes.Buffer
 	if returnPlan {
 		tf.client.SetStdout(&buf)
-
-		var tfLogger *log.Logger
-		if tf.logClient {
-			tfLogger = log.New(log.Writer(), "", log.Flags())
-		} else {
-			tfLogger = log.New(ioutil.Discard, "", 0)
-		}
-		defer tf.client.SetStdout(tfLogger.Writer())
+		defer tf.client.SetStdout(tf.clientLogWriter())
 	}
 
 	tf.logger.Trace("plan", taskNameLogKey, taskName)
@@ -511,25 +947,210 @@ func (tf *Terraform) inspectTask(ctx context.Context, returnPlan bool) (InspectP
 	}, nil
 }
 
-// applyTask applies the task changes.
-func (tf *Terraform) applyTask(ctx context.Context) error {
+// applyTask applies the task changes. When changes are planned or policies or
+// post-plan hooks are configured, the changes are planned and checked before
+// they are applied.
+// Hooks configured for the task run at each stage of applying the changes.
+// The Terraform output is recorded on the event in the context, if any.
+func (tf *Terraform) applyTask(ctx context.Context) (err error) {
 	taskName := tf.task.Name()
+	defer tf.captureLogs(ctx)()
+
+	defer func() {
+		if err != nil && hook.HasStage(tf.hooks, hook.StageOnFailure) {
+			// errors from on-failure hooks are recorded and logged only
+			tf.runHooks(ctx, hook.StageOnFailure, nil, err)
+		}
+	}()
+
+	if err = tf.runHooks(ctx, hook.StagePrePlan, nil, nil); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error running hooks for '%s'", taskName))
+	}
 
-	tf.logger.Trace("apply", taskNameLogKey, taskName)
-	if err := tf.client.Apply(ctx); err != nil {
-		return errors.Wrap(err, fmt.Sprintf("error tf-apply for '%s'", taskName))
+	var changes interface{}
+	if tf.planChanges || len(tf.policies) > 0 || hook.HasStage(tf.hooks, hook.StagePostPlan) {
+		if changes, err = tf.applyPlannedTask(ctx); err != nil {
+			return err
+		}
+	} else {
+		tf.logger.Trace("apply", taskNameLogKey, taskName)
+		if err = tf.client.Apply(ctx); err != nil {
+			return errors.Wrap(err, fmt.Sprintf("error tf-apply for '%s'", taskName))
+		}
 	}
 
 	if tf.postApply != nil {
 		tf.logger.Trace("post-apply out-of-band actions for task", taskNameLogKey, taskName)
-		if err := tf.postApply.Do(ctx, nil); err != nil {
+		if err = tf.postApply.Do(ctx, nil); err != nil {
 			return err
 		}
 	}
 
+	if err = tf.runHooks(ctx, hook.StagePostApply, changes, nil); err != nil {
+		return errors.Wrap(err, fmt.Sprintf("error running hooks for '%s'", taskName))
+	}
+
 	return nil
 }
 
+// applyPlannedTask saves the plan for the task changes, checks the JSON
+// representation of the plan against the policies, runs the post-plan hooks,
+// and then applies the saved plan. The saved plan is applied so that the
+// changes applied are exactly the changes that were checked. Policy results
+// are recorded on the event in the context, if any. The JSON representation
+// of the plan is returned.
+func (tf *Terraform) applyPlannedTask(ctx context.Context) (interface{}, error) {
+	taskName := tf.task.Name()
+	planFile := filepath.Join(tf.task.WorkingDir(), planFilename)
+	defer os.Remove(planFile)
+
+	tf.logger.Trace("plan", taskNameLogKey, taskName, "plan_file", planFile)
+	if _, err := tf.client.SavePlan(ctx, planFile); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error tf-plan for '%s'", taskName))
+	}
+
+	plan, err := tf.client.ShowPlan(ctx, planFile)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error tf-show for '%s'", taskName))
+	}
+	recordChangeSummary(ctx, plan)
+
+	// Policies and hooks use the generic JSON representation of the plan,
+	// which is the same document output by `terraform show -json`
+	b, err := json.Marshal(plan)
+	if err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error encoding plan for '%s'", taskName))
+	}
+	var changes interface{}
+	if err = json.Unmarshal(b, &changes); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error decoding plan for '%s'", taskName))
+	}
+
+	if len(tf.policies) > 0 {
+		tf.logger.Trace("policy check", taskNameLogKey, taskName)
+		results, err := policy.Evaluate(ctx, tf.policies, changes)
+		recordPolicyResults(ctx, results)
+		if err != nil {
+			tf.logger.Error("plan did not pass policy check", taskNameLogKey, taskName,
+				"error", err)
+			// the plan is checked again on retry with the same result, so
+			// policy failures are not retried
+			return nil, &retry.NonRetryableError{
+				Err: errors.Wrap(err, fmt.Sprintf("error policy check for '%s'", taskName)),
+			}
+		}
+	}
+
+	if err = tf.runHooks(ctx, hook.StagePostPlan, changes, nil); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error running hooks for '%s'", taskName))
+	}
+
+	tf.logger.Trace("apply", taskNameLogKey, taskName, "plan_file", planFile)
+	if err := tf.client.ApplyPlan(ctx, planFile); err != nil {
+		return nil, errors.Wrap(err, fmt.Sprintf("error tf-apply for '%s'", taskName))
+	}
+
+	return changes, nil
+}
+
+// runHooks runs the task's hooks for the stage and records the results on the
+// event stored in the context. The plan is redacted before it is passed to
+// the hooks.
+func (tf *Terraform) runHooks(ctx context.Context, stage string, changes interface{}, taskErr error) error {
+	if !hook.HasStage(tf.hooks, stage) {
+		return nil
+	}
+
+	hctx := hook.Context{
+		Task: hook.Task{
+			Name:        tf.task.Name(),
+			Description: tf.task.Description(),
+			Module:      tf.task.Module(),
+			Providers:   tf.task.ProviderIDs(),
+			Services:    tf.task.ServiceNames(),
+		},
+		Event:   event.FromContext(ctx),
+		Changes: redactPlan(changes),
+	}
+	if taskErr != nil {
+		hctx.Error = taskErr.Error()
+	}
+
+	results, err := hook.Run(ctx, tf.hooks, stage, hctx)
+	recordHookResults(ctx, results)
+	return err
+}
+
+// recordHookResults appends the hook results to the event stored in the
+// context.
+func recordHookResults(ctx context.Context, results []hook.Result) {
+	ev := event.FromContext(ctx)
+	if ev == nil {
+		return
+	}
+
+	for _, r := range results {
+		hr := event.HookResult{
+			Name:    r.Name,
+			Stage:   r.Stage,
+			Success: r.Err == nil,
+			Output:  r.Output,
+		}
+		if r.Err != nil {
+			hr.Error = r.Err.Error()
+		}
+		ev.Hooks = append(ev.Hooks, hr)
+	}
+}
+
+// recordChangeSummary records a summary of the resource changes of the plan on
+// the event stored in the context.
+func recordChangeSummary(ctx context.Context, plan *tfjson.Plan) {
+	ev := event.FromContext(ctx)
+	if ev == nil || plan == nil {
+		return
+	}
+
+	var summary event.ChangeSummary
+	for _, rc := range plan.ResourceChanges {
+		if rc == nil || rc.Change == nil {
+			continue
+		}
+		actions := rc.Change.Actions
+		switch {
+		case actions.Replace():
+			summary.Add++
+			summary.Destroy++
+		case actions.Create():
+			summary.Add++
+		case actions.Update():
+			summary.Change++
+		case actions.Delete():
+			summary.Destroy++
+		}
+	}
+	ev.Changes = &summary
+}
+
+// recordPolicyResults records the policy results on the event stored in the
+// context. Results from a previous check on the event are replaced.
+func recordPolicyResults(ctx context.Context, results []policy.Result) {
+	ev := event.FromContext(ctx)
+	if ev == nil {
+		return
+	}
+
+	ev.Policies = make([]event.PolicyResult, len(results))
+	for i, r := range results {
+		ev.Policies[i] = event.PolicyResult{
+			Name:             r.Name,
+			EnforcementLevel: r.EnforcementLevel,
+			Passed:           r.Passed(),
+			Violations:       r.Violations,
+		}
+	}
+}
+
 // initTaskTemplate creates templates to be monitored and rendered.
 func (tf *Terraform) initTaskTemplate() error {
 	wd := tf.task.WorkingDir()
@@ -617,8 +1238,16 @@ func validateTemplate(t *hcat.Template, clients hcat.Looker) error {
 // setNotifier sets a notifier on the template to ensure only the condition's
 // monitored changes (and not the module input's changes) trigger the task.
 func (tf *Terraform) setNotifier(tmpl templates.Template) error {
+	tf.onceNotifier = newOnceNotifier(tf.task, tmpl)
+	tf.template = tf.onceNotifier
+	return nil
+}
+
+// newOnceNotifier wraps the template of a task with a notifier for the
+// task's condition
+func newOnceNotifier(task *Task, tmpl templates.Template) *notifier.OnceNotifier {
 	var notifyTrigger notifier.TriggerCheck
-	switch tf.task.Condition().(type) {
+	switch task.Condition().(type) {
 	case *config.ServicesConditionConfig:
 		notifyTrigger = notifier.TriggerCheckService
 	case *config.CatalogServicesConditionConfig:
@@ -630,9 +1259,7 @@ func (tf *Terraform) setNotifier(tmpl templates.Template) error {
 	default:
 		notifyTrigger = notifier.TriggerCheckService
 	}
-	tf.onceNotifier = notifier.NewOnceNotifier(notifyTrigger, tmpl)
-	tf.template = tf.onceNotifier
-	return nil
+	return notifier.NewOnceNotifier(notifyTrigger, tmpl)
 }
 
 func (tf *Terraform) validateTask(ctx context.Context) error {
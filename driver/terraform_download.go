@@ -1,11 +1,18 @@
 package driver
 
 import (
+	"archive/zip"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"runtime"
 	"strings"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
@@ -25,18 +32,37 @@ const (
 	terraformSubsystemName = "terraform"
 )
 
-const fallbackTFVersion = "1.1.8"
+const (
+	fallbackTFVersion   = "1.1.8"
+	fallbackTofuVersion = "1.6.2"
+
+	// openTofuReleasesURL is the URL that OpenTofu release archives are
+	// downloaded from when a mirror is not configured
+	openTofuReleasesURL = "https://github.com/opentofu/opentofu/releases/download"
+)
 
 // TerraformVersion is the version of Terraform CLI for the Terraform driver.
 var TerraformVersion *goVersion.Version
 
 // InstallTerraform installs the Terraform binary to the configured path.
 // If an existing Terraform exists in the path, it is checked for compatibility.
+// The configured binary is either HashiCorp Terraform or OpenTofu. The binary
+// is installed from a local archive or a mirror when configured, otherwise
+// it is downloaded from the official releases. When a checksum is configured,
+// the binary is verified against it before it is run.
 func InstallTerraform(ctx context.Context, conf *config.TerraformConfig) error {
 	path := *conf.Path
+	binary := terraformBinary(conf)
+	checksum := config.StringVal(conf.Checksum)
 
 	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
-	if isTFInstalled(path) {
+	if isTFInstalled(path, binary) {
+		if err := verifyChecksum(filepath.Join(path, binary), checksum); err != nil {
+			logger.Error("existing terraform failed checksum verification", "binary", binary,
+				"install_path", path, "error", err)
+			return err
+		}
+
 		tfVersion, compatible, err := verifyInstalledTF(ctx, conf)
 		if err != nil {
 			if strings.Contains(err.Error(), "exec format error") {
@@ -50,28 +76,106 @@ func InstallTerraform(ctx context.Context, conf *config.TerraformConfig) error {
 		if !compatible {
 			return errUnsupportedTerraformVersion
 		}
-		logger.Info("skipping install, terraform already exists",
+		logger.Info("skipping install, terraform already exists", "binary", binary,
 			"tf_version", tfVersion.String(), "install_path", path)
 
 		return nil
 	}
 
-	logger.Info("install terraform", "install_path", path)
-	tfVersion, err := installTerraform(ctx, conf)
+	logger.Info("install terraform", "binary", binary, "install_path", path)
+	archivePath := config.StringVal(conf.ArchivePath)
+	if archivePath == "" && binary == config.TerraformBinary && config.StringVal(conf.Mirror) == "" {
+		tfVersion, err := installTerraform(ctx, conf)
+		if err != nil {
+			logger.Error("error installing terraform", "error", err)
+			return err
+		}
+		if err := verifyChecksum(filepath.Join(path, binary), checksum); err != nil {
+			logger.Error("installed terraform failed checksum verification", "error", err)
+			return err
+		}
+		logger.Info("successfully installed terraform")
+
+		// Set the global variable to the installed version
+		TerraformVersion = tfVersion
+		return nil
+	}
+
+	var err error
+	if archivePath != "" {
+		err = installArchive(archivePath, path, binary)
+	} else {
+		err = installFromMirror(ctx, conf)
+	}
 	if err != nil {
-		logger.Error("error installing terraform", "error", err)
+		logger.Error("error installing terraform", "binary", binary, "error", err)
+		return err
+	}
+	if err := verifyChecksum(filepath.Join(path, binary), checksum); err != nil {
+		logger.Error("installed terraform failed checksum verification", "binary", binary,
+			"error", err)
 		return err
 	}
-	logger.Info("successfully installed terraform")
 
-	// Set the global variable to the installed version
+	// The version of a binary installed from an archive is only known once
+	// it is installed
+	tfVersion, compatible, err := verifyInstalledTF(ctx, conf)
+	if err != nil {
+		return err
+	}
 	TerraformVersion = tfVersion
+	if !compatible {
+		return errUnsupportedTerraformVersion
+	}
+	logger.Info("successfully installed terraform", "binary", binary,
+		"tf_version", tfVersion.String())
+	return nil
+}
+
+// terraformBinary returns the name of the configured Terraform CLI binary
+func terraformBinary(conf *config.TerraformConfig) string {
+	if b := config.StringVal(conf.Binary); b != "" {
+		return b
+	}
+	return config.TerraformBinary
+}
+
+// versionConstraint returns the version constraint of the binary for CTS
+func versionConstraint(binary string) (string, goVersion.Constraints) {
+	if binary == config.OpenTofuBinary {
+		return ctsVersion.CompatibleOpenTofuVersionConstraint, ctsVersion.OpenTofuConstraint
+	}
+	return ctsVersion.CompatibleTerraformVersionConstraint, ctsVersion.TerraformConstraint
+}
+
+// verifyChecksum verifies the SHA256 checksum of the binary at the path. Any
+// binary is accepted when the checksum is empty.
+func verifyChecksum(path, checksum string) error {
+	if checksum == "" {
+		return nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return err
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, checksum) {
+		return fmt.Errorf("checksum of %s does not match the configured "+
+			"checksum %s: %s", path, checksum, actual)
+	}
 	return nil
 }
 
-// isTFInstalled checks to see if terraform already exists at path.
-func isTFInstalled(tfPath string) bool {
-	tfPath = filepath.Join(tfPath, "terraform")
+// isTFInstalled checks to see if the binary already exists at path.
+func isTFInstalled(tfPath, binary string) bool {
+	tfPath = filepath.Join(tfPath, binary)
 
 	// Check if terraform exists in target path
 	if _, err := os.Stat(tfPath); err == nil {
@@ -80,7 +184,7 @@ func isTFInstalled(tfPath string) bool {
 
 	// Check if terraform exists in $PATH to notify users about the new
 	// installation for CTS
-	path, err := exec.LookPath("terraform")
+	path, err := exec.LookPath(binary)
 	if err != nil {
 		return false
 	}
@@ -96,6 +200,7 @@ func isTFInstalled(tfPath string) bool {
 // current architecture and is valid within CTS version constraints.
 func verifyInstalledTF(ctx context.Context, conf *config.TerraformConfig) (*goVersion.Version, bool, error) {
 	tfPath := *conf.Path
+	binary := terraformBinary(conf)
 
 	// NewTerraform requires an existing directory. This tfexec client is only
 	// used for validation, so we don't need to use the actual working dir for the task
@@ -106,7 +211,7 @@ func verifyInstalledTF(ctx context.Context, conf *config.TerraformConfig) (*goVe
 
 	// Verify version for existing terraform
 	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
-	tf, err := tfexec.NewTerraform(wd, filepath.Join(tfPath, "terraform"))
+	tf, err := tfexec.NewTerraform(wd, filepath.Join(tfPath, binary))
 	if err != nil {
 		logger.Error("unable to setup Terraform client", "terraform_path", tfPath, "error", err)
 		return nil, false, err
@@ -118,10 +223,11 @@ func verifyInstalledTF(ctx context.Context, conf *config.TerraformConfig) (*goVe
 		return nil, false, err
 	}
 
-	if !ctsVersion.TerraformConstraint.Check(tfVersion) {
+	constraintStr, constraint := versionConstraint(binary)
+	if !constraint.Check(tfVersion) {
 		logger.Error("found Terraform version does not satisfy the version constraint",
-			"terraform_path", tfPath, "version", tfVersion.String(),
-			"compatible_version_constraint", ctsVersion.CompatibleTerraformVersionConstraint)
+			"terraform_path", tfPath, "binary", binary, "version", tfVersion.String(),
+			"compatible_version_constraint", constraintStr)
 		return tfVersion, false, nil
 	}
 
@@ -209,3 +315,118 @@ func installTerraform(ctx context.Context, conf *config.TerraformConfig) (*goVer
 	logger.Debug("successfully installed terraform", "version", tfVersion.String(), "install_path", installedPath)
 	return tfVersion, nil
 }
+
+// installFromMirror downloads the release archive of the configured version
+// of the binary from the mirror and installs the binary into the path. The
+// mirror is either a URL or a local directory. OpenTofu is downloaded from
+// its official releases when a mirror is not configured.
+func installFromMirror(ctx context.Context, conf *config.TerraformConfig) error {
+	binary := terraformBinary(conf)
+	logger := logging.Global().Named(logSystemName).Named(terraformSubsystemName)
+
+	version := config.StringVal(conf.Version)
+	if version == "" {
+		version = fallbackTFVersion
+		if binary == config.OpenTofuBinary {
+			version = fallbackTofuVersion
+		}
+		logger.Info("version is not configured, installing fallback version",
+			"binary", binary, "fallback_version", version)
+	}
+
+	source := mirrorArchivePath(config.StringVal(conf.Mirror), binary, version)
+	if !strings.HasPrefix(source, "http://") && !strings.HasPrefix(source, "https://") {
+		return installArchive(source, *conf.Path, binary)
+	}
+
+	logger.Debug("downloading release archive", "url", source)
+	archive, err := downloadArchive(ctx, source, binary)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(archive)
+
+	return installArchive(archive, *conf.Path, binary)
+}
+
+// mirrorArchivePath returns the path of the release archive of the binary for
+// the current platform. Mirrors mirror the layout of the releases of HashiCorp
+// Terraform: <mirror>/<version>/<binary>_<version>_<os>_<arch>.zip
+func mirrorArchivePath(mirror, binary, version string) string {
+	filename := fmt.Sprintf("%s_%s_%s_%s.zip", binary, version, runtime.GOOS, runtime.GOARCH)
+	if mirror == "" && binary == config.OpenTofuBinary {
+		return fmt.Sprintf("%s/v%s/%s", openTofuReleasesURL, version, filename)
+	}
+
+	if strings.HasPrefix(mirror, "http://") || strings.HasPrefix(mirror, "https://") {
+		return fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(mirror, "/"), version, filename)
+	}
+	return filepath.Join(mirror, version, filename)
+}
+
+// downloadArchive downloads the archive at the URL to a temporary file and
+// returns the path of the file
+func downloadArchive(ctx context.Context, url, binary string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error downloading %s: %s", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error downloading %s: %s", url, resp.Status)
+	}
+
+	f, err := ioutil.TempFile("", binary+"-*.zip")
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err = io.Copy(f, resp.Body); err != nil {
+		os.Remove(f.Name())
+		return "", fmt.Errorf("error downloading %s: %s", url, err)
+	}
+	return f.Name(), nil
+}
+
+// installArchive extracts the binary from the zip archive into the path
+func installArchive(archive, path, binary string) error {
+	r, err := zip.OpenReader(archive)
+	if err != nil {
+		return fmt.Errorf("error opening archive %s: %s", archive, err)
+	}
+	defer r.Close()
+
+	for _, f := range r.File {
+		if f.FileInfo().IsDir() || filepath.Base(f.Name) != binary {
+			continue
+		}
+
+		src, err := f.Open()
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+
+		// Create path if one doesn't already exist
+		if err = os.MkdirAll(path, os.ModePerm); err != nil {
+			return err
+		}
+		dst, err := os.OpenFile(filepath.Join(path, binary),
+			os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0755)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(dst, src); err != nil {
+			dst.Close()
+			return err
+		}
+		return dst.Close()
+	}
+
+	return fmt.Errorf("archive %s does not contain the %s binary", archive, binary)
+}
@@ -1,11 +1,24 @@
 package driver
 
 import (
+	"archive/zip"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/go-version"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestIsTFCompatible(t *testing.T) {
@@ -50,3 +63,207 @@ func TestIsTFCompatible(t *testing.T) {
 		})
 	}
 }
+
+// tofuScript is a fake OpenTofu binary that reports the version passed as the
+// format argument
+const tofuScript = `#!/bin/sh
+echo '{"terraform_version": "%s", "platform": "linux_amd64", "provider_selections": {}}'
+`
+
+// newTestArchive writes a zip archive containing the files to a temporary
+// directory and returns the path of the archive
+func newTestArchive(t *testing.T, dir string, files map[string]string) string {
+	path := filepath.Join(dir, "archive.zip")
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	w := zip.NewWriter(f)
+	for name, content := range files {
+		fw, err := w.CreateHeader(&zip.FileHeader{Name: name, Method: zip.Deflate})
+		require.NoError(t, err)
+		_, err = fw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+	return path
+}
+
+func TestMirrorArchivePath(t *testing.T) {
+	t.Parallel()
+
+	platform := runtime.GOOS + "_" + runtime.GOARCH
+	cases := []struct {
+		name     string
+		mirror   string
+		binary   string
+		expected string
+	}{
+		{
+			"opentofu releases",
+			"",
+			config.OpenTofuBinary,
+			"https://github.com/opentofu/opentofu/releases/download/v1.6.2/tofu_1.6.2_" + platform + ".zip",
+		},
+		{
+			"url",
+			"https://mirror.example.com/terraform/",
+			config.TerraformBinary,
+			"https://mirror.example.com/terraform/1.6.2/terraform_1.6.2_" + platform + ".zip",
+		},
+		{
+			"local directory",
+			"/opt/mirror",
+			config.OpenTofuBinary,
+			filepath.Join("/opt/mirror", "1.6.2", "tofu_1.6.2_"+platform+".zip"),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual := mirrorArchivePath(tc.mirror, tc.binary, "1.6.2")
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestInstallArchive(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	archive := newTestArchive(t, dir, map[string]string{
+		"LICENSE":   "license",
+		"bin/tofu":  "binary",
+		"README.md": "readme",
+	})
+
+	t.Run("happy path", func(t *testing.T) {
+		path := filepath.Join(dir, "install")
+		err := installArchive(archive, path, config.OpenTofuBinary)
+		require.NoError(t, err)
+
+		b, err := ioutil.ReadFile(filepath.Join(path, config.OpenTofuBinary))
+		require.NoError(t, err)
+		assert.Equal(t, "binary", string(b))
+	})
+
+	t.Run("missing binary", func(t *testing.T) {
+		err := installArchive(archive, t.TempDir(), config.TerraformBinary)
+		assert.Error(t, err)
+	})
+
+	t.Run("invalid archive", func(t *testing.T) {
+		err := installArchive(filepath.Join(dir, "missing.zip"), t.TempDir(),
+			config.OpenTofuBinary)
+		assert.Error(t, err)
+	})
+}
+
+func TestInstallTerraform_OpenTofu(t *testing.T) {
+	// not parallel since the installed version is set globally
+	defer func(v *version.Version) { TerraformVersion = v }(TerraformVersion)
+	ctx := context.Background()
+
+	newConfig := func(path string) *config.TerraformConfig {
+		conf := &config.TerraformConfig{
+			Binary:  config.String(config.OpenTofuBinary),
+			Path:    config.String(path),
+			Version: config.String("1.6.2"),
+		}
+		conf.Finalize(nil)
+		return conf
+	}
+
+	t.Run("archive", func(t *testing.T) {
+		dir := t.TempDir()
+		conf := newConfig(filepath.Join(dir, "bin"))
+		conf.ArchivePath = config.String(newTestArchive(t, dir, map[string]string{
+			"tofu": fmt.Sprintf(tofuScript, "1.6.2"),
+		}))
+
+		err := InstallTerraform(ctx, conf)
+		require.NoError(t, err)
+		assert.Equal(t, "1.6.2", TerraformVersion.String())
+		assert.FileExists(t, filepath.Join(dir, "bin", "tofu"))
+
+		// the installed binary is verified on subsequent installs
+		err = InstallTerraform(ctx, conf)
+		assert.NoError(t, err)
+	})
+
+	t.Run("archive unsupported version", func(t *testing.T) {
+		dir := t.TempDir()
+		conf := newConfig(filepath.Join(dir, "bin"))
+		conf.ArchivePath = config.String(newTestArchive(t, dir, map[string]string{
+			"tofu": fmt.Sprintf(tofuScript, "1.5.0"),
+		}))
+
+		err := InstallTerraform(ctx, conf)
+		assert.Equal(t, errUnsupportedTerraformVersion, err)
+	})
+
+	t.Run("checksum", func(t *testing.T) {
+		dir := t.TempDir()
+		script := fmt.Sprintf(tofuScript, "1.6.2")
+		sum := sha256.Sum256([]byte(script))
+
+		conf := newConfig(filepath.Join(dir, "bin"))
+		conf.ArchivePath = config.String(newTestArchive(t, dir, map[string]string{
+			"tofu": script,
+		}))
+		conf.Checksum = config.String(hex.EncodeToString(sum[:]))
+		require.NoError(t, InstallTerraform(ctx, conf))
+
+		// the existing binary no longer matches a different checksum
+		conf.Checksum = config.String(strings.Repeat("0", 64))
+		err := InstallTerraform(ctx, conf)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "does not match the configured checksum")
+	})
+
+	t.Run("mirror url", func(t *testing.T) {
+		dir := t.TempDir()
+		archive := newTestArchive(t, dir, map[string]string{
+			"tofu": fmt.Sprintf(tofuScript, "1.6.2"),
+		})
+		expectedPath := fmt.Sprintf("/tofu/1.6.2/tofu_1.6.2_%s_%s.zip",
+			runtime.GOOS, runtime.GOARCH)
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != expectedPath {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			http.ServeFile(w, r, archive)
+		}))
+		defer server.Close()
+
+		conf := newConfig(filepath.Join(dir, "bin"))
+		conf.Mirror = config.String(server.URL + "/tofu")
+		err := InstallTerraform(ctx, conf)
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "bin", "tofu"))
+
+		conf = newConfig(filepath.Join(dir, "other"))
+		conf.Mirror = config.String(server.URL + "/missing")
+		err = InstallTerraform(ctx, conf)
+		assert.Error(t, err)
+	})
+
+	t.Run("mirror directory", func(t *testing.T) {
+		dir := t.TempDir()
+		mirror := filepath.Join(dir, "mirror")
+		versionDir := filepath.Join(mirror, "1.6.2")
+		require.NoError(t, os.MkdirAll(versionDir, 0755))
+		archive := newTestArchive(t, dir, map[string]string{
+			"tofu": fmt.Sprintf(tofuScript, "1.6.2"),
+		})
+		require.NoError(t, os.Rename(archive, mirrorArchivePath(mirror,
+			config.OpenTofuBinary, "1.6.2")))
+
+		conf := newConfig(filepath.Join(dir, "bin"))
+		conf.Mirror = config.String(mirror)
+		err := InstallTerraform(ctx, conf)
+		require.NoError(t, err)
+		assert.FileExists(t, filepath.Join(dir, "bin", "tofu"))
+	})
+}
@@ -0,0 +1,146 @@
+package driver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"log"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/zclconf/go-cty/cty"
+)
+
+const (
+	// maxEventLogSize is the maximum size of the Terraform output recorded for
+	// a task run. The beginning of larger output is dropped.
+	maxEventLogSize = 1024 * 1024
+
+	// minRedactLen is the minimum length of a configured value to redact from
+	// the output. Shorter values are too likely to match unrelated output.
+	minRedactLen = 6
+
+	redactedValue   = "(redacted)"
+	truncatedPrefix = "(truncated)\n"
+)
+
+// sensitiveAssignmentRegexp matches assignments of values to names of
+// secrets, such as `password = "..."`, in the Terraform output
+var sensitiveAssignmentRegexp = regexp.MustCompile(
+	`(?i)("?[\w-]*(?:password|secret|token)[\w-]*"?\s*[:=]\s*)("[^"]*"|\(sensitive value\)|\S+)`)
+
+// logCapture collects the Terraform output of a task run up to the maximum
+// size, keeping the most recent output
+type logCapture struct {
+	mu        sync.Mutex
+	buf       bytes.Buffer
+	truncated bool
+}
+
+func (c *logCapture) Write(p []byte) (int, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.buf.Write(p)
+	if over := c.buf.Len() - maxEventLogSize; over > 0 {
+		c.buf.Next(over)
+		c.truncated = true
+	}
+	return len(p), nil
+}
+
+// String returns the captured output with the secrets redacted
+func (c *logCapture) String(secrets []string) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := redactLogs(c.buf.String(), secrets)
+	if c.truncated {
+		out = truncatedPrefix + out
+	}
+	return out
+}
+
+// captureLogs captures the Terraform output while the task runs when there
+// is an event in the context to record it on. The returned function stops
+// capturing and records the redacted output on the event.
+func (tf *Terraform) captureLogs(ctx context.Context) func() {
+	ev := event.FromContext(ctx)
+	if ev == nil {
+		return func() {}
+	}
+
+	base := tf.clientLogWriter()
+	capture := &logCapture{}
+	w := io.MultiWriter(base, capture)
+	tf.client.SetStdout(w)
+	tf.client.SetStderr(w)
+
+	return func() {
+		tf.client.SetStdout(base)
+		tf.client.SetStderr(base)
+		ev.Logs = capture.String(taskSecrets(tf.task))
+	}
+}
+
+// clientLogWriter returns the writer for the Terraform output when it is not
+// captured, which logs the output only if logging the client is enabled
+func (tf *Terraform) clientLogWriter() io.Writer {
+	if tf.logClient {
+		return log.Writer()
+	}
+	return ioutil.Discard
+}
+
+// taskSecrets returns the configured values of the task that may be
+// sensitive and appear in the Terraform output: the values of environment
+// variables, provider blocks, and module variables
+func taskSecrets(task *Task) []string {
+	var secrets []string
+	for _, v := range task.Env() {
+		secrets = append(secrets, v)
+	}
+	for _, p := range task.Providers() {
+		secrets = append(secrets, variableStrings(p.ProviderBlock().Variables)...)
+	}
+	secrets = append(secrets, variableStrings(task.Variables())...)
+	return secrets
+}
+
+// variableStrings returns all string values nested in the variables
+func variableStrings(vars hcltmpl.Variables) []string {
+	var values []string
+	for _, v := range vars {
+		cty.Walk(v, func(_ cty.Path, val cty.Value) (bool, error) {
+			if val.IsKnown() && !val.IsNull() && val.Type() == cty.String {
+				values = append(values, val.AsString())
+			}
+			return true, nil
+		})
+	}
+	return values
+}
+
+// redactLogs replaces the secrets and values assigned to names of secrets in
+// the output
+func redactLogs(out string, secrets []string) string {
+	// redact longer secrets first in case a secret contains another
+	sorted := make([]string, 0, len(secrets))
+	for _, s := range secrets {
+		if len(s) >= minRedactLen {
+			sorted = append(sorted, s)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return len(sorted[i]) > len(sorted[j]) })
+
+	for _, s := range sorted {
+		out = strings.ReplaceAll(out, s, redactedValue)
+	}
+
+	return sensitiveAssignmentRegexp.ReplaceAllString(out, "${1}"+redactedValue)
+}
@@ -0,0 +1,84 @@
+package driver
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/zclconf/go-cty/cty"
+)
+
+func TestApplyTask_Logs(t *testing.T) {
+	t.Parallel()
+
+	ev := &event.Event{TaskName: "task"}
+	ctx := event.WithContext(context.Background(), ev)
+
+	var stdout io.Writer
+	c := new(mocks.Client)
+	c.On("SetStdout", mock.Anything).Run(func(args mock.Arguments) {
+		if stdout == nil {
+			stdout = args.Get(0).(io.Writer)
+		}
+	}).Return()
+	c.On("SetStderr", mock.Anything).Return()
+	c.On("Apply", ctx).Run(func(mock.Arguments) {
+		fmt.Fprintln(stdout, "Apply complete! Resources: 1 added")
+		fmt.Fprintln(stdout, `  + api_key  = "env-secret-value"`)
+		fmt.Fprintln(stdout, `  + password = "hunter22"`)
+		fmt.Fprintln(stdout, `  + address  = "10.0.0.1:8080"`)
+	}).Return(nil)
+
+	tf := &Terraform{
+		task: &Task{
+			name:    "task",
+			enabled: true,
+			env:     map[string]string{"API_KEY": "env-secret-value"},
+			variables: hcltmpl.Variables{
+				"address": cty.StringVal("10.0.0.1:8080"),
+			},
+			logger: logging.NewNullLogger(),
+		},
+		client: c,
+		logger: logging.NewNullLogger(),
+	}
+
+	assert.NoError(t, tf.ApplyTask(ctx))
+	assert.Equal(t, `Apply complete! Resources: 1 added
+  + api_key  = "(redacted)"
+  + password = (redacted)
+  + address  = "(redacted)"
+`, ev.Logs)
+	c.AssertExpectations(t)
+}
+
+func TestLogCapture(t *testing.T) {
+	t.Parallel()
+
+	t.Run("truncated", func(t *testing.T) {
+		c := &logCapture{}
+		c.Write([]byte(strings.Repeat("a", maxEventLogSize)))
+		c.Write([]byte("end"))
+
+		out := c.String(nil)
+		assert.True(t, strings.HasPrefix(out, truncatedPrefix))
+		assert.True(t, strings.HasSuffix(out, "end"))
+		assert.Len(t, out, len(truncatedPrefix)+maxEventLogSize)
+	})
+
+	t.Run("redacted", func(t *testing.T) {
+		c := &logCapture{}
+		c.Write([]byte(`token: abc123 short: abc "db_secret" = "s3cr3t!" long-secret-value`))
+
+		assert.Equal(t, `token: (redacted) short: abc "db_secret" = (redacted) (redacted)`,
+			c.String([]string{"abc", "long-secret-value"}))
+	})
+}
@@ -0,0 +1,215 @@
+package driver
+
+const (
+	// sensitiveValue replaces values that Terraform marks as sensitive in
+	// plans passed to hooks. It matches how Terraform displays the values.
+	sensitiveValue = "(sensitive value)"
+)
+
+// redactPlan returns a copy of the JSON decoded Terraform plan that is safe to
+// pass to hooks, which may send it to external endpoints. The variables and
+// configuration are removed since they include the values of sensitive
+// variables and provider arguments, and values that Terraform marks as
+// sensitive are replaced. The plan is not modified.
+func redactPlan(changes interface{}) interface{} {
+	plan, ok := changes.(map[string]interface{})
+	if !ok {
+		return changes
+	}
+
+	redacted := make(map[string]interface{}, len(plan))
+	for k, v := range plan {
+		switch k {
+		case "variables", "configuration":
+			continue
+		case "resource_changes", "resource_drift":
+			v = redactResourceChanges(v)
+		case "output_changes":
+			v = redactOutputChanges(v)
+		case "planned_values":
+			v = redactValues(v)
+		case "prior_state":
+			v = redactState(v)
+		}
+		redacted[k] = v
+	}
+	return redacted
+}
+
+// redactResourceChanges redacts the sensitive values of a list of resource
+// changes
+func redactResourceChanges(v interface{}) interface{} {
+	rcs, ok := v.([]interface{})
+	if !ok {
+		return v
+	}
+
+	redacted := make([]interface{}, len(rcs))
+	for i, rc := range rcs {
+		m, ok := rc.(map[string]interface{})
+		if !ok {
+			redacted[i] = rc
+			continue
+		}
+		m = copyMap(m)
+		m["change"] = redactChange(m["change"])
+		redacted[i] = m
+	}
+	return redacted
+}
+
+// redactOutputChanges redacts the sensitive values of the changes to outputs
+// keyed by output name
+func redactOutputChanges(v interface{}) interface{} {
+	ocs, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	redacted := make(map[string]interface{}, len(ocs))
+	for name, oc := range ocs {
+		redacted[name] = redactChange(oc)
+	}
+	return redacted
+}
+
+// redactChange replaces the before and after values of a change that are
+// marked in before_sensitive and after_sensitive
+func redactChange(v interface{}) interface{} {
+	c, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	c = copyMap(c)
+	if before, ok := c["before"]; ok {
+		c["before"] = redactSensitive(before, c["before_sensitive"])
+	}
+	if after, ok := c["after"]; ok {
+		c["after"] = redactSensitive(after, c["after_sensitive"])
+	}
+	return c
+}
+
+// redactState redacts the sensitive values of a state representation
+func redactState(v interface{}) interface{} {
+	s, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	s = copyMap(s)
+	if values, ok := s["values"]; ok {
+		s["values"] = redactValues(values)
+	}
+	return s
+}
+
+// redactValues redacts the sensitive outputs and resource attributes of a
+// values representation, such as the planned values
+func redactValues(v interface{}) interface{} {
+	vals, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	vals = copyMap(vals)
+	if outputs, ok := vals["outputs"].(map[string]interface{}); ok {
+		redacted := make(map[string]interface{}, len(outputs))
+		for name, o := range outputs {
+			om, ok := o.(map[string]interface{})
+			if ok && om["sensitive"] == true {
+				om = copyMap(om)
+				om["value"] = sensitiveValue
+				o = om
+			}
+			redacted[name] = o
+		}
+		vals["outputs"] = redacted
+	}
+	if root, ok := vals["root_module"]; ok {
+		vals["root_module"] = redactModule(root)
+	}
+	return vals
+}
+
+// redactModule redacts the sensitive resource attributes of a module and its
+// child modules
+func redactModule(v interface{}) interface{} {
+	mod, ok := v.(map[string]interface{})
+	if !ok {
+		return v
+	}
+
+	mod = copyMap(mod)
+	if resources, ok := mod["resources"].([]interface{}); ok {
+		redacted := make([]interface{}, len(resources))
+		for i, r := range resources {
+			rm, ok := r.(map[string]interface{})
+			if ok {
+				rm = copyMap(rm)
+				if values, ok := rm["values"]; ok {
+					rm["values"] = redactSensitive(values, rm["sensitive_values"])
+				}
+				r = rm
+			}
+			redacted[i] = r
+		}
+		mod["resources"] = redacted
+	}
+	if children, ok := mod["child_modules"].([]interface{}); ok {
+		redacted := make([]interface{}, len(children))
+		for i, child := range children {
+			redacted[i] = redactModule(child)
+		}
+		mod["child_modules"] = redacted
+	}
+	return mod
+}
+
+// redactSensitive replaces the parts of the value that are marked sensitive.
+// The sensitive marks mirror the structure of the value, with true marking a
+// sensitive value.
+func redactSensitive(value, sensitive interface{}) interface{} {
+	switch s := sensitive.(type) {
+	case bool:
+		if s {
+			return sensitiveValue
+		}
+	case map[string]interface{}:
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return value
+		}
+		m = copyMap(m)
+		for k, sv := range s {
+			if v, ok := m[k]; ok {
+				m[k] = redactSensitive(v, sv)
+			}
+		}
+		return m
+	case []interface{}:
+		l, ok := value.([]interface{})
+		if !ok {
+			return value
+		}
+		redacted := make([]interface{}, len(l))
+		copy(redacted, l)
+		for i, sv := range s {
+			if i < len(redacted) {
+				redacted[i] = redactSensitive(redacted[i], sv)
+			}
+		}
+		return redacted
+	}
+	return value
+}
+
+// copyMap returns a shallow copy of the map
+func copyMap(m map[string]interface{}) map[string]interface{} {
+	c := make(map[string]interface{}, len(m))
+	for k, v := range m {
+		c[k] = v
+	}
+	return c
+}
@@ -0,0 +1,172 @@
+package driver
+
+import (
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/hook"
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	mocksHook "github.com/hashicorp/consul-terraform-sync/mocks/hook"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+	tfjson "github.com/hashicorp/terraform-json"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedactPlan(t *testing.T) {
+	t.Parallel()
+
+	decode := func(s string) interface{} {
+		var v interface{}
+		require.NoError(t, json.Unmarshal([]byte(s), &v))
+		return v
+	}
+
+	plan := decode(`{
+		"format_version": "1.0",
+		"variables": {"password": {"value": "hunter22"}},
+		"configuration": {"provider_config": {"fortios": {"expressions": {
+			"token": {"constant_value": "abcd"}}}}},
+		"resource_changes": [{
+			"address": "fortios_user.u",
+			"change": {
+				"actions": ["update"],
+				"before": {"name": "u", "passwd": "old", "tags": ["a", "b"]},
+				"after": {"name": "u", "passwd": "new", "tags": ["a", "c"]},
+				"before_sensitive": {"passwd": true},
+				"after_sensitive": {"passwd": true, "tags": [false, true]}
+			}
+		}],
+		"output_changes": {"secret": {
+			"actions": ["create"],
+			"before": null,
+			"after": "s3cret",
+			"before_sensitive": false,
+			"after_sensitive": true
+		}},
+		"planned_values": {
+			"outputs": {"secret": {"sensitive": true, "value": "s3cret"}},
+			"root_module": {"child_modules": [{"resources": [{
+				"address": "module.m.fortios_user.u",
+				"values": {"name": "u", "passwd": "new"},
+				"sensitive_values": {"passwd": true}
+			}]}]}
+		},
+		"prior_state": {"values": {"root_module": {"resources": [{
+			"address": "fortios_user.u",
+			"values": {"name": "u", "passwd": "old"},
+			"sensitive_values": {"passwd": true}
+		}]}}}
+	}`)
+	original, err := json.Marshal(plan)
+	require.NoError(t, err)
+
+	expected := decode(`{
+		"format_version": "1.0",
+		"resource_changes": [{
+			"address": "fortios_user.u",
+			"change": {
+				"actions": ["update"],
+				"before": {"name": "u", "passwd": "(sensitive value)", "tags": ["a", "b"]},
+				"after": {"name": "u", "passwd": "(sensitive value)", "tags": ["a", "(sensitive value)"]},
+				"before_sensitive": {"passwd": true},
+				"after_sensitive": {"passwd": true, "tags": [false, true]}
+			}
+		}],
+		"output_changes": {"secret": {
+			"actions": ["create"],
+			"before": null,
+			"after": "(sensitive value)",
+			"before_sensitive": false,
+			"after_sensitive": true
+		}},
+		"planned_values": {
+			"outputs": {"secret": {"sensitive": true, "value": "(sensitive value)"}},
+			"root_module": {"child_modules": [{"resources": [{
+				"address": "module.m.fortios_user.u",
+				"values": {"name": "u", "passwd": "(sensitive value)"},
+				"sensitive_values": {"passwd": true}
+			}]}]}
+		},
+		"prior_state": {"values": {"root_module": {"resources": [{
+			"address": "fortios_user.u",
+			"values": {"name": "u", "passwd": "(sensitive value)"},
+			"sensitive_values": {"passwd": true}
+		}]}}}
+	}`)
+
+	assert.Equal(t, expected, redactPlan(plan))
+
+	// the plan used for policy checks is not modified
+	actual, err := json.Marshal(plan)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(original), string(actual))
+
+	t.Run("no plan", func(t *testing.T) {
+		assert.Nil(t, redactPlan(nil))
+	})
+}
+
+func TestApplyTask_HooksRedactPlan(t *testing.T) {
+	t.Parallel()
+
+	wd := t.TempDir()
+	planFile := filepath.Join(wd, planFilename)
+	ev := &event.Event{TaskName: "task"}
+	ctx := event.WithContext(context.Background(), ev)
+
+	plan := &tfjson.Plan{
+		FormatVersion: "1.0",
+		Variables: map[string]*tfjson.PlanVariable{
+			"password": {Value: "supersecret"},
+		},
+		ResourceChanges: []*tfjson.ResourceChange{{
+			Address: "fortios_user.u",
+			Change: &tfjson.Change{
+				Actions:        tfjson.Actions{tfjson.ActionCreate},
+				After:          map[string]interface{}{"name": "u", "passwd": "supersecret"},
+				AfterSensitive: map[string]interface{}{"passwd": true},
+			},
+		}},
+	}
+
+	c := new(mocks.Client)
+	c.On("SetStdout", mock.Anything).Return()
+	c.On("SetStderr", mock.Anything).Return()
+	c.On("SavePlan", ctx, planFile).Return(true, nil)
+	c.On("ShowPlan", ctx, planFile).Return(plan, nil)
+	c.On("ApplyPlan", ctx, planFile).Return(nil)
+
+	var inputs [][]byte
+	newHook := func(stage string) *mocksHook.Hook {
+		h := new(mocksHook.Hook)
+		h.On("Name").Return(stage + "-hook")
+		h.On("Stage").Return(stage)
+		h.On("Run", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			inputs = append(inputs, args.Get(1).([]byte))
+		}).Return("", nil)
+		return h
+	}
+
+	tf := &Terraform{
+		task: &Task{name: "task", enabled: true, workingDir: wd,
+			logger: logging.NewNullLogger()},
+		client: c,
+		hooks: []hook.Hook{
+			newHook(hook.StagePostPlan),
+			newHook(hook.StagePostApply),
+		},
+		logger: logging.NewNullLogger(),
+	}
+
+	require.NoError(t, tf.ApplyTask(ctx))
+	require.Len(t, inputs, 2)
+	for _, input := range inputs {
+		assert.NotContains(t, string(input), "supersecret")
+		assert.Contains(t, string(input), `"passwd":"(sensitive value)"`)
+	}
+}
@@ -3,21 +3,37 @@ package driver
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/config"
 	"github.com/hashicorp/consul-terraform-sync/handler"
+	"github.com/hashicorp/consul-terraform-sync/hook"
 	"github.com/hashicorp/consul-terraform-sync/logging"
 	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	mocksHook "github.com/hashicorp/consul-terraform-sync/mocks/hook"
+	mocksPolicy "github.com/hashicorp/consul-terraform-sync/mocks/policy"
 	mocksTmpl "github.com/hashicorp/consul-terraform-sync/mocks/templates"
+	"github.com/hashicorp/consul-terraform-sync/policy"
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl"
 	"github.com/hashicorp/consul-terraform-sync/templates/tftmpl/tmplfunc"
 	"github.com/hashicorp/consul-terraform-sync/testutils"
 	"github.com/hashicorp/go-uuid"
 	goVersion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcat"
+	tfjson "github.com/hashicorp/terraform-json"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -194,6 +210,8 @@ func TestApplyTask(t *testing.T) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			c := new(mocks.Client)
+			c.On("SetStdout", mock.Anything).Return()
+			c.On("SetStderr", mock.Anything).Return()
 			c.On("Apply", ctx).Return(tc.applyReturn).Once()
 
 			tf := &Terraform{
@@ -213,6 +231,244 @@ func TestApplyTask(t *testing.T) {
 	}
 }
 
+func TestApplyTask_Policies(t *testing.T) {
+	t.Parallel()
+
+	newPolicy := func(level string, violations []string, err error) policy.Evaluator {
+		p := new(mocksPolicy.Evaluator)
+		p.On("Name").Return("policy")
+		p.On("EnforcementLevel").Return(level)
+		p.On("Evaluate", mock.Anything, mock.Anything).Return(violations, err)
+		return p
+	}
+
+	cases := []struct {
+		name        string
+		expectError bool
+		expectApply bool
+		policy      policy.Evaluator
+		expected    []event.PolicyResult
+	}{
+		{
+			"policy passes",
+			false,
+			true,
+			newPolicy(policy.EnforcementMandatory, nil, nil),
+			[]event.PolicyResult{{
+				Name:             "policy",
+				EnforcementLevel: policy.EnforcementMandatory,
+				Passed:           true,
+			}},
+		},
+		{
+			"advisory policy fails",
+			false,
+			true,
+			newPolicy(policy.EnforcementAdvisory, []string{"violation"}, nil),
+			[]event.PolicyResult{{
+				Name:             "policy",
+				EnforcementLevel: policy.EnforcementAdvisory,
+				Violations:       []string{"violation"},
+			}},
+		},
+		{
+			"mandatory policy fails",
+			true,
+			false,
+			newPolicy(policy.EnforcementMandatory, []string{"violation"}, nil),
+			[]event.PolicyResult{{
+				Name:             "policy",
+				EnforcementLevel: policy.EnforcementMandatory,
+				Violations:       []string{"violation"},
+			}},
+		},
+		{
+			"policy evaluation error",
+			true,
+			false,
+			newPolicy(policy.EnforcementAdvisory, nil, errors.New("error")),
+			[]event.PolicyResult{},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			wd := t.TempDir()
+			planFile := filepath.Join(wd, planFilename)
+			ev := &event.Event{TaskName: "ApplyTaskTest"}
+			ctx := event.WithContext(context.Background(), ev)
+
+			c := new(mocks.Client)
+			c.On("SetStdout", mock.Anything).Return()
+			c.On("SetStderr", mock.Anything).Return()
+			c.On("SavePlan", ctx, planFile).Return(true, nil).Once()
+			c.On("ShowPlan", ctx, planFile).Return(&tfjson.Plan{}, nil).Once()
+			if tc.expectApply {
+				c.On("ApplyPlan", ctx, planFile).Return(nil).Once()
+			}
+
+			tf := &Terraform{
+				task: &Task{name: "ApplyTaskTest", enabled: true, workingDir: wd,
+					logger: logging.NewNullLogger()},
+				client:   c,
+				policies: []policy.Evaluator{tc.policy},
+				logger:   logging.NewNullLogger(),
+			}
+
+			err := tf.ApplyTask(ctx)
+			if !tc.expectError {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+				var nonRetryable *retry.NonRetryableError
+				assert.True(t, errors.As(err, &nonRetryable))
+			}
+			assert.Equal(t, tc.expected, ev.Policies)
+			c.AssertExpectations(t)
+		})
+	}
+}
+
+func TestApplyTask_PlanChanges(t *testing.T) {
+	t.Parallel()
+
+	wd := t.TempDir()
+	planFile := filepath.Join(wd, planFilename)
+	ev := &event.Event{TaskName: "ApplyTaskTest"}
+	ctx := event.WithContext(context.Background(), ev)
+
+	plan := &tfjson.Plan{
+		ResourceChanges: []*tfjson.ResourceChange{
+			{Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}}},
+			{Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionCreate}}},
+			{Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionUpdate}}},
+			{Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionDelete}}},
+			{Change: &tfjson.Change{Actions: tfjson.Actions{
+				tfjson.ActionDelete, tfjson.ActionCreate}}},
+			{Change: &tfjson.Change{Actions: tfjson.Actions{tfjson.ActionNoop}}},
+		},
+	}
+
+	c := new(mocks.Client)
+	c.On("SetStdout", mock.Anything).Return()
+	c.On("SetStderr", mock.Anything).Return()
+	c.On("SavePlan", ctx, planFile).Return(true, nil).Once()
+	c.On("ShowPlan", ctx, planFile).Return(plan, nil).Once()
+	c.On("ApplyPlan", ctx, planFile).Return(nil).Once()
+
+	tf := &Terraform{
+		task: &Task{name: "ApplyTaskTest", enabled: true, workingDir: wd,
+			logger: logging.NewNullLogger()},
+		client:      c,
+		planChanges: true,
+		logger:      logging.NewNullLogger(),
+	}
+
+	err := tf.ApplyTask(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, &event.ChangeSummary{Add: 3, Change: 1, Destroy: 2}, ev.Changes)
+	c.AssertExpectations(t)
+}
+
+func TestApplyTask_Hooks(t *testing.T) {
+	t.Parallel()
+
+	newHook := func(stage string, err error) *mocksHook.Hook {
+		h := new(mocksHook.Hook)
+		h.On("Name").Return(stage + "-hook")
+		h.On("Stage").Return(stage)
+		h.On("Run", mock.Anything, mock.Anything).Return("output", err)
+		return h
+	}
+
+	cases := []struct {
+		name        string
+		expectError bool
+		applyErr    error
+		hooks       []*mocksHook.Hook
+		expected    []event.HookResult
+	}{
+		{
+			"all stages",
+			false,
+			nil,
+			[]*mocksHook.Hook{
+				newHook(hook.StagePrePlan, nil),
+				newHook(hook.StagePostPlan, nil),
+				newHook(hook.StagePostApply, nil),
+				newHook(hook.StageOnFailure, nil),
+			},
+			[]event.HookResult{
+				{Name: "pre-plan-hook", Stage: hook.StagePrePlan, Success: true, Output: "output"},
+				{Name: "post-plan-hook", Stage: hook.StagePostPlan, Success: true, Output: "output"},
+				{Name: "post-apply-hook", Stage: hook.StagePostApply, Success: true, Output: "output"},
+			},
+		},
+		{
+			"apply error runs on-failure hooks",
+			true,
+			errors.New("apply error"),
+			[]*mocksHook.Hook{
+				newHook(hook.StagePostApply, nil),
+				newHook(hook.StageOnFailure, nil),
+			},
+			[]event.HookResult{
+				{Name: "on-failure-hook", Stage: hook.StageOnFailure, Success: true, Output: "output"},
+			},
+		},
+		{
+			"pre-plan hook error stops run",
+			true,
+			nil,
+			[]*mocksHook.Hook{
+				newHook(hook.StagePrePlan, errors.New("hook error")),
+				newHook(hook.StagePostApply, nil),
+			},
+			[]event.HookResult{
+				{Name: "pre-plan-hook", Stage: hook.StagePrePlan, Output: "output", Error: "hook error"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			wd := t.TempDir()
+			planFile := filepath.Join(wd, planFilename)
+			ev := &event.Event{TaskName: "ApplyTaskTest"}
+			ctx := event.WithContext(context.Background(), ev)
+
+			c := new(mocks.Client)
+			c.On("SetStdout", mock.Anything).Return()
+			c.On("SetStderr", mock.Anything).Return()
+			c.On("Apply", ctx).Return(tc.applyErr)
+			c.On("SavePlan", ctx, planFile).Return(true, nil)
+			c.On("ShowPlan", ctx, planFile).Return(&tfjson.Plan{}, nil)
+			c.On("ApplyPlan", ctx, planFile).Return(tc.applyErr)
+
+			hooks := make([]hook.Hook, len(tc.hooks))
+			for i, h := range tc.hooks {
+				hooks[i] = h
+			}
+
+			tf := &Terraform{
+				task: &Task{name: "ApplyTaskTest", enabled: true, workingDir: wd,
+					logger: logging.NewNullLogger()},
+				client: c,
+				hooks:  hooks,
+				logger: logging.NewNullLogger(),
+			}
+
+			err := tf.ApplyTask(ctx)
+			if !tc.expectError {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+			assert.Equal(t, tc.expected, ev.Hooks)
+		})
+	}
+}
+
 func TestUpdateTask(t *testing.T) {
 	t.Parallel()
 
@@ -850,6 +1106,525 @@ func TestTerraform_DestroyTask(t *testing.T) {
 	tf.DestroyTask(ctx)
 }
 
+func TestTerraform_InspectDestroy(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	c := new(mocks.Client)
+	c.On("PlanDestroy", ctx).Return(true, nil).Once()
+	c.On("SetStdout", mock.Anything).Twice()
+	tf := &Terraform{
+		task:   &Task{name: "test"},
+		client: c,
+		logger: logging.NewNullLogger(),
+		inited: true,
+	}
+
+	plan, err := tf.InspectDestroy(ctx)
+	require.NoError(t, err)
+	assert.True(t, plan.ChangesPresent)
+	c.AssertExpectations(t)
+}
+
+func TestTerraform_ShowState(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	state := &tfjson.State{FormatVersion: "1.0"}
+	c := new(mocks.Client)
+	c.On("ShowState", ctx).Return(state, nil).Once()
+	c.On("SetStdout", ioutil.Discard).Once()
+	c.On("SetStdout", mock.Anything).Once()
+	tf := &Terraform{
+		task:   &Task{name: "test"},
+		client: c,
+		logger: logging.NewNullLogger(),
+		inited: true,
+	}
+
+	actual, err := tf.ShowState(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, state, actual)
+	c.AssertExpectations(t)
+}
+
+func TestTerraform_CheckModuleUpgrade(t *testing.T) {
+	t.Parallel()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/terraform.json", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"modules.v1":"/v1/modules/"}`)
+	})
+	mux.HandleFunc("/v1/modules/example/basic/local/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"modules":[{"versions":[{"version":"1.2.0"},{"version":"1.4.0"},{"version":"2.0.0"}]}]}`)
+	})
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	source := u.Host + "/example/basic/local"
+	c := registry.NewClient(server.Client())
+
+	newTask := func(t *testing.T, module string, installed bool) *Task {
+		wd := t.TempDir()
+		if installed {
+			require.NoError(t, os.MkdirAll(filepath.Join(wd, ".terraform", "modules"), 0755))
+			manifest := fmt.Sprintf(`{"Modules":[{"Key":"test","Source":%q,"Version":"1.2.0","Dir":"."}]}`, source)
+			require.NoError(t, os.WriteFile(filepath.Join(wd, registry.ModulesManifest),
+				[]byte(manifest), 0644))
+		}
+		task, err := NewTask(TaskConfig{
+			Name:       "test",
+			Module:     module,
+			Version:    "~> 1.2",
+			WorkingDir: wd,
+		})
+		require.NoError(t, err)
+		return task
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		tf := &Terraform{task: newTask(t, source, true)}
+		upgrade, err := tf.CheckModuleUpgrade(context.Background(), c)
+		require.NoError(t, err)
+		assert.Equal(t, source, upgrade.Source)
+		assert.Equal(t, "~> 1.2", upgrade.Constraint)
+		assert.Equal(t, "1.2.0", upgrade.InstalledVersion)
+		assert.Equal(t, "1.4.0", upgrade.LatestVersion)
+		assert.True(t, upgrade.Available())
+	})
+
+	t.Run("module source rewrite", func(t *testing.T) {
+		tf := &Terraform{
+			task: newTask(t, "example/basic/local", true),
+			moduleSourceRewrites: map[string]string{
+				"example/": u.Host + "/example/",
+			},
+		}
+		upgrade, err := tf.CheckModuleUpgrade(context.Background(), c)
+		require.NoError(t, err)
+		assert.Equal(t, "1.4.0", upgrade.LatestVersion)
+	})
+
+	t.Run("not installed", func(t *testing.T) {
+		tf := &Terraform{task: newTask(t, source, false)}
+		_, err := tf.CheckModuleUpgrade(context.Background(), c)
+		assert.Error(t, err)
+	})
+
+	t.Run("local module", func(t *testing.T) {
+		tf := &Terraform{task: newTask(t, "./modules/basic", true)}
+		_, err := tf.CheckModuleUpgrade(context.Background(), c)
+		assert.ErrorIs(t, err, registry.ErrNotRegistrySource)
+	})
+}
+
+func TestTerraform_UpgradeModule(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	newTerraform := func(t *testing.T, c *mocks.Client) *Terraform {
+		task, err := NewTask(TaskConfig{
+			Name:       "test",
+			Module:     "example/basic/local",
+			Version:    "~> 1.2",
+			WorkingDir: t.TempDir(),
+		})
+		require.NoError(t, err)
+		return &Terraform{
+			task:   task,
+			client: c,
+			logger: logging.NewNullLogger(),
+			inited: true,
+		}
+	}
+	readMain := func(t *testing.T, tf *Terraform) string {
+		content, err := ioutil.ReadFile(filepath.Join(tf.task.WorkingDir(), tftmpl.RootFilename))
+		require.NoError(t, err)
+		return string(content)
+	}
+	planFile := func(tf *Terraform) string {
+		return filepath.Join(tf.task.WorkingDir(), planFilename)
+	}
+	changesPlan := func(actions ...tfjson.Action) *tfjson.Plan {
+		return &tfjson.Plan{ResourceChanges: []*tfjson.ResourceChange{{
+			Address: "module.test.local_file.a",
+			Change:  &tfjson.Change{Actions: actions},
+		}}}
+	}
+
+	t.Run("happy path", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("Init", ctx).Return(nil).Once()
+		c.On("SetStdout", mock.Anything).Twice()
+		tf := newTerraform(t, c)
+		c.On("SavePlan", ctx, planFile(tf)).Return(true, nil).Once()
+		c.On("ShowPlan", ctx, planFile(tf)).
+			Return(changesPlan(tfjson.ActionUpdate), nil).Once()
+
+		plan, err := tf.UpgradeModule(ctx, "1.4.0", false)
+		require.NoError(t, err)
+		assert.True(t, plan.ChangesPresent)
+		assert.Equal(t, "1.4.0", tf.moduleVersion)
+		assert.True(t, tf.inited)
+		assert.Contains(t, readMain(t, tf), `version  = "1.4.0"`)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("init error restores version", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("Init", ctx).Return(errors.New("error")).Once()
+		c.On("Init", ctx).Return(nil).Once()
+		tf := newTerraform(t, c)
+
+		_, err := tf.UpgradeModule(ctx, "1.4.0", false)
+		assert.Error(t, err)
+		assert.Empty(t, tf.moduleVersion)
+		assert.Contains(t, readMain(t, tf), `version  = "~> 1.2"`)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("plan error restores version", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("Init", ctx).Return(nil).Twice()
+		c.On("SetStdout", mock.Anything).Twice()
+		tf := newTerraform(t, c)
+		c.On("SavePlan", ctx, planFile(tf)).Return(false, errors.New("error")).Once()
+
+		_, err := tf.UpgradeModule(ctx, "1.4.0", false)
+		assert.Error(t, err)
+		assert.Empty(t, tf.moduleVersion)
+		assert.Contains(t, readMain(t, tf), `version  = "~> 1.2"`)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("destroy restores version", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("Init", ctx).Return(nil).Twice()
+		c.On("SetStdout", mock.Anything).Twice()
+		tf := newTerraform(t, c)
+		c.On("SavePlan", ctx, planFile(tf)).Return(true, nil).Once()
+		c.On("ShowPlan", ctx, planFile(tf)).
+			Return(changesPlan(tfjson.ActionDelete, tfjson.ActionCreate), nil).Once()
+
+		_, err := tf.UpgradeModule(ctx, "1.4.0", false)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "module.test.local_file.a")
+		assert.Empty(t, tf.moduleVersion)
+		assert.Contains(t, readMain(t, tf), `version  = "~> 1.2"`)
+		c.AssertExpectations(t)
+	})
+
+	t.Run("destroy allowed", func(t *testing.T) {
+		c := new(mocks.Client)
+		c.On("Init", ctx).Return(nil).Once()
+		c.On("SetStdout", mock.Anything).Twice()
+		tf := newTerraform(t, c)
+		c.On("SavePlan", ctx, planFile(tf)).Return(true, nil).Once()
+
+		plan, err := tf.UpgradeModule(ctx, "1.4.0", true)
+		require.NoError(t, err)
+		assert.True(t, plan.ChangesPresent)
+		assert.Equal(t, "1.4.0", tf.moduleVersion)
+		c.AssertExpectations(t)
+		c.AssertNotCalled(t, "ShowPlan", mock.Anything, mock.Anything)
+	})
+}
+
+func TestTerraform_DestroyResources(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name           string
+		destroyErr     error
+		deleteErr      error
+		expectRemoved  bool
+		expectInitDone bool
+	}{
+		{
+			"happy path",
+			nil,
+			nil,
+			true,
+			false,
+		},
+		{
+			"destroy error",
+			errors.New("error"),
+			nil,
+			false,
+			true,
+		},
+		{
+			"delete workspace error",
+			nil,
+			errors.New("error"),
+			false,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			wd := filepath.Join(t.TempDir(), "test")
+			require.NoError(t, os.Mkdir(wd, 0700))
+
+			ev := &event.Event{TaskName: "test"}
+			ctx := event.WithContext(context.Background(), ev)
+			c := new(mocks.Client)
+			c.On("SetStdout", mock.Anything).Return()
+			c.On("SetStderr", mock.Anything).Return()
+			c.On("Destroy", ctx).Return(tc.destroyErr)
+			c.On("DeleteWorkspace", ctx).Return(tc.deleteErr)
+			tf := &Terraform{
+				task:   &Task{name: "test", workingDir: wd},
+				client: c,
+				logger: logging.NewNullLogger(),
+				inited: true,
+			}
+
+			err := tf.DestroyResources(ctx)
+			if tc.destroyErr != nil || tc.deleteErr != nil {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			if tc.destroyErr != nil {
+				c.AssertNotCalled(t, "DeleteWorkspace", mock.Anything)
+			}
+			assert.Equal(t, tc.expectInitDone, tf.inited)
+
+			_, err = os.Stat(wd)
+			assert.Equal(t, tc.expectRemoved, os.IsNotExist(err))
+		})
+	}
+}
+
+func TestTerraform_MigrateState(t *testing.T) {
+	t.Parallel()
+
+	oldBackend := map[string]interface{}{
+		"local": map[string]interface{}{"path": "old.tfstate"},
+	}
+	newBackend := map[string]interface{}{
+		"local": map[string]interface{}{"path": "new.tfstate"},
+	}
+	state := `{"version": 4, "resources": [{"type": "null_resource"}]}`
+
+	cases := []struct {
+		name        string
+		sourceState string
+		destState   string
+		expectPush  bool
+		expectErr   bool
+	}{
+		{
+			"happy path",
+			state,
+			"",
+			true,
+			false,
+		},
+		{
+			"no source state",
+			"",
+			"",
+			false,
+			false,
+		},
+		{
+			"destination manages resources",
+			state,
+			state,
+			false,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			wd := t.TempDir()
+			task, err := NewTask(TaskConfig{
+				Name:       "test",
+				Module:     "path/to/module",
+				WorkingDir: wd,
+			})
+			require.NoError(t, err)
+
+			ctx := context.Background()
+			c := new(mocks.Client)
+			c.On("SetStdout", mock.Anything).Return()
+			c.On("SetStderr", mock.Anything).Return()
+			c.On("PullState", ctx).Return(tc.sourceState, nil).Once()
+			c.On("Reconfigure", ctx).Return(nil)
+			if tc.sourceState != "" {
+				c.On("PullState", ctx).Return(tc.destState, nil).Once()
+			}
+			c.On("PushState", ctx, mock.Anything).Return(nil).Once()
+			tf := &Terraform{
+				task:    task,
+				backend: oldBackend,
+				client:  c,
+				logger:  logging.NewNullLogger(),
+				inited:  true,
+			}
+
+			err = tf.MigrateState(ctx, newBackend)
+			main, readErr := ioutil.ReadFile(filepath.Join(wd, tftmpl.RootFilename))
+			require.NoError(t, readErr)
+			if tc.expectErr {
+				assert.Error(t, err)
+				assert.Equal(t, oldBackend, tf.backend)
+				assert.Contains(t, string(main), "old.tfstate")
+				c.AssertNumberOfCalls(t, "Reconfigure", 2)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, newBackend, tf.backend)
+				assert.Contains(t, string(main), "new.tfstate")
+			}
+			if tc.expectPush {
+				c.AssertCalled(t, "PushState", ctx, mock.Anything)
+			} else {
+				c.AssertNotCalled(t, "PushState", mock.Anything, mock.Anything)
+			}
+			assert.True(t, tf.inited)
+
+			// the temporary state file is removed
+			files, err := filepath.Glob(filepath.Join(wd, "migrate-*"))
+			require.NoError(t, err)
+			assert.Empty(t, files)
+		})
+	}
+}
+
+func TestTerraform_ExportTask(t *testing.T) {
+	t.Parallel()
+
+	task, err := NewTask(TaskConfig{
+		Name:       "test",
+		Module:     "path/to/module",
+		WorkingDir: "sync-tasks/test",
+		Variables:  map[string]string{"count": "1"},
+	})
+	require.NoError(t, err)
+
+	t.Run("happy path", func(t *testing.T) {
+		var path string
+		tf := &Terraform{
+			task: task,
+			fileReader: func(p string) ([]byte, error) {
+				path = p
+				return []byte("services = {}"), nil
+			},
+		}
+
+		files, err := tf.ExportTask(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join("sync-tasks", "test", "terraform.tfvars"), path)
+		assert.Equal(t, "services = {}", string(files["terraform.tfvars"]))
+		for _, name := range []string{"main.tf", "variables.tf",
+			"variables.module.tf", "variables.auto.tfvars", "providers.auto.tfvars"} {
+			assert.Contains(t, files, name)
+		}
+		assert.NotContains(t, files, "terraform.tfvars.tmpl")
+		assert.Contains(t, string(files["main.tf"]), tftmpl.TerraformRequiredVersion)
+	})
+
+	t.Run("opentofu", func(t *testing.T) {
+		tf := &Terraform{
+			task:   task,
+			binary: config.OpenTofuBinary,
+			fileReader: func(p string) ([]byte, error) {
+				return []byte("services = {}"), nil
+			},
+		}
+
+		files, err := tf.ExportTask(context.Background())
+		require.NoError(t, err)
+		assert.Contains(t, string(files["main.tf"]), tftmpl.OpenTofuRequiredVersion)
+	})
+
+	t.Run("module source rewrite", func(t *testing.T) {
+		tf := &Terraform{
+			task: task,
+			moduleSourceRewrites: map[string]string{
+				"path/to/": "registry.example.com/org/",
+			},
+			fileReader: func(p string) ([]byte, error) {
+				return []byte("services = {}"), nil
+			},
+		}
+
+		files, err := tf.ExportTask(context.Background())
+		require.NoError(t, err)
+		assert.Contains(t, string(files["main.tf"]), `"registry.example.com/org/module"`)
+		assert.Equal(t, "path/to/module", task.Module())
+	})
+
+	t.Run("not rendered", func(t *testing.T) {
+		tf := &Terraform{
+			task: task,
+			fileReader: func(p string) ([]byte, error) {
+				return nil, os.ErrNotExist
+			},
+		}
+
+		_, err := tf.ExportTask(context.Background())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "has not rendered Consul data")
+	})
+}
+
+func TestRewriteModuleSource(t *testing.T) {
+	t.Parallel()
+
+	rewrites := map[string]string{
+		"registry.terraform.io/":           "registry.example.com/",
+		"registry.terraform.io/findkim/":   "registry.example.com/mirror/",
+		"git::https://github.com/example/": "git::https://git.example.com/",
+	}
+
+	cases := []struct {
+		name     string
+		source   string
+		expected string
+	}{
+		{
+			"no match",
+			"findkim/print/cts",
+			"findkim/print/cts",
+		},
+		{
+			"prefix",
+			"registry.terraform.io/org/module/cts",
+			"registry.example.com/org/module/cts",
+		},
+		{
+			"longest prefix",
+			"registry.terraform.io/findkim/print/cts",
+			"registry.example.com/mirror/print/cts",
+		},
+		{
+			"git",
+			"git::https://github.com/example/module.git?ref=v1.0.0",
+			"git::https://git.example.com/module.git?ref=v1.0.0",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, rewriteModuleSource(tc.source, rewrites))
+		})
+	}
+	assert.Equal(t, "findkim/print/cts", rewriteModuleSource("findkim/print/cts", nil))
+}
+
 func TestTerraform_TemplateIDs(t *testing.T) {
 	var tmpl mocksTmpl.Template
 	tf := Terraform{
@@ -0,0 +1,163 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+)
+
+const (
+	// TerraformProviderBigip is the name of the F5 BIG-IP Terraform provider.
+	TerraformProviderBigip = "bigip"
+
+	bigipSubsystemName = "bigip"
+)
+
+//go:generate mockery --name=bigipClient  --structname=BigipClient --output=../mocks/handler
+
+var _ bigipClient = (*bigipREST)(nil)
+
+type bigipClient interface {
+	SaveConfig(ctx context.Context) error
+}
+
+// bigipConfig is the subset of the bigip provider configuration used by the
+// handler
+type bigipConfig struct {
+	Address              string `mapstructure:"address"`
+	Username             string `mapstructure:"username"`
+	Password             string `mapstructure:"password"`
+	Port                 string `mapstructure:"port"`
+	ValidateCertsDisable bool   `mapstructure:"validate_certs_disable"`
+}
+
+var _ Handler = (*Bigip)(nil)
+
+// Bigip is the post-apply handler for the bigip Terraform Provider. It runs
+// `save sys config` after a Terraform apply so that changes persist through a
+// reboot of the BIG-IP.
+//
+// BIG-IP does not queue changes per admin, so all changes in the running
+// configuration are saved.
+//
+// See https://registry.terraform.io/providers/F5Networks/bigip/latest/docs
+// for details on the bigip provider.
+type Bigip struct {
+	next       Handler
+	client     bigipClient
+	address    string
+	autoCommit bool
+	retry      retry.Retry
+	logger     logging.Logger
+}
+
+// NewBigip configures and returns a new bigip handler
+func NewBigip(c map[string]interface{}) (*Bigip, error) {
+	logger := logging.Global().Named(logSystemName).Named(bigipSubsystemName)
+	logger.Info("creating handler")
+
+	var conf bigipConfig
+	if err := decodeProviderConfig(c, &conf); err != nil {
+		return nil, err
+	}
+	if conf.Address == "" {
+		conf.Address = os.Getenv("BIGIP_HOST")
+	}
+	if conf.Username == "" {
+		conf.Username = os.Getenv("BIGIP_USER")
+	}
+	if conf.Password == "" {
+		conf.Password = os.Getenv("BIGIP_PASSWORD")
+	}
+	if conf.Port == "" {
+		conf.Port = os.Getenv("BIGIP_PORT")
+	}
+	if _, ok := c["validate_certs_disable"]; !ok {
+		// The provider disables certificate validation by default
+		conf.ValidateCertsDisable = true
+		if v, ok := os.LookupEnv("BIGIP_VERIFY_CERT_DISABLE"); ok {
+			conf.ValidateCertsDisable, _ = strconv.ParseBool(v)
+		}
+	}
+	if conf.Address == "" {
+		return nil, errors.New("detected bigip provider with missing address. " +
+			"Configure the address for the bigip provider or set the BIGIP_HOST " +
+			"environment variable.")
+	}
+
+	address := conf.Address
+	if conf.Port != "" {
+		address = fmt.Sprintf("%s:%s", address, conf.Port)
+	}
+
+	return &Bigip{
+		next: nil,
+		client: &bigipREST{
+			rest:     newRESTClient(address, conf.ValidateCertsDisable),
+			username: conf.Username,
+			password: conf.Password,
+		},
+		address:    conf.Address,
+		autoCommit: autoCommitEnabled(c),
+		retry:      retry.NewRetry(maxRetries, time.Now().UnixNano()),
+		logger:     logger,
+	}, nil
+}
+
+// Do saves the bigip system configuration and calls next handler while
+// passing on relevant errors
+func (h *Bigip) Do(ctx context.Context, prevErr error) error {
+	h.logger.Trace("commit", "commit", commitStatus(h.autoCommit),
+		"host", h.address)
+	var err error
+	if h.autoCommit {
+		err = h.commit(ctx)
+	}
+	return callNext(ctx, h.next, prevErr, err)
+}
+
+// commit saves the system configuration
+func (h *Bigip) commit(ctx context.Context) error {
+	trySave := func(ctx context.Context) error {
+		err := h.client.SaveConfig(ctx)
+		if err != nil {
+			h.logger.Error("error saving sys config", "error", err)
+		}
+		return err
+	}
+
+	if err := h.retry.Do(ctx, trySave, "bigip save sys config"); err != nil {
+		return err
+	}
+
+	h.logger.Info("commit successful")
+	return nil
+}
+
+// SetNext sets the next handler that should be called.
+func (h *Bigip) SetNext(next Handler) {
+	h.next = next
+}
+
+// bigipREST is the bigipClient for the BIG-IP iControl REST API
+type bigipREST struct {
+	rest     *restClient
+	username string
+	password string
+}
+
+// SaveConfig saves the running configuration
+func (c *bigipREST) SaveConfig(ctx context.Context) error {
+	body := map[string]string{"command": "save"}
+	return c.rest.do(ctx, http.MethodPost, "/mgmt/tm/sys/config", body, nil,
+		func(req *http.Request) {
+			req.SetBasicAuth(c.username, c.password)
+		})
+}
@@ -0,0 +1,169 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/handler"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBigip(t *testing.T) {
+	cases := []struct {
+		name        string
+		expectError bool
+		config      map[string]interface{}
+	}{
+		{
+			"happy path",
+			false,
+			map[string]interface{}{
+				"address":  "10.10.10.10",
+				"username": "admin",
+				"password": "pw123",
+				"port":     8443,
+			},
+		}, {
+			"missing required address",
+			true,
+			map[string]interface{}{
+				"username": "admin",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewBigip(tc.config)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, h)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.config["address"], h.address)
+		})
+	}
+
+	t.Run("validate_certs_disable", func(t *testing.T) {
+		cases := []struct {
+			name     string
+			config   map[string]interface{}
+			env      string
+			insecure bool
+		}{
+			{
+				"provider default",
+				map[string]interface{}{},
+				"",
+				true,
+			}, {
+				"configured",
+				map[string]interface{}{"validate_certs_disable": false},
+				"true",
+				false,
+			}, {
+				"from env",
+				map[string]interface{}{},
+				"false",
+				false,
+			},
+		}
+
+		for _, tc := range cases {
+			t.Run(tc.name, func(t *testing.T) {
+				if tc.env != "" {
+					reset := testutils.Setenv("BIGIP_VERIFY_CERT_DISABLE", tc.env)
+					defer reset()
+				}
+				tc.config["address"] = "10.10.10.10"
+
+				h, err := NewBigip(tc.config)
+				require.NoError(t, err)
+				client, ok := h.client.(*bigipREST)
+				require.True(t, ok)
+				assert.Equal(t, tc.insecure, restInsecure(client.rest))
+			})
+		}
+	})
+}
+
+func TestBigipDo(t *testing.T) {
+	t.Run("autoCommit setting", func(t *testing.T) {
+		m := new(mocks.BigipClient)
+		m.On("SaveConfig", mock.Anything).Return(nil).Once()
+
+		h := &Bigip{client: m, autoCommit: true, retry: retry.NewTestRetry(1),
+			logger: logging.NewNullLogger()}
+		assert.NoError(t, h.Do(context.Background(), nil))
+		h.autoCommit = false
+		assert.NoError(t, h.Do(context.Background(), nil))
+		m.AssertExpectations(t)
+	})
+}
+
+func TestBigipCommit(t *testing.T) {
+	cases := []struct {
+		name      string
+		saveErr   error
+		expectErr bool
+		tries     int
+	}{
+		{
+			"happy path",
+			nil,
+			false,
+			1,
+		},
+		{
+			"error on save",
+			errors.New("save error"),
+			true,
+			2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(mocks.BigipClient)
+			m.On("SaveConfig", mock.Anything).Return(tc.saveErr)
+
+			h := &Bigip{client: m, retry: retry.NewTestRetry(1),
+				logger: logging.NewNullLogger()}
+			err := h.commit(context.Background())
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertNumberOfCalls(t, "SaveConfig", tc.tries)
+		})
+	}
+}
+
+func TestBigipREST_SaveConfig(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/mgmt/tm/sys/config", r.URL.Path)
+		var body map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "save", body["command"])
+		w.Write([]byte(`{"kind":"tm:sys:config:savestate","command":"save"}`))
+	}))
+	defer ts.Close()
+
+	c := &bigipREST{rest: newRESTClient(ts.URL, false), username: "admin",
+		password: "pw123"}
+	assert.NoError(t, c.SaveConfig(context.Background()))
+}
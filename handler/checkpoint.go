@@ -0,0 +1,361 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+)
+
+const (
+	// TerraformProviderCheckpoint is the name of the Check Point Terraform
+	// provider.
+	TerraformProviderCheckpoint = "checkpoint"
+
+	checkpointSubsystemName = "checkpoint"
+
+	// checkpointWaitInterval is the interval to poll the status of a task
+	checkpointWaitInterval = 2 * time.Second
+)
+
+//go:generate mockery --name=checkpointClient  --structname=CheckpointClient --output=../mocks/handler
+
+var _ checkpointClient = (*checkpointREST)(nil)
+
+type checkpointClient interface {
+	Login(ctx context.Context) error
+	Logout(ctx context.Context) error
+	Sessions(ctx context.Context, user string) ([]string, error)
+	Publish(ctx context.Context, sessionUID string) (taskID string, err error)
+	InstallPolicy(ctx context.Context, policyPackage string, targets []string) (taskID string, err error)
+	WaitForTask(ctx context.Context, taskID string, sleep time.Duration) error
+}
+
+// checkpointConfig is the subset of the checkpoint provider configuration
+// used by the handler. The install_policy_* and auto_commit_* attributes are
+// internal settings of the handler and are not passed to the provider. The
+// provider does not support skipping TLS verification, so the handler has its
+// own setting for it.
+type checkpointConfig struct {
+	Server             string   `mapstructure:"server"`
+	Username           string   `mapstructure:"username"`
+	Password           string   `mapstructure:"password"`
+	APIKey             string   `mapstructure:"api_key"`
+	Domain             string   `mapstructure:"domain"`
+	Port               int      `mapstructure:"port"`
+	PolicyPackage      string   `mapstructure:"install_policy_package"`
+	InstallTargets     []string `mapstructure:"install_policy_targets"`
+	InsecureSkipVerify bool     `mapstructure:"auto_commit_insecure_skip_verify"`
+}
+
+var _ Handler = (*Checkpoint)(nil)
+
+// Checkpoint is the post-apply handler for the checkpoint Terraform Provider.
+// It publishes the admin's sessions with pending changes and optionally
+// installs a policy package after a Terraform apply.
+//
+// Only the sessions of the admin user are published, so changes by other
+// admins are not published by Consul-Terraform-Sync.
+//
+// See https://registry.terraform.io/providers/CheckPointSW/checkpoint/latest/docs
+// for details on the checkpoint provider.
+type Checkpoint struct {
+	next           Handler
+	client         checkpointClient
+	server         string
+	adminUser      string
+	policyPackage  string
+	installTargets []string
+	autoCommit     bool
+	retry          retry.Retry
+	logger         logging.Logger
+}
+
+// NewCheckpoint configures and returns a new checkpoint handler
+func NewCheckpoint(c map[string]interface{}) (*Checkpoint, error) {
+	logger := logging.Global().Named(logSystemName).Named(checkpointSubsystemName)
+	logger.Info("creating handler")
+
+	var conf checkpointConfig
+	if err := decodeProviderConfig(c, &conf); err != nil {
+		return nil, err
+	}
+	if conf.Server == "" {
+		conf.Server = os.Getenv("CHECKPOINT_SERVER")
+	}
+	if conf.Username == "" {
+		conf.Username = os.Getenv("CHECKPOINT_USER")
+	}
+	if conf.Password == "" {
+		conf.Password = os.Getenv("CHECKPOINT_PASSWORD")
+	}
+	if conf.APIKey == "" {
+		conf.APIKey = os.Getenv("CHECKPOINT_API_KEY")
+	}
+	if conf.Domain == "" {
+		conf.Domain = os.Getenv("CHECKPOINT_DOMAIN")
+	}
+	if conf.Server == "" {
+		return nil, errors.New("detected checkpoint provider with missing " +
+			"server. Configure the server for the checkpoint provider or set the " +
+			"CHECKPOINT_SERVER environment variable.")
+	}
+
+	// Username is required to limit publishing changes to the sessions of the
+	// admin user instead of all sessions by all users.
+	if conf.Username == "" {
+		return nil, errors.New("detected checkpoint provider with missing " +
+			"username. Username of the admin is required for partial publishes " +
+			"by Consul-Terraform-Sync to limit the changes auto-committed to the " +
+			"admin user. Configure the admin username for the checkpoint " +
+			"provider or set the CHECKPOINT_USER environment variable.")
+	}
+
+	address := conf.Server
+	if conf.Port != 0 {
+		address = fmt.Sprintf("%s:%d", address, conf.Port)
+	}
+
+	return &Checkpoint{
+		next: nil,
+		client: &checkpointREST{
+			rest:     newRESTClient(address, conf.InsecureSkipVerify),
+			username: conf.Username,
+			password: conf.Password,
+			apiKey:   conf.APIKey,
+			domain:   conf.Domain,
+		},
+		server:         conf.Server,
+		adminUser:      conf.Username,
+		policyPackage:  conf.PolicyPackage,
+		installTargets: conf.InstallTargets,
+		autoCommit:     autoCommitEnabled(c),
+		retry:          retry.NewRetry(maxRetries, time.Now().UnixNano()),
+		logger:         logger,
+	}, nil
+}
+
+// Do publishes checkpoint's pending changes, installs the policy, and calls
+// next handler while passing on relevant errors
+func (h *Checkpoint) Do(ctx context.Context, prevErr error) error {
+	h.logger.Trace("commit", "commit", commitStatus(h.autoCommit),
+		"host", h.server)
+	var err error
+	if h.autoCommit {
+		err = h.commit(ctx)
+	}
+	return callNext(ctx, h.next, prevErr, err)
+}
+
+// commit publishes the sessions of the admin user and installs the policy
+// package, if configured
+func (h *Checkpoint) commit(ctx context.Context) error {
+	if err := h.client.Login(ctx); err != nil {
+		h.logger.Error("error logging in to checkpoint", "error", err)
+		return err
+	}
+	defer func() {
+		if err := h.client.Logout(ctx); err != nil {
+			h.logger.Warn("error logging out of checkpoint", "error", err)
+		}
+	}()
+
+	tryPublish := func(ctx context.Context) error {
+		sessions, err := h.client.Sessions(ctx, h.adminUser)
+		if err != nil {
+			h.logger.Error("error listing sessions", "error", err)
+			return err
+		}
+		if len(sessions) == 0 {
+			h.logger.Debug("publish not needed")
+			return nil
+		}
+
+		for _, uid := range sessions {
+			taskID, err := h.client.Publish(ctx, uid)
+			if err != nil {
+				h.logger.Error("error publishing", "session", uid, "error", err)
+				return err
+			}
+			if err := h.client.WaitForTask(ctx, taskID, checkpointWaitInterval); err != nil {
+				h.logger.Error("error waiting for checkpoint publish to finish",
+					"session", uid, "error", err)
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := h.retry.Do(ctx, tryPublish, "checkpoint publish"); err != nil {
+		return err
+	}
+
+	if h.policyPackage != "" {
+		tryInstall := func(ctx context.Context) error {
+			taskID, err := h.client.InstallPolicy(ctx, h.policyPackage, h.installTargets)
+			if err != nil {
+				h.logger.Error("error installing policy", "policy_package",
+					h.policyPackage, "error", err)
+				return err
+			}
+			if err := h.client.WaitForTask(ctx, taskID, checkpointWaitInterval); err != nil {
+				h.logger.Error("error waiting for checkpoint install-policy to finish",
+					"policy_package", h.policyPackage, "error", err)
+				return err
+			}
+			return nil
+		}
+
+		if err := h.retry.Do(ctx, tryInstall, "checkpoint install-policy"); err != nil {
+			return err
+		}
+	}
+
+	h.logger.Info("commit successful")
+	return nil
+}
+
+// SetNext sets the next handler that should be called.
+func (h *Checkpoint) SetNext(next Handler) {
+	h.next = next
+}
+
+// checkpointREST is the checkpointClient for the Check Point Management API
+type checkpointREST struct {
+	rest     *restClient
+	username string
+	password string
+	apiKey   string
+	domain   string
+}
+
+// Login creates a session for the following requests
+func (c *checkpointREST) Login(ctx context.Context) error {
+	req := map[string]interface{}{}
+	if c.apiKey != "" {
+		req["api-key"] = c.apiKey
+	} else {
+		req["user"] = c.username
+		req["password"] = c.password
+	}
+	if c.domain != "" {
+		req["domain"] = c.domain
+	}
+
+	var resp struct {
+		SID string `json:"sid"`
+	}
+	if err := c.rest.do(ctx, http.MethodPost, "/web_api/login", req, &resp); err != nil {
+		return err
+	}
+	if resp.SID == "" {
+		return errors.New("no session id returned")
+	}
+	c.rest.headers["X-chkp-sid"] = resp.SID
+	return nil
+}
+
+// Logout discards the session created by Login
+func (c *checkpointREST) Logout(ctx context.Context) error {
+	err := c.rest.do(ctx, http.MethodPost, "/web_api/logout", struct{}{}, nil)
+	delete(c.rest.headers, "X-chkp-sid")
+	return err
+}
+
+// Sessions returns the UIDs of the user's sessions that have unpublished
+// changes
+func (c *checkpointREST) Sessions(ctx context.Context, user string) ([]string, error) {
+	req := map[string]interface{}{
+		"details-level": "full",
+		"limit":         500,
+	}
+	var resp struct {
+		Objects []struct {
+			UID      string `json:"uid"`
+			UserName string `json:"user-name"`
+			Changes  int    `json:"changes"`
+		} `json:"objects"`
+	}
+	if err := c.rest.do(ctx, http.MethodPost, "/web_api/show-sessions", req, &resp); err != nil {
+		return nil, err
+	}
+
+	var sessions []string
+	for _, s := range resp.Objects {
+		if s.UserName == user && s.Changes > 0 {
+			sessions = append(sessions, s.UID)
+		}
+	}
+	return sessions, nil
+}
+
+// Publish publishes the changes of the session and returns the ID of the task
+func (c *checkpointREST) Publish(ctx context.Context, sessionUID string) (string, error) {
+	req := map[string]interface{}{"uid": sessionUID}
+	var resp struct {
+		TaskID string `json:"task-id"`
+	}
+	if err := c.rest.do(ctx, http.MethodPost, "/web_api/publish", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.TaskID, nil
+}
+
+// InstallPolicy installs the policy package on the targets and returns the ID
+// of the task
+func (c *checkpointREST) InstallPolicy(ctx context.Context, policyPackage string,
+	targets []string) (string, error) {
+
+	req := map[string]interface{}{"policy-package": policyPackage}
+	if len(targets) > 0 {
+		req["targets"] = targets
+	}
+	var resp struct {
+		TaskID string `json:"task-id"`
+	}
+	if err := c.rest.do(ctx, http.MethodPost, "/web_api/install-policy", req, &resp); err != nil {
+		return "", err
+	}
+	return resp.TaskID, nil
+}
+
+// WaitForTask polls the status of the task until it completes
+func (c *checkpointREST) WaitForTask(ctx context.Context, taskID string, sleep time.Duration) error {
+	for {
+		var resp struct {
+			Tasks []struct {
+				Status string `json:"status"`
+			} `json:"tasks"`
+		}
+		req := map[string]interface{}{"task-id": taskID}
+		if err := c.rest.do(ctx, http.MethodPost, "/web_api/show-task", req, &resp); err != nil {
+			return err
+		}
+
+		done := len(resp.Tasks) > 0
+		for _, t := range resp.Tasks {
+			switch strings.ToLower(t.Status) {
+			case "succeeded", "succeeded with warnings":
+			case "failed", "partially succeeded":
+				return fmt.Errorf("task %s %s", taskID, t.Status)
+			default:
+				done = false
+			}
+		}
+		if done {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sleep):
+		}
+	}
+}
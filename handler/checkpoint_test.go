@@ -0,0 +1,287 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/handler"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCheckpoint(t *testing.T) {
+	cases := []struct {
+		name           string
+		expectError    bool
+		config         map[string]interface{}
+		policyPackage  string
+		installTargets []string
+	}{
+		{
+			"happy path",
+			false,
+			map[string]interface{}{
+				"server":                 "10.10.10.10",
+				"username":               "admin",
+				"password":               "pw123",
+				"install_policy_package": "standard",
+				"install_policy_targets": []interface{}{"gw1", "gw2"},
+			},
+			"standard",
+			[]string{"gw1", "gw2"},
+		}, {
+			"missing required server",
+			true,
+			map[string]interface{}{
+				"username": "admin",
+			},
+			"",
+			nil,
+		}, {
+			"missing required username",
+			true,
+			map[string]interface{}{
+				"server":  "10.10.10.10",
+				"api_key": "abcd",
+			},
+			"",
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewCheckpoint(tc.config)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, h)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.policyPackage, h.policyPackage)
+			assert.Equal(t, tc.installTargets, h.installTargets)
+		})
+	}
+
+	t.Run("username from env", func(t *testing.T) {
+		reset := testutils.Setenv("CHECKPOINT_USER", "admin")
+		defer reset()
+
+		h, err := NewCheckpoint(map[string]interface{}{"server": "10.10.10.10"})
+		require.NoError(t, err)
+		assert.Equal(t, "admin", h.adminUser)
+	})
+
+	t.Run("auto_commit_insecure_skip_verify", func(t *testing.T) {
+		conf := map[string]interface{}{
+			"server":   "10.10.10.10",
+			"username": "admin",
+		}
+		h, err := NewCheckpoint(conf)
+		require.NoError(t, err)
+		client, ok := h.client.(*checkpointREST)
+		require.True(t, ok)
+		assert.False(t, restInsecure(client.rest))
+
+		conf["auto_commit_insecure_skip_verify"] = true
+		h, err = NewCheckpoint(conf)
+		require.NoError(t, err)
+		client, ok = h.client.(*checkpointREST)
+		require.True(t, ok)
+		assert.True(t, restInsecure(client.rest))
+	})
+}
+
+func TestCheckpointDo(t *testing.T) {
+	t.Run("autoCommit setting", func(t *testing.T) {
+		m := new(mocks.CheckpointClient)
+		m.On("Login", mock.Anything).Return(nil).Once()
+		m.On("Logout", mock.Anything).Return(nil).Once()
+		m.On("Sessions", mock.Anything, "admin").Return([]string{"uid"}, nil).Once()
+		m.On("Publish", mock.Anything, "uid").Return("task", nil).Once()
+		m.On("WaitForTask", mock.Anything, "task", mock.Anything).Return(nil).Once()
+
+		h := &Checkpoint{client: m, adminUser: "admin", autoCommit: true,
+			retry: retry.NewTestRetry(1), logger: logging.NewNullLogger()}
+		assert.NoError(t, h.Do(context.Background(), nil))
+		h.autoCommit = false
+		assert.NoError(t, h.Do(context.Background(), nil))
+		m.AssertExpectations(t)
+	})
+}
+
+func TestCheckpointCommit(t *testing.T) {
+	cases := []struct {
+		name          string
+		policyPackage string
+		loginErr      error
+		sessions      []string
+		publishErr    error
+		installErr    error
+		expectErr     bool
+		publishTries  int
+		installTries  int
+	}{
+		{
+			"happy path",
+			"",
+			nil,
+			[]string{"uid1", "uid2"},
+			nil,
+			nil,
+			false,
+			2,
+			0,
+		},
+		{
+			"happy path with install policy",
+			"standard",
+			nil,
+			[]string{"uid"},
+			nil,
+			nil,
+			false,
+			1,
+			1,
+		},
+		{
+			"error on login",
+			"standard",
+			errors.New("login error"),
+			nil,
+			nil,
+			nil,
+			true,
+			0,
+			0,
+		},
+		{
+			"no sessions to publish",
+			"",
+			nil,
+			nil,
+			nil,
+			nil,
+			false,
+			0,
+			0,
+		},
+		{
+			"error on publish",
+			"standard",
+			nil,
+			[]string{"uid"},
+			errors.New("publish error"),
+			nil,
+			true,
+			2,
+			0,
+		},
+		{
+			"error on install policy",
+			"standard",
+			nil,
+			[]string{"uid"},
+			nil,
+			errors.New("install error"),
+			true,
+			1,
+			2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(mocks.CheckpointClient)
+			m.On("Login", mock.Anything).Return(tc.loginErr).Once()
+			m.On("Logout", mock.Anything).Return(nil)
+			m.On("Sessions", mock.Anything, "admin").Return(tc.sessions, nil)
+			m.On("Publish", mock.Anything, mock.Anything).
+				Return("task", tc.publishErr)
+			m.On("InstallPolicy", mock.Anything, tc.policyPackage, mock.Anything).
+				Return("task", tc.installErr)
+			m.On("WaitForTask", mock.Anything, mock.Anything, mock.Anything).
+				Return(nil)
+
+			h := &Checkpoint{client: m, adminUser: "admin",
+				policyPackage: tc.policyPackage, retry: retry.NewTestRetry(1),
+				logger: logging.NewNullLogger()}
+			err := h.commit(context.Background())
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertNumberOfCalls(t, "Publish", tc.publishTries)
+			m.AssertNumberOfCalls(t, "InstallPolicy", tc.installTries)
+			if tc.loginErr == nil {
+				m.AssertCalled(t, "Logout", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestCheckpointREST(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+
+		if r.URL.Path == "/web_api/login" {
+			assert.Equal(t, "admin", body["user"])
+			w.Write([]byte(`{"sid":"sid"}`))
+			return
+		}
+
+		assert.Equal(t, "sid", r.Header.Get("X-chkp-sid"))
+		switch r.URL.Path {
+		case "/web_api/show-sessions":
+			w.Write([]byte(`{"objects":[` +
+				`{"uid":"a","user-name":"admin","changes":2},` +
+				`{"uid":"b","user-name":"admin","changes":0},` +
+				`{"uid":"c","user-name":"other","changes":5}]}`))
+		case "/web_api/publish":
+			assert.Equal(t, "a", body["uid"])
+			w.Write([]byte(`{"task-id":"task"}`))
+		case "/web_api/install-policy":
+			assert.Equal(t, "standard", body["policy-package"])
+			w.Write([]byte(`{"task-id":"task"}`))
+		case "/web_api/show-task":
+			w.Write([]byte(`{"tasks":[{"status":"succeeded"}]}`))
+		case "/web_api/logout":
+			w.Write([]byte(`{"message":"OK"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c := &checkpointREST{rest: newRESTClient(ts.URL, false), username: "admin",
+		password: "pw123"}
+	require.NoError(t, c.Login(ctx))
+
+	sessions, err := c.Sessions(ctx, "admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, sessions)
+
+	taskID, err := c.Publish(ctx, "a")
+	require.NoError(t, err)
+	assert.NoError(t, c.WaitForTask(ctx, taskID, time.Millisecond))
+
+	taskID, err = c.InstallPolicy(ctx, "standard", []string{"gw1"})
+	require.NoError(t, err)
+	assert.NoError(t, c.WaitForTask(ctx, taskID, time.Millisecond))
+
+	assert.NoError(t, c.Logout(ctx))
+}
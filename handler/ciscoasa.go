@@ -0,0 +1,148 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+)
+
+const (
+	// TerraformProviderCiscoASA is the name of the Cisco ASA Terraform provider.
+	TerraformProviderCiscoASA = "ciscoasa"
+
+	ciscoASASubsystemName = "ciscoasa"
+)
+
+//go:generate mockery --name=ciscoASAClient  --structname=CiscoASAClient --output=../mocks/handler
+
+var _ ciscoASAClient = (*ciscoASAREST)(nil)
+
+type ciscoASAClient interface {
+	WriteMemory(ctx context.Context) error
+}
+
+// ciscoASAConfig is the subset of the ciscoasa provider configuration used by
+// the handler
+type ciscoASAConfig struct {
+	APIURL      string `mapstructure:"api_url"`
+	Username    string `mapstructure:"username"`
+	Password    string `mapstructure:"password"`
+	SSLNoVerify bool   `mapstructure:"ssl_no_verify"`
+}
+
+var _ Handler = (*CiscoASA)(nil)
+
+// CiscoASA is the post-apply handler for the ciscoasa Terraform Provider.
+// It saves the running configuration to the startup configuration after a
+// Terraform apply so that changes persist through a reload of the appliance.
+//
+// The ASA does not queue changes per admin, so all changes in the running
+// configuration are saved.
+//
+// See https://registry.terraform.io/providers/CiscoDevNet/ciscoasa/latest/docs
+// for details on the ciscoasa provider.
+type CiscoASA struct {
+	next       Handler
+	client     ciscoASAClient
+	apiURL     string
+	autoCommit bool
+	retry      retry.Retry
+	logger     logging.Logger
+}
+
+// NewCiscoASA configures and returns a new ciscoasa handler
+func NewCiscoASA(c map[string]interface{}) (*CiscoASA, error) {
+	logger := logging.Global().Named(logSystemName).Named(ciscoASASubsystemName)
+	logger.Info("creating handler")
+
+	var conf ciscoASAConfig
+	if err := decodeProviderConfig(c, &conf); err != nil {
+		return nil, err
+	}
+	if conf.APIURL == "" {
+		conf.APIURL = os.Getenv("CISCOASA_API_URL")
+	}
+	if conf.Username == "" {
+		conf.Username = os.Getenv("CISCOASA_USERNAME")
+	}
+	if conf.Password == "" {
+		conf.Password = os.Getenv("CISCOASA_PASSWORD")
+	}
+	if _, ok := c["ssl_no_verify"]; !ok {
+		conf.SSLNoVerify, _ = strconv.ParseBool(os.Getenv("CISCOASA_SSLNOVERIFY"))
+	}
+	if conf.APIURL == "" {
+		return nil, errors.New("detected ciscoasa provider with missing api_url. " +
+			"Configure the api_url for the ciscoasa provider or set the " +
+			"CISCOASA_API_URL environment variable.")
+	}
+
+	return &CiscoASA{
+		next: nil,
+		client: &ciscoASAREST{
+			rest:     newRESTClient(conf.APIURL, conf.SSLNoVerify),
+			username: conf.Username,
+			password: conf.Password,
+		},
+		apiURL:     conf.APIURL,
+		autoCommit: autoCommitEnabled(c),
+		retry:      retry.NewRetry(maxRetries, time.Now().UnixNano()),
+		logger:     logger,
+	}, nil
+}
+
+// Do saves the ciscoasa running configuration and calls next handler while
+// passing on relevant errors
+func (h *CiscoASA) Do(ctx context.Context, prevErr error) error {
+	h.logger.Trace("commit", "commit", commitStatus(h.autoCommit),
+		"host", h.apiURL)
+	var err error
+	if h.autoCommit {
+		err = h.commit(ctx)
+	}
+	return callNext(ctx, h.next, prevErr, err)
+}
+
+// commit writes the running configuration to memory
+func (h *CiscoASA) commit(ctx context.Context) error {
+	tryWrite := func(ctx context.Context) error {
+		err := h.client.WriteMemory(ctx)
+		if err != nil {
+			h.logger.Error("error writing memory", "error", err)
+		}
+		return err
+	}
+
+	if err := h.retry.Do(ctx, tryWrite, "ciscoasa write memory"); err != nil {
+		return err
+	}
+
+	h.logger.Info("commit successful")
+	return nil
+}
+
+// SetNext sets the next handler that should be called.
+func (h *CiscoASA) SetNext(next Handler) {
+	h.next = next
+}
+
+// ciscoASAREST is the ciscoASAClient for the Cisco ASA REST API
+type ciscoASAREST struct {
+	rest     *restClient
+	username string
+	password string
+}
+
+// WriteMemory saves the running configuration to the startup configuration
+func (c *ciscoASAREST) WriteMemory(ctx context.Context) error {
+	return c.rest.do(ctx, http.MethodPost, "/api/commands/writemem", nil, nil,
+		func(req *http.Request) {
+			req.SetBasicAuth(c.username, c.password)
+		})
+}
@@ -0,0 +1,126 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/handler"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCiscoASA(t *testing.T) {
+	cases := []struct {
+		name        string
+		expectError bool
+		config      map[string]interface{}
+	}{
+		{
+			"happy path",
+			false,
+			map[string]interface{}{
+				"api_url":       "https://10.10.10.10",
+				"username":      "admin",
+				"password":      "pw123",
+				"ssl_no_verify": true,
+			},
+		}, {
+			"missing required api_url",
+			true,
+			map[string]interface{}{
+				"username": "admin",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewCiscoASA(tc.config)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, h)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.config["api_url"], h.apiURL)
+		})
+	}
+}
+
+func TestCiscoASADo(t *testing.T) {
+	t.Run("autoCommit setting", func(t *testing.T) {
+		m := new(mocks.CiscoASAClient)
+		m.On("WriteMemory", mock.Anything).Return(nil).Once()
+
+		h := &CiscoASA{client: m, autoCommit: true, retry: retry.NewTestRetry(1),
+			logger: logging.NewNullLogger()}
+		assert.NoError(t, h.Do(context.Background(), nil))
+		h.autoCommit = false
+		assert.NoError(t, h.Do(context.Background(), nil))
+		m.AssertExpectations(t)
+	})
+}
+
+func TestCiscoASACommit(t *testing.T) {
+	cases := []struct {
+		name      string
+		writeErr  error
+		expectErr bool
+		tries     int
+	}{
+		{
+			"happy path",
+			nil,
+			false,
+			1,
+		},
+		{
+			"error on write memory",
+			errors.New("write error"),
+			true,
+			2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(mocks.CiscoASAClient)
+			m.On("WriteMemory", mock.Anything).Return(tc.writeErr)
+
+			h := &CiscoASA{client: m, retry: retry.NewTestRetry(1),
+				logger: logging.NewNullLogger()}
+			err := h.commit(context.Background())
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertNumberOfCalls(t, "WriteMemory", tc.tries)
+		})
+	}
+}
+
+func TestCiscoASAREST_WriteMemory(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/commands/writemem", r.URL.Path)
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "admin", user)
+		assert.Equal(t, "pw123", pass)
+		w.Write([]byte(`{"response":["Building configuration...\n[OK]"]}`))
+	}))
+	defer ts.Close()
+
+	c := &ciscoASAREST{rest: newRESTClient(ts.URL, false), username: "admin",
+		password: "pw123"}
+	assert.NoError(t, c.WriteMemory(context.Background()))
+}
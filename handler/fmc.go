@@ -0,0 +1,290 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+)
+
+const (
+	// TerraformProviderFMC is the name of the Cisco Firepower Management Center
+	// Terraform provider, which manages Cisco FTD devices.
+	TerraformProviderFMC = "fmc"
+
+	fmcSubsystemName = "fmc"
+
+	// fmcWaitInterval is the interval to poll the status of a deployment
+	fmcWaitInterval = 5 * time.Second
+)
+
+//go:generate mockery --name=fmcClient  --structname=FMCClient --output=../mocks/handler
+
+var _ fmcClient = (*fmcREST)(nil)
+
+type fmcClient interface {
+	Login(ctx context.Context) error
+	DeployableDevices(ctx context.Context) (version string, devices []string, err error)
+	Deploy(ctx context.Context, version string, devices []string) (taskID string, err error)
+	WaitForTask(ctx context.Context, taskID string, sleep time.Duration) error
+}
+
+// fmcConfig is the subset of the fmc provider configuration used by the
+// handler
+type fmcConfig struct {
+	Host               string `mapstructure:"fmc_host"`
+	Username           string `mapstructure:"fmc_username"`
+	Password           string `mapstructure:"fmc_password"`
+	InsecureSkipVerify bool   `mapstructure:"fmc_insecure_skip_verify"`
+}
+
+var _ Handler = (*FMC)(nil)
+
+// FMC is the post-apply handler for the fmc Terraform Provider. It deploys
+// the pending changes from the Firepower Management Center to the managed
+// Cisco FTD devices after a Terraform apply.
+//
+// The FMC tracks pending changes per device and not per admin, so all devices
+// with pending changes are deployed.
+//
+// See https://registry.terraform.io/providers/CiscoDevNet/fmc/latest/docs
+// for details on the fmc provider.
+type FMC struct {
+	next       Handler
+	client     fmcClient
+	host       string
+	autoCommit bool
+	retry      retry.Retry
+	logger     logging.Logger
+}
+
+// NewFMC configures and returns a new fmc handler
+func NewFMC(c map[string]interface{}) (*FMC, error) {
+	logger := logging.Global().Named(logSystemName).Named(fmcSubsystemName)
+	logger.Info("creating handler")
+
+	var conf fmcConfig
+	if err := decodeProviderConfig(c, &conf); err != nil {
+		return nil, err
+	}
+	if conf.Host == "" {
+		conf.Host = os.Getenv("FMC_HOST")
+	}
+	if conf.Username == "" {
+		conf.Username = os.Getenv("FMC_USERNAME")
+	}
+	if conf.Password == "" {
+		conf.Password = os.Getenv("FMC_PASSWORD")
+	}
+	if _, ok := c["fmc_insecure_skip_verify"]; !ok {
+		conf.InsecureSkipVerify, _ = strconv.ParseBool(
+			os.Getenv("FMC_INSECURE_SKIP_VERIFY"))
+	}
+	if conf.Host == "" {
+		return nil, errors.New("detected fmc provider with missing fmc_host. " +
+			"Configure the fmc_host for the fmc provider or set the FMC_HOST " +
+			"environment variable.")
+	}
+
+	return &FMC{
+		next: nil,
+		client: &fmcREST{
+			rest:     newRESTClient(conf.Host, conf.InsecureSkipVerify),
+			username: conf.Username,
+			password: conf.Password,
+		},
+		host:       conf.Host,
+		autoCommit: autoCommitEnabled(c),
+		retry:      retry.NewRetry(maxRetries, time.Now().UnixNano()),
+		logger:     logger,
+	}, nil
+}
+
+// Do deploys fmc's pending changes and calls next handler while passing on
+// relevant errors
+func (h *FMC) Do(ctx context.Context, prevErr error) error {
+	h.logger.Trace("commit", "commit", commitStatus(h.autoCommit),
+		"host", h.host)
+	var err error
+	if h.autoCommit {
+		err = h.commit(ctx)
+	}
+	return callNext(ctx, h.next, prevErr, err)
+}
+
+// commit deploys the pending changes to the devices
+func (h *FMC) commit(ctx context.Context) error {
+	if err := h.client.Login(ctx); err != nil {
+		h.logger.Error("error logging in to fmc", "error", err)
+		return err
+	}
+
+	tryDeploy := func(ctx context.Context) error {
+		version, devices, err := h.client.DeployableDevices(ctx)
+		if err != nil {
+			h.logger.Error("error listing deployable devices", "error", err)
+			return err
+		}
+		if len(devices) == 0 {
+			h.logger.Debug("deploy not needed")
+			return nil
+		}
+
+		taskID, err := h.client.Deploy(ctx, version, devices)
+		if err != nil {
+			h.logger.Error("error deploying", "devices", devices, "error", err)
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+		}
+
+		if err := h.client.WaitForTask(ctx, taskID, fmcWaitInterval); err != nil {
+			h.logger.Error("error waiting for fmc deployment to finish",
+				"error", err)
+			return err
+		}
+		return nil
+	}
+
+	if err := h.retry.Do(ctx, tryDeploy, "fmc deploy"); err != nil {
+		return err
+	}
+
+	h.logger.Info("commit successful")
+	return nil
+}
+
+// SetNext sets the next handler that should be called.
+func (h *FMC) SetNext(next Handler) {
+	h.next = next
+}
+
+// fmcREST is the fmcClient for the Firepower Management Center REST API
+type fmcREST struct {
+	rest     *restClient
+	username string
+	password string
+	domain   string
+}
+
+// Login generates an access token for the following requests
+func (c *fmcREST) Login(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		c.rest.baseURL+"/api/fmc_platform/v1/auth/generatetoken", nil)
+	if err != nil {
+		return fmt.Errorf("error creating request: %s", err)
+	}
+	req.SetBasicAuth(c.username, c.password)
+
+	resp, err := c.rest.client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("generating token returned status code %d",
+			resp.StatusCode)
+	}
+
+	token := resp.Header.Get("X-auth-access-token")
+	if token == "" {
+		return errors.New("no access token returned")
+	}
+	c.rest.headers["X-auth-access-token"] = token
+	c.domain = resp.Header.Get("DOMAIN_UUID")
+	return nil
+}
+
+// DeployableDevices returns the version of the pending changes and the IDs of
+// the devices with pending changes
+func (c *fmcREST) DeployableDevices(ctx context.Context) (string, []string, error) {
+	var resp struct {
+		Items []struct {
+			Version string `json:"version"`
+			Device  struct {
+				ID string `json:"id"`
+			} `json:"device"`
+		} `json:"items"`
+	}
+	path := fmt.Sprintf("/api/fmc_config/v1/domain/%s/deployment/deployabledevices?expanded=true",
+		c.domain)
+	if err := c.rest.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+		return "", nil, err
+	}
+
+	var version string
+	devices := make([]string, 0, len(resp.Items))
+	for _, item := range resp.Items {
+		// deploy all devices with the latest version of the changes
+		if strings.Compare(item.Version, version) > 0 {
+			version = item.Version
+		}
+		devices = append(devices, item.Device.ID)
+	}
+	return version, devices, nil
+}
+
+// Deploy requests a deployment to the devices and returns the ID of the task
+// for the deployment
+func (c *fmcREST) Deploy(ctx context.Context, version string, devices []string) (string, error) {
+	req := map[string]interface{}{
+		"type":          "DeploymentRequest",
+		"version":       version,
+		"forceDeploy":   false,
+		"ignoreWarning": true,
+		"deviceList":    devices,
+	}
+	var resp struct {
+		Metadata struct {
+			Task struct {
+				ID string `json:"id"`
+			} `json:"task"`
+		} `json:"metadata"`
+	}
+	path := fmt.Sprintf("/api/fmc_config/v1/domain/%s/deployment/deploymentrequests",
+		c.domain)
+	if err := c.rest.do(ctx, http.MethodPost, path, req, &resp); err != nil {
+		return "", err
+	}
+	return resp.Metadata.Task.ID, nil
+}
+
+// WaitForTask polls the status of the task until it completes
+func (c *fmcREST) WaitForTask(ctx context.Context, taskID string, sleep time.Duration) error {
+	path := fmt.Sprintf("/api/fmc_config/v1/domain/%s/job/taskstatuses/%s",
+		c.domain, taskID)
+	for {
+		var resp struct {
+			Status  string `json:"status"`
+			Message string `json:"message"`
+		}
+		if err := c.rest.do(ctx, http.MethodGet, path, nil, &resp); err != nil {
+			return err
+		}
+
+		switch strings.ToLower(resp.Status) {
+		case "deployed", "success", "completed":
+			return nil
+		case "failed", "deployment failed":
+			return fmt.Errorf("deployment task %s failed: %s", taskID, resp.Message)
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sleep):
+		}
+	}
+}
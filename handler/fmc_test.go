@@ -0,0 +1,230 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/handler"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFMC(t *testing.T) {
+	cases := []struct {
+		name        string
+		expectError bool
+		config      map[string]interface{}
+	}{
+		{
+			"happy path",
+			false,
+			map[string]interface{}{
+				"fmc_host":                 "10.10.10.10",
+				"fmc_username":             "admin",
+				"fmc_password":             "pw123",
+				"fmc_insecure_skip_verify": true,
+			},
+		}, {
+			"missing required host",
+			true,
+			map[string]interface{}{
+				"fmc_username": "admin",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewFMC(tc.config)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, h)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.config["fmc_host"], h.host)
+		})
+	}
+}
+
+func TestFMCDo(t *testing.T) {
+	t.Run("autoCommit setting", func(t *testing.T) {
+		m := new(mocks.FMCClient)
+		m.On("Login", mock.Anything).Return(nil).Once()
+		m.On("DeployableDevices", mock.Anything).
+			Return("1", []string{"device"}, nil).Once()
+		m.On("Deploy", mock.Anything, "1", []string{"device"}).
+			Return("task", nil).Once()
+		m.On("WaitForTask", mock.Anything, "task", mock.Anything).
+			Return(nil).Once()
+
+		h := &FMC{client: m, autoCommit: true, retry: retry.NewTestRetry(1),
+			logger: logging.NewNullLogger()}
+		assert.NoError(t, h.Do(context.Background(), nil))
+		h.autoCommit = false
+		assert.NoError(t, h.Do(context.Background(), nil))
+		m.AssertExpectations(t)
+	})
+}
+
+func TestFMCCommit(t *testing.T) {
+	cases := []struct {
+		name        string
+		loginErr    error
+		devices     []string
+		devicesErr  error
+		deployErr   error
+		waitErr     error
+		expectErr   bool
+		deployTries int
+		waitTries   int
+	}{
+		{
+			"happy path",
+			nil,
+			[]string{"device"},
+			nil,
+			nil,
+			nil,
+			false,
+			1,
+			1,
+		},
+		{
+			"error on login",
+			errors.New("login error"),
+			nil,
+			nil,
+			nil,
+			nil,
+			true,
+			0,
+			0,
+		},
+		{
+			"no deployable devices",
+			nil,
+			[]string{},
+			nil,
+			nil,
+			nil,
+			false,
+			0,
+			0,
+		},
+		{
+			"error on deployable devices",
+			nil,
+			nil,
+			errors.New("list error"),
+			nil,
+			nil,
+			true,
+			0,
+			0,
+		},
+		{
+			"error on deploy",
+			nil,
+			[]string{"device"},
+			nil,
+			errors.New("deploy error"),
+			nil,
+			true,
+			2,
+			0,
+		},
+		{
+			"error on wait",
+			nil,
+			[]string{"device"},
+			nil,
+			nil,
+			errors.New("wait error"),
+			true,
+			2,
+			2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(mocks.FMCClient)
+			m.On("Login", mock.Anything).Return(tc.loginErr).Once()
+			m.On("DeployableDevices", mock.Anything).
+				Return("1", tc.devices, tc.devicesErr)
+			m.On("Deploy", mock.Anything, mock.Anything, mock.Anything).
+				Return("task", tc.deployErr)
+			m.On("WaitForTask", mock.Anything, mock.Anything, mock.Anything).
+				Return(tc.waitErr)
+
+			h := &FMC{client: m, retry: retry.NewTestRetry(1),
+				logger: logging.NewNullLogger()}
+			err := h.commit(context.Background())
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertNumberOfCalls(t, "Deploy", tc.deployTries)
+			m.AssertNumberOfCalls(t, "WaitForTask", tc.waitTries)
+		})
+	}
+}
+
+func TestFMCREST(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/fmc_platform/v1/auth/generatetoken":
+			w.Header().Set("X-auth-access-token", "token")
+			w.Header().Set("DOMAIN_UUID", "domain")
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		assert.Equal(t, "token", r.Header.Get("X-auth-access-token"))
+		switch r.URL.Path {
+		case "/api/fmc_config/v1/domain/domain/deployment/deployabledevices":
+			w.Write([]byte(`{"items":[` +
+				`{"version":"100","device":{"id":"a"}},` +
+				`{"version":"200","device":{"id":"b"}}]}`))
+		case "/api/fmc_config/v1/domain/domain/deployment/deploymentrequests":
+			var body map[string]interface{}
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, "200", body["version"])
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"metadata":{"task":{"id":"task"}}}`))
+		case "/api/fmc_config/v1/domain/domain/job/taskstatuses/task":
+			w.Write([]byte(`{"status":"Deployed"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	ctx := context.Background()
+	c := &fmcREST{rest: newRESTClient(ts.URL, false), username: "admin",
+		password: "pw123"}
+	require.NoError(t, c.Login(ctx))
+
+	version, devices, err := c.DeployableDevices(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, "200", version)
+	assert.Equal(t, []string{"a", "b"}, devices)
+
+	taskID, err := c.Deploy(ctx, version, devices)
+	require.NoError(t, err)
+	assert.Equal(t, "task", taskID)
+
+	assert.NoError(t, c.WaitForTask(ctx, taskID, time.Millisecond))
+}
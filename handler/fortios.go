@@ -0,0 +1,149 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+)
+
+const (
+	// TerraformProviderFortios is the name of the Fortinet FortiOS Terraform
+	// provider.
+	TerraformProviderFortios = "fortios"
+
+	fortiosSubsystemName = "fortios"
+)
+
+//go:generate mockery --name=fortiosClient  --structname=FortiosClient --output=../mocks/handler
+
+var _ fortiosClient = (*fortiosREST)(nil)
+
+type fortiosClient interface {
+	SaveRevision(ctx context.Context, comment string) error
+}
+
+// fortiosConfig is the subset of the fortios provider configuration used by
+// the handler
+type fortiosConfig struct {
+	Hostname string `mapstructure:"hostname"`
+	Token    string `mapstructure:"token"`
+	Insecure bool   `mapstructure:"insecure"`
+	Vdom     string `mapstructure:"vdom"`
+}
+
+var _ Handler = (*Fortios)(nil)
+
+// Fortios is the post-apply handler for the fortios Terraform Provider.
+// It saves a configuration revision after a Terraform apply, which is needed
+// when the FortiGate is configured with a manual cfg-save setting.
+//
+// FortiOS does not queue changes per admin, so the saved revision includes
+// all changes on the device.
+//
+// See https://registry.terraform.io/providers/fortinetdev/fortios/latest/docs
+// for details on the fortios provider.
+type Fortios struct {
+	next       Handler
+	client     fortiosClient
+	hostname   string
+	autoCommit bool
+	retry      retry.Retry
+	logger     logging.Logger
+}
+
+// NewFortios configures and returns a new fortios handler
+func NewFortios(c map[string]interface{}) (*Fortios, error) {
+	logger := logging.Global().Named(logSystemName).Named(fortiosSubsystemName)
+	logger.Info("creating handler")
+
+	var conf fortiosConfig
+	if err := decodeProviderConfig(c, &conf); err != nil {
+		return nil, err
+	}
+	if conf.Hostname == "" {
+		conf.Hostname = os.Getenv("FORTIOS_ACCESS_HOSTNAME")
+	}
+	if conf.Token == "" {
+		conf.Token = os.Getenv("FORTIOS_ACCESS_TOKEN")
+	}
+	if _, ok := c["insecure"]; !ok {
+		conf.Insecure, _ = strconv.ParseBool(os.Getenv("FORTIOS_INSECURE"))
+	}
+	if conf.Hostname == "" {
+		return nil, errors.New("detected fortios provider with missing hostname. " +
+			"Configure the hostname for the fortios provider or set the " +
+			"FORTIOS_ACCESS_HOSTNAME environment variable.")
+	}
+
+	rc := newRESTClient(conf.Hostname, conf.Insecure)
+	if conf.Token != "" {
+		rc.headers["Authorization"] = "Bearer " + conf.Token
+	}
+
+	return &Fortios{
+		next:       nil,
+		client:     &fortiosREST{rest: rc, vdom: conf.Vdom},
+		hostname:   conf.Hostname,
+		autoCommit: autoCommitEnabled(c),
+		retry:      retry.NewRetry(maxRetries, time.Now().UnixNano()),
+		logger:     logger,
+	}, nil
+}
+
+// Do saves a fortios configuration revision and calls next handler while
+// passing on relevant errors
+func (h *Fortios) Do(ctx context.Context, prevErr error) error {
+	h.logger.Trace("commit", "commit", commitStatus(h.autoCommit),
+		"host", h.hostname)
+	var err error
+	if h.autoCommit {
+		err = h.commit(ctx)
+	}
+	return callNext(ctx, h.next, prevErr, err)
+}
+
+// commit saves a configuration revision
+func (h *Fortios) commit(ctx context.Context) error {
+	trySave := func(ctx context.Context) error {
+		err := h.client.SaveRevision(ctx, "Consul-Terraform-Sync Commit")
+		if err != nil {
+			h.logger.Error("error saving config revision", "error", err)
+		}
+		return err
+	}
+
+	if err := h.retry.Do(ctx, trySave, "fortios config save"); err != nil {
+		return err
+	}
+
+	h.logger.Info("commit successful")
+	return nil
+}
+
+// SetNext sets the next handler that should be called.
+func (h *Fortios) SetNext(next Handler) {
+	h.next = next
+}
+
+// fortiosREST is the fortiosClient for the FortiOS REST API
+type fortiosREST struct {
+	rest *restClient
+	vdom string
+}
+
+// SaveRevision saves the running configuration as a new revision
+func (c *fortiosREST) SaveRevision(ctx context.Context, comment string) error {
+	path := "/api/v2/monitor/system/config-revision/save"
+	if c.vdom != "" {
+		path += "?vdom=" + url.QueryEscape(c.vdom)
+	}
+	body := map[string]string{"comments": comment}
+	return c.rest.do(ctx, http.MethodPost, path, body, nil)
+}
@@ -0,0 +1,151 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/handler"
+	"github.com/hashicorp/consul-terraform-sync/retry"
+	"github.com/hashicorp/consul-terraform-sync/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFortios(t *testing.T) {
+	cases := []struct {
+		name        string
+		expectError bool
+		config      map[string]interface{}
+		autoCommit  bool
+	}{
+		{
+			"happy path",
+			false,
+			map[string]interface{}{
+				"hostname":    "10.10.10.10",
+				"token":       "abcd",
+				"insecure":    true,
+				"vdom":        "root",
+				"auto_commit": true,
+			},
+			true,
+		}, {
+			"missing required hostname",
+			true,
+			map[string]interface{}{
+				"token": "abcd",
+			},
+			false,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewFortios(tc.config)
+			if tc.expectError {
+				assert.Error(t, err)
+				assert.Nil(t, h)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.autoCommit, h.autoCommit)
+		})
+	}
+
+	t.Run("hostname from env", func(t *testing.T) {
+		reset := testutils.Setenv("FORTIOS_ACCESS_HOSTNAME", "10.10.10.10")
+		defer reset()
+
+		h, err := NewFortios(map[string]interface{}{})
+		require.NoError(t, err)
+		assert.Equal(t, "10.10.10.10", h.hostname)
+	})
+}
+
+func TestFortiosDo(t *testing.T) {
+	t.Run("autoCommit setting", func(t *testing.T) {
+		m := new(mocks.FortiosClient)
+		m.On("SaveRevision", mock.Anything, mock.Anything).Return(nil).Once()
+
+		h := &Fortios{client: m, autoCommit: true, retry: retry.NewTestRetry(1),
+			logger: logging.NewNullLogger()}
+		assert.NoError(t, h.Do(context.Background(), nil))
+		h.autoCommit = false
+		assert.NoError(t, h.Do(context.Background(), nil))
+		m.AssertExpectations(t)
+	})
+
+	t.Run("next handler", func(t *testing.T) {
+		m := new(mocks.FortiosClient)
+		m.On("SaveRevision", mock.Anything, mock.Anything).Return(nil).Twice()
+
+		h := &Fortios{client: m, autoCommit: true, retry: retry.NewTestRetry(1),
+			logger: logging.NewNullLogger()}
+		h.SetNext(&Fortios{client: m, autoCommit: true, retry: retry.NewTestRetry(1),
+			logger: logging.NewNullLogger()})
+		assert.NoError(t, h.Do(context.Background(), nil))
+		m.AssertExpectations(t)
+	})
+}
+
+func TestFortiosCommit(t *testing.T) {
+	cases := []struct {
+		name      string
+		saveErr   error
+		expectErr bool
+		tries     int
+	}{
+		{
+			"happy path",
+			nil,
+			false,
+			1,
+		},
+		{
+			"error on save",
+			errors.New("save error"),
+			true,
+			2,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := new(mocks.FortiosClient)
+			m.On("SaveRevision", mock.Anything, mock.Anything).Return(tc.saveErr)
+
+			h := &Fortios{client: m, retry: retry.NewTestRetry(1),
+				logger: logging.NewNullLogger()}
+			err := h.commit(context.Background())
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			m.AssertNumberOfCalls(t, "SaveRevision", tc.tries)
+		})
+	}
+}
+
+func TestFortiosREST_SaveRevision(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/api/v2/monitor/system/config-revision/save", r.URL.Path)
+		assert.Equal(t, "root", r.URL.Query().Get("vdom"))
+		assert.Equal(t, "Bearer abcd", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"status":"success"}`))
+	}))
+	defer ts.Close()
+
+	rc := newRESTClient(ts.URL, false)
+	rc.headers["Authorization"] = "Bearer abcd"
+	c := &fortiosREST{rest: rc, vdom: "root"}
+	assert.NoError(t, c.SaveRevision(context.Background(), "comment"))
+}
@@ -40,6 +40,16 @@ func TerraformProviderHandler(providerName string, config interface{}) (Handler,
 	switch providerName {
 	case TerraformProviderPanos:
 		return NewPanos(c)
+	case TerraformProviderFortios:
+		return NewFortios(c)
+	case TerraformProviderCiscoASA:
+		return NewCiscoASA(c)
+	case TerraformProviderFMC:
+		return NewFMC(c)
+	case TerraformProviderCheckpoint:
+		return NewCheckpoint(c)
+	case TerraformProviderBigip:
+		return NewBigip(c)
 	case TerraformProviderFake:
 		return NewFake(c)
 	default:
@@ -46,6 +46,60 @@ func TestTerraformProviderHandler(t *testing.T) {
 				"password": "pw123",
 			},
 		},
+		{
+			"fortios provider",
+			false,
+			false,
+			TerraformProviderFortios,
+			map[string]interface{}{
+				"hostname": "10.10.10.10",
+				"token":    "abcd",
+			},
+		},
+		{
+			"ciscoasa provider",
+			false,
+			false,
+			TerraformProviderCiscoASA,
+			map[string]interface{}{
+				"api_url":  "https://10.10.10.10",
+				"username": "user",
+				"password": "pw123",
+			},
+		},
+		{
+			"fmc provider",
+			false,
+			false,
+			TerraformProviderFMC,
+			map[string]interface{}{
+				"fmc_host":     "10.10.10.10",
+				"fmc_username": "user",
+				"fmc_password": "pw123",
+			},
+		},
+		{
+			"checkpoint provider",
+			false,
+			false,
+			TerraformProviderCheckpoint,
+			map[string]interface{}{
+				"server":   "10.10.10.10",
+				"username": "user",
+				"password": "pw123",
+			},
+		},
+		{
+			"bigip provider",
+			false,
+			false,
+			TerraformProviderBigip,
+			map[string]interface{}{
+				"address":  "10.10.10.10",
+				"username": "user",
+				"password": "pw123",
+			},
+		},
 		{
 			"fake provider",
 			false,
@@ -0,0 +1,143 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	// defaultRESTTimeout is the timeout for a single request to a vendor API
+	defaultRESTTimeout = 60 * time.Second
+
+	// maxRESTErrorBody is the maximum length of a response body included in
+	// an error message
+	maxRESTErrorBody = 512
+)
+
+// restClient is a minimal JSON client for the REST APIs of network vendors
+// that do not have a Go SDK.
+type restClient struct {
+	baseURL string
+	headers map[string]string
+	client  *http.Client
+}
+
+// newRESTClient returns a client for the base URL. The scheme defaults to
+// https if the address does not have one.
+func newRESTClient(address string, insecure bool) *restClient {
+	if !strings.HasPrefix(address, "http://") && !strings.HasPrefix(address, "https://") {
+		address = "https://" + address
+	}
+
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	if insecure {
+		tr.TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+
+	return &restClient{
+		baseURL: strings.TrimSuffix(address, "/"),
+		headers: make(map[string]string),
+		client: &http.Client{
+			Timeout:   defaultRESTTimeout,
+			Transport: tr,
+		},
+	}
+}
+
+// do sends a JSON request to the path and decodes the JSON response into out,
+// if out is not nil. Responses with a non-2xx status code return an error.
+func (c *restClient) do(ctx context.Context, method, path string, in, out interface{},
+	opts ...func(*http.Request)) error {
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("error encoding request: %s", err)
+		}
+		body = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("error creating request: %s", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for k, v := range c.headers {
+		req.Header.Set(k, v)
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("error reading response: %s", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		if len(respBody) > maxRESTErrorBody {
+			respBody = respBody[:maxRESTErrorBody]
+		}
+		return fmt.Errorf("%s %s returned status code %d: %s", method, path,
+			resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+
+	if out == nil || len(respBody) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("error decoding response: %s", err)
+	}
+	return nil
+}
+
+// decodeProviderConfig decodes the provider block configuration into the
+// handler's configuration struct using the mapstructure tags
+func decodeProviderConfig(c map[string]interface{}, out interface{}) error {
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		WeaklyTypedInput: true,
+		Result:           out,
+	})
+	if err != nil {
+		return err
+	}
+	return decoder.Decode(c)
+}
+
+// autoCommitEnabled returns whether auto_commit is enabled in the provider
+// block configuration
+func autoCommitEnabled(c map[string]interface{}) bool {
+	if val, ok := c["auto_commit"]; ok {
+		if v, ok := val.(bool); ok && v {
+			return true
+		}
+	}
+	return false
+}
+
+// commitStatus returns the log value for the auto-commit setting
+func commitStatus(autoCommit bool) string {
+	if autoCommit {
+		return "enabled"
+	}
+	return "disabled"
+}
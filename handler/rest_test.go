@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRESTClient_do(t *testing.T) {
+	t.Parallel()
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/echo":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			assert.Equal(t, "token", r.Header.Get("X-Token"))
+			var body map[string]string
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+			json.NewEncoder(w).Encode(body)
+		case "/empty":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"denied"}`))
+		}
+	}))
+	defer ts.Close()
+
+	c := newRESTClient(ts.URL+"/", false)
+	c.headers["X-Token"] = "token"
+	ctx := context.Background()
+
+	t.Run("decode response", func(t *testing.T) {
+		var out map[string]string
+		err := c.do(ctx, http.MethodPost, "/echo", map[string]string{"a": "b"}, &out)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"a": "b"}, out)
+	})
+
+	t.Run("empty response", func(t *testing.T) {
+		var out map[string]string
+		err := c.do(ctx, http.MethodPost, "/empty", nil, &out)
+		require.NoError(t, err)
+		assert.Nil(t, out)
+	})
+
+	t.Run("error status", func(t *testing.T) {
+		err := c.do(ctx, http.MethodGet, "/forbidden", nil, nil)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "403")
+		assert.Contains(t, err.Error(), "denied")
+	})
+}
+
+func TestNewRESTClient(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		address  string
+		expected string
+	}{
+		{
+			"no scheme",
+			"10.10.10.10",
+			"https://10.10.10.10",
+		},
+		{
+			"http scheme",
+			"http://10.10.10.10:8080/",
+			"http://10.10.10.10:8080",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			c := newRESTClient(tc.address, true)
+			assert.Equal(t, tc.expected, c.baseURL)
+			assert.True(t, restInsecure(c))
+		})
+	}
+
+	t.Run("verify certificates", func(t *testing.T) {
+		c := newRESTClient("10.10.10.10", false)
+		assert.False(t, restInsecure(c))
+	})
+}
+
+// restInsecure returns whether the client skips TLS certificate verification
+func restInsecure(c *restClient) bool {
+	tr := c.client.Transport.(*http.Transport)
+	return tr.TLSClientConfig != nil && tr.TLSClientConfig.InsecureSkipVerify
+}
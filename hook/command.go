@@ -0,0 +1,93 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"time"
+)
+
+var _ Hook = (*Command)(nil)
+
+// CommandConfig configures a hook that runs a local command
+type CommandConfig struct {
+	Name       string
+	Stage      string
+	Command    []string
+	WorkingDir string
+	Env        map[string]string
+	Timeout    time.Duration
+}
+
+// Command is a hook that runs a local command. The hook context is passed to
+// the command through stdin and the combined stdout and stderr of the command
+// is captured as the output.
+type Command struct {
+	name       string
+	stage      string
+	args       []string
+	workingDir string
+	env        []string
+	timeout    time.Duration
+}
+
+// NewCommand creates a new command hook
+func NewCommand(conf CommandConfig) (*Command, error) {
+	if len(conf.Command) == 0 {
+		return nil, errors.New("command is required for command hook")
+	}
+
+	env := os.Environ()
+	for k, v := range conf.Env {
+		env = append(env, fmt.Sprintf("%s=%s", k, v))
+	}
+	env = append(env, fmt.Sprintf("CTS_HOOK_STAGE=%s", conf.Stage))
+
+	return &Command{
+		name:       conf.Name,
+		stage:      conf.Stage,
+		args:       conf.Command,
+		workingDir: conf.WorkingDir,
+		env:        env,
+		timeout:    conf.Timeout,
+	}, nil
+}
+
+// Name returns the name of the hook
+func (h *Command) Name() string {
+	return h.name
+}
+
+// Stage returns the stage the hook runs at
+func (h *Command) Stage() string {
+	return h.stage
+}
+
+// Run runs the command with the input on stdin
+func (h *Command) Run(ctx context.Context, input []byte) (string, error) {
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	cmd := exec.CommandContext(ctx, h.args[0], h.args[1:]...)
+	cmd.Dir = h.workingDir
+	cmd.Env = h.env
+	cmd.Stdin = bytes.NewReader(input)
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &out
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() == context.DeadlineExceeded {
+			return out.String(), fmt.Errorf("command timed out after %s", h.timeout)
+		}
+		return out.String(), fmt.Errorf("error running command: %s", err)
+	}
+	return out.String(), nil
+}
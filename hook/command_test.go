@@ -0,0 +1,89 @@
+package hook
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCommand(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewCommand(CommandConfig{Name: "empty"})
+	assert.Error(t, err)
+
+	h, err := NewCommand(CommandConfig{
+		Name:    "cmd",
+		Stage:   StagePostApply,
+		Command: []string{"echo"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "cmd", h.Name())
+	assert.Equal(t, StagePostApply, h.Stage())
+}
+
+func TestCommand_Run(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		command   []string
+		timeout   time.Duration
+		expected  string
+		expectErr bool
+	}{
+		{
+			"stdin",
+			[]string{"cat"},
+			0,
+			`{"stage":"post-apply"}`,
+			false,
+		},
+		{
+			"env",
+			[]string{"sh", "-c", "echo $CTS_HOOK_STAGE $TASK"},
+			0,
+			"post-apply task\n",
+			false,
+		},
+		{
+			"error",
+			[]string{"sh", "-c", "echo failed; exit 1"},
+			0,
+			"failed\n",
+			true,
+		},
+		{
+			"timeout",
+			[]string{"sleep", "5"},
+			10 * time.Millisecond,
+			"",
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			h, err := NewCommand(CommandConfig{
+				Name:       tc.name,
+				Stage:      StagePostApply,
+				Command:    tc.command,
+				WorkingDir: t.TempDir(),
+				Env:        map[string]string{"TASK": "task"},
+				Timeout:    tc.timeout,
+			})
+			require.NoError(t, err)
+
+			out, err := h.Run(context.Background(), []byte(`{"stage":"post-apply"}`))
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, out)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	consulapi "github.com/hashicorp/consul/api"
+)
+
+var _ Hook = (*ConsulKV)(nil)
+
+// kvClient is the subset of the Consul client used by the Consul KV hook
+type kvClient interface {
+	KVPut(ctx context.Context, p *consulapi.KVPair, q *consulapi.WriteOptions) (*consulapi.WriteMeta, error)
+}
+
+// ConsulKVConfig configures a hook that writes a Consul KV key
+type ConsulKVConfig struct {
+	Name      string
+	Stage     string
+	Path      string
+	Namespace string
+	Timeout   time.Duration
+}
+
+// ConsulKV is a hook that writes the hook context as the value of a Consul KV
+// key.
+type ConsulKV struct {
+	name      string
+	stage     string
+	path      string
+	namespace string
+	timeout   time.Duration
+	client    kvClient
+}
+
+// NewConsulKV creates a new Consul KV hook
+func NewConsulKV(conf ConsulKVConfig, client kvClient) (*ConsulKV, error) {
+	if conf.Path == "" {
+		return nil, errors.New("path is required for consul_kv hook")
+	}
+	if client == nil {
+		return nil, errors.New("consul client is required for consul_kv hook")
+	}
+
+	return &ConsulKV{
+		name:      conf.Name,
+		stage:     conf.Stage,
+		path:      conf.Path,
+		namespace: conf.Namespace,
+		timeout:   conf.Timeout,
+		client:    client,
+	}, nil
+}
+
+// Name returns the name of the hook
+func (h *ConsulKV) Name() string {
+	return h.name
+}
+
+// Stage returns the stage the hook runs at
+func (h *ConsulKV) Stage() string {
+	return h.stage
+}
+
+// Run writes the input to the Consul KV key
+func (h *ConsulKV) Run(ctx context.Context, input []byte) (string, error) {
+	if h.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.timeout)
+		defer cancel()
+	}
+
+	q := &consulapi.WriteOptions{Namespace: h.namespace}
+	q = q.WithContext(ctx)
+	_, err := h.client.KVPut(ctx, &consulapi.KVPair{Key: h.path, Value: input}, q)
+	if err != nil {
+		return "", fmt.Errorf("error writing key %s: %s", h.path, err)
+	}
+	return fmt.Sprintf("wrote %d bytes to %s", len(input), h.path), nil
+}
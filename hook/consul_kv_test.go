@@ -0,0 +1,64 @@
+package hook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/client"
+	consulapi "github.com/hashicorp/consul/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConsulKV_Run(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		putErr    error
+		expectErr bool
+	}{
+		{
+			"success",
+			nil,
+			false,
+		},
+		{
+			"error",
+			errors.New("error"),
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := []byte(`{"stage":"on-failure"}`)
+
+			c := new(mocks.ConsulClientInterface)
+			c.On("KVPut", mock.Anything, &consulapi.KVPair{Key: "cts/task", Value: input},
+				mock.Anything).Return(&consulapi.WriteMeta{}, tc.putErr)
+
+			h, err := NewConsulKV(ConsulKVConfig{
+				Name:  tc.name,
+				Stage: StageOnFailure,
+				Path:  "cts/task",
+			}, c)
+			require.NoError(t, err)
+
+			_, err = h.Run(context.Background(), input)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			c.AssertExpectations(t)
+		})
+	}
+
+	t.Run("missing_path", func(t *testing.T) {
+		_, err := NewConsulKV(ConsulKVConfig{Name: "missing"}, new(mocks.ConsulClientInterface))
+		assert.Error(t, err)
+	})
+}
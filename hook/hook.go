@@ -0,0 +1,145 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+	"github.com/hashicorp/consul-terraform-sync/state/event"
+)
+
+//go:generate mockery --name=Hook --filename=hook.go --output=../mocks/hook
+
+const (
+	logSystemName = "hook"
+
+	// StagePrePlan runs hooks before the task's changes are planned
+	StagePrePlan = "pre-plan"
+
+	// StagePostPlan runs hooks after the task's changes are planned
+	StagePostPlan = "post-plan"
+
+	// StagePostApply runs hooks after the task's changes are applied
+	StagePostApply = "post-apply"
+
+	// StageOnFailure runs hooks when the task fails to run
+	StageOnFailure = "on-failure"
+
+	// maxOutputLen is the maximum length of hook output that is captured
+	maxOutputLen = 4096
+)
+
+// Hook is a user-configured action that runs at a stage of a task run
+type Hook interface {
+	// Name returns the name of the hook
+	Name() string
+
+	// Stage returns the stage of the task run that the hook runs at
+	Stage() string
+
+	// Run runs the hook with the JSON encoded hook context as input and
+	// returns the output of the hook
+	Run(ctx context.Context, input []byte) (string, error)
+}
+
+// Context is the information about the task run that is passed to a hook as
+// JSON
+type Context struct {
+	Stage string `json:"stage"`
+	Task  Task   `json:"task"`
+
+	// Event is the event of the task run, if any
+	Event *event.Event `json:"event,omitempty"`
+
+	// Changes is the JSON representation of the Terraform plan, if the
+	// changes were planned. The variables and configuration are removed and
+	// sensitive values are redacted.
+	Changes interface{} `json:"changes,omitempty"`
+
+	// Error is the error the task failed with for on-failure hooks
+	Error string `json:"error,omitempty"`
+}
+
+// Task is the information about the task in the hook context
+type Task struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Module      string   `json:"module"`
+	Providers   []string `json:"providers"`
+	Services    []string `json:"services"`
+}
+
+// Result is the outcome of running a single hook
+type Result struct {
+	Name   string
+	Stage  string
+	Output string
+	Err    error
+}
+
+// Run runs the hooks configured for the stage in order with the hook context.
+// All hooks for the stage run, regardless of earlier failures, so that all
+// results can be recorded. An error is returned if any hook failed.
+func Run(ctx context.Context, hooks []Hook, stage string, hctx Context) ([]Result, error) {
+	logger := logging.FromContext(ctx).Named(logSystemName)
+
+	var results []Result
+	var failed []string
+	var input []byte
+	for _, h := range hooks {
+		if h.Stage() != stage {
+			continue
+		}
+
+		if input == nil {
+			hctx.Stage = stage
+			var err error
+			input, err = json.Marshal(hctx)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding hook context: %s", err)
+			}
+		}
+
+		logger.Trace("running hook", "hook", h.Name(), "stage", stage)
+		output, err := h.Run(ctx, input)
+		results = append(results, Result{
+			Name:   h.Name(),
+			Stage:  stage,
+			Output: truncate(output),
+			Err:    err,
+		})
+
+		if err != nil {
+			logger.Error("error running hook", "hook", h.Name(), "stage", stage,
+				"error", err)
+			failed = append(failed, h.Name())
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, fmt.Errorf("%s hooks failed: %s", stage,
+			strings.Join(failed, ", "))
+	}
+
+	return results, nil
+}
+
+// HasStage returns true if any of the hooks run at the stage
+func HasStage(hooks []Hook, stage string) bool {
+	for _, h := range hooks {
+		if h.Stage() == stage {
+			return true
+		}
+	}
+	return false
+}
+
+// truncate limits the length of the captured output of a hook
+func truncate(output string) string {
+	if len(output) <= maxOutputLen {
+		return output
+	}
+	return output[:maxOutputLen] + "\n...(truncated)"
+}
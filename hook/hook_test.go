@@ -0,0 +1,83 @@
+package hook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHook is a hook that records its input
+type testHook struct {
+	name   string
+	stage  string
+	output string
+	err    error
+	input  []byte
+}
+
+func (h *testHook) Name() string  { return h.name }
+func (h *testHook) Stage() string { return h.stage }
+func (h *testHook) Run(_ context.Context, input []byte) (string, error) {
+	h.input = input
+	return h.output, h.err
+}
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	t.Run("runs_stage_hooks", func(t *testing.T) {
+		pre := &testHook{name: "pre", stage: StagePrePlan, output: "ok"}
+		post := &testHook{name: "post", stage: StagePostApply}
+		hctx := Context{Task: Task{Name: "task"}}
+
+		results, err := Run(context.Background(), []Hook{pre, post}, StagePrePlan, hctx)
+		require.NoError(t, err)
+		assert.Equal(t, []Result{{Name: "pre", Stage: StagePrePlan, Output: "ok"}}, results)
+		assert.Nil(t, post.input)
+
+		var actual Context
+		require.NoError(t, json.Unmarshal(pre.input, &actual))
+		assert.Equal(t, StagePrePlan, actual.Stage)
+		assert.Equal(t, "task", actual.Task.Name)
+	})
+
+	t.Run("all_hooks_run_on_failure", func(t *testing.T) {
+		a := &testHook{name: "a", stage: StagePostApply, err: errors.New("error")}
+		b := &testHook{name: "b", stage: StagePostApply}
+
+		results, err := Run(context.Background(), []Hook{a, b}, StagePostApply, Context{})
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "a")
+		assert.Len(t, results, 2)
+		assert.NotNil(t, b.input)
+	})
+
+	t.Run("no_hooks", func(t *testing.T) {
+		results, err := Run(context.Background(), nil, StagePostApply, Context{})
+		assert.NoError(t, err)
+		assert.Empty(t, results)
+	})
+
+	t.Run("output_truncated", func(t *testing.T) {
+		h := &testHook{name: "a", stage: StagePostPlan,
+			output: strings.Repeat("a", maxOutputLen+10)}
+
+		results, err := Run(context.Background(), []Hook{h}, StagePostPlan, Context{})
+		require.NoError(t, err)
+		assert.True(t, strings.HasSuffix(results[0].Output, "(truncated)"))
+	})
+}
+
+func TestHasStage(t *testing.T) {
+	t.Parallel()
+
+	hooks := []Hook{&testHook{stage: StagePrePlan}}
+	assert.True(t, HasStage(hooks, StagePrePlan))
+	assert.False(t, HasStage(hooks, StagePostPlan))
+	assert.False(t, HasStage(nil, StagePrePlan))
+}
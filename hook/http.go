@@ -0,0 +1,95 @@
+package hook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var _ Hook = (*HTTP)(nil)
+
+// HTTPConfig configures a hook that calls an HTTP endpoint
+type HTTPConfig struct {
+	Name    string
+	Stage   string
+	URL     string
+	Method  string
+	Headers map[string]string
+	Timeout time.Duration
+}
+
+// HTTP is a hook that sends the hook context as a JSON request body to an
+// HTTP endpoint. The response body is captured as the output.
+type HTTP struct {
+	name    string
+	stage   string
+	url     string
+	method  string
+	headers map[string]string
+	client  *http.Client
+}
+
+// NewHTTP creates a new HTTP hook
+func NewHTTP(conf HTTPConfig) (*HTTP, error) {
+	if conf.URL == "" {
+		return nil, errors.New("url is required for http hook")
+	}
+
+	method := conf.Method
+	if method == "" {
+		method = http.MethodPost
+	}
+
+	return &HTTP{
+		name:    conf.Name,
+		stage:   conf.Stage,
+		url:     conf.URL,
+		method:  method,
+		headers: conf.Headers,
+		client:  &http.Client{Timeout: conf.Timeout},
+	}, nil
+}
+
+// Name returns the name of the hook
+func (h *HTTP) Name() string {
+	return h.name
+}
+
+// Stage returns the stage the hook runs at
+func (h *HTTP) Stage() string {
+	return h.stage
+}
+
+// Run sends the input to the HTTP endpoint. Responses with a non-2xx status
+// code are considered a failure.
+func (h *HTTP) Run(ctx context.Context, input []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, h.method, h.url, bytes.NewReader(input))
+	if err != nil {
+		return "", fmt.Errorf("error creating request: %s", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range h.headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error sending request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxOutputLen+1))
+	if err != nil {
+		return "", fmt.Errorf("error reading response: %s", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return string(body), fmt.Errorf("unexpected response status code %d",
+			resp.StatusCode)
+	}
+	return string(body), nil
+}
@@ -0,0 +1,74 @@
+package hook
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTP_Run(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		statusCode int
+		expectErr  bool
+	}{
+		{
+			"success",
+			http.StatusOK,
+			false,
+		},
+		{
+			"error_status_code",
+			http.StatusInternalServerError,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var body []byte
+			var header http.Header
+			var method string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ = io.ReadAll(r.Body)
+				header = r.Header
+				method = r.Method
+				w.WriteHeader(tc.statusCode)
+				w.Write([]byte("response"))
+			}))
+			defer ts.Close()
+
+			h, err := NewHTTP(HTTPConfig{
+				Name:    tc.name,
+				Stage:   StagePostPlan,
+				URL:     ts.URL,
+				Headers: map[string]string{"Authorization": "Bearer token"},
+			})
+			require.NoError(t, err)
+
+			out, err := h.Run(context.Background(), []byte(`{"stage":"post-plan"}`))
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, "response", out)
+			assert.Equal(t, http.MethodPost, method)
+			assert.Equal(t, `{"stage":"post-plan"}`, string(body))
+			assert.Equal(t, "application/json", header.Get("Content-Type"))
+			assert.Equal(t, "Bearer token", header.Get("Authorization"))
+		})
+	}
+
+	t.Run("missing_url", func(t *testing.T) {
+		_, err := NewHTTP(HTTPConfig{Name: "missing"})
+		assert.Error(t, err)
+	})
+}
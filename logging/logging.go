@@ -40,6 +40,13 @@ type Config struct {
 	// Level is the log level to use.
 	Level string
 
+	// SubsystemLevels are log levels that override Level for subsystems, keyed
+	// by subsystem name.
+	SubsystemLevels map[string]string
+
+	// JSON formats logs as JSON objects instead of text.
+	JSON bool
+
 	// Syslog and SyslogFacility is the syslog configuration options.
 	Syslog         bool
 	SyslogFacility string
@@ -63,6 +70,11 @@ func Setup(config *Config) error {
 			Levels)
 	}
 
+	subsystems, err := parseSubsystemLevels(config.SubsystemLevels)
+	if err != nil {
+		return err
+	}
+
 	// Set the global log level, this will be used by all loggers
 	setLogLevel(config.Level)
 
@@ -78,13 +90,21 @@ func Setup(config *Config) error {
 		logOutput = config.Writer
 	}
 
+	// Logs are filtered by the level of their subsystem, so the underlying
+	// logger logs at all levels
 	logger := hclog.New(&hclog.LoggerOptions{
-		Level:      hclog.LevelFromString(LogLevel),
+		Level:      hclog.Trace,
 		Output:     logOutput,
 		TimeFormat: hclog.TimeFormat,
+		JSONFormat: config.JSON,
 	})
 
-	hclog.SetDefault(logger)
+	globalLevels.mu.Lock()
+	globalLevels.global = parseLevel(LogLevel)
+	globalLevels.subsystems = subsystems
+	globalLevels.mu.Unlock()
+
+	hclog.SetDefault(newSubsystemLogger(logger, globalLevels))
 	return nil
 }
 
@@ -98,6 +118,25 @@ func NewNullLogger() Logger {
 	return hclog.NewNullLogger()
 }
 
+// SetLevel updates the log level of the global logger and all loggers derived
+// from it. Log levels of subsystems are not changed.
+func SetLevel(level string) error {
+	if !validateLogLevel(level) {
+		return fmt.Errorf("Invalid log level: %s. Valid log levels are: %v",
+			level,
+			Levels)
+	}
+
+	setLogLevel(level)
+	globalLevels.mu.Lock()
+	globalLevels.global = parseLevel(LogLevel)
+	globalLevels.mu.Unlock()
+
+	// The global logger may not have been set up to filter by subsystem
+	hclog.Default().SetLevel(parseLevel(LogLevel))
+	return nil
+}
+
 func DisableLogging() {
 	hclog.Default().SetLevel(hclog.Off)
 }
@@ -122,7 +161,7 @@ func SetupLocal(writer io.Writer, systemName string, subsystemName string, value
 	// Create default logger
 	logger := hclog.New(&hclog.LoggerOptions{
 		Name:       systemName,
-		Level:      hclog.LevelFromString(LogLevel),
+		Level:      parseLevel(LogLevel),
 		Output:     writer,
 		TimeFormat: hclog.TimeFormat,
 	})
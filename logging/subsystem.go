@@ -0,0 +1,286 @@
+package logging
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// levels is the global log level and the log levels of subsystems that
+// override it. It is shared by the global logger and all loggers derived from
+// it so that levels can be changed at runtime.
+type levels struct {
+	mu         sync.RWMutex
+	global     Level
+	subsystems map[string]Level
+}
+
+// globalLevels are the levels of the logger configured by Setup
+var globalLevels = &levels{
+	global:     parseLevel(defaultLogLevel),
+	subsystems: make(map[string]Level),
+}
+
+// levelFor returns the level of a logger with the given name. Names of loggers
+// are the names of their systems and subsystems joined by ".", for example
+// "ctrl.tasksmanager". The level of the most specific subsystem with an
+// override is used, otherwise the global level.
+func (l *levels) levelFor(name string) Level {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+
+	if len(l.subsystems) == 0 || name == "" {
+		return l.global
+	}
+
+	parts := strings.Split(name, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		if level, ok := l.subsystems[parts[i]]; ok {
+			return level
+		}
+	}
+	return l.global
+}
+
+// UpdateLevels updates the global log level, unless it is empty, and
+// overrides the log levels of the given subsystems. An empty level for a
+// subsystem removes its override so that it logs at the global level. Levels
+// are validated before any are changed.
+func UpdateLevels(level string, subsystems map[string]string) error {
+	if level != "" && !validateLogLevel(level) {
+		return fmt.Errorf("Invalid log level: %s. Valid log levels are: %v",
+			level, Levels)
+	}
+
+	set := make(map[string]string, len(subsystems))
+	var unset []string
+	for name, l := range subsystems {
+		if name == "" {
+			return fmt.Errorf("subsystem name is required to set its log level")
+		}
+		if l == "" {
+			unset = append(unset, name)
+			continue
+		}
+		set[name] = l
+	}
+	levels, err := parseSubsystemLevels(set)
+	if err != nil {
+		return err
+	}
+
+	if level != "" {
+		if err := SetLevel(level); err != nil {
+			return err
+		}
+	}
+
+	globalLevels.mu.Lock()
+	defer globalLevels.mu.Unlock()
+	for name, l := range levels {
+		globalLevels.subsystems[name] = l
+	}
+	for _, name := range unset {
+		delete(globalLevels.subsystems, name)
+	}
+	return nil
+}
+
+// SetLevels replaces the global log level and all log levels of subsystems
+func SetLevels(level string, subsystems map[string]string) error {
+	levels, err := parseSubsystemLevels(subsystems)
+	if err != nil {
+		return err
+	}
+	if err := SetLevel(level); err != nil {
+		return err
+	}
+
+	globalLevels.mu.Lock()
+	globalLevels.subsystems = levels
+	globalLevels.mu.Unlock()
+	return nil
+}
+
+// GetLevels returns the global log level and the log levels of subsystems
+// that override it
+func GetLevels() (string, map[string]string) {
+	globalLevels.mu.RLock()
+	defer globalLevels.mu.RUnlock()
+
+	subsystems := make(map[string]string, len(globalLevels.subsystems))
+	for name, level := range globalLevels.subsystems {
+		subsystems[name] = levelString(level)
+	}
+	return levelString(globalLevels.global), subsystems
+}
+
+func parseSubsystemLevels(subsystems map[string]string) (map[string]Level, error) {
+	levels := make(map[string]Level, len(subsystems))
+	for name, level := range subsystems {
+		if !validateLogLevel(level) {
+			return nil, fmt.Errorf("Invalid log level for subsystem %s: %s. "+
+				"Valid log levels are: %v", name, level, Levels)
+		}
+		levels[name] = parseLevel(level)
+	}
+	return levels, nil
+}
+
+// parseLevel returns the level for a valid log level name. hclog does not
+// recognize "ERR", which is how the error level is configured.
+func parseLevel(level string) Level {
+	if strings.ToUpper(level) == "ERR" {
+		return Error
+	}
+	return hclog.LevelFromString(level)
+}
+
+// levelString returns the name of a level as it is configured
+func levelString(level Level) string {
+	if level == Error {
+		return "ERR"
+	}
+	return strings.ToUpper(level.String())
+}
+
+// subsystemLogger filters the logs of the wrapped logger by the level of its
+// subsystem. The wrapped logger must log at all levels.
+type subsystemLogger struct {
+	hclog.Logger
+	levels *levels
+}
+
+var _ hclog.Logger = (*subsystemLogger)(nil)
+
+func newSubsystemLogger(l hclog.Logger, lv *levels) *subsystemLogger {
+	return &subsystemLogger{Logger: l, levels: lv}
+}
+
+func (l *subsystemLogger) enabled(level Level) bool {
+	return level >= l.levels.levelFor(l.Name())
+}
+
+func (l *subsystemLogger) Log(level Level, msg string, args ...interface{}) {
+	if l.enabled(level) {
+		l.Logger.Log(level, msg, args...)
+	}
+}
+
+func (l *subsystemLogger) Trace(msg string, args ...interface{}) {
+	if l.enabled(Trace) {
+		l.Logger.Trace(msg, args...)
+	}
+}
+
+func (l *subsystemLogger) Debug(msg string, args ...interface{}) {
+	if l.enabled(Debug) {
+		l.Logger.Debug(msg, args...)
+	}
+}
+
+func (l *subsystemLogger) Info(msg string, args ...interface{}) {
+	if l.enabled(Info) {
+		l.Logger.Info(msg, args...)
+	}
+}
+
+func (l *subsystemLogger) Warn(msg string, args ...interface{}) {
+	if l.enabled(Warn) {
+		l.Logger.Warn(msg, args...)
+	}
+}
+
+func (l *subsystemLogger) Error(msg string, args ...interface{}) {
+	if l.enabled(Error) {
+		l.Logger.Error(msg, args...)
+	}
+}
+
+func (l *subsystemLogger) IsTrace() bool { return l.enabled(Trace) }
+func (l *subsystemLogger) IsDebug() bool { return l.enabled(Debug) }
+func (l *subsystemLogger) IsInfo() bool  { return l.enabled(Info) }
+func (l *subsystemLogger) IsWarn() bool  { return l.enabled(Warn) }
+func (l *subsystemLogger) IsError() bool { return l.enabled(Error) }
+
+func (l *subsystemLogger) With(args ...interface{}) hclog.Logger {
+	return newSubsystemLogger(l.Logger.With(args...), l.levels)
+}
+
+func (l *subsystemLogger) Named(name string) hclog.Logger {
+	return newSubsystemLogger(l.Logger.Named(name), l.levels)
+}
+
+func (l *subsystemLogger) ResetNamed(name string) hclog.Logger {
+	return newSubsystemLogger(l.Logger.ResetNamed(name), l.levels)
+}
+
+// SetLevel sets the global level, which is shared by all loggers as it is for
+// hclog loggers that are not created with independent levels
+func (l *subsystemLogger) SetLevel(level Level) {
+	l.levels.mu.Lock()
+	defer l.levels.mu.Unlock()
+	l.levels.global = level
+}
+
+func (l *subsystemLogger) StandardLogger(opts *hclog.StandardLoggerOptions) *log.Logger {
+	return log.New(l.StandardWriter(opts), "", 0)
+}
+
+func (l *subsystemLogger) StandardWriter(opts *hclog.StandardLoggerOptions) io.Writer {
+	if opts == nil {
+		opts = &hclog.StandardLoggerOptions{}
+	}
+	return &stdlogWriter{
+		log:         l,
+		inferLevels: opts.InferLevels,
+		forceLevel:  opts.ForceLevel,
+	}
+}
+
+// stdlogWriter writes the lines of a standard library logger to a logger so
+// that they are filtered by its level
+type stdlogWriter struct {
+	log         hclog.Logger
+	inferLevels bool
+	forceLevel  Level
+}
+
+func (w *stdlogWriter) Write(data []byte) (int, error) {
+	str := string(bytes.TrimRight(data, " \t\n"))
+
+	switch {
+	case w.forceLevel != NoLevel:
+		_, str = pickLevel(str)
+		w.log.Log(w.forceLevel, str)
+	case w.inferLevels:
+		level, str := pickLevel(str)
+		w.log.Log(level, str)
+	default:
+		w.log.Info(str)
+	}
+	return len(data), nil
+}
+
+// pickLevel returns the level of a line logged with a "[LEVEL]" prefix and the
+// line without the prefix. Lines without a prefix are logged at INFO.
+func pickLevel(str string) (Level, string) {
+	for prefix, level := range map[string]Level{
+		"[TRACE]": Trace,
+		"[DEBUG]": Debug,
+		"[INFO]":  Info,
+		"[WARN]":  Warn,
+		"[ERROR]": Error,
+		"[ERR]":   Error,
+	} {
+		if strings.HasPrefix(str, prefix) {
+			return level, strings.TrimSpace(str[len(prefix):])
+		}
+	}
+	return Info, str
+}
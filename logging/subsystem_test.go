@@ -0,0 +1,76 @@
+package logging
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-hclog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSubsystemLogger(t *testing.T) {
+	var buf bytes.Buffer
+	lv := &levels{
+		global:     Info,
+		subsystems: map[string]Level{"templates": Trace},
+	}
+	root := newSubsystemLogger(hclog.New(&hclog.LoggerOptions{
+		Level:      hclog.Trace,
+		Output:     &buf,
+		JSONFormat: true,
+	}), lv)
+
+	ctrl := root.Named("ctrl")
+	tmpl := root.Named("templates").Named("tftmpl").With("task_name", "task")
+
+	ctrl.Debug("ctrl debug")
+	ctrl.Info("ctrl info")
+	tmpl.Trace("templates trace")
+	assert.False(t, ctrl.IsDebug())
+	assert.True(t, tmpl.IsTrace())
+
+	// levels changed at runtime apply to existing loggers
+	lv.subsystems["ctrl"] = Error
+	ctrl.Warn("ctrl warn")
+	root.SetLevel(Warn)
+	root.Info("root info")
+
+	var msgs []string
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		msgs = append(msgs, entry["@message"].(string))
+	}
+	assert.Equal(t, []string{"ctrl info", "templates trace"}, msgs)
+
+	buf.Reset()
+	std := tmpl.StandardLogger(&hclog.StandardLoggerOptions{InferLevels: true})
+	std.Print("[DEBUG] from std logger")
+	assert.Contains(t, buf.String(), `"@level":"debug"`)
+}
+
+func TestUpdateLevels(t *testing.T) {
+	defer SetLevels(defaultLogLevel, nil)
+
+	require.NoError(t, SetLevels("INFO", map[string]string{"api": "DEBUG"}))
+
+	err := UpdateLevels("", map[string]string{"templates": "TRACE", "api": ""})
+	require.NoError(t, err)
+	level, subsystems := GetLevels()
+	assert.Equal(t, "INFO", level)
+	assert.Equal(t, map[string]string{"templates": "TRACE"}, subsystems)
+
+	// invalid levels do not change any levels
+	err = UpdateLevels("DEBUG", map[string]string{"registration": "LOUD"})
+	assert.Error(t, err)
+	level, subsystems = GetLevels()
+	assert.Equal(t, "INFO", level)
+	assert.Equal(t, map[string]string{"templates": "TRACE"}, subsystems)
+
+	require.NoError(t, UpdateLevels("ERR", nil))
+	level, _ = GetLevels()
+	assert.Equal(t, "ERR", level)
+}
@@ -16,6 +16,96 @@ type ClientWithResponsesInterface struct {
 	mock.Mock
 }
 
+// BulkTasksWithBodyWithResponse provides a mock function with given fields: ctx, operation, contentType, body, reqEditors
+func (_m *ClientWithResponsesInterface) BulkTasksWithBodyWithResponse(ctx context.Context, operation oapigen.BulkTasksParamsOperation, contentType string, body io.Reader, reqEditors ...oapigen.RequestEditorFn) (*oapigen.BulkTasksResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, operation, contentType, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.BulkTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, oapigen.BulkTasksParamsOperation, string, io.Reader, ...oapigen.RequestEditorFn) *oapigen.BulkTasksResponse); ok {
+		r0 = rf(ctx, operation, contentType, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.BulkTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, oapigen.BulkTasksParamsOperation, string, io.Reader, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, operation, contentType, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BulkTasksWithResponse provides a mock function with given fields: ctx, operation, body, reqEditors
+func (_m *ClientWithResponsesInterface) BulkTasksWithResponse(ctx context.Context, operation oapigen.BulkTasksParamsOperation, body oapigen.BulkTasksJSONRequestBody, reqEditors ...oapigen.RequestEditorFn) (*oapigen.BulkTasksResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, operation, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.BulkTasksResponse
+	if rf, ok := ret.Get(0).(func(context.Context, oapigen.BulkTasksParamsOperation, oapigen.BulkTasksJSONRequestBody, ...oapigen.RequestEditorFn) *oapigen.BulkTasksResponse); ok {
+		r0 = rf(ctx, operation, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.BulkTasksResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, oapigen.BulkTasksParamsOperation, oapigen.BulkTasksJSONRequestBody, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, operation, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CancelTaskByNameWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *ClientWithResponsesInterface) CancelTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...oapigen.RequestEditorFn) (*oapigen.CancelTaskByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.CancelTaskByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapigen.RequestEditorFn) *oapigen.CancelTaskByNameResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.CancelTaskByNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CreateTaskWithBodyWithResponse provides a mock function with given fields: ctx, params, contentType, body, reqEditors
 func (_m *ClientWithResponsesInterface) CreateTaskWithBodyWithResponse(ctx context.Context, params *oapigen.CreateTaskParams, contentType string, body io.Reader, reqEditors ...oapigen.RequestEditorFn) (*oapigen.CreateTaskResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
@@ -76,26 +166,56 @@ func (_m *ClientWithResponsesInterface) CreateTaskWithResponse(ctx context.Conte
 	return r0, r1
 }
 
-// DeleteTaskByNameWithResponse provides a mock function with given fields: ctx, name, reqEditors
-func (_m *ClientWithResponsesInterface) DeleteTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...oapigen.RequestEditorFn) (*oapigen.DeleteTaskByNameResponse, error) {
+// DeleteTaskByNameWithResponse provides a mock function with given fields: ctx, name, params, reqEditors
+func (_m *ClientWithResponsesInterface) DeleteTaskByNameWithResponse(ctx context.Context, name string, params *oapigen.DeleteTaskByNameParams, reqEditors ...oapigen.RequestEditorFn) (*oapigen.DeleteTaskByNameResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
 	for _i := range reqEditors {
 		_va[_i] = reqEditors[_i]
 	}
 	var _ca []interface{}
-	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, ctx, name, params)
 	_ca = append(_ca, _va...)
 	ret := _m.Called(_ca...)
 
 	var r0 *oapigen.DeleteTaskByNameResponse
-	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapigen.RequestEditorFn) *oapigen.DeleteTaskByNameResponse); ok {
-		r0 = rf(ctx, name, reqEditors...)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *oapigen.DeleteTaskByNameParams, ...oapigen.RequestEditorFn) *oapigen.DeleteTaskByNameResponse); ok {
+		r0 = rf(ctx, name, params, reqEditors...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*oapigen.DeleteTaskByNameResponse)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *oapigen.DeleteTaskByNameParams, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, params, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportTaskByNameWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *ClientWithResponsesInterface) ExportTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...oapigen.RequestEditorFn) (*oapigen.ExportTaskByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.ExportTaskByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapigen.RequestEditorFn) *oapigen.ExportTaskByNameResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.ExportTaskByNameResponse)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapigen.RequestEditorFn) error); ok {
 		r1 = rf(ctx, name, reqEditors...)
@@ -166,6 +286,66 @@ func (_m *ClientWithResponsesInterface) GetHealthWithResponse(ctx context.Contex
 	return r0, r1
 }
 
+// GetLogLevelsWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *ClientWithResponsesInterface) GetLogLevelsWithResponse(ctx context.Context, reqEditors ...oapigen.RequestEditorFn) (*oapigen.GetLogLevelsResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.GetLogLevelsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapigen.RequestEditorFn) *oapigen.GetLogLevelsResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.GetLogLevelsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRolloutWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *ClientWithResponsesInterface) GetRolloutWithResponse(ctx context.Context, reqEditors ...oapigen.RequestEditorFn) (*oapigen.GetRolloutResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.GetRolloutResponse
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapigen.RequestEditorFn) *oapigen.GetRolloutResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.GetRolloutResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetTaskByNameWithResponse provides a mock function with given fields: ctx, name, reqEditors
 func (_m *ClientWithResponsesInterface) GetTaskByNameWithResponse(ctx context.Context, name string, reqEditors ...oapigen.RequestEditorFn) (*oapigen.GetTaskByNameResponse, error) {
 	_va := make([]interface{}, len(reqEditors))
@@ -196,6 +376,186 @@ func (_m *ClientWithResponsesInterface) GetTaskByNameWithResponse(ctx context.Co
 	return r0, r1
 }
 
+// GetTaskStateByNameWithResponse provides a mock function with given fields: ctx, name, reqEditors
+func (_m *ClientWithResponsesInterface) GetTaskStateByNameWithResponse(ctx context.Context, name string, reqEditors ...oapigen.RequestEditorFn) (*oapigen.GetTaskStateByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.GetTaskStateByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...oapigen.RequestEditorFn) *oapigen.GetTaskStateByNameResponse); ok {
+		r0 = rf(ctx, name, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.GetTaskStateByNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateTaskStateByNameWithBodyWithResponse provides a mock function with given fields: ctx, name, contentType, body, reqEditors
+func (_m *ClientWithResponsesInterface) MigrateTaskStateByNameWithBodyWithResponse(ctx context.Context, name string, contentType string, body io.Reader, reqEditors ...oapigen.RequestEditorFn) (*oapigen.MigrateTaskStateByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, contentType, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.MigrateTaskStateByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, io.Reader, ...oapigen.RequestEditorFn) *oapigen.MigrateTaskStateByNameResponse); ok {
+		r0 = rf(ctx, name, contentType, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.MigrateTaskStateByNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, io.Reader, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, contentType, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MigrateTaskStateByNameWithResponse provides a mock function with given fields: ctx, name, body, reqEditors
+func (_m *ClientWithResponsesInterface) MigrateTaskStateByNameWithResponse(ctx context.Context, name string, body oapigen.MigrateTaskStateByNameJSONRequestBody, reqEditors ...oapigen.RequestEditorFn) (*oapigen.MigrateTaskStateByNameResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, name, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.MigrateTaskStateByNameResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, oapigen.MigrateTaskStateByNameJSONRequestBody, ...oapigen.RequestEditorFn) *oapigen.MigrateTaskStateByNameResponse); ok {
+		r0 = rf(ctx, name, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.MigrateTaskStateByNameResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, oapigen.MigrateTaskStateByNameJSONRequestBody, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, name, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReloadConfigWithResponse provides a mock function with given fields: ctx, reqEditors
+func (_m *ClientWithResponsesInterface) ReloadConfigWithResponse(ctx context.Context, reqEditors ...oapigen.RequestEditorFn) (*oapigen.ReloadConfigResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.ReloadConfigResponse
+	if rf, ok := ret.Get(0).(func(context.Context, ...oapigen.RequestEditorFn) *oapigen.ReloadConfigResponse); ok {
+		r0 = rf(ctx, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.ReloadConfigResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLogLevelsWithBodyWithResponse provides a mock function with given fields: ctx, contentType, body, reqEditors
+func (_m *ClientWithResponsesInterface) UpdateLogLevelsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...oapigen.RequestEditorFn) (*oapigen.UpdateLogLevelsResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, contentType, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.UpdateLogLevelsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, string, io.Reader, ...oapigen.RequestEditorFn) *oapigen.UpdateLogLevelsResponse); ok {
+		r0 = rf(ctx, contentType, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.UpdateLogLevelsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, io.Reader, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, contentType, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateLogLevelsWithResponse provides a mock function with given fields: ctx, body, reqEditors
+func (_m *ClientWithResponsesInterface) UpdateLogLevelsWithResponse(ctx context.Context, body oapigen.UpdateLogLevelsJSONRequestBody, reqEditors ...oapigen.RequestEditorFn) (*oapigen.UpdateLogLevelsResponse, error) {
+	_va := make([]interface{}, len(reqEditors))
+	for _i := range reqEditors {
+		_va[_i] = reqEditors[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, body)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *oapigen.UpdateLogLevelsResponse
+	if rf, ok := ret.Get(0).(func(context.Context, oapigen.UpdateLogLevelsJSONRequestBody, ...oapigen.RequestEditorFn) *oapigen.UpdateLogLevelsResponse); ok {
+		r0 = rf(ctx, body, reqEditors...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*oapigen.UpdateLogLevelsResponse)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, oapigen.UpdateLogLevelsJSONRequestBody, ...oapigen.RequestEditorFn) error); ok {
+		r1 = rf(ctx, body, reqEditors...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 type mockConstructorTestingTNewClientWithResponsesInterface interface {
 	mock.TestingT
 	Cleanup(func())
@@ -7,6 +7,8 @@ import (
 	io "io"
 
 	mock "github.com/stretchr/testify/mock"
+
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
 // Client is an autogenerated mock type for the Client type
@@ -28,6 +30,48 @@ func (_m *Client) Apply(ctx context.Context) error {
 	return r0
 }
 
+// ApplyPlan provides a mock function with given fields: ctx, planFile
+func (_m *Client) ApplyPlan(ctx context.Context, planFile string) error {
+	ret := _m.Called(ctx, planFile)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, planFile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteWorkspace provides a mock function with given fields: ctx
+func (_m *Client) DeleteWorkspace(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Destroy provides a mock function with given fields: ctx
+func (_m *Client) Destroy(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // GoString provides a mock function with given fields:
 func (_m *Client) GoString() string {
 	ret := _m.Called()
@@ -77,6 +121,97 @@ func (_m *Client) Plan(ctx context.Context) (bool, error) {
 	return r0, r1
 }
 
+// PlanDestroy provides a mock function with given fields: ctx
+func (_m *Client) PlanDestroy(ctx context.Context) (bool, error) {
+	ret := _m.Called(ctx)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context) bool); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PullState provides a mock function with given fields: ctx
+func (_m *Client) PullState(ctx context.Context) (string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PushState provides a mock function with given fields: ctx, stateFile
+func (_m *Client) PushState(ctx context.Context, stateFile string) error {
+	ret := _m.Called(ctx, stateFile)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, stateFile)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Reconfigure provides a mock function with given fields: ctx
+func (_m *Client) Reconfigure(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SavePlan provides a mock function with given fields: ctx, planFile
+func (_m *Client) SavePlan(ctx context.Context, planFile string) (bool, error) {
+	ret := _m.Called(ctx, planFile)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, planFile)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, planFile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SetEnv provides a mock function with given fields: _a0
 func (_m *Client) SetEnv(_a0 map[string]string) error {
 	ret := _m.Called(_a0)
@@ -91,11 +226,62 @@ func (_m *Client) SetEnv(_a0 map[string]string) error {
 	return r0
 }
 
+// SetStderr provides a mock function with given fields: w
+func (_m *Client) SetStderr(w io.Writer) {
+	_m.Called(w)
+}
+
 // SetStdout provides a mock function with given fields: w
 func (_m *Client) SetStdout(w io.Writer) {
 	_m.Called(w)
 }
 
+// ShowPlan provides a mock function with given fields: ctx, planFile
+func (_m *Client) ShowPlan(ctx context.Context, planFile string) (*tfjson.Plan, error) {
+	ret := _m.Called(ctx, planFile)
+
+	var r0 *tfjson.Plan
+	if rf, ok := ret.Get(0).(func(context.Context, string) *tfjson.Plan); ok {
+		r0 = rf(ctx, planFile)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tfjson.Plan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, planFile)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShowState provides a mock function with given fields: ctx
+func (_m *Client) ShowState(ctx context.Context) (*tfjson.State, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *tfjson.State
+	if rf, ok := ret.Get(0).(func(context.Context) *tfjson.State); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tfjson.State)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Validate provides a mock function with given fields: ctx
 func (_m *Client) Validate(ctx context.Context) error {
 	ret := _m.Called(ctx)
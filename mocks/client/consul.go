@@ -23,6 +23,166 @@ func (_m *ConsulClientInterface) EXPECT() *ConsulClientInterface_Expecter {
 	return &ConsulClientInterface_Expecter{mock: &_m.Mock}
 }
 
+// ACLTokenReadSelf provides a mock function with given fields: ctx, q
+func (_m *ConsulClientInterface) ACLTokenReadSelf(ctx context.Context, q *api.QueryOptions) (*api.ACLToken, error) {
+	ret := _m.Called(ctx, q)
+
+	var r0 *api.ACLToken
+	if rf, ok := ret.Get(0).(func(context.Context, *api.QueryOptions) *api.ACLToken); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.ACLToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *api.QueryOptions) error); ok {
+		r1 = rf(ctx, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsulClientInterface_ACLTokenReadSelf_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ACLTokenReadSelf'
+type ConsulClientInterface_ACLTokenReadSelf_Call struct {
+	*mock.Call
+}
+
+// ACLTokenReadSelf is a helper method to define mock.On call
+//  - ctx context.Context
+//  - q *api.QueryOptions
+func (_e *ConsulClientInterface_Expecter) ACLTokenReadSelf(ctx interface{}, q interface{}) *ConsulClientInterface_ACLTokenReadSelf_Call {
+	return &ConsulClientInterface_ACLTokenReadSelf_Call{Call: _e.mock.On("ACLTokenReadSelf", ctx, q)}
+}
+
+func (_c *ConsulClientInterface_ACLTokenReadSelf_Call) Run(run func(ctx context.Context, q *api.QueryOptions)) *ConsulClientInterface_ACLTokenReadSelf_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*api.QueryOptions))
+	})
+	return _c
+}
+
+func (_c *ConsulClientInterface_ACLTokenReadSelf_Call) Return(_a0 *api.ACLToken, _a1 error) *ConsulClientInterface_ACLTokenReadSelf_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+// CatalogService provides a mock function with given fields: ctx, service, q
+func (_m *ConsulClientInterface) CatalogService(ctx context.Context, service string, q *api.QueryOptions) ([]*api.CatalogService, *api.QueryMeta, error) {
+	ret := _m.Called(ctx, service, q)
+
+	var r0 []*api.CatalogService
+	if rf, ok := ret.Get(0).(func(context.Context, string, *api.QueryOptions) []*api.CatalogService); ok {
+		r0 = rf(ctx, service, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*api.CatalogService)
+		}
+	}
+
+	var r1 *api.QueryMeta
+	if rf, ok := ret.Get(1).(func(context.Context, string, *api.QueryOptions) *api.QueryMeta); ok {
+		r1 = rf(ctx, service, q)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.QueryMeta)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, string, *api.QueryOptions) error); ok {
+		r2 = rf(ctx, service, q)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ConsulClientInterface_CatalogService_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CatalogService'
+type ConsulClientInterface_CatalogService_Call struct {
+	*mock.Call
+}
+
+// CatalogService is a helper method to define mock.On call
+//  - ctx context.Context
+//  - service string
+//  - q *api.QueryOptions
+func (_e *ConsulClientInterface_Expecter) CatalogService(ctx interface{}, service interface{}, q interface{}) *ConsulClientInterface_CatalogService_Call {
+	return &ConsulClientInterface_CatalogService_Call{Call: _e.mock.On("CatalogService", ctx, service, q)}
+}
+
+func (_c *ConsulClientInterface_CatalogService_Call) Run(run func(ctx context.Context, service string, q *api.QueryOptions)) *ConsulClientInterface_CatalogService_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(*api.QueryOptions))
+	})
+	return _c
+}
+
+func (_c *ConsulClientInterface_CatalogService_Call) Return(_a0 []*api.CatalogService, _a1 *api.QueryMeta, _a2 error) *ConsulClientInterface_CatalogService_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+// CatalogServices provides a mock function with given fields: ctx, q
+func (_m *ConsulClientInterface) CatalogServices(ctx context.Context, q *api.QueryOptions) (map[string][]string, *api.QueryMeta, error) {
+	ret := _m.Called(ctx, q)
+
+	var r0 map[string][]string
+	if rf, ok := ret.Get(0).(func(context.Context, *api.QueryOptions) map[string][]string); ok {
+		r0 = rf(ctx, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]string)
+		}
+	}
+
+	var r1 *api.QueryMeta
+	if rf, ok := ret.Get(1).(func(context.Context, *api.QueryOptions) *api.QueryMeta); ok {
+		r1 = rf(ctx, q)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*api.QueryMeta)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context, *api.QueryOptions) error); ok {
+		r2 = rf(ctx, q)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// ConsulClientInterface_CatalogServices_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CatalogServices'
+type ConsulClientInterface_CatalogServices_Call struct {
+	*mock.Call
+}
+
+// CatalogServices is a helper method to define mock.On call
+//  - ctx context.Context
+//  - q *api.QueryOptions
+func (_e *ConsulClientInterface_Expecter) CatalogServices(ctx interface{}, q interface{}) *ConsulClientInterface_CatalogServices_Call {
+	return &ConsulClientInterface_CatalogServices_Call{Call: _e.mock.On("CatalogServices", ctx, q)}
+}
+
+func (_c *ConsulClientInterface_CatalogServices_Call) Run(run func(ctx context.Context, q *api.QueryOptions)) *ConsulClientInterface_CatalogServices_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*api.QueryOptions))
+	})
+	return _c
+}
+
+func (_c *ConsulClientInterface_CatalogServices_Call) Return(_a0 map[string][]string, _a1 *api.QueryMeta, _a2 error) *ConsulClientInterface_CatalogServices_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
 // DeregisterService provides a mock function with given fields: ctx, serviceID, q
 func (_m *ConsulClientInterface) DeregisterService(ctx context.Context, serviceID string, q *api.QueryOptions) error {
 	ret := _m.Called(ctx, serviceID, q)
@@ -164,6 +324,54 @@ func (_c *ConsulClientInterface_KVGet_Call) Return(_a0 *api.KVPair, _a1 *api.Que
 	return _c
 }
 
+// KVPut provides a mock function with given fields: ctx, p, q
+func (_m *ConsulClientInterface) KVPut(ctx context.Context, p *api.KVPair, q *api.WriteOptions) (*api.WriteMeta, error) {
+	ret := _m.Called(ctx, p, q)
+
+	var r0 *api.WriteMeta
+	if rf, ok := ret.Get(0).(func(context.Context, *api.KVPair, *api.WriteOptions) *api.WriteMeta); ok {
+		r0 = rf(ctx, p, q)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*api.WriteMeta)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *api.KVPair, *api.WriteOptions) error); ok {
+		r1 = rf(ctx, p, q)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ConsulClientInterface_KVPut_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'KVPut'
+type ConsulClientInterface_KVPut_Call struct {
+	*mock.Call
+}
+
+// KVPut is a helper method to define mock.On call
+//  - ctx context.Context
+//  - p *api.KVPair
+//  - q *api.WriteOptions
+func (_e *ConsulClientInterface_Expecter) KVPut(ctx interface{}, p interface{}, q interface{}) *ConsulClientInterface_KVPut_Call {
+	return &ConsulClientInterface_KVPut_Call{Call: _e.mock.On("KVPut", ctx, p, q)}
+}
+
+func (_c *ConsulClientInterface_KVPut_Call) Run(run func(ctx context.Context, p *api.KVPair, q *api.WriteOptions)) *ConsulClientInterface_KVPut_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*api.KVPair), args[2].(*api.WriteOptions))
+	})
+	return _c
+}
+
+func (_c *ConsulClientInterface_KVPut_Call) Return(_a0 *api.WriteMeta, _a1 error) *ConsulClientInterface_KVPut_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
 // Lock provides a mock function with given fields: l, stopCh
 func (_m *ConsulClientInterface) Lock(l *api.Lock, stopCh <-chan struct{}) (<-chan struct{}, error) {
 	ret := _m.Called(l, stopCh)
@@ -39,6 +39,27 @@ func (_m *TerraformExec) Apply(ctx context.Context, opts ...tfexec.ApplyOption)
 	return r0
 }
 
+// Destroy provides a mock function with given fields: ctx, opts
+func (_m *TerraformExec) Destroy(ctx context.Context, opts ...tfexec.DestroyOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, ...tfexec.DestroyOption) error); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Init provides a mock function with given fields: ctx, opts
 func (_m *TerraformExec) Init(ctx context.Context, opts ...tfexec.InitOption) error {
 	_va := make([]interface{}, len(opts))
@@ -102,11 +123,125 @@ func (_m *TerraformExec) SetEnv(env map[string]string) error {
 	return r0
 }
 
+// SetStderr provides a mock function with given fields: w
+func (_m *TerraformExec) SetStderr(w io.Writer) {
+	_m.Called(w)
+}
+
 // SetStdout provides a mock function with given fields: w
 func (_m *TerraformExec) SetStdout(w io.Writer) {
 	_m.Called(w)
 }
 
+// Show provides a mock function with given fields: ctx, opts
+func (_m *TerraformExec) Show(ctx context.Context, opts ...tfexec.ShowOption) (*tfjson.State, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *tfjson.State
+	if rf, ok := ret.Get(0).(func(context.Context, ...tfexec.ShowOption) *tfjson.State); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tfjson.State)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...tfexec.ShowOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ShowPlanFile provides a mock function with given fields: ctx, planPath, opts
+func (_m *TerraformExec) ShowPlanFile(ctx context.Context, planPath string, opts ...tfexec.ShowOption) (*tfjson.Plan, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, planPath)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *tfjson.Plan
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...tfexec.ShowOption) *tfjson.Plan); ok {
+		r0 = rf(ctx, planPath, opts...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tfjson.Plan)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, ...tfexec.ShowOption) error); ok {
+		r1 = rf(ctx, planPath, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StatePull provides a mock function with given fields: ctx, opts
+func (_m *TerraformExec) StatePull(ctx context.Context, opts ...tfexec.StatePullOption) (string, error) {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, ...tfexec.StatePullOption) string); ok {
+		r0 = rf(ctx, opts...)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...tfexec.StatePullOption) error); ok {
+		r1 = rf(ctx, opts...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// StatePush provides a mock function with given fields: ctx, path, opts
+func (_m *TerraformExec) StatePush(ctx context.Context, path string, opts ...tfexec.StatePushCmdOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, path)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...tfexec.StatePushCmdOption) error); ok {
+		r0 = rf(ctx, path, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Validate provides a mock function with given fields: ctx
 func (_m *TerraformExec) Validate(ctx context.Context) (*tfjson.ValidateOutput, error) {
 	ret := _m.Called(ctx)
@@ -130,6 +265,27 @@ func (_m *TerraformExec) Validate(ctx context.Context) (*tfjson.ValidateOutput,
 	return r0, r1
 }
 
+// WorkspaceDelete provides a mock function with given fields: ctx, workspace, opts
+func (_m *TerraformExec) WorkspaceDelete(ctx context.Context, workspace string, opts ...tfexec.WorkspaceDeleteCmdOption) error {
+	_va := make([]interface{}, len(opts))
+	for _i := range opts {
+		_va[_i] = opts[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, workspace)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, ...tfexec.WorkspaceDeleteCmdOption) error); ok {
+		r0 = rf(ctx, workspace, opts...)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // WorkspaceNew provides a mock function with given fields: ctx, workspace, opts
 func (_m *TerraformExec) WorkspaceNew(ctx context.Context, workspace string, opts ...tfexec.WorkspaceNewCmdOption) error {
 	_va := make([]interface{}, len(opts))
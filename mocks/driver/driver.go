@@ -7,6 +7,10 @@ import (
 
 	driver "github.com/hashicorp/consul-terraform-sync/driver"
 	mock "github.com/stretchr/testify/mock"
+
+	registry "github.com/hashicorp/consul-terraform-sync/registry"
+
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
 // Driver is an autogenerated mock type for the Driver type
@@ -28,11 +32,69 @@ func (_m *Driver) ApplyTask(ctx context.Context) error {
 	return r0
 }
 
+// CheckModuleUpgrade provides a mock function with given fields: ctx, c
+func (_m *Driver) CheckModuleUpgrade(ctx context.Context, c *registry.Client) (registry.ModuleUpgrade, error) {
+	ret := _m.Called(ctx, c)
+
+	var r0 registry.ModuleUpgrade
+	if rf, ok := ret.Get(0).(func(context.Context, *registry.Client) registry.ModuleUpgrade); ok {
+		r0 = rf(ctx, c)
+	} else {
+		r0 = ret.Get(0).(registry.ModuleUpgrade)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *registry.Client) error); ok {
+		r1 = rf(ctx, c)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DestroyResources provides a mock function with given fields: ctx
+func (_m *Driver) DestroyResources(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // DestroyTask provides a mock function with given fields: ctx
 func (_m *Driver) DestroyTask(ctx context.Context) {
 	_m.Called(ctx)
 }
 
+// ExportTask provides a mock function with given fields: ctx
+func (_m *Driver) ExportTask(ctx context.Context) (map[string][]byte, error) {
+	ret := _m.Called(ctx)
+
+	var r0 map[string][]byte
+	if rf, ok := ret.Get(0).(func(context.Context) map[string][]byte); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InitTask provides a mock function with given fields: ctx
 func (_m *Driver) InitTask(ctx context.Context) error {
 	ret := _m.Called(ctx)
@@ -47,6 +109,27 @@ func (_m *Driver) InitTask(ctx context.Context) error {
 	return r0
 }
 
+// InspectDestroy provides a mock function with given fields: ctx
+func (_m *Driver) InspectDestroy(ctx context.Context) (driver.InspectPlan, error) {
+	ret := _m.Called(ctx)
+
+	var r0 driver.InspectPlan
+	if rf, ok := ret.Get(0).(func(context.Context) driver.InspectPlan); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(driver.InspectPlan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // InspectTask provides a mock function with given fields: ctx
 func (_m *Driver) InspectTask(ctx context.Context) (driver.InspectPlan, error) {
 	ret := _m.Called(ctx)
@@ -68,6 +151,20 @@ func (_m *Driver) InspectTask(ctx context.Context) (driver.InspectPlan, error) {
 	return r0, r1
 }
 
+// MigrateState provides a mock function with given fields: ctx, backend
+func (_m *Driver) MigrateState(ctx context.Context, backend map[string]interface{}) error {
+	ret := _m.Called(ctx, backend)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, map[string]interface{}) error); ok {
+		r0 = rf(ctx, backend)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RenderTemplate provides a mock function with given fields: ctx
 func (_m *Driver) RenderTemplate(ctx context.Context) (bool, error) {
 	ret := _m.Called(ctx)
@@ -94,6 +191,29 @@ func (_m *Driver) SetBufferPeriod() {
 	_m.Called()
 }
 
+// ShowState provides a mock function with given fields: ctx
+func (_m *Driver) ShowState(ctx context.Context) (*tfjson.State, error) {
+	ret := _m.Called(ctx)
+
+	var r0 *tfjson.State
+	if rf, ok := ret.Get(0).(func(context.Context) *tfjson.State); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tfjson.State)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Task provides a mock function with given fields:
 func (_m *Driver) Task() *driver.Task {
 	ret := _m.Called()
@@ -147,6 +267,27 @@ func (_m *Driver) UpdateTask(ctx context.Context, task driver.PatchTask) (driver
 	return r0, r1
 }
 
+// UpgradeModule provides a mock function with given fields: ctx, version, allowDestroy
+func (_m *Driver) UpgradeModule(ctx context.Context, version string, allowDestroy bool) (driver.InspectPlan, error) {
+	ret := _m.Called(ctx, version, allowDestroy)
+
+	var r0 driver.InspectPlan
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) driver.InspectPlan); ok {
+		r0 = rf(ctx, version, allowDestroy)
+	} else {
+		r0 = ret.Get(0).(driver.InspectPlan)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, version, allowDestroy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Version provides a mock function with given fields:
 func (_m *Driver) Version() string {
 	ret := _m.Called()
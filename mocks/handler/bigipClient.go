@@ -0,0 +1,43 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// BigipClient is an autogenerated mock type for the bigipClient type
+type BigipClient struct {
+	mock.Mock
+}
+
+// SaveConfig provides a mock function with given fields: ctx
+func (_m *BigipClient) SaveConfig(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewBigipClient interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewBigipClient creates a new instance of BigipClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewBigipClient(t mockConstructorTestingTNewBigipClient) *BigipClient {
+	mock := &BigipClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
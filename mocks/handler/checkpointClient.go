@@ -0,0 +1,138 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// CheckpointClient is an autogenerated mock type for the checkpointClient type
+type CheckpointClient struct {
+	mock.Mock
+}
+
+// InstallPolicy provides a mock function with given fields: ctx, policyPackage, targets
+func (_m *CheckpointClient) InstallPolicy(ctx context.Context, policyPackage string, targets []string) (string, error) {
+	ret := _m.Called(ctx, policyPackage, targets)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) string); ok {
+		r0 = rf(ctx, policyPackage, targets)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, policyPackage, targets)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Login provides a mock function with given fields: ctx
+func (_m *CheckpointClient) Login(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Logout provides a mock function with given fields: ctx
+func (_m *CheckpointClient) Logout(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Publish provides a mock function with given fields: ctx, sessionUID
+func (_m *CheckpointClient) Publish(ctx context.Context, sessionUID string) (string, error) {
+	ret := _m.Called(ctx, sessionUID)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, sessionUID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, sessionUID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Sessions provides a mock function with given fields: ctx, user
+func (_m *CheckpointClient) Sessions(ctx context.Context, user string) ([]string, error) {
+	ret := _m.Called(ctx, user)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, string) []string); ok {
+		r0 = rf(ctx, user)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, user)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// WaitForTask provides a mock function with given fields: ctx, taskID, sleep
+func (_m *CheckpointClient) WaitForTask(ctx context.Context, taskID string, sleep time.Duration) error {
+	ret := _m.Called(ctx, taskID, sleep)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, taskID, sleep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewCheckpointClient interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCheckpointClient creates a new instance of CheckpointClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCheckpointClient(t mockConstructorTestingTNewCheckpointClient) *CheckpointClient {
+	mock := &CheckpointClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
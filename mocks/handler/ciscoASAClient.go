@@ -0,0 +1,43 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// CiscoASAClient is an autogenerated mock type for the ciscoASAClient type
+type CiscoASAClient struct {
+	mock.Mock
+}
+
+// WriteMemory provides a mock function with given fields: ctx
+func (_m *CiscoASAClient) WriteMemory(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewCiscoASAClient interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewCiscoASAClient creates a new instance of CiscoASAClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewCiscoASAClient(t mockConstructorTestingTNewCiscoASAClient) *CiscoASAClient {
+	mock := &CiscoASAClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,110 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// FMCClient is an autogenerated mock type for the fmcClient type
+type FMCClient struct {
+	mock.Mock
+}
+
+// Deploy provides a mock function with given fields: ctx, version, devices
+func (_m *FMCClient) Deploy(ctx context.Context, version string, devices []string) (string, error) {
+	ret := _m.Called(ctx, version, devices)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) string); ok {
+		r0 = rf(ctx, version, devices)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, version, devices)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeployableDevices provides a mock function with given fields: ctx
+func (_m *FMCClient) DeployableDevices(ctx context.Context) (string, []string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context) string); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(context.Context) []string); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 error
+	if rf, ok := ret.Get(2).(func(context.Context) error); ok {
+		r2 = rf(ctx)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Login provides a mock function with given fields: ctx
+func (_m *FMCClient) Login(ctx context.Context) error {
+	ret := _m.Called(ctx)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context) error); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// WaitForTask provides a mock function with given fields: ctx, taskID, sleep
+func (_m *FMCClient) WaitForTask(ctx context.Context, taskID string, sleep time.Duration) error {
+	ret := _m.Called(ctx, taskID, sleep)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, time.Duration) error); ok {
+		r0 = rf(ctx, taskID, sleep)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewFMCClient interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFMCClient creates a new instance of FMCClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFMCClient(t mockConstructorTestingTNewFMCClient) *FMCClient {
+	mock := &FMCClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
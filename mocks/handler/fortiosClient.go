@@ -0,0 +1,43 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// FortiosClient is an autogenerated mock type for the fortiosClient type
+type FortiosClient struct {
+	mock.Mock
+}
+
+// SaveRevision provides a mock function with given fields: ctx, comment
+func (_m *FortiosClient) SaveRevision(ctx context.Context, comment string) error {
+	ret := _m.Called(ctx, comment)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, comment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewFortiosClient interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewFortiosClient creates a new instance of FortiosClient. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewFortiosClient(t mockConstructorTestingTNewFortiosClient) *FortiosClient {
+	mock := &FortiosClient{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
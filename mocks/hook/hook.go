@@ -0,0 +1,78 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Hook is an autogenerated mock type for the Hook type
+type Hook struct {
+	mock.Mock
+}
+
+// Name provides a mock function with given fields:
+func (_m *Hook) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Run provides a mock function with given fields: ctx, input
+func (_m *Hook) Run(ctx context.Context, input []byte) (string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(context.Context, []byte) string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, []byte) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Stage provides a mock function with given fields:
+func (_m *Hook) Stage() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewHook interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewHook creates a new instance of Hook. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewHook(t mockConstructorTestingTNewHook) *Hook {
+	mock := &Hook{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
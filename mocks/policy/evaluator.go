@@ -0,0 +1,80 @@
+// Code generated by mockery v2.13.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	mock "github.com/stretchr/testify/mock"
+)
+
+// Evaluator is an autogenerated mock type for the Evaluator type
+type Evaluator struct {
+	mock.Mock
+}
+
+// EnforcementLevel provides a mock function with given fields:
+func (_m *Evaluator) EnforcementLevel() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+// Evaluate provides a mock function with given fields: ctx, input
+func (_m *Evaluator) Evaluate(ctx context.Context, input interface{}) ([]string, error) {
+	ret := _m.Called(ctx, input)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context, interface{}) []string); ok {
+		r0 = rf(ctx, input)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, interface{}) error); ok {
+		r1 = rf(ctx, input)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Name provides a mock function with given fields:
+func (_m *Evaluator) Name() string {
+	ret := _m.Called()
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func() string); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
+type mockConstructorTestingTNewEvaluator interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewEvaluator creates a new instance of Evaluator. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewEvaluator(t mockConstructorTestingTNewEvaluator) *Evaluator {
+	mock := &Evaluator{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -10,6 +10,12 @@ import (
 	event "github.com/hashicorp/consul-terraform-sync/state/event"
 
 	mock "github.com/stretchr/testify/mock"
+
+	registry "github.com/hashicorp/consul-terraform-sync/registry"
+
+	rollout "github.com/hashicorp/consul-terraform-sync/rollout"
+
+	tfjson "github.com/hashicorp/terraform-json"
 )
 
 // Server is an autogenerated mock type for the Server type
@@ -54,6 +60,68 @@ func (_m *Server) Events(ctx context.Context, taskName string) (map[string][]eve
 	return r0, r1
 }
 
+// Reload provides a mock function with given fields: ctx
+func (_m *Server) Reload(ctx context.Context) ([]string, []string, []string, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []string
+	if rf, ok := ret.Get(0).(func(context.Context) []string); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	var r1 []string
+	if rf, ok := ret.Get(1).(func(context.Context) []string); ok {
+		r1 = rf(ctx)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]string)
+		}
+	}
+
+	var r2 []string
+	if rf, ok := ret.Get(2).(func(context.Context) []string); ok {
+		r2 = rf(ctx)
+	} else {
+		if ret.Get(2) != nil {
+			r2 = ret.Get(2).([]string)
+		}
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context) error); ok {
+		r3 = rf(ctx)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// Rollout provides a mock function with given fields: ctx
+func (_m *Server) Rollout(ctx context.Context) (rollout.Rollout, bool) {
+	ret := _m.Called(ctx)
+
+	var r0 rollout.Rollout
+	if rf, ok := ret.Get(0).(func(context.Context) rollout.Rollout); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(rollout.Rollout)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context) bool); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
 // Task provides a mock function with given fields: ctx, taskName
 func (_m *Server) Task(ctx context.Context, taskName string) (config.TaskConfig, error) {
 	ret := _m.Called(ctx, taskName)
@@ -75,6 +143,27 @@ func (_m *Server) Task(ctx context.Context, taskName string) (config.TaskConfig,
 	return r0, r1
 }
 
+// TaskCancel provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskCancel(ctx context.Context, taskName string) (bool, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TaskCreate provides a mock function with given fields: _a0, _a1
 func (_m *Server) TaskCreate(_a0 context.Context, _a1 config.TaskConfig) (config.TaskConfig, error) {
 	ret := _m.Called(_a0, _a1)
@@ -117,13 +206,13 @@ func (_m *Server) TaskCreateAndRun(_a0 context.Context, _a1 config.TaskConfig) (
 	return r0, r1
 }
 
-// TaskDelete provides a mock function with given fields: ctx, taskName
-func (_m *Server) TaskDelete(ctx context.Context, taskName string) error {
-	ret := _m.Called(ctx, taskName)
+// TaskDelete provides a mock function with given fields: ctx, taskName, destroy, cancel
+func (_m *Server) TaskDelete(ctx context.Context, taskName string, destroy bool, cancel bool) error {
+	ret := _m.Called(ctx, taskName, destroy, cancel)
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, taskName)
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool, bool) error); ok {
+		r0 = rf(ctx, taskName, destroy, cancel)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -131,6 +220,29 @@ func (_m *Server) TaskDelete(ctx context.Context, taskName string) error {
 	return r0
 }
 
+// TaskExport provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskExport(ctx context.Context, taskName string) (map[string][]byte, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 map[string][]byte
+	if rf, ok := ret.Get(0).(func(context.Context, string) map[string][]byte); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]byte)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TaskInspect provides a mock function with given fields: _a0, _a1
 func (_m *Server) TaskInspect(_a0 context.Context, _a1 config.TaskConfig) (bool, string, string, error) {
 	ret := _m.Called(_a0, _a1)
@@ -166,6 +278,99 @@ func (_m *Server) TaskInspect(_a0 context.Context, _a1 config.TaskConfig) (bool,
 	return r0, r1, r2, r3
 }
 
+// TaskInspectDestroy provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskInspectDestroy(ctx context.Context, taskName string) (bool, string, string, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 string
+	if rf, ok := ret.Get(1).(func(context.Context, string) string); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Get(1).(string)
+	}
+
+	var r2 string
+	if rf, ok := ret.Get(2).(func(context.Context, string) string); ok {
+		r2 = rf(ctx, taskName)
+	} else {
+		r2 = ret.Get(2).(string)
+	}
+
+	var r3 error
+	if rf, ok := ret.Get(3).(func(context.Context, string) error); ok {
+		r3 = rf(ctx, taskName)
+	} else {
+		r3 = ret.Error(3)
+	}
+
+	return r0, r1, r2, r3
+}
+
+// TaskMigrateState provides a mock function with given fields: ctx, taskName, backend
+func (_m *Server) TaskMigrateState(ctx context.Context, taskName string, backend map[string]interface{}) error {
+	ret := _m.Called(ctx, taskName, backend)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, map[string]interface{}) error); ok {
+		r0 = rf(ctx, taskName, backend)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// TaskModuleUpgrade provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskModuleUpgrade(ctx context.Context, taskName string) (registry.ModuleUpgrade, bool) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 registry.ModuleUpgrade
+	if rf, ok := ret.Get(0).(func(context.Context, string) registry.ModuleUpgrade); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		r0 = ret.Get(0).(registry.ModuleUpgrade)
+	}
+
+	var r1 bool
+	if rf, ok := ret.Get(1).(func(context.Context, string) bool); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	return r0, r1
+}
+
+// TaskState provides a mock function with given fields: ctx, taskName
+func (_m *Server) TaskState(ctx context.Context, taskName string) (*tfjson.State, error) {
+	ret := _m.Called(ctx, taskName)
+
+	var r0 *tfjson.State
+	if rf, ok := ret.Get(0).(func(context.Context, string) *tfjson.State); ok {
+		r0 = rf(ctx, taskName)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*tfjson.State)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, taskName)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // TaskUpdate provides a mock function with given fields: ctx, updateConf, runOp
 func (_m *Server) TaskUpdate(ctx context.Context, updateConf config.TaskConfig, runOp string) (bool, string, string, error) {
 	ret := _m.Called(ctx, updateConf, runOp)
@@ -123,6 +123,20 @@ func (_m *Store) GetTaskEvents(taskName string) map[string][]event.Event {
 	return r0
 }
 
+// SetConfig provides a mock function with given fields: conf
+func (_m *Store) SetConfig(conf config.Config) error {
+	ret := _m.Called(conf)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(config.Config) error); ok {
+		r0 = rf(conf)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SetTask provides a mock function with given fields: taskConf
 func (_m *Store) SetTask(taskConf config.TaskConfig) error {
 	ret := _m.Called(taskConf)
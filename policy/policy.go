@@ -0,0 +1,101 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/logging"
+)
+
+//go:generate mockery --name=Evaluator --filename=evaluator.go --output=../mocks/policy
+
+const (
+	logSystemName = "policy"
+
+	// EnforcementAdvisory records violations of a policy without blocking
+	EnforcementAdvisory = "advisory"
+
+	// EnforcementMandatory blocks the apply when a policy has violations
+	EnforcementMandatory = "mandatory"
+)
+
+// Evaluator evaluates a policy against an input document, such as the JSON
+// representation of a Terraform plan.
+type Evaluator interface {
+	// Name returns the name of the policy
+	Name() string
+
+	// EnforcementLevel returns the enforcement level of the policy
+	EnforcementLevel() string
+
+	// Evaluate evaluates the policy against the input and returns the
+	// violations found. No violations means the input passes the policy.
+	Evaluate(ctx context.Context, input interface{}) ([]string, error)
+}
+
+// Result is the outcome of evaluating a single policy
+type Result struct {
+	Name             string
+	EnforcementLevel string
+	Violations       []string
+}
+
+// Passed returns true when the policy has no violations
+func (r Result) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// MandatoryViolationError is returned when one or more policies with a
+// mandatory enforcement level are violated.
+type MandatoryViolationError struct {
+	Policies []string
+}
+
+// Error returns an error string
+func (e *MandatoryViolationError) Error() string {
+	return fmt.Sprintf("mandatory policy check failed: %s",
+		strings.Join(e.Policies, ", "))
+}
+
+// Evaluate evaluates all of the policies against the input. Every policy is
+// evaluated, regardless of earlier failures, so that all results can be
+// recorded. An error is returned if a policy could not be evaluated or if any
+// mandatory policy has violations.
+func Evaluate(ctx context.Context, evaluators []Evaluator, input interface{}) ([]Result, error) {
+	logger := logging.FromContext(ctx).Named(logSystemName)
+
+	results := make([]Result, 0, len(evaluators))
+	var failed []string
+	for _, e := range evaluators {
+		violations, err := e.Evaluate(ctx, input)
+		if err != nil {
+			logger.Error("error evaluating policy", "policy", e.Name(), "error", err)
+			return results, fmt.Errorf("error evaluating policy '%s': %s", e.Name(), err)
+		}
+
+		r := Result{
+			Name:             e.Name(),
+			EnforcementLevel: e.EnforcementLevel(),
+			Violations:       violations,
+		}
+		results = append(results, r)
+
+		if r.Passed() {
+			logger.Trace("policy passed", "policy", r.Name)
+			continue
+		}
+
+		logger.Warn("policy violated", "policy", r.Name,
+			"enforcement_level", r.EnforcementLevel, "violations", r.Violations)
+		if r.EnforcementLevel == EnforcementMandatory {
+			failed = append(failed, r.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return results, &MandatoryViolationError{Policies: failed}
+	}
+
+	return results, nil
+}
@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	mocks "github.com/hashicorp/consul-terraform-sync/mocks/policy"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	newEvaluator := func(name, level string, violations []string, err error) Evaluator {
+		e := new(mocks.Evaluator)
+		e.On("Name").Return(name)
+		e.On("EnforcementLevel").Return(level)
+		e.On("Evaluate", mock.Anything, mock.Anything).Return(violations, err)
+		return e
+	}
+
+	cases := []struct {
+		name       string
+		evaluators []Evaluator
+		expected   []Result
+		expectErr  bool
+	}{
+		{
+			"no_policies",
+			nil,
+			[]Result{},
+			false,
+		},
+		{
+			"all_pass",
+			[]Evaluator{
+				newEvaluator("a", EnforcementMandatory, nil, nil),
+				newEvaluator("b", EnforcementAdvisory, nil, nil),
+			},
+			[]Result{
+				{Name: "a", EnforcementLevel: EnforcementMandatory},
+				{Name: "b", EnforcementLevel: EnforcementAdvisory},
+			},
+			false,
+		},
+		{
+			"advisory_violation",
+			[]Evaluator{
+				newEvaluator("a", EnforcementAdvisory, []string{"bad"}, nil),
+			},
+			[]Result{
+				{Name: "a", EnforcementLevel: EnforcementAdvisory, Violations: []string{"bad"}},
+			},
+			false,
+		},
+		{
+			"mandatory_violation",
+			[]Evaluator{
+				newEvaluator("a", EnforcementMandatory, []string{"bad"}, nil),
+				newEvaluator("b", EnforcementAdvisory, nil, nil),
+			},
+			[]Result{
+				{Name: "a", EnforcementLevel: EnforcementMandatory, Violations: []string{"bad"}},
+				{Name: "b", EnforcementLevel: EnforcementAdvisory},
+			},
+			true,
+		},
+		{
+			"evaluation_error",
+			[]Evaluator{
+				newEvaluator("a", EnforcementAdvisory, nil, errors.New("error")),
+			},
+			[]Result{},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			results, err := Evaluate(context.Background(), tc.evaluators, nil)
+			if tc.expectErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, results)
+		})
+	}
+
+	t.Run("mandatory_violation_error", func(t *testing.T) {
+		_, err := Evaluate(context.Background(), []Evaluator{
+			newEvaluator("a", EnforcementMandatory, []string{"bad"}, nil),
+		}, nil)
+		var mErr *MandatoryViolationError
+		assert.True(t, errors.As(err, &mErr))
+		assert.Equal(t, []string{"a"}, mErr.Policies)
+	})
+}
@@ -0,0 +1,218 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+const regoFileExt = ".rego"
+
+var _ Evaluator = (*Rego)(nil)
+
+// RegoConfig configures a Rego policy
+type RegoConfig struct {
+	Name             string
+	Path             string
+	Query            string
+	EnforcementLevel string
+}
+
+// Rego is a policy written in Rego that is evaluated with an embedded
+// evaluator. The query must return the deny results of the policy, a set or
+// array of messages or an object of messages, which are interpreted as the
+// violations of the policy. Queries that are undefined or that return a
+// boolean are errors so that a misconfigured policy does not pass.
+type Rego struct {
+	name             string
+	enforcementLevel string
+	query            rego.PreparedEvalQuery
+}
+
+// NewRego loads the Rego files found at the configured path and compiles the
+// policy query. The query must reference rules that are defined by the loaded
+// files.
+func NewRego(ctx context.Context, conf *RegoConfig) (*Rego, error) {
+	files, err := regoFiles(conf.Path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading policy '%s': %s", conf.Name, err)
+	}
+
+	modules := make(map[string]string, len(files))
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, fmt.Errorf("error reading policy '%s' file %s: %s",
+				conf.Name, f, err)
+		}
+		modules[f] = string(b)
+	}
+
+	compiler, err := ast.CompileModules(modules)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling policy '%s': %s", conf.Name, err)
+	}
+	if err := checkQueryRules(compiler, conf.Query); err != nil {
+		return nil, fmt.Errorf("error with policy '%s' query: %s", conf.Name, err)
+	}
+
+	query, err := rego.New(
+		rego.Query(conf.Query),
+		rego.Compiler(compiler),
+	).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error compiling policy '%s': %s", conf.Name, err)
+	}
+
+	level := conf.EnforcementLevel
+	if level == "" {
+		level = EnforcementMandatory
+	}
+
+	return &Rego{
+		name:             conf.Name,
+		enforcementLevel: level,
+		query:            query,
+	}, nil
+}
+
+// Name returns the name of the policy
+func (r *Rego) Name() string {
+	return r.name
+}
+
+// EnforcementLevel returns the enforcement level of the policy
+func (r *Rego) EnforcementLevel() string {
+	return r.enforcementLevel
+}
+
+// Evaluate evaluates the policy query against the input. An undefined query
+// result is an error, not a pass.
+func (r *Rego) Evaluate(ctx context.Context, input interface{}) ([]string, error) {
+	rs, err := r.query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, err
+	}
+	if len(rs) == 0 {
+		return nil, fmt.Errorf("policy query is undefined, the query must " +
+			"return the deny results of the policy")
+	}
+
+	var violations []string
+	for _, result := range rs {
+		for _, expr := range result.Expressions {
+			v, err := toViolations(expr.Value)
+			if err != nil {
+				return nil, err
+			}
+			violations = append(violations, v...)
+		}
+	}
+	return violations, nil
+}
+
+// checkQueryRules checks that the query references at least one rule and
+// that every rule it references is defined by the compiled modules
+func checkQueryRules(compiler *ast.Compiler, query string) error {
+	body, err := ast.ParseBody(query)
+	if err != nil {
+		return err
+	}
+
+	var refs []ast.Ref
+	ast.WalkRefs(body, func(ref ast.Ref) bool {
+		if ref.HasPrefix(ast.DefaultRootRef) {
+			refs = append(refs, ref)
+		}
+		return false
+	})
+	if len(refs) == 0 {
+		return fmt.Errorf("query '%s' does not reference any policy rules", query)
+	}
+
+	for _, ref := range refs {
+		prefix := ref.ConstantPrefix()
+		if len(compiler.GetRules(prefix)) == 0 {
+			return fmt.Errorf("query references '%s' which is not defined by "+
+				"the policy files", prefix)
+		}
+	}
+	return nil
+}
+
+// toViolations converts the value of a query expression to a list of
+// violation messages. Booleans are rejected since the query must return the
+// deny results of the policy.
+func toViolations(v interface{}) ([]string, error) {
+	switch val := v.(type) {
+	case nil:
+		return nil, nil
+	case bool:
+		return nil, fmt.Errorf("policy query returned a boolean, the query " +
+			"must return the deny results of the policy as a set, array, or " +
+			"object of messages")
+	case string:
+		if val == "" {
+			return nil, nil
+		}
+		return []string{val}, nil
+	case []interface{}:
+		var msgs []string
+		for _, item := range val {
+			msgs = append(msgs, fmt.Sprint(item))
+		}
+		return msgs, nil
+	case map[string]interface{}:
+		keys := make([]string, 0, len(val))
+		for k := range val {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		var msgs []string
+		for _, k := range keys {
+			msgs = append(msgs, fmt.Sprintf("%s: %v", k, val[k]))
+		}
+		return msgs, nil
+	default:
+		return []string{fmt.Sprint(val)}, nil
+	}
+}
+
+// regoFiles returns the Rego files at the path. The path can either be a
+// single file or a directory, which is walked recursively.
+func regoFiles(path string) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	var files []string
+	err = filepath.WalkDir(path, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && filepath.Ext(p) == regoFileExt {
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no %s files found in %s", regoFileExt, path)
+	}
+	return files, nil
+}
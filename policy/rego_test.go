@@ -0,0 +1,187 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewRego(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name      string
+		conf      *RegoConfig
+		expectErr bool
+	}{
+		{
+			"directory",
+			&RegoConfig{
+				Name:  "security",
+				Path:  "testdata/security",
+				Query: "data.terraform.security.deny",
+			},
+			false,
+		},
+		{
+			"file",
+			&RegoConfig{
+				Name:  "security",
+				Path:  "testdata/security/ingress.rego",
+				Query: "data.terraform.security.deny",
+			},
+			false,
+		},
+		{
+			"missing_path",
+			&RegoConfig{
+				Name:  "missing",
+				Path:  "testdata/missing",
+				Query: "data.terraform.deny",
+			},
+			true,
+		},
+		{
+			"undefined_rule",
+			&RegoConfig{
+				Name:  "security",
+				Path:  "testdata/security",
+				Query: "data.terraform.deny",
+			},
+			true,
+		},
+		{
+			"no_rules",
+			&RegoConfig{
+				Name:  "security",
+				Path:  "testdata/security",
+				Query: "input.resource_changes",
+			},
+			true,
+		},
+		{
+			"invalid_query",
+			&RegoConfig{
+				Name:  "invalid",
+				Path:  "testdata/security",
+				Query: "data.terraform[",
+			},
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			r, err := NewRego(context.Background(), tc.conf)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.conf.Name, r.Name())
+			assert.Equal(t, EnforcementMandatory, r.EnforcementLevel())
+		})
+	}
+
+	t.Run("empty_directory", func(t *testing.T) {
+		dir := t.TempDir()
+		err := os.WriteFile(filepath.Join(dir, "README.md"), []byte("docs"), 0644)
+		require.NoError(t, err)
+
+		_, err = NewRego(context.Background(), &RegoConfig{
+			Name:  "empty",
+			Path:  dir,
+			Query: "data.terraform.deny",
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestRego_Evaluate(t *testing.T) {
+	t.Parallel()
+
+	plan := func(cidr string) map[string]interface{} {
+		return map[string]interface{}{
+			"resource_changes": []interface{}{
+				map[string]interface{}{
+					"address": "aws_security_group_rule.ingress",
+					"type":    "aws_security_group_rule",
+					"change": map[string]interface{}{
+						"after": map[string]interface{}{
+							"type":        "ingress",
+							"cidr_blocks": []interface{}{cidr},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	cases := []struct {
+		name      string
+		query     string
+		input     interface{}
+		expected  []string
+		expectErr bool
+	}{
+		{
+			"set_violation",
+			"data.terraform.security.deny",
+			plan("0.0.0.0/0"),
+			[]string{"aws_security_group_rule.ingress allows ingress from 0.0.0.0/0"},
+			false,
+		},
+		{
+			"set_no_violation",
+			"data.terraform.security.deny",
+			plan("10.0.0.0/8"),
+			nil,
+			false,
+		},
+		{
+			"bool_violation",
+			"data.terraform.security.allow",
+			plan("0.0.0.0/0"),
+			nil,
+			true,
+		},
+		{
+			"bool_no_violation",
+			"data.terraform.security.allow",
+			plan("10.0.0.0/8"),
+			nil,
+			true,
+		},
+		{
+			"undefined",
+			"data.terraform.security.deny[msg]",
+			plan("10.0.0.0/8"),
+			nil,
+			true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx := context.Background()
+			r, err := NewRego(ctx, &RegoConfig{
+				Name:  "security",
+				Path:  "testdata/security",
+				Query: tc.query,
+			})
+			require.NoError(t, err)
+
+			violations, err := r.Evaluate(ctx, tc.input)
+			if tc.expectErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, violations)
+		})
+	}
+}
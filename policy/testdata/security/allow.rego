@@ -0,0 +1,5 @@
+package terraform.security
+
+allow {
+	count(deny) == 0
+}
@@ -0,0 +1,9 @@
+package terraform.security
+
+deny[msg] {
+	rc := input.resource_changes[_]
+	rc.type == "aws_security_group_rule"
+	rc.change.after.type == "ingress"
+	rc.change.after.cidr_blocks[_] == "0.0.0.0/0"
+	msg := sprintf("%s allows ingress from 0.0.0.0/0", [rc.address])
+}
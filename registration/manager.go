@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/url"
 	"path"
+	"time"
 
 	"github.com/hashicorp/consul-terraform-sync/client"
 	"github.com/hashicorp/consul-terraform-sync/config"
@@ -30,6 +31,10 @@ const (
 	defaultTimeout        = "2s"
 	defaultTLSSkipVerify  = true
 
+	// Deregistration happens after the context of Start is cancelled, so it
+	// is bounded by its own timeout
+	deregisterTimeout = 10 * time.Second
+
 	logSystemName = "registration"
 )
 
@@ -115,7 +120,9 @@ func (m *ServiceRegistrationManager) Start(ctx context.Context) error {
 
 	// Wait until the context is cancelled, initiate deregistration
 	<-ctx.Done()
-	err = m.deregister(ctx)
+	dCtx, cancel := context.WithTimeout(context.Background(), deregisterTimeout)
+	defer cancel()
+	err = m.deregister(dCtx)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,50 @@
+package registry
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+)
+
+// ModulesManifest is the path of the manifest of the modules installed by
+// Terraform, relative to the working directory
+const ModulesManifest = ".terraform/modules/modules.json"
+
+// InstalledModule is a module recorded in the modules manifest
+type InstalledModule struct {
+	// Key is the name of the module call, e.g. the task name
+	Key string `json:"Key"`
+
+	// Source is the source of the module. Terraform records registry sources
+	// with the hostname of the registry.
+	Source string `json:"Source"`
+
+	// Version is the version of modules installed from a module registry
+	Version string `json:"Version"`
+
+	// Dir is the directory of the module relative to the working directory
+	Dir string `json:"Dir"`
+}
+
+// FindInstalledModule returns the module with the key from the modules
+// manifest in the working directory
+func FindInstalledModule(workingDir, key string) (InstalledModule, bool) {
+	content, err := ioutil.ReadFile(filepath.Join(workingDir, ModulesManifest))
+	if err != nil {
+		return InstalledModule{}, false
+	}
+
+	var manifest struct {
+		Modules []InstalledModule `json:"Modules"`
+	}
+	if err = json.Unmarshal(content, &manifest); err != nil {
+		return InstalledModule{}, false
+	}
+
+	for _, m := range manifest.Modules {
+		if m.Key == key {
+			return m, true
+		}
+	}
+	return InstalledModule{}, false
+}
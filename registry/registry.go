@@ -0,0 +1,245 @@
+// Package registry finds the versions of modules in a Terraform module
+// registry, which implements the module registry protocol:
+// https://www.terraform.io/internals/module-registry-protocol
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/go-version"
+)
+
+// DefaultHost is the hostname of module registry addresses without a hostname
+const DefaultHost = "registry.terraform.io"
+
+// ErrNotRegistrySource is returned when checking the versions of a module
+// that is not sourced from a module registry
+var ErrNotRegistrySource = errors.New("module is not from a module registry")
+
+// sourceRegexp matches module registry addresses of the format
+// [<HOSTNAME>/]<NAMESPACE>/<NAME>/<PROVIDER>[//<SUBDIR>]
+var sourceRegexp = regexp.MustCompile(`^(?:([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)+(?::\d+)?)/)?` +
+	`([0-9A-Za-z][0-9A-Za-z_-]*)/([0-9A-Za-z][0-9A-Za-z_-]*)/([0-9a-z]{1,64})(?://(.*))?$`)
+
+// Source is a module registry address
+type Source struct {
+	// Host is the hostname of the registry
+	Host string
+
+	// Path is the path of the module, <NAMESPACE>/<NAME>/<PROVIDER>
+	Path string
+
+	// Subdir is the subdirectory of the module package, if any
+	Subdir string
+}
+
+// ParseSource parses a module registry address. Returns false if the source
+// is not a registry address.
+func ParseSource(source string) (Source, bool) {
+	if strings.Contains(source, "::") {
+		return Source{}, false
+	}
+
+	m := sourceRegexp.FindStringSubmatch(source)
+	if m == nil {
+		return Source{}, false
+	}
+
+	host := m[1]
+	switch host {
+	case "":
+		host = DefaultHost
+	case "github.com", "bitbucket.org":
+		// shorthands for repositories that are fetched with go-getter
+		return Source{}, false
+	}
+	return Source{Host: host, Path: strings.Join(m[2:5], "/"), Subdir: m[5]}, true
+}
+
+// Client requests module information from module registries
+type Client struct {
+	httpClient *http.Client
+}
+
+// NewClient returns a client for module registries. The default HTTP client
+// is used if httpClient is nil.
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{httpClient: httpClient}
+}
+
+// Versions returns the versions of the module that are available in the
+// registry
+func (c *Client) Versions(ctx context.Context, src Source) ([]string, error) {
+	base, err := c.modulesURL(ctx, src.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	versionsURL, err := base.Parse(src.Path + "/versions")
+	if err != nil {
+		return nil, err
+	}
+	var versions struct {
+		Modules []struct {
+			Versions []struct {
+				Version string `json:"version"`
+			} `json:"versions"`
+		} `json:"modules"`
+	}
+	if _, err := c.getJSON(ctx, src.Host, versionsURL.String(), &versions); err != nil {
+		return nil, err
+	}
+
+	var available []string
+	for _, m := range versions.Modules {
+		for _, v := range m.Versions {
+			available = append(available, v.Version)
+		}
+	}
+	return available, nil
+}
+
+// DownloadURL returns the location to fetch the version of the module from.
+// The location is a go-getter address and does not include the subdirectory
+// of the source.
+func (c *Client) DownloadURL(ctx context.Context, src Source, v string) (string, error) {
+	base, err := c.modulesURL(ctx, src.Host)
+	if err != nil {
+		return "", err
+	}
+
+	downloadURL, err := base.Parse(fmt.Sprintf("%s/%s/download", src.Path, v))
+	if err != nil {
+		return "", err
+	}
+	resp, err := c.getJSON(ctx, src.Host, downloadURL.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	location := resp.Header.Get("X-Terraform-Get")
+	if location == "" {
+		return "", fmt.Errorf("registry did not return a location to fetch "+
+			"version %s of the module from", v)
+	}
+
+	// the location is either a go-getter address or a URL path relative to
+	// the download URL
+	if strings.HasPrefix(location, "/") || strings.HasPrefix(location, "./") ||
+		strings.HasPrefix(location, "../") {
+		loc, err := downloadURL.Parse(location)
+		if err != nil {
+			return "", err
+		}
+		return loc.String(), nil
+	}
+	return location, nil
+}
+
+// modulesURL returns the base URL of the modules API of the registry host
+// using service discovery
+func (c *Client) modulesURL(ctx context.Context, host string) (*url.URL, error) {
+	hostURL := &url.URL{Scheme: "https", Host: host, Path: "/"}
+	discoveryURL, _ := hostURL.Parse(".well-known/terraform.json")
+
+	var services map[string]interface{}
+	if _, err := c.getJSON(ctx, host, discoveryURL.String(), &services); err != nil {
+		return nil, err
+	}
+	modules, ok := services["modules.v1"].(string)
+	if !ok {
+		return nil, fmt.Errorf("host %q does not provide a module registry", host)
+	}
+	if !strings.HasSuffix(modules, "/") {
+		modules += "/"
+	}
+	return hostURL.Parse(modules)
+}
+
+// getJSON requests the URL and decodes the JSON response body into v, if v
+// is not nil. Requests are authenticated with the token for the registry host
+// set in the environment the same way as for Terraform, e.g. the token for
+// registry.example.com is set with TF_TOKEN_registry_example_com.
+func (c *Client) getJSON(ctx context.Context, host, u string, v interface{}) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if token := hostToken(host); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("request to %s returned %d status code", u,
+			resp.StatusCode)
+	}
+	if v != nil {
+		if err = json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return nil, err
+		}
+	}
+	return resp, nil
+}
+
+// hostToken returns the token for the registry host from the environment
+func hostToken(host string) string {
+	host = strings.Split(host, ":")[0]
+	name := "TF_TOKEN_" + strings.NewReplacer(".", "_", "-", "__").Replace(host)
+	return os.Getenv(name)
+}
+
+// LatestVersion returns the latest of the versions that meets the
+// constraint. Prereleases are only selected if the constraint is an exact
+// version.
+func LatestVersion(versions []string, constraint string) (string, error) {
+	var c version.Constraints
+	if constraint != "" {
+		var err error
+		if c, err = version.NewConstraint(constraint); err != nil {
+			return "", fmt.Errorf("invalid module version constraint %q: %s",
+				constraint, err)
+		}
+	}
+
+	var candidates []*version.Version
+	for _, s := range versions {
+		v, err := version.NewVersion(s)
+		if err != nil {
+			continue
+		}
+		if v.Prerelease() != "" && constraint != v.Original() {
+			continue
+		}
+		if c != nil && !c.Check(v) {
+			continue
+		}
+		candidates = append(candidates, v)
+	}
+	if len(candidates) == 0 {
+		if constraint == "" {
+			return "", errors.New("no versions of the module are available")
+		}
+		return "", fmt.Errorf("no versions of the module meet the version "+
+			"constraint %q", constraint)
+	}
+
+	sort.Sort(version.Collection(candidates))
+	return candidates[len(candidates)-1].Original(), nil
+}
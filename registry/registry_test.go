@@ -0,0 +1,197 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSource(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		source string
+		host   string
+		path   string
+		subdir string
+		ok     bool
+	}{
+		{
+			source: "hashicorp/consul/aws",
+			host:   DefaultHost,
+			path:   "hashicorp/consul/aws",
+			ok:     true,
+		},
+		{
+			source: "app.terraform.io/example-corp/k8s-cluster/azurerm//modules/nodes",
+			host:   "app.terraform.io",
+			path:   "example-corp/k8s-cluster/azurerm",
+			subdir: "modules/nodes",
+			ok:     true,
+		},
+		{source: "github.com/hashicorp/example"},
+		{source: "github.com/hashicorp/example/module"},
+		{source: "git::https://example.com/vpc.git"},
+		{source: "./modules/basic"},
+		{source: "s3::https://s3-eu-west-1.amazonaws.com/bucket/vpc.zip"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.source, func(t *testing.T) {
+			t.Parallel()
+			src, ok := ParseSource(tc.source)
+			assert.Equal(t, tc.ok, ok)
+			if !tc.ok {
+				return
+			}
+			assert.Equal(t, tc.host, src.Host)
+			assert.Equal(t, tc.path, src.Path)
+			assert.Equal(t, tc.subdir, src.Subdir)
+		})
+	}
+}
+
+func TestLatestVersion(t *testing.T) {
+	t.Parallel()
+
+	versions := []string{"0.9.0", "1.0.0", "1.2.0", "1.10.0", "2.0.0-beta1", "invalid"}
+	cases := []struct {
+		name       string
+		constraint string
+		expected   string
+		err        bool
+	}{
+		{"no constraint", "", "1.10.0", false},
+		{"pessimistic constraint", "~> 1.0.0", "1.0.0", false},
+		{"range constraint", ">= 1.0, < 1.5", "1.2.0", false},
+		{"exact prerelease", "2.0.0-beta1", "2.0.0-beta1", false},
+		{"no match", ">= 3.0", "", true},
+		{"invalid constraint", "latest", "", true},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			v, err := LatestVersion(versions, tc.constraint)
+			if tc.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, v)
+		})
+	}
+}
+
+// newTestRegistry returns a module registry server with the module
+// example/basic/local and the source of the module on the server
+func newTestRegistry(t *testing.T) (*httptest.Server, string) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/terraform.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"modules.v1": "/v1/modules/"})
+	})
+	mux.HandleFunc("/v1/modules/example/basic/local/versions", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		fmt.Fprint(w, `{"modules":[{"versions":[{"version":"1.0.0"},{"version":"1.1.0"},{"version":"2.0.0"}]}]}`)
+	})
+	mux.HandleFunc("/v1/modules/example/basic/local/1.1.0/download", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Terraform-Get", "./archive.tgz")
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewTLSServer(mux)
+	t.Cleanup(server.Close)
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	return server, fmt.Sprintf("%s/example/basic/local", u.Host)
+}
+
+func TestClient(t *testing.T) {
+	server, source := newTestRegistry(t)
+	src, ok := ParseSource(source)
+	require.True(t, ok)
+
+	// the test server host is an IP address with a port
+	host := strings.Split(src.Host, ":")[0]
+	t.Setenv("TF_TOKEN_"+strings.ReplaceAll(host, ".", "_"), "secret")
+
+	c := NewClient(server.Client())
+	ctx := context.Background()
+
+	t.Run("versions", func(t *testing.T) {
+		versions, err := c.Versions(ctx, src)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"1.0.0", "1.1.0", "2.0.0"}, versions)
+	})
+
+	t.Run("download url", func(t *testing.T) {
+		u, err := c.DownloadURL(ctx, src, "1.1.0")
+		require.NoError(t, err)
+		assert.Equal(t, server.URL+"/v1/modules/example/basic/local/1.1.0/archive.tgz", u)
+	})
+
+	t.Run("check upgrade", func(t *testing.T) {
+		u, err := c.CheckUpgrade(ctx, source, "~> 1.0", "1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", u.LatestVersion)
+		assert.True(t, u.Available())
+
+		u, err = c.CheckUpgrade(ctx, source, "~> 1.0", "1.1.0")
+		require.NoError(t, err)
+		assert.False(t, u.Available())
+	})
+
+	t.Run("check upgrade not registry", func(t *testing.T) {
+		_, err := c.CheckUpgrade(ctx, "./modules/basic", "", "")
+		assert.ErrorIs(t, err, ErrNotRegistrySource)
+	})
+
+	t.Run("unauthorized", func(t *testing.T) {
+		t.Setenv("TF_TOKEN_"+strings.ReplaceAll(host, ".", "_"), "")
+		_, err := c.Versions(ctx, src)
+		assert.Error(t, err)
+	})
+}
+
+func TestFindInstalledModule(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, ".terraform", "modules"), 0755))
+	manifest := `{"Modules":[
+		{"Key":"","Source":"","Dir":"."},
+		{"Key":"task","Source":"registry.terraform.io/example/basic/local","Version":"1.1.0","Dir":".terraform/modules/task"}
+	]}`
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, ModulesManifest),
+		[]byte(manifest), 0644))
+
+	m, ok := FindInstalledModule(workingDir, "task")
+	assert.True(t, ok)
+	assert.Equal(t, InstalledModule{
+		Key:     "task",
+		Source:  "registry.terraform.io/example/basic/local",
+		Version: "1.1.0",
+		Dir:     ".terraform/modules/task",
+	}, m)
+
+	_, ok = FindInstalledModule(workingDir, "other")
+	assert.False(t, ok)
+
+	_, ok = FindInstalledModule(t.TempDir(), "task")
+	assert.False(t, ok)
+}
@@ -0,0 +1,79 @@
+package registry
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/go-version"
+)
+
+// ModuleUpgrade is the result of checking the registry for a newer version of
+// a task's module
+type ModuleUpgrade struct {
+	// Source is the registry address of the module
+	Source string `json:"source"`
+
+	// Constraint is the version constraint of the task's module, if any
+	Constraint string `json:"constraint,omitempty"`
+
+	// InstalledVersion is the version of the module that is installed for
+	// the task
+	InstalledVersion string `json:"installed_version"`
+
+	// LatestVersion is the latest version of the module in the registry that
+	// meets the version constraint
+	LatestVersion string `json:"latest_version"`
+
+	// CheckedAt is the time the registry was checked
+	CheckedAt time.Time `json:"checked_at"`
+
+	// Error is the error checking the registry or upgrading the module, if
+	// any
+	Error string `json:"error,omitempty"`
+}
+
+// Available returns whether a newer version than the installed version is
+// available
+func (u ModuleUpgrade) Available() bool {
+	if u.LatestVersion == "" || u.InstalledVersion == "" {
+		return false
+	}
+	latest, err := version.NewVersion(u.LatestVersion)
+	if err != nil {
+		return false
+	}
+	installed, err := version.NewVersion(u.InstalledVersion)
+	if err != nil {
+		return false
+	}
+	return latest.GreaterThan(installed)
+}
+
+// CheckUpgrade returns the latest version of the module in the registry that
+// meets the version constraint along with the installed version
+func (c *Client) CheckUpgrade(ctx context.Context, source, constraint,
+	installedVersion string) (ModuleUpgrade, error) {
+
+	u := ModuleUpgrade{
+		Source:           source,
+		Constraint:       constraint,
+		InstalledVersion: installedVersion,
+		CheckedAt:        time.Now(),
+	}
+
+	src, ok := ParseSource(source)
+	if !ok {
+		return u, fmt.Errorf("module %q: %w", source, ErrNotRegistrySource)
+	}
+	versions, err := c.Versions(ctx, src)
+	if err != nil {
+		return u, err
+	}
+	latest, err := LatestVersion(versions, constraint)
+	if err != nil {
+		return u, err
+	}
+	u.LatestVersion = latest
+	return u, nil
+}
@@ -0,0 +1,144 @@
+// Package rollout tracks the progress of rolling out configuration changes
+// across tasks in batches. The first batch is a canary subset of the tasks and
+// each following batch is only started once the previous batch succeeded.
+package rollout
+
+import (
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+)
+
+const (
+	// StatusInProgress is the status of a rollout or batch that has started
+	// and has not completed yet
+	StatusInProgress = "in_progress"
+
+	// StatusSoaking is the status of a batch whose tasks were updated
+	// successfully and that is waiting for the soak time to elapse
+	StatusSoaking = "soaking"
+
+	// StatusCompleted is the status of a rollout or batch that completed
+	// successfully
+	StatusCompleted = "completed"
+
+	// StatusHalted is the status of a rollout that stopped because a batch
+	// failed
+	StatusHalted = "halted"
+
+	// StatusFailed is the status of a batch with a task that failed
+	StatusFailed = "failed"
+
+	// StatusPending is the status of a batch that has not started
+	StatusPending = "pending"
+)
+
+// Rollout is the progress of rolling out configuration changes across tasks
+type Rollout struct {
+	ID        string     `json:"id"`
+	Status    string     `json:"status"`
+	Batches   []Batch    `json:"batches"`
+	StartTime time.Time  `json:"start_time"`
+	EndTime   *time.Time `json:"end_time,omitempty"`
+
+	// Error is the reason the rollout halted, if any
+	Error string `json:"error,omitempty"`
+}
+
+// Batch is a set of tasks that are updated together
+type Batch struct {
+	Canary bool     `json:"canary"`
+	Tasks  []string `json:"tasks"`
+	Status string   `json:"status"`
+
+	// FailedTasks are the tasks of the batch that failed to update or had
+	// failed runs once the soak time elapsed
+	FailedTasks []string `json:"failed_tasks,omitempty"`
+}
+
+// New returns a rollout of the tasks. The first canarySize tasks are the
+// canary batch and the remaining tasks are split into batches of batchSize.
+func New(tasks []string, canarySize, batchSize int) *Rollout {
+	if canarySize < 1 {
+		canarySize = 1
+	}
+	if batchSize < 1 {
+		batchSize = 1
+	}
+
+	id, _ := uuid.GenerateUUID()
+	r := &Rollout{
+		ID:        id,
+		Status:    StatusInProgress,
+		StartTime: time.Now(),
+	}
+
+	size, canary := canarySize, true
+	for len(tasks) > 0 {
+		if size > len(tasks) {
+			size = len(tasks)
+		}
+		r.Batches = append(r.Batches, Batch{
+			Canary: canary,
+			Tasks:  append([]string{}, tasks[:size]...),
+			Status: StatusPending,
+		})
+		tasks = tasks[size:]
+		size, canary = batchSize, false
+	}
+	return r
+}
+
+// Halt stops the rollout with the error. Batches that have not started
+// remain pending.
+func (r *Rollout) Halt(err error) {
+	r.Status = StatusHalted
+	if err != nil {
+		r.Error = err.Error()
+	}
+	r.end()
+}
+
+// Complete marks the rollout as completed
+func (r *Rollout) Complete() {
+	r.Status = StatusCompleted
+	r.end()
+}
+
+// PendingTasks returns the tasks of the batches that have not started
+func (r *Rollout) PendingTasks() []string {
+	var tasks []string
+	for _, b := range r.Batches {
+		if b.Status == StatusPending {
+			tasks = append(tasks, b.Tasks...)
+		}
+	}
+	return tasks
+}
+
+func (r *Rollout) end() {
+	now := time.Now()
+	r.EndTime = &now
+}
+
+// Copy returns a deep copy of the rollout
+func (r *Rollout) Copy() *Rollout {
+	if r == nil {
+		return nil
+	}
+
+	o := *r
+	if r.EndTime != nil {
+		end := *r.EndTime
+		o.EndTime = &end
+	}
+	o.Batches = make([]Batch, len(r.Batches))
+	for i, b := range r.Batches {
+		o.Batches[i] = b
+		o.Batches[i].Tasks = append([]string{}, b.Tasks...)
+		if b.FailedTasks != nil {
+			o.Batches[i].FailedTasks = append([]string{}, b.FailedTasks...)
+		}
+	}
+	return &o
+}
@@ -0,0 +1,102 @@
+package rollout
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name       string
+		tasks      []string
+		canarySize int
+		batchSize  int
+		expected   [][]string
+	}{
+		{
+			"canary and batches",
+			[]string{"a", "b", "c", "d", "e", "f"},
+			1,
+			2,
+			[][]string{{"a"}, {"b", "c"}, {"d", "e"}, {"f"}},
+		},
+		{
+			"canary only",
+			[]string{"a", "b"},
+			3,
+			2,
+			[][]string{{"a", "b"}},
+		},
+		{
+			"invalid sizes",
+			[]string{"a", "b", "c"},
+			0,
+			0,
+			[][]string{{"a"}, {"b"}, {"c"}},
+		},
+		{
+			"no tasks",
+			nil,
+			1,
+			1,
+			nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := New(tc.tasks, tc.canarySize, tc.batchSize)
+			assert.NotEmpty(t, r.ID)
+			assert.Equal(t, StatusInProgress, r.Status)
+
+			var actual [][]string
+			for i, b := range r.Batches {
+				assert.Equal(t, i == 0, b.Canary)
+				assert.Equal(t, StatusPending, b.Status)
+				actual = append(actual, b.Tasks)
+			}
+			assert.Equal(t, tc.expected, actual)
+		})
+	}
+}
+
+func TestRollout_Halt(t *testing.T) {
+	t.Parallel()
+
+	r := New([]string{"a", "b", "c"}, 1, 1)
+	r.Batches[0].Status = StatusFailed
+	r.Batches[0].FailedTasks = []string{"a"}
+	r.Halt(errors.New("canary failed"))
+
+	assert.Equal(t, StatusHalted, r.Status)
+	assert.Equal(t, "canary failed", r.Error)
+	require.NotNil(t, r.EndTime)
+	assert.Equal(t, []string{"b", "c"}, r.PendingTasks())
+}
+
+func TestRollout_Copy(t *testing.T) {
+	t.Parallel()
+
+	r := New([]string{"a", "b"}, 1, 1)
+	r.Batches[0].FailedTasks = []string{"a"}
+	r.Complete()
+
+	cp := r.Copy()
+	assert.Equal(t, r, cp)
+
+	cp.Batches[0].Tasks[0] = "changed"
+	cp.Batches[0].FailedTasks[0] = "changed"
+	assert.Equal(t, "a", r.Batches[0].Tasks[0])
+	assert.Equal(t, "a", r.Batches[0].FailedTasks[0])
+
+	var nilRollout *Rollout
+	assert.Nil(t, nilRollout.Copy())
+}
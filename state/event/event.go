@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
@@ -25,6 +26,26 @@ type Event struct {
 	TaskName   string    `json:"task_name"`
 	EventError *Error    `json:"error"`
 
+	// Cancelled is true if the task run was cancelled or timed out before it
+	// completed.
+	Cancelled bool `json:"cancelled,omitempty"`
+
+	// Policies are the results of the policy checks evaluated against the
+	// task's plan before it was applied.
+	Policies []PolicyResult `json:"policies,omitempty"`
+
+	// Hooks are the results of the hooks that ran during the task run
+	Hooks []HookResult `json:"hooks,omitempty"`
+
+	// Changes summarizes the resource changes of the task run. It is only
+	// recorded when the changes were planned before they were applied.
+	Changes *ChangeSummary `json:"changes,omitempty"`
+
+	// Logs is the Terraform output of the task run with sensitive values
+	// redacted. It is excluded from the event's JSON since it can be large and
+	// is retrieved separately.
+	Logs string `json:"-"`
+
 	// Config is deprecated in v0.5. This is configuration details about the
 	// task rather than status information. Users should switch to using the
 	// Get Task API to request the task's config information.
@@ -38,6 +59,33 @@ type Error struct {
 	Message string `json:"message"`
 }
 
+// PolicyResult captures the result of evaluating a policy against a task's
+// plan
+type PolicyResult struct {
+	Name             string   `json:"name"`
+	EnforcementLevel string   `json:"enforcement_level"`
+	Passed           bool     `json:"passed"`
+	Violations       []string `json:"violations,omitempty"`
+}
+
+// HookResult captures the result and output of a hook that ran during a task
+// run
+type HookResult struct {
+	Name    string `json:"name"`
+	Stage   string `json:"stage"`
+	Success bool   `json:"success"`
+	Output  string `json:"output,omitempty"`
+	Error   string `json:"error,omitempty"`
+}
+
+// ChangeSummary counts the resource changes of a task run by action. A
+// replaced resource is counted as both added and destroyed.
+type ChangeSummary struct {
+	Add     int `json:"add"`
+	Change  int `json:"change"`
+	Destroy int `json:"destroy"`
+}
+
 // Config provides details on an event's task configuration. It is deprecated
 // in v0.5 and should be removed in 0.8
 type Config struct {
@@ -64,6 +112,26 @@ func NewEvent(taskName string, config *Config) (*Event, error) {
 	}, nil
 }
 
+type ctxKey struct{}
+
+// WithContext stores the event in the context so that the components running
+// the task can record details on the event.
+func WithContext(ctx context.Context, e *Event) context.Context {
+	return context.WithValue(ctx, ctxKey{}, e)
+}
+
+// FromContext returns the event stored in the context. Returns nil if the
+// context does not have an event.
+func FromContext(ctx context.Context) *Event {
+	if ctx == nil {
+		return nil
+	}
+	if e, ok := ctx.Value(ctxKey{}).(*Event); ok {
+		return e
+	}
+	return nil
+}
+
 // Start sets the start time on an event. Can only be called once.
 func (e *Event) Start() {
 	if !e.StartTime.IsZero() {
@@ -1,6 +1,7 @@
 package event
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -147,6 +148,20 @@ func TestEvent_Start(t *testing.T) {
 	}
 }
 
+func TestEvent_Context(t *testing.T) {
+	t.Parallel()
+
+	t.Run("event", func(t *testing.T) {
+		e := &Event{ID: "123"}
+		ctx := WithContext(context.Background(), e)
+		assert.Equal(t, e, FromContext(ctx))
+	})
+
+	t.Run("no_event", func(t *testing.T) {
+		assert.Nil(t, FromContext(context.Background()))
+	})
+}
+
 func TestEvent_End(t *testing.T) {
 	t.Parallel()
 
@@ -44,6 +44,18 @@ func (s *InMemoryStore) GetConfig() config.Config {
 	return *s.conf.Copy()
 }
 
+// SetConfig replaces the CTS configuration, excluding the task
+// configurations. The returned error will always be nil.
+func (s *InMemoryStore) SetConfig(conf config.Config) error {
+	s.conf.mu.Lock()
+	defer s.conf.mu.Unlock()
+
+	tasks := s.conf.Tasks
+	s.conf.Config = *conf.Copy()
+	s.conf.Tasks = tasks
+	return nil
+}
+
 // GetAllTasks returns a copy of the configs for all the tasks
 func (s *InMemoryStore) GetAllTasks() config.TaskConfigs {
 	s.conf.mu.RLock()
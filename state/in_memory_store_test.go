@@ -111,6 +111,41 @@ func Test_InMemoryStore_GetConfig(t *testing.T) {
 	})
 }
 
+func Test_InMemoryStore_SetConfig(t *testing.T) {
+	t.Parallel()
+
+	tasks := config.TaskConfigs{
+		{Name: config.String("task_a")},
+	}
+	store := NewInMemoryStore(&config.Config{
+		Port:  config.Int(1234),
+		Tasks: &tasks,
+	})
+
+	newTasks := config.TaskConfigs{
+		{Name: config.String("task_b")},
+	}
+	newConf := &config.Config{
+		Port:     config.Int(5678),
+		LogLevel: config.String("DEBUG"),
+		Tasks:    &newTasks,
+	}
+	err := store.SetConfig(*newConf)
+	require.NoError(t, err)
+
+	actual := store.GetConfig()
+	assert.Equal(t, 5678, config.IntVal(actual.Port))
+	assert.Equal(t, "DEBUG", config.StringVal(actual.LogLevel))
+
+	// tasks are not replaced
+	assert.Equal(t, tasks, store.GetAllTasks())
+
+	// stored config is dereferenced from the input
+	*newConf.Port = 0
+	actual = store.GetConfig()
+	assert.Equal(t, 5678, config.IntVal(actual.Port))
+}
+
 func Test_InMemoryStore_GetAllTasks(t *testing.T) {
 	t.Parallel()
 
@@ -454,7 +489,7 @@ func Test_InMemoryStore_AddTaskEvent(t *testing.T) {
 			actualEvents := actual[taskName]
 			exists := false
 			for _, actualEvent := range actualEvents {
-				if actualEvent == tc.event {
+				if assert.ObjectsAreEqual(actualEvent, tc.event) {
 					exists = true
 				}
 			}
@@ -13,6 +13,10 @@ type Store interface {
 	// GetConfig returns a copy of the CTS configuration
 	GetConfig() config.Config
 
+	// SetConfig replaces the CTS configuration, excluding the task
+	// configurations which are set with SetTask and DeleteTask
+	SetConfig(conf config.Config) error
+
 	// GetAllTasks returns a copy of the configs for all the tasks
 	GetAllTasks() config.TaskConfigs
 
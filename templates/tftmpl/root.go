@@ -1,6 +1,7 @@
 package tftmpl
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"os"
@@ -24,6 +25,10 @@ const (
 	// modules.
 	TerraformRequiredVersion = version.CompatibleTerraformVersionConstraint
 
+	// OpenTofuRequiredVersion is the version constraint pinned to the
+	// generated root module when the root module is run with OpenTofu.
+	OpenTofuRequiredVersion = version.CompatibleOpenTofuVersionConstraint
+
 	// RootFilename is the file name for the root module.
 	RootFilename = "main.tf"
 
@@ -76,6 +81,15 @@ var (
 # Description: %s
 `
 
+	// internalProviderAttrs are provider attributes that configure the
+	// provider handlers and are not passed to the Terraform provider
+	internalProviderAttrs = map[string]bool{
+		"auto_commit":                      true,
+		"auto_commit_insecure_skip_verify": true,
+		"install_policy_package":           true,
+		"install_policy_targets":           true,
+	}
+
 	rootFileFuncs = map[string]tfFileFunc{
 		RootFilename:       newMainTF,
 		VarsFilename:       newVariablesTF,
@@ -102,6 +116,11 @@ type tfFileFunc func(io.Writer, string, *RootModuleInputData) error
 // RootModuleInputData is the input data used to generate the root module
 type RootModuleInputData struct {
 	TerraformVersion *goVersion.Version
+
+	// RequiredVersion is the version constraint pinned to the root module.
+	// Defaults to TerraformRequiredVersion.
+	RequiredVersion string
+
 	Backend          map[string]interface{}
 	Providers        []hcltmpl.NamedBlock
 	ProviderInfo     map[string]interface{}
@@ -154,6 +173,49 @@ func InitRootModule(input *RootModuleInputData) error {
 	return initModule(input, fileFuncs)
 }
 
+// InitTFVarsTemplate writes only the template for terraform.tfvars to disk.
+// It is used by drivers that consume the rendered module inputs without
+// running a Terraform root module.
+func InitTFVarsTemplate(input *RootModuleInputData) error {
+	input.init()
+
+	return initModule(input, map[string]tfFileFunc{
+		TFVarsTmplFilename: newTFVarsTmpl,
+	})
+}
+
+// RenderRootModule generates the files of the root module that are used to
+// run the task, without the template for terraform.tfvars, and returns their
+// contents keyed by file name. Unlike InitRootModule, no files are written to
+// disk.
+func RenderRootModule(input *RootModuleInputData) (map[string][]byte, error) {
+	input.init()
+
+	fileFuncs := map[string]tfFileFunc{
+		ProvidersTFVarsFilename: newProvidersTFVars,
+	}
+	if len(input.Variables) != 0 {
+		fileFuncs[VarsTFVarsFileName] = newVariablesTFVars
+	}
+	for k, v := range rootFileFuncs {
+		fileFuncs[k] = v
+	}
+
+	files := make(map[string][]byte, len(fileFuncs))
+	for filename, newFileFunc := range fileFuncs {
+		if filename == ModuleVarsFilename && len(input.Variables) == 0 {
+			continue
+		}
+
+		var buf bytes.Buffer
+		if err := newFileFunc(&buf, filename, input); err != nil {
+			return nil, err
+		}
+		files[filename] = buf.Bytes()
+	}
+	return files, nil
+}
+
 func initModule(input *RootModuleInputData, fileFuncs map[string]tfFileFunc) error {
 	for filename, newFileFunc := range fileFuncs {
 		if filename == ModuleVarsFilename && len(input.Variables) == 0 {
@@ -207,7 +269,11 @@ func newMainTF(w io.Writer, filename string, input *RootModuleInputData) error {
 	hclFile := hclwrite.NewEmptyFile()
 	rootBody := hclFile.Body()
 	rootBody.AppendNewline()
-	appendRootTerraformBlock(rootBody, input.backend, input.ProviderInfo)
+	requiredVersion := input.RequiredVersion
+	if requiredVersion == "" {
+		requiredVersion = TerraformRequiredVersion
+	}
+	appendRootTerraformBlock(rootBody, requiredVersion, input.backend, input.ProviderInfo)
 	rootBody.AppendNewline()
 	appendRootProviderBlocks(rootBody, input.Providers)
 	rootBody.AppendNewline()
@@ -222,12 +288,12 @@ func newMainTF(w io.Writer, filename string, input *RootModuleInputData) error {
 
 // appendRootTerraformBlock appends the Terraform block with version constraint
 // and backend.
-func appendRootTerraformBlock(body *hclwrite.Body, backend *hcltmpl.NamedBlock,
-	providerInfo map[string]interface{}) {
+func appendRootTerraformBlock(body *hclwrite.Body, requiredVersion string,
+	backend *hcltmpl.NamedBlock, providerInfo map[string]interface{}) {
 
 	tfBlock := body.AppendNewBlock("terraform", nil)
 	tfBody := tfBlock.Body()
-	tfBody.SetAttributeValue("required_version", cty.StringVal(TerraformRequiredVersion))
+	tfBody.SetAttributeValue("required_version", cty.StringVal(requiredVersion))
 
 	if len(providerInfo) != 0 {
 		requiredProvidersBody := tfBody.AppendNewBlock("required_providers", nil).Body()
@@ -271,8 +337,9 @@ func appendRootProviderBlocks(body *hclwrite.Body, providers []hcltmpl.NamedBloc
 			if attr == "alias" {
 				continue
 			}
-			// auto_commit is an internal setting
-			if attr == "auto_commit" {
+			// auto_commit* and install_policy_* are internal settings for the
+			// provider handlers
+			if internalProviderAttrs[attr] {
 				continue
 			}
 
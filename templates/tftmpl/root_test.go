@@ -1,11 +1,16 @@
 package tftmpl
 
 import (
+	"io/ioutil"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/consul-terraform-sync/templates/hcltmpl"
+	goVersion "github.com/hashicorp/go-version"
 	"github.com/hashicorp/hcl/v2/hclwrite"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zclconf/go-cty/cty"
 )
 
 func TestAppendRootTerraformBlock_backend(t *testing.T) {
@@ -101,7 +106,7 @@ func TestAppendRootTerraformBlock_backend(t *testing.T) {
 				b := hcltmpl.NewNamedBlock(tc.rawBackend)
 				backend = &b
 			}
-			appendRootTerraformBlock(body, backend, nil)
+			appendRootTerraformBlock(body, TerraformRequiredVersion, backend, nil)
 
 			content := hclFile.Bytes()
 			content = hclwrite.Format(content)
@@ -143,6 +148,16 @@ func TestAppendRootProviderBlocks(t *testing.T) {
 			}},
 			`provider "foo" {
 }
+`,
+		}, {
+			"internal install_policy leak",
+			map[string]interface{}{"checkpoint": map[string]interface{}{
+				"install_policy_package":           "standard",
+				"install_policy_targets":           []interface{}{"gw1"},
+				"auto_commit_insecure_skip_verify": true,
+			}},
+			`provider "checkpoint" {
+}
 `,
 		}, {
 			"invalid structure",
@@ -273,3 +288,98 @@ module "test" {
 		})
 	}
 }
+
+func TestRenderRootModule(t *testing.T) {
+	t.Parallel()
+
+	newInput := func(path string) *RootModuleInputData {
+		return &RootModuleInputData{
+			TerraformVersion: goVersion.Must(goVersion.NewSemver("1.2.0")),
+			Backend: map[string]interface{}{
+				"consul": map[string]interface{}{
+					"path": "consul-terraform-sync/terraform",
+				},
+			},
+			Providers: []hcltmpl.NamedBlock{hcltmpl.NewNamedBlock(
+				map[string]interface{}{
+					"testProvider": map[string]interface{}{
+						"attr": "value",
+					},
+				})},
+			ProviderInfo: map[string]interface{}{
+				"testProvider": map[string]interface{}{
+					"source": "namespace/testProvider",
+				},
+			},
+			Task: Task{
+				Name:   "test",
+				Module: "namespace/consul-terraform-sync/consul",
+			},
+			Variables: hcltmpl.Variables{
+				"one": cty.NumberIntVal(1),
+			},
+			Templates: []Template{&ServicesTemplate{Names: []string{"api"}}},
+			Path:      path,
+			FilePerms: 0640,
+		}
+	}
+
+	files, err := RenderRootModule(newInput(""))
+	require.NoError(t, err)
+
+	// the rendered files are the same as the files written to disk, except
+	// for the template of terraform.tfvars
+	dir := t.TempDir()
+	require.NoError(t, InitRootModule(newInput(dir)))
+
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, len(infos)-1)
+	for _, info := range infos {
+		if info.Name() == TFVarsTmplFilename {
+			assert.NotContains(t, files, info.Name())
+			continue
+		}
+		expected, err := ioutil.ReadFile(filepath.Join(dir, info.Name()))
+		require.NoError(t, err)
+		assert.Equal(t, string(expected), string(files[info.Name()]), info.Name())
+	}
+}
+
+func TestInitTFVarsTemplate(t *testing.T) {
+	t.Parallel()
+
+	newInput := func(path string) *RootModuleInputData {
+		return &RootModuleInputData{
+			TerraformVersion: goVersion.Must(goVersion.NewSemver("1.2.0")),
+			Task: Task{
+				Name:   "test",
+				Module: "./scripts/run.sh",
+			},
+			Variables: hcltmpl.Variables{
+				"one": cty.NumberIntVal(1),
+			},
+			Templates: []Template{&ServicesTemplate{Names: []string{"api"}}},
+			Path:      path,
+			FilePerms: 0640,
+		}
+	}
+
+	dir := t.TempDir()
+	require.NoError(t, InitTFVarsTemplate(newInput(dir)))
+
+	infos, err := ioutil.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, infos, 1)
+	assert.Equal(t, TFVarsTmplFilename, infos[0].Name())
+
+	// the template is the same as the one written for the root module
+	rootDir := t.TempDir()
+	require.NoError(t, InitRootModule(newInput(rootDir)))
+
+	expected, err := ioutil.ReadFile(filepath.Join(rootDir, TFVarsTmplFilename))
+	require.NoError(t, err)
+	actual, err := ioutil.ReadFile(filepath.Join(dir, TFVarsTmplFilename))
+	require.NoError(t, err)
+	assert.Equal(t, string(expected), string(actual))
+}
@@ -0,0 +1,272 @@
+package validate
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/hcl/hcl/ast"
+	hclparser "github.com/hashicorp/hcl/hcl/parser"
+	"github.com/hashicorp/hcl/hcl/token"
+	jsonparser "github.com/hashicorp/hcl/json/parser"
+)
+
+// Location is a position in a file
+type Location struct {
+	Filename string
+	Line     int
+	Column   int
+}
+
+// IsValid returns whether the location refers to a position in a file
+func (l Location) IsValid() bool {
+	return l.Filename != "" && l.Line > 0
+}
+
+// String returns the location in the format "file:line:column"
+func (l Location) String() string {
+	if !l.IsValid() {
+		return l.Filename
+	}
+	if l.Column > 0 {
+		return fmt.Sprintf("%s:%d:%d", l.Filename, l.Line, l.Column)
+	}
+	return fmt.Sprintf("%s:%d", l.Filename, l.Line)
+}
+
+// taskLocation is the location of a task block and of its attributes and
+// nested blocks, keyed by name
+type taskLocation struct {
+	block Location
+	attrs map[string]Location
+}
+
+// locations indexes where tasks are configured in the configuration files
+type locations struct {
+	tasks map[string]taskLocation
+}
+
+// task returns the location of an attribute or nested block of a task. It
+// falls back to the location of the task block if the attribute is not
+// configured, and is invalid if the task is not found.
+func (l *locations) task(name, attr string) Location {
+	t, ok := l.tasks[name]
+	if !ok {
+		return Location{}
+	}
+	if loc, ok := t.attrs[attr]; ok && attr != "" {
+		return loc
+	}
+	return t.block
+}
+
+// configFiles returns the configuration files for the paths in the order
+// they are loaded. Directories are expanded to the configuration files they
+// directly contain, and empty files are skipped, as they are by
+// config.BuildConfig.
+func configFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		stat, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if stat.Mode().IsRegular() {
+			if stat.Size() > 0 && supportedFile(path) {
+				files = append(files, path)
+			}
+			continue
+		}
+
+		infos, err := ioutil.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, info := range infos {
+			if info.IsDir() || info.Size() == 0 || !supportedFile(info.Name()) {
+				continue
+			}
+			files = append(files, filepath.Join(path, info.Name()))
+		}
+	}
+	return files, nil
+}
+
+func supportedFile(path string) bool {
+	ext := filepath.Ext(path)
+	return ext == ".hcl" || ext == ".json"
+}
+
+// syntaxError is a syntax error in a configuration file
+type syntaxError struct {
+	loc Location
+	err error
+}
+
+func (e *syntaxError) Error() string {
+	return fmt.Sprintf("%s: %s", e.loc, e.err)
+}
+
+// parseLocations parses the configuration files and indexes the locations
+// of tasks. Syntax errors are returned as a
+// *syntaxError with the location of the error.
+func parseLocations(files []string) (*locations, error) {
+	locs := &locations{
+		tasks: make(map[string]taskLocation),
+	}
+
+	for _, file := range files {
+		content, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		var f *ast.File
+		if filepath.Ext(file) == ".json" {
+			if err = checkJSONSyntax(file, content); err != nil {
+				return nil, err
+			}
+			// the config is decoded as JSON, so the HCL JSON parser is only
+			// used for the locations of the blocks
+			if f, err = jsonparser.Parse(content); err != nil {
+				continue
+			}
+		} else {
+			f, err = hclparser.Parse(content)
+			if err != nil {
+				var posErr *hclparser.PosError
+				if errors.As(err, &posErr) {
+					return nil, &syntaxError{
+						loc: Location{
+							Filename: file,
+							Line:     posErr.Pos.Line,
+							Column:   posErr.Pos.Column,
+						},
+						err: posErr.Err,
+					}
+				}
+				return nil, fmt.Errorf("%s: %s", file, err)
+			}
+		}
+
+		list, ok := f.Node.(*ast.ObjectList)
+		if !ok {
+			continue
+		}
+		locs.index(file, list)
+	}
+
+	return locs, nil
+}
+
+// index adds the locations of the task blocks in the file
+func (l *locations) index(file string, list *ast.ObjectList) {
+	for _, item := range list.Items {
+		if len(item.Keys) == 0 || item.Keys[0].Token.Value() != "task" {
+			continue
+		}
+		for _, obj := range objects(item.Val) {
+			name, loc, ok := taskLoc(file, item, obj)
+			if ok {
+				l.tasks[name] = loc
+			}
+		}
+	}
+}
+
+// taskLoc returns the name and location of a task block
+func taskLoc(file string, item *ast.ObjectItem, obj *ast.ObjectType) (string, taskLocation, bool) {
+	name, ok := stringAttr(obj, "name")
+	if !ok {
+		return "", taskLocation{}, false
+	}
+
+	// locate the block by its key unless it is an element of a JSON list
+	pos := obj.Pos()
+	if item.Pos().Line == pos.Line {
+		pos = item.Pos()
+	}
+
+	loc := taskLocation{
+		block: newLocation(file, pos),
+		attrs: make(map[string]Location),
+	}
+	for _, attr := range obj.List.Items {
+		if len(attr.Keys) == 0 {
+			continue
+		}
+		key, ok := attr.Keys[0].Token.Value().(string)
+		if !ok {
+			continue
+		}
+		// keep the first location of repeated blocks, e.g. module_input
+		if _, ok := loc.attrs[key]; !ok {
+			loc.attrs[key] = newLocation(file, attr.Pos())
+		}
+	}
+	return name, loc, true
+}
+
+// objects returns the objects of a block, which is either a single object or
+// a list of objects in JSON
+func objects(n ast.Node) []*ast.ObjectType {
+	switch v := n.(type) {
+	case *ast.ObjectType:
+		return []*ast.ObjectType{v}
+	case *ast.ListType:
+		var objs []*ast.ObjectType
+		for _, elem := range v.List {
+			if obj, ok := elem.(*ast.ObjectType); ok {
+				objs = append(objs, obj)
+			}
+		}
+		return objs
+	}
+	return nil
+}
+
+// stringAttr returns the value of a string attribute of the object
+func stringAttr(obj *ast.ObjectType, name string) (string, bool) {
+	for _, item := range obj.List.Filter(name).Items {
+		lit, ok := item.Val.(*ast.LiteralType)
+		if !ok {
+			continue
+		}
+		if s, ok := lit.Token.Value().(string); ok {
+			return s, true
+		}
+	}
+	return "", false
+}
+
+func newLocation(file string, pos token.Pos) Location {
+	return Location{Filename: file, Line: pos.Line, Column: pos.Column}
+}
+
+// checkJSONSyntax returns a *syntaxError if the content is not valid JSON
+func checkJSONSyntax(file string, content []byte) error {
+	var v interface{}
+	err := json.Unmarshal(content, &v)
+	if err == nil {
+		return nil
+	}
+
+	var jsonErr *json.SyntaxError
+	if !errors.As(err, &jsonErr) {
+		return fmt.Errorf("%s: %s", file, err)
+	}
+
+	// convert the offset of the error to a line and column
+	before := content[:jsonErr.Offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	col := len(before) - bytes.LastIndexByte(before, '\n')
+	return &syntaxError{
+		loc: Location{Filename: file, Line: line, Column: col},
+		err: jsonErr,
+	}
+}
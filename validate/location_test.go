@@ -0,0 +1,63 @@
+package validate
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocation_String(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		loc      Location
+		expected string
+	}{
+		{"empty", Location{}, ""},
+		{"file", Location{Filename: "config.hcl"}, "config.hcl"},
+		{"line", Location{Filename: "config.hcl", Line: 2}, "config.hcl:2"},
+		{"column", Location{Filename: "config.hcl", Line: 2, Column: 3}, "config.hcl:2:3"},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, tc.loc.String())
+		})
+	}
+}
+
+func TestParseLocations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("hcl", func(t *testing.T) {
+		t.Parallel()
+		file := "testdata/config/invalid.hcl"
+		locs, err := parseLocations([]string{file})
+		require.NoError(t, err)
+
+		assert.Equal(t, Location{Filename: file, Line: 5, Column: 1},
+			locs.task("provider_alias", ""))
+		assert.Equal(t, Location{Filename: file, Line: 8, Column: 3},
+			locs.task("provider_alias", "providers"))
+
+		// falls back to the task block for attributes that are not configured
+		assert.Equal(t, Location{Filename: file, Line: 5, Column: 1},
+			locs.task("provider_alias", "variable_files"))
+		assert.Equal(t, Location{}, locs.task("missing", "module"))
+	})
+
+	t.Run("json syntax error", func(t *testing.T) {
+		t.Parallel()
+		file := "testdata/config/invalid.json"
+		_, err := parseLocations([]string{file})
+
+		var syntaxErr *syntaxError
+		require.True(t, errors.As(err, &syntaxErr))
+		assert.Equal(t, Location{Filename: file, Line: 6, Column: 6}, syntaxErr.loc)
+	})
+}
@@ -0,0 +1,148 @@
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/hashicorp/go-getter"
+)
+
+// errModuleNotCached is returned when a remote module is not installed in the
+// task's working directory and fetching remote modules is disabled
+var errModuleNotCached = errors.New("module is not installed in the task's " +
+	"working directory and remote modules are not fetched when offline")
+
+// moduleResolver finds the directory of a task's module. Local modules and
+// modules already installed by Terraform are used in place. Other modules are
+// fetched to a temporary directory.
+type moduleResolver struct {
+	offline bool
+	client  *http.Client
+
+	// tempDir is the directory that remote modules are fetched to. It is
+	// created when the first module is fetched.
+	tempDir string
+}
+
+// cleanup removes the modules that were fetched
+func (r *moduleResolver) cleanup() error {
+	if r.tempDir == "" {
+		return nil
+	}
+	return os.RemoveAll(r.tempDir)
+}
+
+// resolve returns the directory of the module source of the task
+func (r *moduleResolver) resolve(ctx context.Context, taskName, source,
+	moduleVersion, workingDir string) (string, error) {
+
+	// local modules are relative to the directory CTS is run from
+	if isLocalSource(source) {
+		dir, err := filepath.Abs(source)
+		if err != nil {
+			return "", err
+		}
+		if !isDir(dir) {
+			return "", fmt.Errorf("local module directory %q does not exist", source)
+		}
+		return dir, nil
+	}
+
+	if dir, ok := cachedModule(taskName, source, workingDir); ok {
+		return dir, nil
+	}
+
+	if r.offline {
+		return "", errModuleNotCached
+	}
+
+	if r.tempDir == "" {
+		dir, err := ioutil.TempDir("", "cts-validate-")
+		if err != nil {
+			return "", err
+		}
+		r.tempDir = dir
+	}
+	dst := filepath.Join(r.tempDir, taskName)
+
+	src := source
+	if rs, ok := registry.ParseSource(source); ok {
+		u, err := r.registryDownloadURL(ctx, rs, moduleVersion)
+		if err != nil {
+			return "", fmt.Errorf("unable to find module %q in the module "+
+				"registry: %s", source, err)
+		}
+		src = u
+		if rs.Subdir != "" {
+			src = fmt.Sprintf("%s//%s", u, rs.Subdir)
+		}
+	}
+
+	pwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	client := &getter.Client{
+		Ctx:  ctx,
+		Src:  src,
+		Dst:  dst,
+		Pwd:  pwd,
+		Mode: getter.ClientModeDir,
+	}
+	if err := client.Get(); err != nil {
+		return "", fmt.Errorf("unable to fetch module %q: %s", source, err)
+	}
+	return dst, nil
+}
+
+// isLocalSource returns whether the module source is a local path. Relative
+// paths must start with "./" or "../" as they must for Terraform.
+func isLocalSource(source string) bool {
+	return strings.HasPrefix(source, "./") || strings.HasPrefix(source, "../") ||
+		filepath.IsAbs(source)
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// cachedModule returns the directory of the task's module if Terraform has
+// already installed the module source in the task's working directory
+func cachedModule(taskName, source, workingDir string) (string, bool) {
+	m, ok := registry.FindInstalledModule(workingDir, taskName)
+	if !ok {
+		return "", false
+	}
+
+	// Terraform records registry sources with the default hostname
+	if m.Source != source && m.Source != registry.DefaultHost+"/"+source {
+		return "", false
+	}
+	dir := filepath.Join(workingDir, m.Dir)
+	return dir, isDir(dir)
+}
+
+// registryDownloadURL returns the location to fetch the latest version of
+// the module that meets the version constraint from
+func (r *moduleResolver) registryDownloadURL(ctx context.Context,
+	src registry.Source, constraint string) (string, error) {
+
+	c := registry.NewClient(r.client)
+	versions, err := c.Versions(ctx, src)
+	if err != nil {
+		return "", err
+	}
+	v, err := registry.LatestVersion(versions, constraint)
+	if err != nil {
+		return "", err
+	}
+	return c.DownloadURL(ctx, src, v)
+}
@@ -0,0 +1,108 @@
+package validate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/registry"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCachedModule(t *testing.T) {
+	t.Parallel()
+
+	workingDir := t.TempDir()
+	moduleDir := filepath.Join(".terraform", "modules", "task")
+	require.NoError(t, os.MkdirAll(filepath.Join(workingDir, moduleDir), 0755))
+	manifest := fmt.Sprintf(`{"Modules":[
+		{"Key":"","Source":"","Dir":"."},
+		{"Key":"task","Source":"registry.terraform.io/example/basic/local","Dir":%q}
+	]}`, moduleDir)
+	require.NoError(t, os.WriteFile(filepath.Join(workingDir, registry.ModulesManifest),
+		[]byte(manifest), 0644))
+
+	t.Run("cached", func(t *testing.T) {
+		dir, ok := cachedModule("task", "example/basic/local", workingDir)
+		assert.True(t, ok)
+		assert.Equal(t, filepath.Join(workingDir, moduleDir), dir)
+	})
+
+	t.Run("different source", func(t *testing.T) {
+		_, ok := cachedModule("task", "example/other/local", workingDir)
+		assert.False(t, ok)
+	})
+
+	t.Run("different task", func(t *testing.T) {
+		_, ok := cachedModule("other", "example/basic/local", workingDir)
+		assert.False(t, ok)
+	})
+
+	t.Run("no manifest", func(t *testing.T) {
+		_, ok := cachedModule("task", "example/basic/local", t.TempDir())
+		assert.False(t, ok)
+	})
+}
+
+func TestModuleResolver_Registry(t *testing.T) {
+	t.Parallel()
+
+	moduleDir, err := filepath.Abs("testdata/modules/basic")
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/terraform.json", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"modules.v1": "/v1/modules/"})
+	})
+	mux.HandleFunc("/v1/modules/example/basic/local/versions", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"modules":[{"versions":[{"version":"1.0.0"},{"version":"1.1.0"},{"version":"2.0.0"}]}]}`)
+	})
+	var downloaded string
+	mux.HandleFunc("/v1/modules/example/basic/local/1.1.0/download", func(w http.ResponseWriter, r *http.Request) {
+		downloaded = "1.1.0"
+		w.Header().Set("X-Terraform-Get", "file://"+moduleDir)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewTLSServer(mux)
+	defer server.Close()
+
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+	source := fmt.Sprintf("%s/example/basic/local", u.Host)
+
+	resolver := &moduleResolver{client: server.Client()}
+	defer resolver.cleanup()
+
+	t.Run("fetched", func(t *testing.T) {
+		dir, err := resolver.resolve(context.Background(), "task", source,
+			"~> 1.0", t.TempDir())
+		require.NoError(t, err)
+		assert.Equal(t, "1.1.0", downloaded)
+
+		vars, err := moduleVariables(dir)
+		require.NoError(t, err)
+		assert.Contains(t, vars, "services")
+		assert.Contains(t, vars, "prefix")
+	})
+
+	t.Run("no matching version", func(t *testing.T) {
+		_, err := resolver.resolve(context.Background(), "task", source,
+			"~> 3.0", t.TempDir())
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "no versions of the module meet")
+	})
+
+	t.Run("offline", func(t *testing.T) {
+		offline := &moduleResolver{offline: true}
+		_, err := offline.resolve(context.Background(), "task", source, "",
+			t.TempDir())
+		assert.ErrorIs(t, err, errModuleNotCached)
+	})
+}
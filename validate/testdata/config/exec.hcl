@@ -0,0 +1,12 @@
+driver "exec" {
+  plan_args = ["--check"]
+}
+
+task {
+  name   = "script"
+  module = "./scripts/missing.sh"
+
+  condition "services" {
+    names = ["api"]
+  }
+}
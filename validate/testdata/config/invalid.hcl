@@ -0,0 +1,41 @@
+terraform_provider "local" {
+  alias = "primary"
+}
+
+task {
+  name      = "provider_alias"
+  module    = "./testdata/modules/basic"
+  providers = ["local.secondary"]
+
+  condition "services" {
+    names = ["api"]
+  }
+}
+
+task {
+  name   = "undeclared_input"
+  module = "./testdata/modules/basic"
+
+  condition "catalog-services" {
+    regexp              = ".*"
+    use_as_module_input = true
+  }
+}
+
+task {
+  name   = "required_variable"
+  module = "./testdata/modules/required"
+
+  condition "services" {
+    names = ["api"]
+  }
+}
+
+task {
+  name   = "missing_module"
+  module = "./testdata/modules/missing"
+
+  condition "services" {
+    names = ["api"]
+  }
+}
@@ -0,0 +1,9 @@
+task {
+  name    = "remote"
+  module  = "example/basic/local"
+  version = "~> 1.0"
+
+  condition "services" {
+    names = ["api"]
+  }
+}
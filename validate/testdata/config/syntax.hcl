@@ -0,0 +1,7 @@
+task {
+  name   = "syntax"
+  module = "./testdata/modules/basic"
+
+  condition "services" {
+    names = ["api"]
+}
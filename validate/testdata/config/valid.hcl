@@ -0,0 +1,11 @@
+terraform_provider "local" {}
+
+task {
+  name      = "valid"
+  module    = "./testdata/modules/basic"
+  providers = ["local"]
+
+  condition "services" {
+    names = ["api"]
+  }
+}
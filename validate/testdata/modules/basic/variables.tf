@@ -0,0 +1,9 @@
+variable "services" {
+  description = "Consul services monitored by Consul-Terraform-Sync"
+  type        = map(any)
+}
+
+variable "prefix" {
+  type    = string
+  default = "cts"
+}
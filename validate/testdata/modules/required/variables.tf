@@ -0,0 +1,8 @@
+variable "services" {
+  description = "Consul services monitored by Consul-Terraform-Sync"
+  type        = map(any)
+}
+
+variable "token" {
+  type = string
+}
@@ -0,0 +1,313 @@
+// Package validate checks a Consul-Terraform-Sync configuration for errors
+// beyond those found when it is loaded, without running tasks or connecting
+// to Consul. Task providers, modules, and module inputs are checked so that
+// errors that would otherwise only be found when a task runs are reported
+// with the location of the configuration that caused them.
+package validate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+)
+
+// Issue is a problem found in the configuration
+type Issue struct {
+	// Location is where the problem is configured. It is empty if the
+	// problem cannot be attributed to a location.
+	Location Location
+
+	// Task is the name of the task with the problem, if any
+	Task string
+
+	Message string
+}
+
+// String returns the issue prefixed by its location and task
+func (i Issue) String() string {
+	var b strings.Builder
+	if i.Location.Filename != "" {
+		fmt.Fprintf(&b, "%s: ", i.Location)
+	}
+	if i.Task != "" {
+		fmt.Fprintf(&b, "task %q: ", i.Task)
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// Result is the outcome of validating a configuration. The configuration is
+// valid if there are no errors. Warnings are problems that do not prevent
+// CTS from running the tasks.
+type Result struct {
+	Errors   []Issue
+	Warnings []Issue
+}
+
+// Valid returns whether the configuration has no errors
+func (r *Result) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+func (r *Result) addError(loc Location, task, format string, args ...interface{}) {
+	r.Errors = append(r.Errors, Issue{Location: loc, Task: task,
+		Message: fmt.Sprintf(format, args...)})
+}
+
+func (r *Result) addWarning(loc Location, task, format string, args ...interface{}) {
+	r.Warnings = append(r.Warnings, Issue{Location: loc, Task: task,
+		Message: fmt.Sprintf(format, args...)})
+}
+
+// Options configure what is validated
+type Options struct {
+	// Offline disables fetching modules that are not local or already
+	// installed in the task's working directory. These modules are reported
+	// as warnings and their inputs are not checked.
+	Offline bool
+
+	// httpClient is used for requests to module registries
+	httpClient *http.Client
+}
+
+// Config validates the configuration loaded from the paths of files and
+// directories. The configuration is loaded and validated as it is by the
+// daemon, and then each task is checked that:
+//   - its providers match the configured terraform_provider blocks
+//   - its module is a local directory, is installed in its working directory,
+//     or can be fetched
+//   - its module declares the input variables that the task provides to it,
+//     and the task provides the variables the module requires
+//
+// The module checks are only made for the Terraform driver.
+//
+// An error is only returned if validation could not be completed.
+func Config(ctx context.Context, paths []string, opts Options) (*Result, error) {
+	result := &Result{}
+
+	files, err := configFiles(paths)
+	if err != nil {
+		result.addError(Location{}, "", "%s", err)
+		return result, nil
+	}
+	locs, err := parseLocations(files)
+	if err != nil {
+		var syntaxErr *syntaxError
+		if errors.As(err, &syntaxErr) {
+			result.addError(syntaxErr.loc, "", "%s", syntaxErr.err)
+		} else {
+			result.addError(Location{}, "", "%s", err)
+		}
+		return result, nil
+	}
+
+	conf, err := config.BuildConfig(paths)
+	if err != nil {
+		result.addError(Location{}, "", "%s", err)
+		return result, nil
+	}
+	if err = conf.Finalize(); err != nil {
+		result.addError(Location{}, "", "%s", err)
+		return result, nil
+	}
+
+	// Validate tasks individually so that errors are attributed to the task
+	// that caused them. The rest of the configuration is validated with only
+	// the valid tasks.
+	var tasks config.TaskConfigs
+	for _, t := range *conf.Tasks {
+		name := config.StringVal(t.Name)
+		if err := t.Validate(); err != nil {
+			result.addError(locs.task(name, ""), name, "%s", err)
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+
+	global := conf.Copy()
+	global.Tasks = &tasks
+	if err = global.Validate(); err != nil {
+		result.addError(Location{}, "", "%s", err)
+	}
+
+	resolver := &moduleResolver{offline: opts.Offline, client: opts.httpClient}
+	defer resolver.cleanup()
+
+	for _, t := range tasks {
+		task := t.InheritParentConfig(*conf.WorkingDir, *conf.BufferPeriod)
+		validateTaskProviders(result, locs, task, *conf.TerraformProviders)
+		if conf.Driver.Terraform == nil {
+			// the module of the other drivers is an executable or a
+			// playbook, not a Terraform module
+			continue
+		}
+		if err = validateTaskModule(ctx, result, locs, resolver, task); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// validateTaskProviders checks that the providers of the task match a
+// terraform_provider block. Providers without a block use their default
+// configuration, which is only a warning.
+func validateTaskProviders(result *Result, locs *locations, task *config.TaskConfig,
+	providers config.TerraformProviderConfigs) {
+
+	names := make(map[string]bool)
+	ids := make(map[string]bool)
+	for _, p := range providers {
+		for name, values := range *p {
+			names[name] = true
+			ids[name] = true
+			if v, ok := values.(map[string]interface{}); ok {
+				if alias, ok := v["alias"]; ok {
+					ids[fmt.Sprintf("%s.%s", name, alias)] = true
+				}
+			}
+		}
+	}
+
+	taskName := *task.Name
+	loc := locs.task(taskName, "providers")
+	for _, id := range task.Providers {
+		if ids[id] {
+			continue
+		}
+
+		name := strings.SplitN(id, ".", 2)[0]
+		if name != id {
+			result.addError(loc, taskName, "provider %q does not match a "+
+				"terraform_provider %q block with alias %q", id, name,
+				strings.TrimPrefix(id, name+"."))
+			continue
+		}
+		if !names[name] {
+			result.addWarning(loc, taskName, "provider %q does not match a "+
+				"terraform_provider block and will use the provider's default "+
+				"configuration", id)
+		}
+	}
+}
+
+// moduleInput is an input variable that a task provides to its module
+type moduleInput struct {
+	name string
+
+	// attr is the task attribute or block that provides the input
+	attr string
+
+	// from describes what provides the input
+	from string
+}
+
+// taskModuleInputs returns the input variables that a task provides to its
+// module
+func taskModuleInputs(task *config.TaskConfig) []moduleInput {
+	// the services variable is always provided for backwards compatibility
+	inputs := []moduleInput{{name: "services", from: "all tasks"}}
+
+	switch c := task.Condition.(type) {
+	case *config.CatalogServicesConditionConfig:
+		if config.BoolVal(c.UseAsModuleInput) {
+			inputs = append(inputs, moduleInput{name: "catalog_services",
+				attr: "condition", from: `condition "catalog-services"`})
+		}
+	case *config.ConsulKVConditionConfig:
+		if config.BoolVal(c.UseAsModuleInput) {
+			inputs = append(inputs, moduleInput{name: "consul_kv",
+				attr: "condition", from: `condition "consul-kv"`})
+		}
+	}
+
+	if task.ModuleInputs != nil {
+		for _, mi := range *task.ModuleInputs {
+			if _, ok := mi.(*config.ConsulKVModuleInputConfig); ok {
+				inputs = append(inputs, moduleInput{name: "consul_kv",
+					attr: "module_input", from: `module_input "consul-kv"`})
+			}
+		}
+	}
+
+	names := make([]string, 0, len(task.Variables))
+	for name := range task.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		inputs = append(inputs, moduleInput{name: name,
+			attr: "variable_files", from: "variable_files"})
+	}
+	return inputs
+}
+
+// validateTaskModule checks that the module of the task can be found and
+// that its input variables match the inputs that the task provides. An error
+// is only returned if the context is cancelled.
+func validateTaskModule(ctx context.Context, result *Result, locs *locations,
+	resolver *moduleResolver, task *config.TaskConfig) error {
+
+	taskName := *task.Name
+	if err := task.SetVariables(); err != nil {
+		result.addError(locs.task(taskName, "variable_files"), taskName,
+			"unable to read variable_files: %s", err)
+		return nil
+	}
+
+	moduleLoc := locs.task(taskName, "module")
+	dir, err := resolver.resolve(ctx, taskName, *task.Module,
+		config.StringVal(task.Version), *task.WorkingDir)
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case errors.Is(err, errModuleNotCached):
+		result.addWarning(moduleLoc, taskName, "module %q was not checked: %s",
+			*task.Module, err)
+		return nil
+	case err != nil:
+		result.addError(moduleLoc, taskName, "%s", err)
+		return nil
+	}
+
+	vars, err := moduleVariables(dir)
+	if err != nil {
+		result.addError(moduleLoc, taskName, "unable to parse the variables of "+
+			"module %q: %s", *task.Module, err)
+		return nil
+	}
+
+	provided := make(map[string]bool)
+	for _, input := range taskModuleInputs(task) {
+		provided[input.name] = true
+		if _, ok := vars[input.name]; ok {
+			continue
+		}
+		result.addError(locs.task(taskName, input.attr), taskName,
+			"module %q does not declare the input variable %q provided by %s",
+			*task.Module, input.name, input.from)
+	}
+
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		v := vars[name]
+		if !v.required || provided[name] {
+			continue
+		}
+		result.addError(moduleLoc, taskName, "module %q requires the input "+
+			"variable %q declared at %s, which the task does not provide. "+
+			"Set it in a file of the task's variable_files", *task.Module,
+			name, v.loc)
+	}
+
+	return nil
+}
@@ -0,0 +1,165 @@
+package validate
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/consul-terraform-sync/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig(t *testing.T) {
+	t.Parallel()
+
+	requiredVarFile, err := filepath.Abs("testdata/modules/required/variables.tf")
+	require.NoError(t, err)
+
+	cases := []struct {
+		name     string
+		path     string
+		errors   []string
+		warnings []string
+	}{
+		{
+			name: "valid",
+			path: "testdata/config/valid.hcl",
+		},
+		{
+			name: "hcl syntax error",
+			path: "testdata/config/syntax.hcl",
+			errors: []string{
+				"testdata/config/syntax.hcl:8:2: object expected closing RBRACE got: EOF",
+			},
+		},
+		{
+			name: "json syntax error",
+			path: "testdata/config/invalid.json",
+			errors: []string{
+				"testdata/config/invalid.json:6:6: invalid character '}' looking " +
+					"for beginning of object key string",
+			},
+		},
+		{
+			name: "invalid tasks",
+			path: "testdata/config/invalid.hcl",
+			errors: []string{
+				`testdata/config/invalid.hcl:8:3: task "provider_alias": provider ` +
+					`"local.secondary" does not match a terraform_provider "local" ` +
+					`block with alias "secondary"`,
+				`testdata/config/invalid.hcl:19:3: task "undeclared_input": module ` +
+					`"./testdata/modules/basic" does not declare the input variable ` +
+					`"catalog_services" provided by condition "catalog-services"`,
+				`testdata/config/invalid.hcl:27:3: task "required_variable": module ` +
+					`"./testdata/modules/required" requires the input variable "token" ` +
+					`declared at ` + requiredVarFile + `:6:1, which the task does not ` +
+					`provide. Set it in a file of the task's variable_files`,
+				`testdata/config/invalid.hcl:36:3: task "missing_module": local ` +
+					`module directory "./testdata/modules/missing" does not exist`,
+			},
+		},
+		{
+			name: "remote module offline",
+			path: "testdata/config/remote.hcl",
+			warnings: []string{
+				`testdata/config/remote.hcl:3:3: task "remote": module ` +
+					`"example/basic/local" was not checked: module is not installed ` +
+					`in the task's working directory and remote modules are not ` +
+					`fetched when offline`,
+			},
+		},
+		{
+			name: "exec driver",
+			path: "testdata/config/exec.hcl",
+		},
+		{
+			name:   "missing file",
+			path:   "testdata/config/missing.hcl",
+			errors: []string{"stat testdata/config/missing.hcl: no such file or directory"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			result, err := Config(context.Background(), []string{tc.path},
+				Options{Offline: true})
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.errors, issueStrings(result.Errors))
+			assert.Equal(t, tc.warnings, issueStrings(result.Warnings))
+			assert.Equal(t, len(tc.errors) == 0, result.Valid())
+		})
+	}
+}
+
+func TestTaskModuleInputs(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		name     string
+		task     *config.TaskConfig
+		expected []moduleInput
+	}{
+		{
+			name: "services",
+			task: &config.TaskConfig{
+				Condition: &config.ServicesConditionConfig{},
+			},
+			expected: []moduleInput{{name: "services", from: "all tasks"}},
+		},
+		{
+			name: "catalog-services condition",
+			task: &config.TaskConfig{
+				Condition: &config.CatalogServicesConditionConfig{
+					CatalogServicesMonitorConfig: config.CatalogServicesMonitorConfig{
+						UseAsModuleInput: config.Bool(true),
+					},
+				},
+			},
+			expected: []moduleInput{
+				{name: "services", from: "all tasks"},
+				{name: "catalog_services", attr: "condition",
+					from: `condition "catalog-services"`},
+			},
+		},
+		{
+			name: "consul-kv module_input and variables",
+			task: &config.TaskConfig{
+				Condition: &config.ServicesConditionConfig{},
+				ModuleInputs: &config.ModuleInputConfigs{
+					&config.ConsulKVModuleInputConfig{},
+				},
+				Variables: map[string]string{"b": "2", "a": "1"},
+			},
+			expected: []moduleInput{
+				{name: "services", from: "all tasks"},
+				{name: "consul_kv", attr: "module_input",
+					from: `module_input "consul-kv"`},
+				{name: "a", attr: "variable_files", from: "variable_files"},
+				{name: "b", attr: "variable_files", from: "variable_files"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, tc.expected, taskModuleInputs(tc.task))
+		})
+	}
+}
+
+func issueStrings(issues []Issue) []string {
+	if len(issues) == 0 {
+		return nil
+	}
+	s := make([]string, len(issues))
+	for i, issue := range issues {
+		s[i] = issue.String()
+	}
+	return s
+}
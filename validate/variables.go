@@ -0,0 +1,87 @@
+package validate
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclparse"
+)
+
+// variableSchema is the schema of the variable blocks of a Terraform module
+var variableSchema = &hcl.BodySchema{
+	Blocks: []hcl.BlockHeaderSchema{
+		{Type: "variable", LabelNames: []string{"name"}},
+	},
+}
+
+var variableBlockSchema = &hcl.BodySchema{
+	Attributes: []hcl.AttributeSchema{{Name: "default"}},
+}
+
+// moduleVariable is an input variable declared by a Terraform module
+type moduleVariable struct {
+	name     string
+	required bool
+	loc      Location
+}
+
+// moduleVariables parses the input variables declared in the Terraform files
+// of the module directory, which are conventionally declared in variables.tf
+func moduleVariables(dir string) (map[string]moduleVariable, error) {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	p := hclparse.NewParser()
+	vars := make(map[string]moduleVariable)
+	var diags hcl.Diagnostics
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() {
+			continue
+		}
+
+		var f *hcl.File
+		var diag hcl.Diagnostics
+		path := filepath.Join(dir, name)
+		switch {
+		case strings.HasSuffix(name, ".tf"):
+			f, diag = p.ParseHCLFile(path)
+		case strings.HasSuffix(name, ".tf.json"):
+			f, diag = p.ParseJSONFile(path)
+		default:
+			continue
+		}
+		diags = diags.Extend(diag)
+		if diag.HasErrors() {
+			continue
+		}
+
+		content, _, diag := f.Body.PartialContent(variableSchema)
+		diags = diags.Extend(diag)
+		for _, block := range content.Blocks {
+			attrs, _, diag := block.Body.PartialContent(variableBlockSchema)
+			diags = diags.Extend(diag)
+
+			_, hasDefault := attrs.Attributes["default"]
+			rng := block.DefRange
+			vars[block.Labels[0]] = moduleVariable{
+				name:     block.Labels[0],
+				required: !hasDefault,
+				loc: Location{
+					Filename: rng.Filename,
+					Line:     rng.Start.Line,
+					Column:   rng.Start.Column,
+				},
+			}
+		}
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+	return vars, nil
+}
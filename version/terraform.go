@@ -15,10 +15,19 @@ import (
 // and enhancements between versions.
 const CompatibleTerraformVersionConstraint = ">= 0.13.0, < 1.3.0"
 
+// CompatibleOpenTofuVersionConstraint is the version constraint imposed for
+// running OpenTofu in automation with CTS. OpenTofu is versioned separately
+// from Terraform, starting from the Terraform 1.6 feature set.
+const CompatibleOpenTofuVersionConstraint = ">= 1.6.0, < 1.9.0"
+
 // TerraformConstraint is the go-version constraint variable for
 // CompatibleTerraformVersionConstraint
 var TerraformConstraint version.Constraints
 
+// OpenTofuConstraint is the go-version constraint variable for
+// CompatibleOpenTofuVersionConstraint
+var OpenTofuConstraint version.Constraints
+
 func init() {
 	var err error
 	TerraformConstraint, err = version.NewConstraint(CompatibleTerraformVersionConstraint)
@@ -26,4 +35,10 @@ func init() {
 		log.Panicf("error setting up Terraform version constraint %q: %s",
 			CompatibleTerraformVersionConstraint, err)
 	}
+
+	OpenTofuConstraint, err = version.NewConstraint(CompatibleOpenTofuVersionConstraint)
+	if err != nil {
+		log.Panicf("error setting up OpenTofu version constraint %q: %s",
+			CompatibleOpenTofuVersionConstraint, err)
+	}
 }